@@ -31,7 +31,7 @@ func (ms *masterServer) RegisterStore(stream pb.VastoMaster_RegisterStoreServer)
 	}
 	defer ms.topo.dataCenter.deleteServer(storeResource)
 
-	seenShardsOnThisServer := make(map[string]*pb.ShardInfo)
+	seenShardsOnThisServer := make(seenShards)
 	defer ms.unRegisterShards(seenShardsOnThisServer, storeResource)
 
 	var e error
@@ -93,7 +93,7 @@ func (ms *masterServer) notifyPromotion(shardInfo *pb.ShardInfo, storeResource *
 	)
 }
 
-func (ms *masterServer) processShardInfo(seenShardsOnThisServer map[string]*pb.ShardInfo,
+func (ms *masterServer) processShardInfo(seenShardsOnThisServer seenShards,
 	storeResource *pb.StoreResource, shardInfo *pb.ShardInfo) error {
 	keyspace := ms.topo.keyspaces.getOrCreateKeyspace(shardInfo.KeyspaceName)
 	cluster := keyspace.getOrCreateCluster(int(shardInfo.ClusterSize), int(shardInfo.ReplicationFactor))
@@ -106,16 +106,25 @@ func (ms *masterServer) processShardInfo(seenShardsOnThisServer map[string]*pb.S
 	}
 
 	if shardInfo.Status == pb.ShardInfo_DELETED {
-		cluster.RemoveShard(storeResource, shardInfo)
+		seenShardsOnThisServer.remove(shardInfo)
+		if removed := cluster.RemoveNode(storeResource, shardInfo); removed == nil {
+			glog.V(2).Infof("[master] - %s on %s already removed from master cluster %s",
+				shardInfo.IdentifierOnThisServer(), storeResource.Address, cluster)
+			return nil
+		}
 		ms.notifyDeletion(shardInfo, storeResource)
-		delete(seenShardsOnThisServer, shardInfo.IdentifierOnThisServer())
 		glog.V(2).Infof("[master] - %s on %s master cluster %s",
 			shardInfo.IdentifierOnThisServer(), storeResource.Address, cluster)
 	} else {
 		// println("updated shard info:", shardInfo.String(), "store", storeResource.GetAddress())
-		oldShardInfo := cluster.SetShard(storeResource, shardInfo)
+		displaced := cluster.SetNode(storeResource, shardInfo)
+		if displaced != nil && displaced.StoreResource.Address != storeResource.Address {
+			glog.Warningf("[master] %s moved from %s to %s",
+				shardInfo.IdentifierOnThisServer(), displaced.StoreResource.Address, storeResource.Address)
+		}
+		oldShardInfo := displaced.GetShardInfo()
 		ms.notifyUpdate(shardInfo, storeResource)
-		seenShardsOnThisServer[shardInfo.IdentifierOnThisServer()] = shardInfo
+		seenShardsOnThisServer.set(shardInfo)
 		if oldShardInfo == nil {
 			if shardInfo.IsCandidate {
 				glog.V(1).Infof("[master] => %s on %s master cluster %s",
@@ -134,8 +143,8 @@ func (ms *masterServer) processShardInfo(seenShardsOnThisServer map[string]*pb.S
 	return nil
 }
 
-func (ms *masterServer) unRegisterShards(seenShardsOnThisServer map[string]*pb.ShardInfo, storeResource *pb.StoreResource) {
-	for _, shardInfo := range seenShardsOnThisServer {
+func (ms *masterServer) unRegisterShards(seenShardsOnThisServer seenShards, storeResource *pb.StoreResource) {
+	for _, shardInfo := range seenShardsOnThisServer.shardInfos() {
 		keyspace := ms.topo.keyspaces.getOrCreateKeyspace(string(shardInfo.KeyspaceName))
 		cluster := keyspace.cluster
 		if cluster != nil {
@@ -145,8 +154,9 @@ func (ms *masterServer) unRegisterShards(seenShardsOnThisServer map[string]*pb.S
 				}
 				cluster = cluster.GetNextCluster()
 			}
-			cluster.RemoveShard(storeResource, shardInfo)
-			ms.notifyDeletion(shardInfo, storeResource)
+			if removed := cluster.RemoveNode(storeResource, shardInfo); removed != nil {
+				ms.notifyDeletion(shardInfo, storeResource)
+			}
 		}
 	}
 }
@@ -0,0 +1,59 @@
+package master
+
+import (
+	"sort"
+
+	"github.com/chrislusf/vasto/pb"
+)
+
+// seenShardKey tells apart the shard and the candidate shard with the same IdentifierOnThisServer(),
+// e.g., while the cluster is resized and the store has both.
+type seenShardKey struct {
+	identifier  string
+	isCandidate bool
+}
+
+// seenShards are the shards reported by one store, keyed by IdentifierOnThisServer() and IsCandidate
+type seenShards map[seenShardKey]*pb.ShardInfo
+
+func seenShardKeyOf(shardInfo *pb.ShardInfo) seenShardKey {
+	return seenShardKey{identifier: shardInfo.IdentifierOnThisServer(), isCandidate: shardInfo.IsCandidate}
+}
+
+// set adds or updates the shard.
+func (shards seenShards) set(shardInfo *pb.ShardInfo) {
+	shards[seenShardKeyOf(shardInfo)] = shardInfo
+}
+
+// remove drops the shard.
+func (shards seenShards) remove(shardInfo *pb.ShardInfo) {
+	delete(shards, seenShardKeyOf(shardInfo))
+}
+
+// get looks up the shard by its IdentifierOnThisServer() and IsCandidate
+func (shards seenShards) get(identifier string, isCandidate bool) (shardInfo *pb.ShardInfo, found bool) {
+	shardInfo, found = shards[seenShardKey{identifier: identifier, isCandidate: isCandidate}]
+	return
+}
+
+// shardInfos lists the shards sorted by their identifiers, with the shard before the candidate shard
+func (shards seenShards) shardInfos() (shardInfos []*pb.ShardInfo) {
+	for _, key := range shards.keys() {
+		shardInfos = append(shardInfos, shards[key])
+	}
+	return
+}
+
+// keys lists the sorted keys of the shards
+func (shards seenShards) keys() (keys []seenShardKey) {
+	for key := range shards {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].identifier != keys[j].identifier {
+			return keys[i].identifier < keys[j].identifier
+		}
+		return !keys[i].isCandidate && keys[j].isCandidate
+	})
+	return
+}
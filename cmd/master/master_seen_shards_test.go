@@ -0,0 +1,65 @@
+package master
+
+import (
+	"testing"
+
+	"github.com/chrislusf/vasto/pb"
+)
+
+func TestSeenShardsGet(t *testing.T) {
+
+	shards := make(seenShards)
+	shards.set(&pb.ShardInfo{KeyspaceName: "ks1", ServerId: 1, ShardId: 2, Status: pb.ShardInfo_READY})
+
+	shardInfo, found := shards.get("ks1.1.2", false)
+	if !found || shardInfo.Status != pb.ShardInfo_READY {
+		t.Errorf("get ks1.1.2: %v %v", shardInfo, found)
+	}
+
+	if shardInfo, found := shards.get("ks1.2.1", false); found || shardInfo != nil {
+		t.Errorf("get absent ks1.2.1: %v %v", shardInfo, found)
+	}
+
+	// the candidate shard with the same identifier is kept separately
+	shards.set(&pb.ShardInfo{KeyspaceName: "ks1", ServerId: 1, ShardId: 2, Status: pb.ShardInfo_BOOTSTRAP, IsCandidate: true})
+	if shardInfo, found := shards.get("ks1.1.2", false); !found || shardInfo.Status != pb.ShardInfo_READY {
+		t.Errorf("get ks1.1.2 after adding the candidate: %v %v", shardInfo, found)
+	}
+	if shardInfo, found := shards.get("ks1.1.2", true); !found || shardInfo.Status != pb.ShardInfo_BOOTSTRAP {
+		t.Errorf("get candidate ks1.1.2: %v %v", shardInfo, found)
+	}
+	if shardInfos := shards.shardInfos(); len(shardInfos) != 2 || shardInfos[0].IsCandidate || !shardInfos[1].IsCandidate {
+		t.Errorf("shard before the candidate: %v", shardInfos)
+	}
+
+	shards.remove(&pb.ShardInfo{KeyspaceName: "ks1", ServerId: 1, ShardId: 2, IsCandidate: true})
+	if _, found := shards.get("ks1.1.2", true); found {
+		t.Errorf("candidate ks1.1.2 removed")
+	}
+
+}
+
+func TestSeenShardsStableOrder(t *testing.T) {
+
+	shards := make(seenShards)
+	for _, keyspace := range []string{"ks2", "ks1"} {
+		for shardId := uint32(0); shardId < 5; shardId++ {
+			shards.set(&pb.ShardInfo{KeyspaceName: keyspace, ServerId: 1, ShardId: shardId})
+		}
+	}
+
+	expected := shards.shardInfos()
+	if len(expected) != 10 || expected[0].IdentifierOnThisServer() != "ks1.1.0" || expected[9].IdentifierOnThisServer() != "ks2.1.4" {
+		t.Fatalf("sorted shards: %v", expected)
+	}
+
+	for i := 0; i < 10; i++ {
+		shardInfos := shards.shardInfos()
+		for j, shardInfo := range shardInfos {
+			if shardInfo != expected[j] {
+				t.Fatalf("call %d shard %d: %v, expecting %v", i, j, shardInfo, expected[j])
+			}
+		}
+	}
+
+}
@@ -42,6 +42,11 @@ func (ms *masterServer) ResizeCluster(ctx context.Context, req *pb.ResizeRequest
 		return
 	}
 
+	if errs := cluster.Validate(); len(errs) > 0 {
+		resp.Error = fmt.Sprintf("cluster %s is not valid to resize: %v", req.Keyspace, errs)
+		return
+	}
+
 	var existingServers, newServers []*pb.StoreResource
 	for i := 0; i < cluster.ExpectedSize(); i++ {
 		if node, found := cluster.GetNode(i, 0); found {
@@ -1,6 +1,7 @@
 package master
 
 import (
+	"github.com/chrislusf/glog"
 	"github.com/chrislusf/vasto/pb"
 	"github.com/chrislusf/vasto/topology"
 	"sync"
@@ -92,9 +93,17 @@ func (k *keyspace) doGetOrCreateCluster(clusterSize int, replicationFactor int)
 }
 
 func (k *keyspace) getOrCreateCluster(clusterSize int, replicationFactor int) *topology.Cluster {
-	cluster, _ := k.doGetOrCreateCluster(clusterSize, replicationFactor)
+	cluster, isNew := k.doGetOrCreateCluster(clusterSize, replicationFactor)
 	cluster.SetExpectedSize(clusterSize)
-	cluster.SetReplicationFactor(replicationFactor)
+	for _, c := range cluster.ChangeReplicationFactor(replicationFactor) {
+		glog.V(1).Infof("[master] keyspace %s re-replicates shard %d to server %d from server %d",
+			k.name, c.ShardId, c.ServerId, c.SourceServerId)
+	}
+
+	if isNew && !cluster.IsReplicationSatisfiable() {
+		glog.Warningf("[master] keyspace %s has replication factor %d larger than cluster size %d",
+			k.name, cluster.ReplicationFactor(), cluster.ExpectedSize())
+	}
 
 	return cluster
 }
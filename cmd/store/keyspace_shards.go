@@ -45,6 +45,16 @@ func (ks *keyspaceShards) getShard(keyspaceName string, shardId VastoShardId) (s
 	return
 }
 
+// allShards lists the shards of all keyspaces.
+func (ks *keyspaceShards) allShards() (shards []*shard) {
+	ks.RLock()
+	for _, t := range ks.keyspaceToShards {
+		shards = append(shards, t...)
+	}
+	ks.RUnlock()
+	return
+}
+
 func (ks *keyspaceShards) addShards(ksName string, nodes ...*shard) {
 	ks.Lock()
 	shards := ks.keyspaceToShards[keyspaceName(ksName)]
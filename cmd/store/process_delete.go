@@ -1,47 +1,279 @@
 package store
 
 import (
-	"github.com/chrislusf/glog"
+	"context"
+	"fmt"
+
 	"github.com/chrislusf/vasto/pb"
+	"github.com/chrislusf/vasto/storage/binlog"
+	"github.com/chrislusf/vasto/storage/codec"
 	"time"
 )
 
+const (
+	statusTimeout = "timeout"
+)
+
+// processDelete deletes the key, and reports whether the key existed.
+// A key not found, already expired, or already soft deleted, is not deleted nor logged.
+// A soft delete keeps the value marked as deleted, until purged by compaction.
+// A delete older than the stored entry is dropped, the same as when followers apply the binlog.
+// A versioned delete is ordered by its version instead, and keeps a soft deleted entry with the version, see resolveVersion.
+// A delete waiting for replicas is applied and logged even if it returns the not replicated status.
+// The delete is logged first, so a delete failing to be logged within its timeout_ms is not applied either.
+// A key reserved by a prepared transaction is not deleted.
 func (ss *storeServer) processDelete(shard *shard, deleteRequest *pb.DeleteRequest) *pb.WriteResponse {
+	return ss.processTxnDelete(shard, deleteRequest, "")
+}
+
+// processTxnDelete is the same as processDelete, but can also delete the keys reserved by the transaction txnId.
+func (ss *storeServer) processTxnDelete(shard *shard, deleteRequest *pb.DeleteRequest, txnId string) *pb.WriteResponse {
+	resp, _ := ss.deleteKey(shard, deleteRequest, txnId)
+	return resp
+}
+
+// deleteKey is the same as processTxnDelete, and also returns the error if the delete fails to be logged or synced,
+// i.e., the binlog is failing, beyond the delete being rejected.
+func (ss *storeServer) deleteKey(shard *shard, deleteRequest *pb.DeleteRequest, txnId string) (resp *pb.WriteResponse, logErr error) {
 
-	resp := &pb.WriteResponse{
+	receivedAt := time.Now()
+	resp = &pb.WriteResponse{
 		Ok: true,
 	}
+	defer func() {
+		ss.metrics.record(metricsOpDelete, resp.Ok, resp.Status)
+	}()
+
+	if err := ss.validateKey(deleteRequest.Key); err != nil {
+		resp.Ok = false
+		resp.Status = err.Error()
+		return resp, nil
+	}
+
+	if err := shard.checkOwnership(deleteRequest.PartitionHash); err != nil {
+		resp.Ok = false
+		resp.Status = err.Error()
+		return resp, nil
+	}
+
+	unlock := shard.keyLocks.lock(deleteRequest.Key)
+	locked := true
+	defer func() {
+		if locked {
+			unlock()
+		}
+	}()
 
-	err := shard.db.Delete(deleteRequest.Key)
+	if err := shard.deleteIntents.checkReserved(deleteRequest.Key, txnId); err != nil {
+		resp.Ok = false
+		resp.Status = err.Error()
+		return resp, nil
+	}
+
+	entry, err := shard.getLiveEntry(deleteRequest.Key)
 	if err != nil {
 		resp.Ok = false
 		resp.Status = err.Error()
+		return resp, nil
+	}
+
+	if !deleteCondition(deleteRequest).guard(entry, resp) {
+		return resp, nil
+	}
+
+	nowInNano := deleteRequest.UpdatedAtNs
+	if nowInNano == 0 {
+		nowInNano = uint64(time.Now().UnixNano())
+	}
+
+	// for a versioned delete, the entry to write instead of deleting the key
+	var versioned, existing *codec.Entry
+	if deleteRequest.Version != nil {
+		if existing, err = shard.storedEntry(deleteRequest.Key); err != nil {
+			resp.Ok = false
+			resp.Status = err.Error()
+			return resp, nil
+		}
+		nowInNano = orderVersioned(existing, codec.NewVersionVector(deleteRequest.Version), nowInNano)
+		versioned, resp.Concurrent = resolveVersion(existing, newDeletedEntry(deleteRequest, nowInNano))
+		if versioned == nil {
+			resp.Version = existing.Version.ToPb()
+			return resp, nil
+		}
+		resp.Version = versioned.Version.ToPb()
+	} else if entry == nil || entry.UpdatedAtNs > nowInNano {
+		return resp, nil
+	}
+
+	// the delete is logged before the db delete, so that a binlog append failing or timing out leaves the key as is
+	logged := !*ss.option.DisableBinLog
+	var position binlog.LogPosition
+	slowStartTime := time.Now()
+	if logged {
+		ctx, cancel := shard.deleteContext(deleteRequest, receivedAt)
+		position, err = shard.logDeleteContext(ctx, deleteRequest, nowInNano)
+		cancel()
+		if err != nil {
+			ss.checkSlowDelete(shard, deleteRequest, slowStartTime)
+			resp.Ok = false
+			resp.Status = errNotDeleted(err).Error()
+			return resp, err
+		}
+	}
+
+	startTime := time.Now()
+	if versioned != nil {
+		err = shard.putEntry(deleteRequest.Key, existing, versioned)
+	} else if deleteRequest.Soft {
+		err = shard.softDelete(deleteRequest.Key, entry, nowInNano)
 	} else {
-		if !*ss.option.DisableBinLog {
-			nowInNano := deleteRequest.UpdatedAtNs
-			if nowInNano == 0 {
-				nowInNano = uint64(time.Now().UnixNano())
+		err = shard.deleteEntry(deleteRequest.Key, entry)
+	}
+	ss.metrics.observe(metricsOpDelete, startTime)
+	if err != nil {
+		ss.checkSlowDelete(shard, deleteRequest, slowStartTime)
+		// only on db errors, and the followers still apply the logged delete, so the db is repaired from the binlog
+		if logged {
+			shard.markForRepair(position.Segment, err)
+		}
+		resp.Ok = false
+		resp.Status = err.Error()
+		return resp, nil
+	}
+
+	// the key is unlocked before waiting for the binlog sync and the replicas, which do not change the key
+	unlock()
+	locked = false
+
+	resp.Existed = entry != nil && (versioned == nil || versioned.IsDeleted())
+	if logged {
+		err = shard.syncDeleteLog(deleteRequest)
+	}
+	// the replica wait is not timed, since it depends on the followers rather than the local storage
+	ss.checkSlowDelete(shard, deleteRequest, slowStartTime)
+	if logged {
+		if err != nil {
+			logErr = err
+			resp.Ok = false
+			resp.Status = errNotLogged(err).Error()
+		} else {
+			resp.LogSegment, resp.LogOffset = position.Segment, uint64(position.Offset)
+			if err = shard.waitForDeleteReplicas(deleteRequest, position); err != nil {
+				resp.Ok = false
+				resp.Status = errNotReplicated(err).Error()
 			}
-			shard.logDelete(deleteRequest, nowInNano)
 		}
+	} else if deleteRequest.WaitForReplicas > 0 {
+		resp.Ok = false
+		resp.Status = errNotReplicated(fmt.Errorf("binlog disabled")).Error()
+	}
+	return resp, logErr
+
+}
+
+// processBatchDelete deletes all the keys in one call, and logs one binlog entry for each deleted key.
+// A failed delete does not stop the other deletes, and is reported in its result,
+// which is in the same order as the deletes.
+func (ss *storeServer) processBatchDelete(shard *shard, batchDeleteRequest *pb.BatchDeleteRequest) *pb.BatchDeleteResponse {
+
+	resp := &pb.BatchDeleteResponse{
+		Ok: true,
+	}
+	for _, deleteRequest := range batchDeleteRequest.Deletes {
+		resp.Results = append(resp.Results, ss.processDelete(shard, deleteRequest))
 	}
 	return resp
 
 }
 
-func (s *shard) logDelete(deleteRequest *pb.DeleteRequest, updatedAtNs uint64) {
+// getLiveEntry returns the entry of the key, or nil if the key is not found, expired, or soft deleted.
+func (s *shard) getLiveEntry(key []byte) (*codec.Entry, error) {
+	b, err := s.db.Get(key)
+	if err != nil || len(b) == 0 {
+		return nil, err
+	}
+	return liveEntry(codec.FromBytes(b)), nil
+}
 
-	if s.lm == nil {
-		return
+// liveEntry returns the stored entry, or nil if it is nil, expired, or soft deleted.
+func liveEntry(entry *codec.Entry) *codec.Entry {
+	if entry == nil || entry.IsExpired() || entry.IsDeleted() {
+		return nil
 	}
+	return entry
+}
+
+// softDelete marks the entry as deleted at the time, keeping the value.
+func (s *shard) softDelete(key []byte, entry *codec.Entry, deletedAtNs uint64) error {
+	deleted := *entry
+	deleted.UpdatedAtNs = deletedAtNs
+	deleted.DeletedAtNs = deletedAtNs
+	if err := s.db.Put(key, deleted.ToBytes()); err != nil {
+		return err
+	}
+	s.keyStats.onWrite(key, entry, &deleted)
+	return nil
+}
 
-	err := s.lm.AppendEntry(&pb.LogEntry{
+// logDelete appends the delete to the binlog, and returns the position of the log entry.
+// An error means the delete is not replicated to the followers.
+func (s *shard) logDelete(deleteRequest *pb.DeleteRequest, updatedAtNs uint64) (binlog.LogPosition, error) {
+	return s.logDeleteContext(context.Background(), deleteRequest, updatedAtNs)
+}
+
+// logDeleteContext is the same as logDelete, but gives up with the ctx error if the ctx is done before the append.
+func (s *shard) logDeleteContext(ctx context.Context, deleteRequest *pb.DeleteRequest, updatedAtNs uint64) (binlog.LogPosition, error) {
+	return s.logMutationContext(ctx, &pb.LogEntry{
 		UpdatedAtNs: updatedAtNs,
 		Delete:      deleteRequest,
+		Flags:       uint32(pb.DeleteFlags(deleteRequest)),
 	})
+}
 
-	if err != nil {
-		glog.Errorf("append delete log entry: %v", err)
+// deleteContext is done when the delete's timeout_ms since receivedAt passes, or when the shard stops.
+func (s *shard) deleteContext(deleteRequest *pb.DeleteRequest, receivedAt time.Time) (context.Context, context.CancelFunc) {
+	if deleteRequest.TimeoutMs == 0 {
+		return context.WithCancel(s.ctx)
+	}
+	return context.WithDeadline(s.ctx, receivedAt.Add(time.Duration(deleteRequest.TimeoutMs)*time.Millisecond))
+}
+
+// syncDeleteLog flushes the binlog to the disk if the delete asks for it.
+func (s *shard) syncDeleteLog(deleteRequest *pb.DeleteRequest) error {
+	if !deleteRequest.SyncLog || s.lm == nil {
+		return nil
+	}
+	if err := s.lm.Flush(); err != nil {
+		return fmt.Errorf("flush: %v", err)
 	}
+	return nil
+}
+
+// waitForDeleteReplicas waits for the replicas requested by the delete, until the replica wait time.
+func (s *shard) waitForDeleteReplicas(deleteRequest *pb.DeleteRequest, position binlog.LogPosition) error {
 
+	if deleteRequest.WaitForReplicas == 0 {
+		return nil
+	}
+
+	timeout := defaultReplicaWaitTimeout
+	if deleteRequest.ReplicaWaitMs > 0 {
+		timeout = time.Duration(deleteRequest.ReplicaWaitMs) * time.Millisecond
+	}
+	ctx, cancel := context.WithTimeout(s.ctx, timeout)
+	defer cancel()
+
+	return s.waitForReplicas(ctx, position, int(deleteRequest.WaitForReplicas))
+}
+
+func errNotLogged(err error) error {
+	return fmt.Errorf("deleted but not logged: %v", err)
+}
+
+// errNotDeleted reports a delete failing to be logged, which is then not applied either.
+func errNotDeleted(err error) error {
+	if err == context.DeadlineExceeded {
+		return fmt.Errorf("%s: log delete: %v", statusTimeout, err)
+	}
+	return fmt.Errorf("not logged nor deleted: %v", err)
 }
@@ -0,0 +1,60 @@
+package store
+
+import (
+	"context"
+
+	"github.com/chrislusf/vasto/pb"
+	"github.com/chrislusf/vasto/storage/codec"
+)
+
+// processDeleteByPrefix deletes the entries keyed with the prefix, at most limit entries if limit is not 0.
+// The keys are collected from one scan first, and then deleted one by one the same way as processDelete,
+// so the scan does not see its own deletes. It stops at the first delete rejected, e.g., of a key reserved by a transaction. The scan and the deletes stop once ctx is done or the shard is shut down.
+func (ss *storeServer) processDeleteByPrefix(ctx context.Context, shard *shard, deleteByPrefixRequest *pb.DeleteByPrefixRequest) *pb.DeleteByPrefixResponse {
+
+	resp := &pb.DeleteByPrefixResponse{
+		Ok: true,
+	}
+
+	ctx, cancel := shard.requestContext(ctx)
+	defer cancel()
+
+	var deleteRequests []*pb.DeleteRequest
+	err := shard.db.PrefixScanContext(ctx, deleteByPrefixRequest.Prefix, nil, int(deleteByPrefixRequest.Limit), func(key, value []byte) bool {
+		t := make([]byte, len(key))
+		copy(t, key)
+		deleteRequests = append(deleteRequests, &pb.DeleteRequest{
+			Key:           t,
+			PartitionHash: codec.FromBytes(value).PartitionHash,
+			UpdatedAtNs:   deleteByPrefixRequest.UpdatedAtNs,
+		})
+		return true
+	})
+	if err != nil {
+		resp.Ok = false
+		resp.Status = err.Error()
+		return resp
+	}
+	resp.HasMore = deleteByPrefixRequest.Limit > 0 && len(deleteRequests) >= int(deleteByPrefixRequest.Limit)
+
+	for _, deleteRequest := range deleteRequests {
+		if err = ctx.Err(); err != nil {
+			resp.Ok = false
+			resp.Status = err.Error()
+			resp.HasMore = true
+			return resp
+		}
+		deleteResp, _ := ss.deleteKey(shard, deleteRequest, "")
+		if !deleteResp.Ok {
+			resp.Ok = false
+			resp.Status = deleteResp.Status
+			resp.HasMore = true
+			return resp
+		}
+		if deleteResp.Existed {
+			resp.DeletedCount++
+		}
+	}
+
+	return resp
+}
@@ -0,0 +1,119 @@
+package store
+
+import (
+	"context"
+	"testing"
+
+	"github.com/chrislusf/vasto/pb"
+	"github.com/chrislusf/vasto/storage/codec"
+)
+
+func TestProcessDeleteByPrefix(t *testing.T) {
+
+	s, ss, cleanup := newTestShard(t, "delete_prefix")
+	defer cleanup()
+
+	for _, key := range []string{"t1/a", "t1/b", "t1/c", "t2/a"} {
+		putRequest := &pb.PutRequest{Key: []byte(key), Value: []byte(key)}
+		s.db.Put(putRequest.Key, codec.NewPutEntry(putRequest, 1).ToBytes())
+	}
+
+	resp := ss.processDeleteByPrefix(context.Background(), s, &pb.DeleteByPrefixRequest{Prefix: []byte("t1/"), Limit: 2})
+	if !resp.Ok || resp.DeletedCount != 2 || !resp.HasMore {
+		t.Errorf("delete by prefix with limit: %+v", resp)
+	}
+
+	resp = ss.processDeleteByPrefix(context.Background(), s, &pb.DeleteByPrefixRequest{Prefix: []byte("t1/"), Limit: 2})
+	if !resp.Ok || resp.DeletedCount != 1 || resp.HasMore {
+		t.Errorf("delete the rest by prefix: %+v", resp)
+	}
+
+	for key, exists := range map[string]bool{"t1/a": false, "t1/c": false, "t2/a": true} {
+		if b, _ := s.db.Get([]byte(key)); (len(b) > 0) != exists {
+			t.Errorf("key %s exists: %v", key, len(b) > 0)
+		}
+	}
+
+	deleteCount := 0
+	s.lm.ScanEntries(0, func(segment uint32, entry *pb.LogEntry) error {
+		if entry.GetDelete() != nil {
+			deleteCount++
+		}
+		return nil
+	})
+	if deleteCount != 3 {
+		t.Errorf("logged %d deletes, expecting 3", deleteCount)
+	}
+
+}
+
+func TestProcessDeleteByPrefixCancelled(t *testing.T) {
+
+	s, ss, cleanup := newTestShard(t, "delete_prefix_cancelled")
+	defer cleanup()
+
+	putRequest := &pb.PutRequest{Key: []byte("t1/a"), Value: []byte("a")}
+	s.db.Put(putRequest.Key, codec.NewPutEntry(putRequest, 1).ToBytes())
+
+	s.cancelFunc()
+
+	resp := ss.processDeleteByPrefix(context.Background(), s, &pb.DeleteByPrefixRequest{Prefix: []byte("t1/")})
+	if resp.Ok || resp.DeletedCount != 0 || resp.Status != context.Canceled.Error() {
+		t.Errorf("delete by prefix on a shut down shard: %+v", resp)
+	}
+	if b, _ := s.db.Get([]byte("t1/a")); len(b) == 0 {
+		t.Errorf("key t1/a deleted after cancel")
+	}
+
+}
+
+func TestProcessPrefixRequestCancelled(t *testing.T) {
+
+	s, ss, cleanup := newTestShard(t, "prefix_cancelled")
+	defer cleanup()
+
+	putRequest := &pb.PutRequest{Key: []byte("t1/a"), Value: []byte("a")}
+	s.db.Put(putRequest.Key, codec.NewPutEntry(putRequest, 1).ToBytes())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if resp := ss.processPrefix(ctx, s, &pb.GetByPrefixRequest{Prefix: []byte("t1/")}); resp.Ok || resp.Status != context.Canceled.Error() {
+		t.Errorf("get by prefix with the request cancelled: %+v", resp)
+	}
+	resp := ss.processDeleteByPrefix(ctx, s, &pb.DeleteByPrefixRequest{Prefix: []byte("t1/")})
+	if resp.Ok || resp.DeletedCount != 0 || resp.Status != context.Canceled.Error() {
+		t.Errorf("delete by prefix with the request cancelled: %+v", resp)
+	}
+	if b, _ := s.db.Get([]byte("t1/a")); len(b) == 0 {
+		t.Errorf("key t1/a deleted after the request is cancelled")
+	}
+
+	// the shard is still serving the other requests
+	if resp := ss.processPrefix(context.Background(), s, &pb.GetByPrefixRequest{Prefix: []byte("t1/")}); !resp.Ok || len(resp.KeyValues) != 1 {
+		t.Errorf("get by prefix: %+v", resp)
+	}
+
+}
+
+func TestProcessDeleteByPrefixReserved(t *testing.T) {
+
+	s, ss, cleanup := newTestShard(t, "delete_prefix_reserved")
+	defer cleanup()
+
+	for _, key := range []string{"t1/a", "t1/b"} {
+		ss.processPut(s, &pb.PutRequest{Key: []byte(key), Value: []byte(key)})
+	}
+	if resp := ss.processPrepareDelete(s, &pb.PrepareDeleteRequest{TxnId: "t1", Deletes: []*pb.DeleteRequest{{Key: []byte("t1/b")}}}); !resp.Ok {
+		t.Fatalf("prepare delete: %+v", resp)
+	}
+
+	resp := ss.processDeleteByPrefix(context.Background(), s, &pb.DeleteByPrefixRequest{Prefix: []byte("t1/")})
+	if resp.Ok || resp.DeletedCount != 1 || !resp.HasMore {
+		t.Errorf("delete by prefix with a reserved key: %+v", resp)
+	}
+	if b, _ := s.db.Get([]byte("t1/b")); len(b) == 0 {
+		t.Errorf("reserved key t1/b deleted by prefix")
+	}
+
+}
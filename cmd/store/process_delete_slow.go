@@ -0,0 +1,41 @@
+package store
+
+import (
+	"time"
+
+	"github.com/chrislusf/glog"
+	"github.com/chrislusf/vasto/pb"
+	"github.com/chrislusf/vasto/util"
+)
+
+// defaultSlowDeleteThreshold is used when the store option does not set it, e.g., in tests
+const defaultSlowDeleteThreshold = 100 * time.Millisecond
+
+// slowDeleteThreshold returns the duration over which a delete is slow, or 0 if slow deletes are not checked.
+func (ss *storeServer) slowDeleteThreshold() time.Duration {
+	if ss.option == nil || ss.option.SlowDeleteMs == nil {
+		return defaultSlowDeleteThreshold
+	}
+	if *ss.option.SlowDeleteMs <= 0 {
+		return 0
+	}
+	return time.Duration(*ss.option.SlowDeleteMs) * time.Millisecond
+}
+
+// checkSlowDelete logs and counts the delete if its binlog append, db delete, and binlog sync, since the start time,
+// took longer than the slow delete threshold, to catch the stalls of the storage.
+// The key is logged by its hash only. It returns whether the delete is slow.
+func (ss *storeServer) checkSlowDelete(shard *shard, deleteRequest *pb.DeleteRequest, startTime time.Time) bool {
+	threshold := ss.slowDeleteThreshold()
+	if threshold <= 0 {
+		return false
+	}
+	duration := time.Since(startTime)
+	if duration < threshold {
+		return false
+	}
+	ss.metrics.recordSlow(metricsOpDelete)
+	glog.Warningf("slow delete: shard=%s key_hash=%d duration_ms=%d threshold_ms=%d",
+		shard, util.Hash(deleteRequest.Key), duration/time.Millisecond, threshold/time.Millisecond)
+	return true
+}
@@ -0,0 +1,81 @@
+package store
+
+import (
+	"testing"
+	"time"
+
+	"github.com/chrislusf/vasto/pb"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestCheckSlowDelete(t *testing.T) {
+
+	s, ss, cleanup := newTestShard(t, "slow_delete")
+	defer cleanup()
+
+	ss.metrics = newStoreMetrics()
+	deleteRequest := &pb.DeleteRequest{Key: []byte("k1")}
+
+	if ss.checkSlowDelete(s, deleteRequest, time.Now()) {
+		t.Errorf("fast delete reported as slow")
+	}
+	if !ss.checkSlowDelete(s, deleteRequest, time.Now().Add(-time.Second)) {
+		t.Errorf("slow delete not reported")
+	}
+
+	ss.processPut(s, &pb.PutRequest{Key: []byte("k1"), Value: []byte("v1")})
+	ss.processDelete(s, deleteRequest)
+
+	if count := testutil.ToFloat64(ss.metrics.slowOps.WithLabelValues(metricsOpDelete)); count != 1 {
+		t.Errorf("slow delete count %v, expecting 1", count)
+	}
+
+	slowDeleteMs := 0
+	ss.option.SlowDeleteMs = &slowDeleteMs
+	if ss.checkSlowDelete(s, deleteRequest, time.Now().Add(-time.Second)) {
+		t.Errorf("slow delete reported while disabled")
+	}
+
+	// no metrics address
+	ss.metrics = nil
+	slowDeleteMs = 1
+	if !ss.checkSlowDelete(s, deleteRequest, time.Now().Add(-time.Second)) {
+		t.Errorf("slow delete not reported without metrics")
+	}
+
+}
+
+func TestDeleteBatchSlowDelete(t *testing.T) {
+
+	s, ss, cleanup := newTestShard(t, "batch_slow_delete")
+	defer cleanup()
+
+	ss.metrics = newStoreMetrics()
+	slowDeleteMs := 10
+	ss.option.SlowDeleteMs = &slowDeleteMs
+
+	ss.processPut(s, &pb.PutRequest{Key: []byte("k1"), Value: []byte("v1")})
+	ss.processPut(s, &pb.PutRequest{Key: []byte("k2"), Value: []byte("v2")})
+
+	// the binlog append of the first delete waits for the epoch fence
+	s.fence.Lock()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		deletedCount, failedCount, err := ss.deleteBatch(s, []*pb.DeleteRequest{
+			{Key: []byte("k1"), SyncLog: true},
+			{Key: []byte("k2"), SyncLog: true},
+		})
+		if err != nil || deletedCount != 2 || failedCount != 0 {
+			t.Errorf("delete batch: deleted %d failed %d: %v", deletedCount, failedCount, err)
+		}
+	}()
+	time.Sleep(50 * time.Millisecond)
+	s.fence.Unlock()
+	<-done
+
+	if count := testutil.ToFloat64(ss.metrics.slowOps.WithLabelValues(metricsOpDelete)); count != 1 {
+		t.Errorf("slow delete count %v, expecting 1", count)
+	}
+
+}
@@ -0,0 +1,322 @@
+package store
+
+import (
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/chrislusf/vasto/pb"
+	"github.com/chrislusf/vasto/storage/codec"
+	"github.com/chrislusf/vasto/topology"
+)
+
+func TestProcessBatchDelete(t *testing.T) {
+
+	s, ss, cleanup := newTestShard(t, "batch_delete")
+	defer cleanup()
+
+	s.cluster = topology.NewCluster("ks1", 2, 1)
+	var ownedHash, otherHash uint64
+	for hash := uint64(1); ownedHash == 0 || otherHash == 0; hash++ {
+		if s.cluster.FindShardId(hash) == 0 {
+			ownedHash = hash
+		} else {
+			otherHash = hash
+		}
+	}
+
+	for _, key := range []string{"k1", "k2"} {
+		putRequest := &pb.PutRequest{Key: []byte(key), PartitionHash: ownedHash, Value: []byte(key)}
+		s.db.Put(putRequest.Key, codec.NewPutEntry(putRequest, 1).ToBytes())
+	}
+
+	resp := ss.processBatchDelete(s, &pb.BatchDeleteRequest{
+		PartitionHash: ownedHash,
+		Deletes: []*pb.DeleteRequest{
+			{Key: []byte("k1"), PartitionHash: ownedHash},
+			{Key: []byte("k3"), PartitionHash: otherHash},
+			{Key: []byte("k2"), PartitionHash: ownedHash},
+		},
+	})
+
+	if !resp.Ok || len(resp.Results) != 3 {
+		t.Fatalf("batch delete: %+v", resp)
+	}
+	if !resp.Results[0].Ok || resp.Results[1].Ok || !resp.Results[2].Ok {
+		t.Errorf("batch delete results: %+v", resp.Results)
+	}
+	for _, key := range []string{"k1", "k2"} {
+		if b, _ := s.db.Get([]byte(key)); len(b) != 0 {
+			t.Errorf("key %s not deleted", key)
+		}
+	}
+
+	deleteCount := 0
+	s.lm.ScanEntries(0, func(segment uint32, entry *pb.LogEntry) error {
+		if entry.GetDelete() != nil {
+			deleteCount++
+		}
+		return nil
+	})
+	if deleteCount != 2 {
+		t.Errorf("logged %d deletes, expecting 2", deleteCount)
+	}
+
+}
+
+func TestProcessConditionalDelete(t *testing.T) {
+
+	s, ss, cleanup := newTestShard(t, "conditional_delete")
+	defer cleanup()
+
+	putRequest := &pb.PutRequest{Key: []byte("k1"), Value: []byte("v1")}
+	s.db.Put(putRequest.Key, codec.NewPutEntry(putRequest, 10).ToBytes())
+
+	for _, deleteRequest := range []*pb.DeleteRequest{
+		{Key: []byte("k1"), ExpectedValue: []byte("v2")},
+		{Key: []byte("k1"), ExpectedUpdatedAtNs: 11},
+		{Key: []byte("k1"), ExpectedValue: []byte("v1"), ExpectedUpdatedAtNs: 11},
+		{Key: []byte("k2"), ExpectedValue: []byte("v1")},
+	} {
+		resp := ss.processDelete(s, deleteRequest)
+		if resp.Ok || resp.Status != pb.StatusPreconditionFailed {
+			t.Errorf("conditional delete %+v: %+v", deleteRequest, resp)
+		}
+	}
+	if b, _ := s.db.Get([]byte("k1")); len(b) == 0 {
+		t.Errorf("key deleted without matching condition")
+	}
+	logged := 0
+	s.lm.ScanEntries(0, func(segment uint32, entry *pb.LogEntry) error {
+		logged++
+		return nil
+	})
+	if logged != 0 {
+		t.Errorf("logged %d entries for failed deletes", logged)
+	}
+
+	resp := ss.processDelete(s, &pb.DeleteRequest{Key: []byte("k1"), ExpectedValue: []byte("v1"), ExpectedUpdatedAtNs: 10})
+	if !resp.Ok {
+		t.Errorf("matched conditional delete: %+v", resp)
+	}
+	if b, _ := s.db.Get([]byte("k1")); len(b) != 0 {
+		t.Errorf("key not deleted")
+	}
+
+}
+
+func TestProcessDeleteExisted(t *testing.T) {
+
+	s, ss, cleanup := newTestShard(t, "delete_existed")
+	defer cleanup()
+
+	putRequest := &pb.PutRequest{Key: []byte("k1"), Value: []byte("v1")}
+	s.db.Put(putRequest.Key, codec.NewPutEntry(putRequest, 10).ToBytes())
+
+	if resp := ss.processDelete(s, &pb.DeleteRequest{Key: []byte("k1")}); !resp.Ok || !resp.Existed {
+		t.Errorf("delete present key: %+v", resp)
+	}
+	if resp := ss.processDelete(s, &pb.DeleteRequest{Key: []byte("k1")}); !resp.Ok || resp.Existed {
+		t.Errorf("delete absent key: %+v", resp)
+	}
+
+	deleteCount := 0
+	s.lm.ScanEntries(0, func(segment uint32, entry *pb.LogEntry) error {
+		if entry.GetDelete() != nil {
+			deleteCount++
+		}
+		return nil
+	})
+	if deleteCount != 1 {
+		t.Errorf("logged %d deletes, expecting only the delete of the present key", deleteCount)
+	}
+
+}
+
+func TestProcessDeleteNotLogged(t *testing.T) {
+
+	s, ss, cleanup := newTestShard(t, "delete_not_logged")
+	defer cleanup()
+
+	putRequest := &pb.PutRequest{Key: []byte("k1"), Value: []byte("v1")}
+	s.db.Put(putRequest.Key, codec.NewPutEntry(putRequest, 10).ToBytes())
+
+	// the binlog can not be written any more
+	s.lm.Shutdown()
+
+	resp := ss.processDelete(s, &pb.DeleteRequest{Key: []byte("k1")})
+	if resp.Ok || resp.Existed || !strings.HasPrefix(resp.Status, "not logged nor deleted") {
+		t.Errorf("delete without binlog: %+v", resp)
+	}
+	if entry, _ := s.getLiveEntry(putRequest.Key); entry == nil {
+		t.Errorf("key deleted without being logged")
+	}
+
+}
+
+func TestProcessDeleteTimeout(t *testing.T) {
+
+	s, ss, cleanup := newTestShard(t, "delete_timeout")
+	defer cleanup()
+
+	putRequest := &pb.PutRequest{Key: []byte("k1"), Value: []byte("v1")}
+	s.db.Put(putRequest.Key, codec.NewPutEntry(putRequest, 10).ToBytes())
+
+	// the delete waits past its timeout before reaching the binlog
+	unlock := s.keyLocks.lock(putRequest.Key)
+	respChan := make(chan *pb.WriteResponse)
+	go func() {
+		respChan <- ss.processDelete(s, &pb.DeleteRequest{Key: putRequest.Key, TimeoutMs: 1})
+	}()
+	time.Sleep(20 * time.Millisecond)
+	unlock()
+
+	resp := <-respChan
+	if resp.Ok || resp.Existed || !strings.HasPrefix(resp.Status, statusTimeout) {
+		t.Errorf("delete past timeout: %+v", resp)
+	}
+	if entry, _ := s.getLiveEntry(putRequest.Key); entry == nil {
+		t.Errorf("key deleted after timeout")
+	}
+	if segment, offset := s.lm.GetSegmentOffset(); segment != 0 || offset != 0 {
+		t.Errorf("delete logged after timeout at %d:%d", segment, offset)
+	}
+
+	if resp := ss.processDelete(s, &pb.DeleteRequest{Key: putRequest.Key, TimeoutMs: 1000}); !resp.Ok || !resp.Existed {
+		t.Errorf("delete within timeout: %+v", resp)
+	}
+
+}
+
+func TestProcessDeleteLastWriterWins(t *testing.T) {
+
+	s, ss, cleanup := newTestShard(t, "delete_last_writer_wins")
+	defer cleanup()
+
+	ss.processPut(s, &pb.PutRequest{Key: []byte("k1"), Value: []byte("v1"), UpdatedAtNs: 20})
+
+	// an older put and an older delete are both dropped
+	ss.processPut(s, &pb.PutRequest{Key: []byte("k1"), Value: []byte("v0"), UpdatedAtNs: 10})
+	if resp := ss.processDelete(s, &pb.DeleteRequest{Key: []byte("k1"), UpdatedAtNs: 15}); !resp.Ok || resp.Existed {
+		t.Errorf("older delete: %+v", resp)
+	}
+	if entry, _ := s.getLiveEntry([]byte("k1")); entry == nil || string(entry.Value) != "v1" {
+		t.Errorf("entry after older writes: %+v", entry)
+	}
+
+	if resp := ss.processDelete(s, &pb.DeleteRequest{Key: []byte("k1"), UpdatedAtNs: 25}); !resp.Ok || !resp.Existed {
+		t.Errorf("newer delete: %+v", resp)
+	}
+
+	logged := 0
+	s.lm.ScanEntries(0, func(segment uint32, entry *pb.LogEntry) error {
+		logged++
+		return nil
+	})
+	if logged != 2 {
+		t.Errorf("logged %d entries, expecting the first put and the newer delete", logged)
+	}
+
+}
+
+func TestProcessDeleteInMemory(t *testing.T) {
+
+	dir, err := ioutil.TempDir("", "delete_in_memory")
+	if err != nil {
+		t.Fatalf("temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	s := newShard("ks1", dir, 0, 0, nil, nil, 1, 1, 2, true)
+	defer s.db.Close()
+	defer s.shutdownNode()
+
+	putRequest := &pb.PutRequest{Key: []byte("k1"), Value: []byte("v1")}
+	s.db.Put(putRequest.Key, codec.NewPutEntry(putRequest, 10).ToBytes())
+
+	disableBinLog := false
+	ss := &storeServer{option: &StoreOption{DisableBinLog: &disableBinLog}}
+
+	resp := ss.processDelete(s, &pb.DeleteRequest{Key: []byte("k1"), ExpectedValue: []byte("v2")})
+	if resp.Ok || resp.Status != pb.StatusPreconditionFailed {
+		t.Errorf("unmatched conditional delete: %+v", resp)
+	}
+
+	resp = ss.processDelete(s, &pb.DeleteRequest{Key: []byte("k1"), ExpectedValue: []byte("v1")})
+	if !resp.Ok || !resp.Existed {
+		t.Errorf("matched conditional delete: %+v", resp)
+	}
+	if b, _ := s.db.Get([]byte("k1")); len(b) != 0 {
+		t.Errorf("key not deleted")
+	}
+
+	logged := 0
+	s.lm.ScanEntries(0, func(segment uint32, entry *pb.LogEntry) error {
+		logged++
+		return nil
+	})
+	if logged != 1 {
+		t.Errorf("logged %d entries, expecting 1", logged)
+	}
+
+}
+
+func TestProcessSoftDelete(t *testing.T) {
+
+	s, ss, cleanup := newTestShard(t, "soft_delete")
+	defer cleanup()
+
+	ss.processPut(s, &pb.PutRequest{Key: []byte("k1"), Value: []byte("v1"), UpdatedAtNs: 10})
+
+	resp := ss.processDelete(s, &pb.DeleteRequest{Key: []byte("k1"), UpdatedAtNs: 20, Soft: true})
+	if !resp.Ok || !resp.Existed {
+		t.Errorf("soft delete: %+v", resp)
+	}
+	if resp = ss.processDelete(s, &pb.DeleteRequest{Key: []byte("k1"), UpdatedAtNs: 30, Soft: true}); resp.Existed {
+		t.Errorf("soft delete again: %+v", resp)
+	}
+
+	if getResp := ss.processGet(s, &pb.GetRequest{Key: []byte("k1")}); !getResp.Ok || getResp.KeyValue != nil {
+		t.Errorf("get soft deleted: %+v", getResp)
+	}
+	getResp := ss.processGet(s, &pb.GetRequest{Key: []byte("k1"), IncludeDeleted: true})
+	if !getResp.Ok || getResp.KeyValue == nil || string(getResp.KeyValue.Value) != "v1" || getResp.KeyValue.DeletedAtNs != 20 {
+		t.Errorf("get including soft deleted: %+v", getResp)
+	}
+	batchGetResp := ss.processBatchGet(s, &pb.BatchGetRequest{Keys: [][]byte{[]byte("k1")}})
+	if !batchGetResp.Ok || len(batchGetResp.Results) != 1 || batchGetResp.Results[0].KeyValue != nil {
+		t.Errorf("batch get soft deleted: %+v", batchGetResp)
+	}
+	batchGetResp = ss.processBatchGet(s, &pb.BatchGetRequest{Keys: [][]byte{[]byte("k1")}, IncludeDeleted: true})
+	if !batchGetResp.Ok || len(batchGetResp.Results) != 1 || batchGetResp.Results[0].KeyValue.GetDeletedAtNs() != 20 {
+		t.Errorf("batch get including soft deleted: %+v", batchGetResp)
+	}
+
+	// the followers mirror the soft delete
+	followerDir, err := ioutil.TempDir("", "soft_delete_follower")
+	if err != nil {
+		t.Fatalf("temp dir: %v", err)
+	}
+	defer os.RemoveAll(followerDir)
+	follower := newShard("ks1", followerDir, 1, 0, nil, nil, 1, 0, 0, true)
+	defer follower.shutdownNode()
+	s.lm.ScanEntries(0, func(segment uint32, entry *pb.LogEntry) error {
+		if err := follower.processEntry(entry); err != nil {
+			t.Errorf("follow %+v: %v", entry, err)
+		}
+		return nil
+	})
+	b, _ := follower.db.Get([]byte("k1"))
+	if entry := codec.FromBytes(b); entry == nil || entry.DeletedAtNs != 20 || string(entry.Value) != "v1" {
+		t.Errorf("followed soft delete: %+v", entry)
+	}
+
+	// restore by putting the value again
+	ss.processPut(s, &pb.PutRequest{Key: []byte("k1"), Value: getResp.KeyValue.Value})
+	if getResp = ss.processGet(s, &pb.GetRequest{Key: []byte("k1")}); getResp.KeyValue == nil || getResp.KeyValue.DeletedAtNs != 0 {
+		t.Errorf("get restored: %+v", getResp)
+	}
+
+}
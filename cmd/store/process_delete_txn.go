@@ -0,0 +1,451 @@
+package store
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/chrislusf/glog"
+	"github.com/chrislusf/vasto/pb"
+	"github.com/golang/protobuf/proto"
+)
+
+// defaultIntentTtl is used when the store option does not set the intent ttl, e.g., in tests
+const defaultIntentTtl = 10 * time.Minute
+
+// deleteIntents are the deletes staged by prepared transactions, by transaction id.
+// The keys of the staged deletes are reserved by the transaction until it is committed or aborted,
+// so that the other writes to them are rejected, and the conditions checked when prepared still hold when committed.
+// They are kept in memory, and recovered from the binlog when the shard is opened,
+// or, for the transactions followed from the primary, from the followed intent file.
+// A transaction not committed or aborted within the intent ttl is aborted, e.g., after the coordinator is gone.
+type deleteIntents struct {
+	sync.Mutex
+	staged   map[string]*stagedDeletes
+	reserved map[string]string
+}
+
+type stagedDeletes struct {
+	deletes      []*pb.DeleteRequest
+	preparedAtNs uint64
+	// the binlog segment of the prepare intent, which is kept until the transaction ends
+	segment uint32
+	logged  bool
+	// followed from the primary, and saved in the followed intent file
+	followed bool
+}
+
+// stage stages the deletes prepared at preparedAtNs, and reserves their keys for the transaction.
+// Staging the same deletes again for the transaction does nothing, and returns isStaged,
+// so that the coordinator can safely retry the prepare.
+// It fails without staging anything if the transaction is staged with other deletes, or still being prepared,
+// or any key is reserved by another transaction.
+func (d *deleteIntents) stage(txnId string, deletes []*pb.DeleteRequest, preparedAtNs uint64) (isStaged bool, err error) {
+	d.Lock()
+	defer d.Unlock()
+	if staged, found := d.staged[txnId]; found {
+		if !sameDeletes(staged.deletes, deletes) {
+			return false, fmt.Errorf("transaction %s already prepared with other deletes", txnId)
+		}
+		if !staged.logged && !staged.followed {
+			return false, fmt.Errorf("transaction %s is being prepared", txnId)
+		}
+		return true, nil
+	}
+	for _, deleteRequest := range deletes {
+		if other, found := d.reserved[string(deleteRequest.Key)]; found && other != txnId {
+			return false, errKeyReserved(deleteRequest.Key, other)
+		}
+	}
+	if d.staged == nil {
+		d.staged = make(map[string]*stagedDeletes)
+		d.reserved = make(map[string]string)
+	}
+	d.staged[txnId] = &stagedDeletes{deletes: deletes, preparedAtNs: preparedAtNs}
+	for _, deleteRequest := range deletes {
+		d.reserved[string(deleteRequest.Key)] = txnId
+	}
+	return false, nil
+}
+
+func sameDeletes(a, b []*pb.DeleteRequest) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !proto.Equal(a[i], b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// setFollowed marks the transaction as followed from the primary.
+func (d *deleteIntents) setFollowed(txnId string) {
+	d.Lock()
+	defer d.Unlock()
+	if staged, found := d.staged[txnId]; found {
+		staged.followed = true
+	}
+}
+
+// followedIntents returns the prepare intents of the transactions followed from the primary.
+func (d *deleteIntents) followedIntents() (intents []*pb.DeleteIntent) {
+	d.Lock()
+	defer d.Unlock()
+	for txnId, staged := range d.staged {
+		if staged.followed {
+			intents = append(intents, &pb.DeleteIntent{
+				TxnId:        txnId,
+				Phase:        pb.DeleteIntent_PREPARE,
+				Deletes:      staged.deletes,
+				PreparedAtNs: staged.preparedAtNs,
+			})
+		}
+	}
+	return
+}
+
+// expired returns the transactions prepared before the time, and already logged or followed.
+func (d *deleteIntents) expired(beforeNs uint64) (txnIds []string) {
+	d.Lock()
+	defer d.Unlock()
+	for txnId, staged := range d.staged {
+		if (staged.logged || staged.followed) && staged.preparedAtNs < beforeNs {
+			txnIds = append(txnIds, txnId)
+		}
+	}
+	return
+}
+
+// setLogged records the binlog segment of the prepare intent, so that the segment is not purged.
+func (d *deleteIntents) setLogged(txnId string, segment uint32) {
+	d.Lock()
+	defer d.Unlock()
+	if staged, found := d.staged[txnId]; found {
+		staged.segment, staged.logged = segment, true
+	}
+}
+
+func (d *deleteIntents) get(txnId string) (deletes []*pb.DeleteRequest, found bool) {
+	d.Lock()
+	defer d.Unlock()
+	staged, found := d.staged[txnId]
+	if !found {
+		return nil, false
+	}
+	return staged.deletes, true
+}
+
+// isFollowed checks whether the transaction is staged and followed from the primary.
+func (d *deleteIntents) isFollowed(txnId string) bool {
+	d.Lock()
+	defer d.Unlock()
+	staged, found := d.staged[txnId]
+	return found && staged.followed
+}
+
+// remove drops the staged deletes, and releases their keys.
+func (d *deleteIntents) remove(txnId string) (found bool) {
+	d.Lock()
+	defer d.Unlock()
+	staged, found := d.staged[txnId]
+	if !found {
+		return false
+	}
+	for _, deleteRequest := range staged.deletes {
+		if d.reserved[string(deleteRequest.Key)] == txnId {
+			delete(d.reserved, string(deleteRequest.Key))
+		}
+	}
+	delete(d.staged, txnId)
+	return true
+}
+
+// checkReserved fails if the key is reserved by a transaction other than txnId, which is empty for non transactional writes.
+func (d *deleteIntents) checkReserved(key []byte, txnId string) error {
+	d.Lock()
+	defer d.Unlock()
+	if other, found := d.reserved[string(key)]; found && other != txnId {
+		return errKeyReserved(key, other)
+	}
+	return nil
+}
+
+// earliestSegment returns the earliest binlog segment with a prepare intent of a staged transaction.
+func (d *deleteIntents) earliestSegment() (segment uint32, found bool) {
+	d.Lock()
+	defer d.Unlock()
+	for _, staged := range d.staged {
+		if staged.logged && (!found || staged.segment < segment) {
+			segment, found = staged.segment, true
+		}
+	}
+	return
+}
+
+func errKeyReserved(key []byte, txnId string) error {
+	return fmt.Errorf("key %s reserved by transaction %s", string(key), txnId)
+}
+
+// processPrepareDelete checks the ownership and the conditions of the deletes, and stages them for the transaction.
+// The keys are reserved before the conditions are checked, so that no other write changes them until committed or aborted,
+// or until the intent ttl passes. Preparing the same deletes again for the transaction succeeds without doing anything.
+// The deletes are not applied, but the intent is logged and flushed to the binlog, so it survives a crash,
+// and is replicated to the followers.
+// The binlog is required, since the intent is recovered from it.
+func (ss *storeServer) processPrepareDelete(shard *shard, prepareRequest *pb.PrepareDeleteRequest) *pb.WriteResponse {
+
+	if prepareRequest.TxnId == "" {
+		return &pb.WriteResponse{Status: "missing transaction id"}
+	}
+	if *ss.option.DisableBinLog || shard.lm == nil {
+		return &pb.WriteResponse{Status: "binlog disabled"}
+	}
+
+	for _, deleteRequest := range prepareRequest.Deletes {
+		if err := ss.validateKey(deleteRequest.Key); err != nil {
+			return &pb.WriteResponse{Status: err.Error()}
+		}
+		if err := shard.checkOwnership(deleteRequest.PartitionHash); err != nil {
+			return &pb.WriteResponse{Status: err.Error()}
+		}
+	}
+
+	preparedAtNs := uint64(time.Now().UnixNano())
+	isStaged, err := shard.deleteIntents.stage(prepareRequest.TxnId, prepareRequest.Deletes, preparedAtNs)
+	if err != nil {
+		return &pb.WriteResponse{Status: err.Error()}
+	}
+	if isStaged {
+		// a retried prepare
+		return &pb.WriteResponse{Ok: true}
+	}
+
+	for _, deleteRequest := range prepareRequest.Deletes {
+		unlock := shard.keyLocks.lock(deleteRequest.Key)
+		entry, err := shard.getLiveEntry(deleteRequest.Key)
+		unlock()
+		if err != nil {
+			shard.deleteIntents.remove(prepareRequest.TxnId)
+			return &pb.WriteResponse{Status: err.Error()}
+		}
+		if !deleteCondition(deleteRequest).match(entry) {
+			shard.deleteIntents.remove(prepareRequest.TxnId)
+			return &pb.WriteResponse{Status: pb.StatusPreconditionFailed}
+		}
+	}
+
+	position, err := shard.logMutation(&pb.LogEntry{
+		DeleteIntent: &pb.DeleteIntent{
+			TxnId:        prepareRequest.TxnId,
+			Phase:        pb.DeleteIntent_PREPARE,
+			Deletes:      prepareRequest.Deletes,
+			PreparedAtNs: preparedAtNs,
+		},
+	})
+	if err == nil {
+		err = shard.lm.Flush()
+	}
+	if err != nil {
+		shard.deleteIntents.remove(prepareRequest.TxnId)
+		return &pb.WriteResponse{Status: fmt.Sprintf("log delete intent: %v", err)}
+	}
+	shard.deleteIntents.setLogged(prepareRequest.TxnId, position.Segment)
+
+	return &pb.WriteResponse{Ok: true}
+}
+
+// processCommitDelete applies the deletes staged for the transaction, each logged as a normal delete.
+// The conditions were checked when prepared, and the keys are reserved since, so they are not checked again.
+// If any delete fails, the transaction stays prepared, and committing again applies all the deletes again.
+func (ss *storeServer) processCommitDelete(shard *shard, commitRequest *pb.CommitDeleteRequest) *pb.WriteResponse {
+
+	deletes, found := shard.deleteIntents.get(commitRequest.TxnId)
+	if !found {
+		return &pb.WriteResponse{Status: fmt.Sprintf("transaction %s not prepared", commitRequest.TxnId)}
+	}
+
+	for _, deleteRequest := range deletes {
+		unconditional := *deleteRequest
+		unconditional.ExpectedValue, unconditional.ExpectedUpdatedAtNs = nil, 0
+		if resp := ss.processTxnDelete(shard, &unconditional, commitRequest.TxnId); !resp.Ok {
+			return &pb.WriteResponse{Status: fmt.Sprintf("commit transaction %s: %s", commitRequest.TxnId, resp.Status)}
+		}
+	}
+
+	if err := shard.logDeleteIntent(&pb.DeleteIntent{
+		TxnId: commitRequest.TxnId,
+		Phase: pb.DeleteIntent_COMMIT,
+	}); err != nil {
+		// the deletes are applied, and committing again after recovery only deletes them again
+		glog.Errorf("%s commit transaction %s: %v", shard, commitRequest.TxnId, err)
+	}
+	shard.releaseDeleteIntent(commitRequest.TxnId)
+
+	return &pb.WriteResponse{Ok: true}
+}
+
+// processAbortDelete drops the deletes staged for the transaction, and releases their keys.
+// Aborting a transaction not prepared, e.g., already aborted, or failed to prepare, also succeeds,
+// so that the coordinator can safely retry.
+func (ss *storeServer) processAbortDelete(shard *shard, abortRequest *pb.AbortDeleteRequest) *pb.WriteResponse {
+
+	shard.abortDeleteIntent(abortRequest.TxnId)
+
+	return &pb.WriteResponse{Ok: true}
+}
+
+// abortDeleteIntent drops the transaction if staged, and logs the abort.
+func (s *shard) abortDeleteIntent(txnId string) {
+
+	if !s.releaseDeleteIntent(txnId) {
+		return
+	}
+
+	if err := s.logDeleteIntent(&pb.DeleteIntent{
+		TxnId: txnId,
+		Phase: pb.DeleteIntent_ABORT,
+	}); err != nil {
+		// the transaction would be prepared again after recovery, and can be aborted again
+		glog.Errorf("%s abort transaction %s: %v", s, txnId, err)
+	}
+
+}
+
+// abortExpiredDeleteIntents aborts the transactions prepared longer than the intent ttl ago,
+// e.g., when the coordinator is gone, so that their keys and their binlog segments are released.
+func (s *shard) abortExpiredDeleteIntents() {
+
+	if s.intentTtl <= 0 {
+		return
+	}
+
+	before := uint64(time.Now().Add(-s.intentTtl).UnixNano())
+	for _, txnId := range s.deleteIntents.expired(before) {
+		glog.Warningf("%s aborts transaction %s prepared over %v ago", s, txnId, s.intentTtl)
+		s.abortDeleteIntent(txnId)
+	}
+
+}
+
+// releaseDeleteIntent drops the transaction if staged, and saves the followed intents if it was followed.
+func (s *shard) releaseDeleteIntent(txnId string) (found bool) {
+	followed := s.deleteIntents.isFollowed(txnId)
+	if found = s.deleteIntents.remove(txnId); found && followed {
+		s.saveFollowedIntents()
+	}
+	return found
+}
+
+func (s *shard) logDeleteIntent(intent *pb.DeleteIntent) error {
+	_, err := s.logMutation(&pb.LogEntry{
+		DeleteIntent: intent,
+	})
+	return err
+}
+
+// followDeleteIntent stages or drops the transaction replicated from the primary,
+// so that it can still be committed or aborted if this shard becomes the primary.
+// The replicated intents are saved in the followed intent file, to be recovered when the shard is opened again.
+func (s *shard) followDeleteIntent(intent *pb.DeleteIntent) {
+	if intent.Phase != pb.DeleteIntent_PREPARE {
+		s.releaseDeleteIntent(intent.TxnId)
+		return
+	}
+	if isStaged, err := s.deleteIntents.stage(intent.TxnId, intent.Deletes, preparedAt(intent)); err != nil || isStaged {
+		if err != nil {
+			glog.V(1).Infof("%s follow prepared transaction %s: %v", s, intent.TxnId, err)
+		}
+		return
+	}
+	s.deleteIntents.setFollowed(intent.TxnId)
+	s.saveFollowedIntents()
+}
+
+func (s *shard) saveFollowedIntents() {
+	if err := s.followedIntents.save(s.deleteIntents.followedIntents()); err != nil {
+		glog.Errorf("%s save followed delete intents: %v", s, err)
+	}
+}
+
+// preparedAt returns when the transaction is prepared, or now for the intents logged without the time.
+func preparedAt(intent *pb.DeleteIntent) uint64 {
+	if intent.PreparedAtNs == 0 {
+		return uint64(time.Now().UnixNano())
+	}
+	return intent.PreparedAtNs
+}
+
+// recoverDeleteIntents stages the transactions prepared but not committed or aborted in the binlog,
+// and the transactions followed from the primary in the followed intent file.
+// The segments with the prepare intents of the staged transactions are not purged, nor are the intents compacted,
+// so that no prepared transaction is lost.
+func (s *shard) recoverDeleteIntents() error {
+	if err := s.recoverLoggedDeleteIntents(); err != nil {
+		return err
+	}
+	return s.recoverFollowedDeleteIntents()
+}
+
+func (s *shard) recoverLoggedDeleteIntents() error {
+
+	if s.lm == nil {
+		return nil
+	}
+
+	type preparedIntent struct {
+		deletes      []*pb.DeleteRequest
+		preparedAtNs uint64
+		segment      uint32
+	}
+
+	start, _ := s.lm.GetSegmentRange()
+	prepared := make(map[string]preparedIntent)
+	err := s.lm.ScanEntries(start, func(segment uint32, entry *pb.LogEntry) error {
+		intent := entry.GetDeleteIntent()
+		if intent == nil {
+			return nil
+		}
+		if intent.Phase == pb.DeleteIntent_PREPARE {
+			prepared[intent.TxnId] = preparedIntent{deletes: intent.Deletes, preparedAtNs: preparedAt(intent), segment: segment}
+		} else {
+			delete(prepared, intent.TxnId)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("scan delete intents: %v", err)
+	}
+
+	for txnId, intent := range prepared {
+		glog.V(1).Infof("%s recovered prepared transaction %s with %d deletes", s, txnId, len(intent.deletes))
+		if _, err = s.deleteIntents.stage(txnId, intent.deletes, intent.preparedAtNs); err != nil {
+			glog.Errorf("%s recover prepared transaction %s: %v", s, txnId, err)
+			continue
+		}
+		s.deleteIntents.setLogged(txnId, intent.segment)
+	}
+	return nil
+}
+
+func (s *shard) recoverFollowedDeleteIntents() error {
+
+	followed, err := s.followedIntents.load()
+	if err != nil {
+		return err
+	}
+
+	for _, intent := range followed {
+		glog.V(1).Infof("%s recovered followed transaction %s with %d deletes", s, intent.TxnId, len(intent.Deletes))
+		isStaged, err := s.deleteIntents.stage(intent.TxnId, intent.Deletes, preparedAt(intent))
+		if err != nil {
+			glog.Errorf("%s recover followed transaction %s: %v", s, intent.TxnId, err)
+			continue
+		}
+		if !isStaged {
+			s.deleteIntents.setFollowed(intent.TxnId)
+		}
+	}
+	return nil
+}
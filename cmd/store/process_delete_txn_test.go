@@ -0,0 +1,171 @@
+package store
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/chrislusf/vasto/pb"
+)
+
+func TestTwoPhaseDelete(t *testing.T) {
+
+	s, ss, cleanup := newTestShard(t, "delete_txn")
+	defer cleanup()
+
+	for _, key := range []string{"k1", "k2", "k3"} {
+		ss.processPut(s, &pb.PutRequest{Key: []byte(key), Value: []byte(key)})
+	}
+	exists := func(key string) bool {
+		entry, _ := s.getLiveEntry([]byte(key))
+		return entry != nil
+	}
+	deletes := func(keys ...string) (deleteRequests []*pb.DeleteRequest) {
+		for _, key := range keys {
+			deleteRequests = append(deleteRequests, &pb.DeleteRequest{Key: []byte(key)})
+		}
+		return
+	}
+
+	if resp := ss.processPrepareDelete(s, &pb.PrepareDeleteRequest{TxnId: "t1", Deletes: deletes("k1", "k2")}); !resp.Ok {
+		t.Fatalf("prepare t1: %+v", resp)
+	}
+	if !exists("k1") || !exists("k2") {
+		t.Errorf("prepared deletes should not be applied")
+	}
+	if resp := ss.processPrepareDelete(s, &pb.PrepareDeleteRequest{TxnId: "t1", Deletes: deletes("k3")}); resp.Ok {
+		t.Errorf("prepare t1 twice with other deletes")
+	}
+	if resp := ss.processPrepareDelete(s, &pb.PrepareDeleteRequest{TxnId: "t1", Deletes: deletes("k1", "k2")}); !resp.Ok {
+		t.Errorf("retried prepare t1: %+v", resp)
+	}
+	failing := &pb.DeleteRequest{Key: []byte("k3"), ExpectedValue: []byte("other")}
+	if resp := ss.processPrepareDelete(s, &pb.PrepareDeleteRequest{TxnId: "t2", Deletes: []*pb.DeleteRequest{failing}}); resp.Status != pb.StatusPreconditionFailed {
+		t.Errorf("prepare with failing condition: %+v", resp)
+	}
+
+	if resp := ss.processCommitDelete(s, &pb.CommitDeleteRequest{TxnId: "t1"}); !resp.Ok {
+		t.Fatalf("commit t1: %+v", resp)
+	}
+	if exists("k1") || exists("k2") || !exists("k3") {
+		t.Errorf("committed deletes should be applied")
+	}
+	if resp := ss.processCommitDelete(s, &pb.CommitDeleteRequest{TxnId: "t1"}); resp.Ok {
+		t.Errorf("commit t1 twice")
+	}
+
+	ss.processPrepareDelete(s, &pb.PrepareDeleteRequest{TxnId: "t3", Deletes: deletes("k3")})
+	if resp := ss.processAbortDelete(s, &pb.AbortDeleteRequest{TxnId: "t3"}); !resp.Ok {
+		t.Errorf("abort t3: %+v", resp)
+	}
+	if resp := ss.processAbortDelete(s, &pb.AbortDeleteRequest{TxnId: "t3"}); !resp.Ok {
+		t.Errorf("abort t3 again should succeed: %+v", resp)
+	}
+	if resp := ss.processAbortDelete(s, &pb.AbortDeleteRequest{TxnId: "unknown"}); !resp.Ok {
+		t.Errorf("abort unknown transaction should succeed: %+v", resp)
+	}
+
+	// the keys are reserved until the transaction ends
+	ss.processPrepareDelete(s, &pb.PrepareDeleteRequest{TxnId: "t4", Deletes: deletes("k3")})
+	if resp := ss.processPut(s, &pb.PutRequest{Key: []byte("k3"), Value: []byte("changed")}); resp.Ok {
+		t.Errorf("put to a reserved key")
+	}
+	if resp := ss.processMerge(s, &pb.MergeRequest{Key: []byte("k3"), Value: []byte("changed")}); resp.Ok {
+		t.Errorf("merge to a reserved key")
+	}
+	if resp := ss.processDelete(s, &pb.DeleteRequest{Key: []byte("k3")}); resp.Ok {
+		t.Errorf("delete of a reserved key")
+	}
+	if resp := ss.processPrepareDelete(s, &pb.PrepareDeleteRequest{TxnId: "t5", Deletes: deletes("k3")}); resp.Ok {
+		t.Errorf("prepare a reserved key for another transaction")
+	}
+
+	// followers stage the replicated intents, and drop them when committed or aborted
+	followerDir, err := ioutil.TempDir("", "delete_txn_follower")
+	if err != nil {
+		t.Fatalf("temp dir: %v", err)
+	}
+	defer os.RemoveAll(followerDir)
+	follower := newShard("ks1", followerDir, 1, 0, nil, nil, 1, 0, 0, false)
+	defer follower.db.Close()
+	defer follower.shutdownNode()
+	s.lm.ScanEntries(0, func(segment uint32, entry *pb.LogEntry) error {
+		return follower.processEntry(entry)
+	})
+	if txnDeletes, found := follower.deleteIntents.get("t4"); !found || len(txnDeletes) != 1 {
+		t.Errorf("follower staged transactions: %+v", follower.deleteIntents.staged)
+	}
+	if len(follower.deleteIntents.staged) != 1 {
+		t.Errorf("follower should only stage t4: %+v", follower.deleteIntents.staged)
+	}
+	// the followed intents are recovered, e.g., after the follower restarts
+	follower.deleteIntents = deleteIntents{}
+	if err := follower.recoverDeleteIntents(); err != nil {
+		t.Fatalf("follower recover delete intents: %v", err)
+	}
+	if _, found := follower.deleteIntents.get("t4"); !found || len(follower.deleteIntents.staged) != 1 {
+		t.Errorf("follower recovered transactions: %+v", follower.deleteIntents.staged)
+	}
+
+	// only the prepared transaction is recovered, e.g., after a restart
+	s.deleteIntents = deleteIntents{}
+	if err := s.recoverDeleteIntents(); err != nil {
+		t.Fatalf("recover delete intents: %v", err)
+	}
+	if len(s.deleteIntents.staged) != 1 {
+		t.Errorf("recovered transactions: %+v", s.deleteIntents.staged)
+	}
+	if segment, found := s.deleteIntents.earliestSegment(); !found || segment != 0 {
+		t.Errorf("retained segment %d %v", segment, found)
+	}
+	if resp := ss.processCommitDelete(s, &pb.CommitDeleteRequest{TxnId: "t4"}); !resp.Ok || exists("k3") {
+		t.Errorf("commit recovered t4: %+v", resp)
+	}
+	if _, found := s.deleteIntents.earliestSegment(); found {
+		t.Errorf("no segment retained after commit")
+	}
+	if resp := ss.processPut(s, &pb.PutRequest{Key: []byte("k3"), Value: []byte("k3")}); !resp.Ok {
+		t.Errorf("put after the commit released the key: %+v", resp)
+	}
+
+}
+
+func TestDeleteIntentTtl(t *testing.T) {
+
+	s, ss, cleanup := newTestShard(t, "delete_intent_ttl")
+	defer cleanup()
+
+	ss.processPut(s, &pb.PutRequest{Key: []byte("k1"), Value: []byte("v1")})
+	if resp := ss.processPrepareDelete(s, &pb.PrepareDeleteRequest{TxnId: "t1", Deletes: []*pb.DeleteRequest{{Key: []byte("k1")}}}); !resp.Ok {
+		t.Fatalf("prepare t1: %+v", resp)
+	}
+
+	s.abortExpiredDeleteIntents()
+	if _, found := s.deleteIntents.get("t1"); !found {
+		t.Errorf("t1 aborted before the intent ttl")
+	}
+
+	s.intentTtl = time.Millisecond
+	time.Sleep(2 * time.Millisecond)
+	s.abortExpiredDeleteIntents()
+	if _, found := s.deleteIntents.get("t1"); found {
+		t.Errorf("t1 not aborted after the intent ttl")
+	}
+	if _, found := s.deleteIntents.earliestSegment(); found {
+		t.Errorf("segment retained after the abort")
+	}
+	if resp := ss.processPut(s, &pb.PutRequest{Key: []byte("k1"), Value: []byte("v2")}); !resp.Ok {
+		t.Errorf("put after the abort released the key: %+v", resp)
+	}
+
+	// the abort is logged, so the followers and the recovery also drop the transaction
+	s.deleteIntents = deleteIntents{}
+	if err := s.recoverDeleteIntents(); err != nil {
+		t.Fatalf("recover delete intents: %v", err)
+	}
+	if len(s.deleteIntents.staged) != 0 {
+		t.Errorf("recovered transactions: %+v", s.deleteIntents.staged)
+	}
+
+}
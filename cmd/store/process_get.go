@@ -1,37 +1,99 @@
 package store
 
 import (
+	"time"
+
+	"github.com/chrislusf/glog"
 	"github.com/chrislusf/vasto/pb"
 	"github.com/chrislusf/vasto/storage/codec"
 )
 
-func (ss *storeServer) processGet(shard *shard, getRequest *pb.GetRequest) *pb.GetResponse {
+func (ss *storeServer) processGet(shard *shard, getRequest *pb.GetRequest) (resp *pb.GetResponse) {
+	defer func() {
+		ss.metrics.record(metricsOpGet, resp.Ok, resp.Status)
+	}()
+
+	if err := shard.checkOwnership(getRequest.PartitionHash); err != nil {
+		return &pb.GetResponse{
+			Status: err.Error(),
+		}
+	}
+
 	key := getRequest.Key
 	// println("replica", replica, "shard", shards[replica].id, "keyspace", shards[replica].keyspace, "server", shards[replica].serverId, "request", getRequest.String())
-	if b, err := shard.db.Get(key); err != nil {
+	startTime := time.Now()
+	b, err := shard.db.Get(key)
+	ss.metrics.observe(metricsOpGet, startTime)
+	if err != nil {
 		return &pb.GetResponse{
 			Status: err.Error(),
 		}
-	} else if len(b) == 0 {
+	}
+	return ss.toGetResponse(shard, key, b, getRequest.IncludeDeleted)
+}
+
+// toGetResponse returns the stored value of the key, or an empty KeyValue if the value is empty, expired,
+// or soft deleted unless includeDeleted is set.
+func (ss *storeServer) toGetResponse(shard *shard, key []byte, b []byte, includeDeleted bool) *pb.GetResponse {
+	if len(b) == 0 {
 		return &pb.GetResponse{
 			Ok: true,
 		}
-	} else {
-		entry := codec.FromBytes(b)
-		if entry.IsExpired() {
-			return &pb.GetResponse{
-				Ok:     false,
-				Status: "expired",
-			}
+	}
+	entry := codec.FromBytes(b)
+	if entry.IsExpired() {
+		ss.dropExpired(shard, key)
+		return &pb.GetResponse{
+			Ok: true,
 		}
+	}
+	if entry.IsDeleted() && !includeDeleted {
 		return &pb.GetResponse{
 			Ok: true,
-			KeyValue: &pb.KeyTypeValue{
-				Key:           key,
-				PartitionHash: entry.PartitionHash,
-				DataType:      pb.OpAndDataType(entry.OpAndDataType),
-				Value:         entry.Value,
-			},
 		}
 	}
+	return &pb.GetResponse{
+		Ok: true,
+		KeyValue: &pb.KeyTypeValue{
+			Key:           key,
+			PartitionHash: entry.PartitionHash,
+			DataType:      pb.OpAndDataType(entry.OpAndDataType),
+			Value:         entry.Value,
+			DeletedAtNs:   entry.DeletedAtNs,
+			Version:       entry.Version.ToPb(),
+		},
+	}
+}
+
+// processBatchGet reads all the keys in one call.
+// The results are in the same order as the keys, and a missing key has an empty KeyValue.
+// The soft deleted values are returned if IncludeDeleted is set, the same as processGet.
+func (ss *storeServer) processBatchGet(shard *shard, batchGetRequest *pb.BatchGetRequest) *pb.BatchGetResponse {
+	if err := shard.checkOwnership(batchGetRequest.PartitionHash); err != nil {
+		return &pb.BatchGetResponse{
+			Status: err.Error(),
+		}
+	}
+
+	values, err := shard.db.MultiGet(batchGetRequest.Keys)
+	if err != nil {
+		return &pb.BatchGetResponse{
+			Status: err.Error(),
+		}
+	}
+
+	resp := &pb.BatchGetResponse{
+		Ok: true,
+	}
+	for i, key := range batchGetRequest.Keys {
+		resp.Results = append(resp.Results, ss.toGetResponse(shard, key, values[i], batchGetRequest.IncludeDeleted))
+	}
+	return resp
+}
+
+// dropExpired lazily deletes the expired key found on read, so that it reads as not found afterwards too.
+func (ss *storeServer) dropExpired(shard *shard, key []byte) {
+	if _, err := shard.dropExpired(key, !*ss.option.DisableBinLog); err != nil {
+		glog.V(1).Infof("%s drop expired %s: %v", shard, string(key), err)
+	}
 }
@@ -3,7 +3,6 @@ package store
 import (
 	"time"
 
-	"github.com/chrislusf/glog"
 	"github.com/chrislusf/vasto/pb"
 	"github.com/chrislusf/vasto/storage/codec"
 )
@@ -21,40 +20,52 @@ func (ss *storeServer) processMerge(shard *shard, mergeRequest *pb.MergeRequest)
 		Ok: true,
 	}
 
+	if err := ss.validateKey(mergeRequest.Key); err != nil {
+		resp.Ok = false
+		resp.Status = err.Error()
+		return resp
+	}
+
+	if err := shard.checkOwnership(mergeRequest.PartitionHash); err != nil {
+		resp.Ok = false
+		resp.Status = err.Error()
+		return resp
+	}
+
 	// glog.V(2).Infof"shard %d put key: %v\n", shard.id, string(mergeRequest.KeyValue.Key))
 
+	unlock := shard.keyLocks.lock(key)
+	defer unlock()
+
+	if err := shard.deleteIntents.checkReserved(key, ""); err != nil {
+		resp.Ok = false
+		resp.Status = err.Error()
+		return resp
+	}
+
 	err := shard.db.Merge(key, entry.ToBytes())
 	if err != nil {
 		resp.Ok = false
 		resp.Status = err.Error()
 	} else {
 		if !*ss.option.DisableBinLog {
-			shard.logMerge(mergeRequest, nowInNano)
+			if err = shard.logMerge(mergeRequest, nowInNano); err != nil {
+				// the followers would miss this merge
+				resp.Ok = false
+				resp.Status = errWriteNotLogged(err).Error()
+			}
 		}
 	}
 
 	return resp
 }
 
-func (s *shard) logMerge(mergeRequest *pb.MergeRequest, updatedAtNs uint64) {
-
-	// println("logMerge1", mergeRequest.String())
-
-	if s.lm == nil {
-		return
-	}
-
-	// println("logMerge2", mergeRequest.String())
-
-	err := s.lm.AppendEntry(&pb.LogEntry{
+// logMerge appends the merge to the binlog.
+// An error means the merge is applied to the db, but not replicated to the followers.
+func (s *shard) logMerge(mergeRequest *pb.MergeRequest, updatedAtNs uint64) error {
+	_, err := s.logMutation(&pb.LogEntry{
 		UpdatedAtNs: updatedAtNs,
 		Merge:       mergeRequest,
 	})
-
-	if err != nil {
-		glog.Errorf("append put log entry: %v", err)
-	}
-
-	// println("logMerge3", mergeRequest.String())
-
+	return err
 }
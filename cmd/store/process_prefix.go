@@ -1,23 +1,30 @@
 package store
 
 import (
+	"context"
+
 	"github.com/chrislusf/vasto/pb"
 	"github.com/chrislusf/vasto/storage/codec"
 )
 
-func (ss *storeServer) processPrefix(shard *shard, prefixRequest *pb.GetByPrefixRequest) *pb.GetByPrefixResponse {
+// processPrefix scans the live entries keyed with the prefix, until ctx is done or the shard is shut down.
+func (ss *storeServer) processPrefix(ctx context.Context, shard *shard, prefixRequest *pb.GetByPrefixRequest) *pb.GetByPrefixResponse {
+
+	ctx, cancel := shard.requestContext(ctx)
+	defer cancel()
 
 	var keyValues []*pb.KeyTypeValue
 	resp := &pb.GetByPrefixResponse{
 		Ok: true,
 	}
-	err := shard.db.PrefixScan(
+	err := shard.db.PrefixScanContext(
+		ctx,
 		prefixRequest.Prefix,
 		prefixRequest.LastSeenKey,
 		int(prefixRequest.Limit),
 		func(key, value []byte) bool {
 			entry := codec.FromBytes(value)
-			if !entry.IsExpired() {
+			if !entry.IsExpired() && !entry.IsDeleted() {
 				t := make([]byte, len(key))
 				copy(t, key)
 				keyValues = append(keyValues, &pb.KeyTypeValue{
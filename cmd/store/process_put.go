@@ -3,11 +3,19 @@ package store
 import (
 	"time"
 
-	"github.com/chrislusf/glog"
 	"github.com/chrislusf/vasto/pb"
 	"github.com/chrislusf/vasto/storage/codec"
+	"github.com/golang/protobuf/proto"
 )
 
+// processPut writes the entry. If the put has its own updated_at_ns,
+// and is older than the stored entry, even a soft deleted one, it is dropped, the same as when followers apply the binlog.
+// A conditional put is only written if the current entry matches the expectation, the same as the conditional delete,
+// or if the key is absent when expect_absent is set. Its own updated_at_ns is moved after the stored entry's,
+// instead of being dropped as older, so that a matched put is always written, and can be the expectation of the next conditional write.
+// A versioned put is ordered by its version instead of its updated_at_ns, see resolveVersion.
+// A key reserved by a prepared transaction of deletes is not written.
+// The hard expiry of a live entry is kept, see keepHardExpiry, and the put is logged with the kept one.
 func (ss *storeServer) processPut(shard *shard, putRequest *pb.PutRequest) *pb.WriteResponse {
 
 	key := putRequest.Key
@@ -20,41 +28,115 @@ func (ss *storeServer) processPut(shard *shard, putRequest *pb.PutRequest) *pb.W
 	resp := &pb.WriteResponse{
 		Ok: true,
 	}
+	defer func() {
+		ss.metrics.record(metricsOpPut, resp.Ok, resp.Status)
+	}()
+
+	if err := ss.validateKey(putRequest.Key); err != nil {
+		resp.Ok = false
+		resp.Status = err.Error()
+		return resp
+	}
+
+	if err := shard.checkOwnership(putRequest.PartitionHash); err != nil {
+		resp.Ok = false
+		resp.Status = err.Error()
+		return resp
+	}
 
 	// glog.V(2).Infof"shard %d put key: %v\n", shard.id, string(putRequest.KeyValue.Key))
 
-	err := shard.db.Put(key, entry.ToBytes())
+	unlock := shard.keyLocks.lock(key)
+	defer unlock()
+
+	if err := shard.deleteIntents.checkReserved(key, ""); err != nil {
+		resp.Ok = false
+		resp.Status = err.Error()
+		return resp
+	}
+
+	// the existing entry is also read to adjust the key stats
+	existing, err := shard.storedEntry(key)
+	if err != nil {
+		resp.Ok = false
+		resp.Status = err.Error()
+		return resp
+	}
+	live := liveEntry(existing)
+	condition := putCondition(putRequest)
+	if !condition.guard(live, resp) {
+		return resp
+	}
+	if entry.Version == nil && !condition.isSet() && putRequest.UpdatedAtNs != 0 && existing != nil && existing.UpdatedAtNs > putRequest.UpdatedAtNs {
+		return resp
+	}
+	if condition.isSet() && existing != nil && existing.UpdatedAtNs >= nowInNano {
+		nowInNano = existing.UpdatedAtNs + 1
+		entry.UpdatedAtNs = nowInNano
+	}
+	if entry.Version != nil {
+		nowInNano = orderVersioned(existing, entry.Version, nowInNano)
+		entry.UpdatedAtNs = nowInNano
+		resolved, concurrent := resolveVersion(existing, entry)
+		resp.Concurrent = concurrent
+		if resolved == nil {
+			resp.Version = existing.Version.ToPb()
+			return resp
+		}
+		resp.Version = resolved.Version.ToPb()
+		entry = resolved
+	}
+	keepHardExpiry(live, entry)
+
+	startTime := time.Now()
+	err = shard.putEntry(key, existing, entry)
+	ss.metrics.observe(metricsOpPut, startTime)
 	if err != nil {
 		resp.Ok = false
 		resp.Status = err.Error()
 	} else {
+		resp.UpdatedAtNs = entry.UpdatedAtNs
 		if !*ss.option.DisableBinLog {
-			shard.logPut(putRequest, nowInNano)
+			if err = shard.logPut(withHardExpiry(putRequest, entry.ExpireAtNs), nowInNano); err != nil {
+				// the followers would miss this put
+				resp.Ok = false
+				resp.Status = errWriteNotLogged(err).Error()
+			}
 		}
 	}
 
 	return resp
 }
 
-func (s *shard) logPut(putRequest *pb.PutRequest, updatedAtNs uint64) {
-
-	// println("logPut1", putRequest.String())
-
-	if s.lm == nil {
+// keepHardExpiry keeps the hard expiry of the live entry, unless the entry has an earlier one,
+// so that the later puts, e.g., refreshing the ttl, do not extend it.
+func keepHardExpiry(live, entry *codec.Entry) {
+	if live == nil || live.ExpireAtNs == 0 || entry.IsDeleted() {
 		return
 	}
+	if entry.ExpireAtNs == 0 || live.ExpireAtNs < entry.ExpireAtNs {
+		entry.ExpireAtNs = live.ExpireAtNs
+	}
+}
 
-	// println("logPut2", putRequest.String())
+// withHardExpiry returns the put with the hard expiry, copied if changed,
+// so that the followers apply the hard expiry kept by the leader instead of keeping their own.
+func withHardExpiry(putRequest *pb.PutRequest, expireAtNs uint64) *pb.PutRequest {
+	if putRequest.ExpireAtNs == expireAtNs {
+		return putRequest
+	}
+	logged := proto.Clone(putRequest).(*pb.PutRequest)
+	logged.ExpireAtNs = expireAtNs
+	return logged
+}
 
-	err := s.lm.AppendEntry(&pb.LogEntry{
+// logPut appends the put to the binlog.
+// An error means the put is applied to the db, but not replicated to the followers.
+func (s *shard) logPut(putRequest *pb.PutRequest, updatedAtNs uint64) error {
+	_, err := s.logMutation(&pb.LogEntry{
 		UpdatedAtNs: updatedAtNs,
 		Put:         putRequest,
+		Flags:       uint32(pb.PutFlags(putRequest)),
 	})
-
-	if err != nil {
-		glog.Errorf("append put log entry: %v", err)
-	}
-
-	// println("logPut3", putRequest.String())
-
+	return err
 }
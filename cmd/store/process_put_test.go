@@ -0,0 +1,191 @@
+package store
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/chrislusf/vasto/pb"
+	"github.com/chrislusf/vasto/storage/binlog"
+	"github.com/chrislusf/vasto/storage/codec"
+	"golang.org/x/net/context"
+)
+
+func TestProcessConditionalPut(t *testing.T) {
+
+	s, ss, cleanup := newTestShard(t, "conditional_put")
+	defer cleanup()
+
+	// missing key
+	resp := ss.processPut(s, &pb.PutRequest{Key: []byte("k1"), Value: []byte("v2"), ExpectedValue: []byte("v1")})
+	if resp.Ok || resp.Status != pb.StatusPreconditionFailed || resp.CurrentValue != nil || resp.CurrentUpdatedAtNs != 0 {
+		t.Errorf("conditional put of missing key: %+v", resp)
+	}
+	if b, _ := s.db.Get([]byte("k1")); len(b) != 0 {
+		t.Errorf("missing key written without matching condition")
+	}
+
+	putRequest := &pb.PutRequest{Key: []byte("k1"), Value: []byte("v1")}
+	s.db.Put(putRequest.Key, codec.NewPutEntry(putRequest, 10).ToBytes())
+
+	// mismatch
+	for _, putRequest := range []*pb.PutRequest{
+		{Key: []byte("k1"), Value: []byte("v3"), ExpectedValue: []byte("v2")},
+		{Key: []byte("k1"), Value: []byte("v3"), ExpectedUpdatedAtNs: 11},
+		{Key: []byte("k1"), Value: []byte("v3"), ExpectedValue: []byte("v1"), ExpectedUpdatedAtNs: 11},
+	} {
+		resp := ss.processPut(s, putRequest)
+		if resp.Ok || resp.Status != pb.StatusPreconditionFailed ||
+			!bytes.Equal(resp.CurrentValue, []byte("v1")) || resp.CurrentUpdatedAtNs != 10 {
+			t.Errorf("conditional put %+v: %+v", putRequest, resp)
+		}
+	}
+	if b, _ := s.db.Get([]byte("k1")); !bytes.Equal(codec.FromBytes(b).Value, []byte("v1")) {
+		t.Errorf("key written without matching condition")
+	}
+
+	// match
+	resp = ss.processPut(s, &pb.PutRequest{Key: []byte("k1"), Value: []byte("v2"), ExpectedValue: []byte("v1"), ExpectedUpdatedAtNs: 10})
+	if !resp.Ok || resp.UpdatedAtNs <= 10 {
+		t.Errorf("matched conditional put: %+v", resp)
+	}
+	if b, _ := s.db.Get([]byte("k1")); !bytes.Equal(codec.FromBytes(b).Value, []byte("v2")) {
+		t.Errorf("key not written")
+	}
+
+	// the returned version is the expectation of the next conditional put
+	resp = ss.processPut(s, &pb.PutRequest{Key: []byte("k1"), Value: []byte("v3"), ExpectedUpdatedAtNs: resp.UpdatedAtNs})
+	if !resp.Ok {
+		t.Errorf("conditional put on returned version: %+v", resp)
+	}
+
+	var logged []*pb.LogEntry
+	s.lm.ScanEntries(0, func(segment uint32, entry *pb.LogEntry) error {
+		logged = append(logged, entry)
+		return nil
+	})
+	if len(logged) != 2 {
+		t.Fatalf("logged %d entries, expecting only the 2 matched puts", len(logged))
+	}
+	if !logged[0].HasFlag(pb.LogEntryConditional) || logged[1].UpdatedAtNs != resp.UpdatedAtNs {
+		t.Errorf("logged %+v, expecting conditional put with version %d", logged[1], resp.UpdatedAtNs)
+	}
+
+	// a matched put with an older updated_at_ns is still written, after the current version
+	resp = ss.processPut(s, &pb.PutRequest{Key: []byte("k1"), Value: []byte("v4"), ExpectedValue: []byte("v3"), UpdatedAtNs: 5})
+	if !resp.Ok || resp.UpdatedAtNs <= logged[1].UpdatedAtNs {
+		t.Errorf("matched conditional put with an older version: %+v", resp)
+	}
+	if b, _ := s.db.Get([]byte("k1")); !bytes.Equal(codec.FromBytes(b).Value, []byte("v4")) {
+		t.Errorf("matched conditional put with an older version not written")
+	}
+
+}
+
+func TestProcessPutIfAbsent(t *testing.T) {
+
+	s, ss, cleanup := newTestShard(t, "put_if_absent")
+	defer cleanup()
+
+	if resp := ss.processPut(s, &pb.PutRequest{Key: []byte("k1"), Value: []byte("v1"), ExpectAbsent: true}); !resp.Ok {
+		t.Errorf("put if absent of missing key: %+v", resp)
+	}
+	resp := ss.processPut(s, &pb.PutRequest{Key: []byte("k1"), Value: []byte("v2"), ExpectAbsent: true})
+	if resp.Ok || resp.Status != pb.StatusPreconditionFailed || !bytes.Equal(resp.CurrentValue, []byte("v1")) {
+		t.Errorf("put if absent of existing key: %+v", resp)
+	}
+
+	// a soft deleted key is absent, and the put is after the delete
+	deleteResp := ss.processDelete(s, &pb.DeleteRequest{Key: []byte("k1"), Soft: true, UpdatedAtNs: uint64(time.Now().Add(time.Hour).UnixNano())})
+	if !deleteResp.Ok {
+		t.Fatalf("soft delete: %+v", deleteResp)
+	}
+	if resp := ss.processPut(s, &pb.PutRequest{Key: []byte("k1"), Value: []byte("v3"), ExpectAbsent: true}); !resp.Ok {
+		t.Errorf("put if absent of soft deleted key: %+v", resp)
+	}
+	if entry, _ := s.getLiveEntry([]byte("k1")); entry == nil || !bytes.Equal(entry.Value, []byte("v3")) {
+		t.Errorf("put if absent of soft deleted key not written: %+v", entry)
+	}
+
+}
+
+func TestProcessPutOlderThanSoftDelete(t *testing.T) {
+
+	s, ss, cleanup := newTestShard(t, "put_soft_deleted")
+	defer cleanup()
+
+	ss.processPut(s, &pb.PutRequest{Key: []byte("k1"), Value: []byte("v1"), UpdatedAtNs: 10})
+	ss.processDelete(s, &pb.DeleteRequest{Key: []byte("k1"), Soft: true, UpdatedAtNs: 20})
+
+	if resp := ss.processPut(s, &pb.PutRequest{Key: []byte("k1"), Value: []byte("v2"), UpdatedAtNs: 15}); !resp.Ok {
+		t.Errorf("older put: %+v", resp)
+	}
+	if entry, _ := s.getLiveEntry([]byte("k1")); entry != nil {
+		t.Errorf("older put resurrected the soft deleted key: %+v", entry)
+	}
+
+	if resp := ss.processPut(s, &pb.PutRequest{Key: []byte("k1"), Value: []byte("v3"), UpdatedAtNs: 30}); !resp.Ok {
+		t.Errorf("newer put: %+v", resp)
+	}
+	if entry, _ := s.getLiveEntry([]byte("k1")); entry == nil || !bytes.Equal(entry.Value, []byte("v3")) {
+		t.Errorf("newer put after the soft delete: %+v", entry)
+	}
+
+}
+
+func TestProcessPutKeepsHardExpiry(t *testing.T) {
+
+	s, ss, cleanup := newTestShard(t, "put_hard_expiry")
+	defer cleanup()
+
+	expireAtNs := uint64(time.Now().Add(time.Hour).UnixNano())
+	expireAt := func() uint64 {
+		b, _ := s.db.Get([]byte("k1"))
+		return codec.FromBytes(b).ExpireAtNs
+	}
+
+	if resp := ss.processPut(s, &pb.PutRequest{Key: []byte("k1"), Value: []byte("v1"), TtlSecond: 60, ExpireAtNs: expireAtNs}); !resp.Ok {
+		t.Fatalf("put with hard expiry: %+v", resp)
+	}
+
+	// refreshing the ttl keeps the hard expiry
+	if resp := ss.processPut(s, &pb.PutRequest{Key: []byte("k1"), Value: []byte("v2"), TtlSecond: 60}); !resp.Ok {
+		t.Fatalf("put without hard expiry: %+v", resp)
+	}
+	if got := expireAt(); got != expireAtNs {
+		t.Errorf("hard expiry after a put without one: %d, expected %d", got, expireAtNs)
+	}
+	// the followers apply the kept hard expiry
+	entries, err := s.lm.ReadEntriesFrom(context.Background(), binlog.LogPosition{}, 2)
+	if err != nil || len(entries) != 2 || entries[1].Entry.GetPut().GetExpireAtNs() != expireAtNs {
+		t.Errorf("logged put without hard expiry: %v %+v", err, entries)
+	}
+
+	// a later hard expiry does not extend it
+	if resp := ss.processPut(s, &pb.PutRequest{Key: []byte("k1"), Value: []byte("v3"), ExpireAtNs: expireAtNs + 1}); !resp.Ok {
+		t.Fatalf("put with a later hard expiry: %+v", resp)
+	}
+	if got := expireAt(); got != expireAtNs {
+		t.Errorf("hard expiry after a put with a later one: %d, expected %d", got, expireAtNs)
+	}
+
+	// an earlier hard expiry applies
+	if resp := ss.processPut(s, &pb.PutRequest{Key: []byte("k1"), Value: []byte("v4"), ExpireAtNs: expireAtNs - 1}); !resp.Ok {
+		t.Fatalf("put with an earlier hard expiry: %+v", resp)
+	}
+	if got := expireAt(); got != expireAtNs-1 {
+		t.Errorf("hard expiry after a put with an earlier one: %d, expected %d", got, expireAtNs-1)
+	}
+
+	// a deleted key starts over
+	if resp := ss.processDelete(s, &pb.DeleteRequest{Key: []byte("k1")}); !resp.Ok {
+		t.Fatalf("delete: %+v", resp)
+	}
+	if resp := ss.processPut(s, &pb.PutRequest{Key: []byte("k1"), Value: []byte("v5")}); !resp.Ok {
+		t.Fatalf("put after delete: %+v", resp)
+	}
+	if got := expireAt(); got != 0 {
+		t.Errorf("hard expiry after a put over a deleted key: %d", got)
+	}
+
+}
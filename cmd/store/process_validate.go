@@ -0,0 +1,39 @@
+package store
+
+import (
+	"fmt"
+)
+
+const (
+	statusInvalidKey = "invalid key"
+
+	// defaultMaxKeyLength is used when the store option does not set the max key length, e.g., in tests
+	defaultMaxKeyLength = 64 * 1024
+)
+
+// validateKey rejects an empty key, or a key longer than the max key length, before any db or binlog work.
+func (ss *storeServer) validateKey(key []byte) error {
+	return validateKey(key, ss.maxKeyLength())
+}
+
+// maxKeyLength returns the max key length in bytes, or 0 for no limit.
+func (ss *storeServer) maxKeyLength() int {
+	if ss.option == nil || ss.option.MaxKeyLength == nil {
+		return defaultMaxKeyLength
+	}
+	if *ss.option.MaxKeyLength < 0 {
+		return 0
+	}
+	return *ss.option.MaxKeyLength
+}
+
+// validateKey checks the key is not empty, and is not longer than maxKeyLength unless it is 0.
+func validateKey(key []byte, maxKeyLength int) error {
+	if len(key) == 0 {
+		return fmt.Errorf("%s: empty", statusInvalidKey)
+	}
+	if maxKeyLength > 0 && len(key) > maxKeyLength {
+		return fmt.Errorf("%s: %d bytes over the max key length %d", statusInvalidKey, len(key), maxKeyLength)
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+package store
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/chrislusf/vasto/pb"
+)
+
+func TestValidateKey(t *testing.T) {
+
+	if err := validateKey(nil, 8); err == nil || !strings.HasPrefix(err.Error(), statusInvalidKey) {
+		t.Errorf("nil key: %v", err)
+	}
+	if err := validateKey([]byte{}, 8); err == nil || !strings.HasPrefix(err.Error(), statusInvalidKey) {
+		t.Errorf("empty key: %v", err)
+	}
+	if err := validateKey(bytes.Repeat([]byte("k"), 9), 8); err == nil || !strings.HasPrefix(err.Error(), statusInvalidKey) {
+		t.Errorf("too long key: %v", err)
+	}
+	if err := validateKey(bytes.Repeat([]byte("k"), 8), 8); err != nil {
+		t.Errorf("valid key: %v", err)
+	}
+	if err := validateKey(bytes.Repeat([]byte("k"), 9), 0); err != nil {
+		t.Errorf("no max key length: %v", err)
+	}
+
+}
+
+func TestProcessInvalidKey(t *testing.T) {
+
+	s, ss, cleanup := newTestShard(t, "invalid_key")
+	defer cleanup()
+
+	maxKeyLength := 8
+	ss.option.MaxKeyLength = &maxKeyLength
+
+	tooLong := bytes.Repeat([]byte("k"), 9)
+	for _, key := range [][]byte{nil, tooLong} {
+		if resp := ss.processPut(s, &pb.PutRequest{Key: key, Value: []byte("v1")}); resp.Ok || !strings.HasPrefix(resp.Status, statusInvalidKey) {
+			t.Errorf("put key %q: %+v", key, resp)
+		}
+		if resp := ss.processDelete(s, &pb.DeleteRequest{Key: key}); resp.Ok || !strings.HasPrefix(resp.Status, statusInvalidKey) {
+			t.Errorf("delete key %q: %+v", key, resp)
+		}
+	}
+	if b, _ := s.db.Get(tooLong); len(b) != 0 {
+		t.Errorf("too long key written")
+	}
+
+	if resp := ss.processPut(s, &pb.PutRequest{Key: []byte("k1"), Value: []byte("v1")}); !resp.Ok {
+		t.Errorf("put valid key: %+v", resp)
+	}
+
+	resp := ss.processBatchDelete(s, &pb.BatchDeleteRequest{Deletes: []*pb.DeleteRequest{
+		{Key: []byte("k1")},
+		{Key: tooLong},
+	}})
+	if !resp.Results[0].Ok || !resp.Results[0].Existed {
+		t.Errorf("batch delete valid key: %+v", resp.Results[0])
+	}
+	if resp.Results[1].Ok || !strings.HasPrefix(resp.Results[1].Status, statusInvalidKey) {
+		t.Errorf("batch delete too long key: %+v", resp.Results[1])
+	}
+
+}
@@ -0,0 +1,77 @@
+package store
+
+import (
+	"bytes"
+
+	"github.com/chrislusf/vasto/pb"
+	"github.com/chrislusf/vasto/storage/codec"
+)
+
+// writeCondition is the expected current entry of a conditional put or delete.
+// Both are checked by the same guard, holding the key lock from reading the current entry to applying the write,
+// so that the write applies only to the entry it was compared with.
+type writeCondition struct {
+	expectedValue       []byte
+	expectedUpdatedAtNs uint64
+	expectAbsent        bool
+}
+
+func deleteCondition(deleteRequest *pb.DeleteRequest) writeCondition {
+	return writeCondition{
+		expectedValue:       deleteRequest.ExpectedValue,
+		expectedUpdatedAtNs: deleteRequest.ExpectedUpdatedAtNs,
+	}
+}
+
+func putCondition(putRequest *pb.PutRequest) writeCondition {
+	return writeCondition{
+		expectedValue:       putRequest.ExpectedValue,
+		expectedUpdatedAtNs: putRequest.ExpectedUpdatedAtNs,
+		expectAbsent:        putRequest.ExpectAbsent,
+	}
+}
+
+func (c writeCondition) isSet() bool {
+	return len(c.expectedValue) > 0 || c.expectedUpdatedAtNs != 0 || c.expectAbsent
+}
+
+// match checks the expected value and updated_at_ns against the current live entry,
+// which is nil if the key is not found, expired, or soft deleted. It always matches if the condition is not set.
+// A condition expecting the key absent only matches a nil entry.
+func (c writeCondition) match(entry *codec.Entry) bool {
+
+	if !c.isSet() {
+		return true
+	}
+
+	if c.expectAbsent {
+		return entry == nil
+	}
+
+	if entry == nil {
+		return false
+	}
+
+	if c.expectedUpdatedAtNs != 0 && entry.UpdatedAtNs != c.expectedUpdatedAtNs {
+		return false
+	}
+	if len(c.expectedValue) > 0 && !bytes.Equal(entry.Value, c.expectedValue) {
+		return false
+	}
+	return true
+}
+
+// guard checks the condition against the current live entry. If not matched, it fails the response
+// with the precondition failed status and the current value and updated_at_ns, so that the client can retry.
+func (c writeCondition) guard(entry *codec.Entry, resp *pb.WriteResponse) bool {
+	if c.match(entry) {
+		return true
+	}
+	resp.Ok = false
+	resp.Status = pb.StatusPreconditionFailed
+	if entry != nil {
+		resp.CurrentValue = entry.Value
+		resp.CurrentUpdatedAtNs = entry.UpdatedAtNs
+	}
+	return false
+}
@@ -6,7 +6,6 @@ import (
 	"github.com/chrislusf/glog"
 	"github.com/chrislusf/vasto/pb"
 	"github.com/chrislusf/vasto/storage/binlog"
-	"github.com/chrislusf/vasto/storage/rocks"
 	"github.com/chrislusf/vasto/topology"
 	"github.com/chrislusf/vasto/topology/clusterlistener"
 	"github.com/chrislusf/vasto/util"
@@ -25,7 +24,7 @@ type shard struct {
 	keyspace            string
 	id                  VastoShardId
 	serverId            VastoServerId
-	db                  *rocks.Rocks
+	db                  shardDb
 	lm                  *binlog.LogManager
 	cluster             *topology.Cluster
 	clusterListener     *clusterlistener.ClusterListener
@@ -34,11 +33,26 @@ type shard struct {
 	isShutdown          bool
 	followProgress      map[progressKey]progressValue
 	followProgressLock  sync.Mutex
+	consumed            map[progressKey]progressValue // the next binlog positions of all followed shards, for Acked
 	followProcesses     map[topology.ClusterShard]*followProcess
 	followProcessesLock sync.Mutex
 	ctx                 context.Context
 	oneTimeFollowCancel context.CancelFunc
 	hasBackfilled       bool // whether addSst() has been called on this db
+	fence               *epochFence
+	deadLetters         *deadLetterLog
+	deadLetterAfter     int           // failed attempts before a followed entry is dead lettered, 0 to retry forever
+	intentTtl           time.Duration // prepared delete transactions are aborted after this long, 0 to keep them
+	followedIntents     *followedIntentFile
+	admission           admission
+	keyLocks            keyLocks
+	keyStats            keyStats
+	deleteIntents       deleteIntents
+	repairMarker        *repairMarker
+	compactedExpired    compactedExpired
+	untrackFollowers    func()        // unregisters the listener of untrackRemovedFollowers
+	batchWindow         time.Duration // max wait for more binlog entries to send to the followers, 0 to send right away
+	batchBytes          int64         // the binlog entries are sent right away once these bytes are available
 }
 
 func (s *shard) String() string {
@@ -47,19 +61,17 @@ func (s *shard) String() string {
 
 func newShard(keyspaceName, dir string, serverId, nodeId int, cluster *topology.Cluster,
 	clusterListener *clusterlistener.ClusterListener,
-	replicationFactor int, logFileSizeMb int, logFileCount int) *shard {
+	replicationFactor int, logFileSizeMb int, logFileCount int, inMemory bool) *shard {
 
 	ctx, cancelFunc := context.WithCancel(context.Background())
 
 	glog.V(1).Infof("open %s.%d.%d in %s", keyspaceName, serverId, nodeId, dir)
 
-	mergeOperator := NewVastoMergeOperator()
-
 	s := &shard{
 		keyspace:        keyspaceName,
 		id:              VastoShardId(nodeId),
 		serverId:        VastoServerId(serverId),
-		db:              rocks.NewDb(dir, mergeOperator),
+		db:              openShardDb(dir, inMemory),
 		cluster:         cluster,
 		clusterListener: clusterListener,
 		nodeFinishChan:  make(chan bool),
@@ -68,17 +80,43 @@ func newShard(keyspaceName, dir string, serverId, nodeId int, cluster *topology.
 			cancelFunc()
 		},
 		followProgress:  make(map[progressKey]progressValue),
+		consumed:        make(map[progressKey]progressValue),
 		followProcesses: make(map[topology.ClusterShard]*followProcess),
 		ctx:             ctx,
+		deadLetters:     newDeadLetterLog(dir),
+		repairMarker:    newRepairMarker(dir),
+		intentTtl:       defaultIntentTtl,
+		followedIntents: newFollowedIntentFile(dir),
+		batchWindow:     defaultBatchWindow,
+		batchBytes:      defaultBatchBytes,
 	}
+	s.fence = newEpochFence(s.id, s.loadEpoch())
 	if logFileSizeMb > 0 {
 		s.lm = binlog.NewLogManager(dir, nodeId, int64(logFileSizeMb*1024*1024), logFileCount)
 		s.lm.Initialze()
+		s.lm.SetRetention(s.deleteIntents.earliestSegment)
 	}
 
 	return s
 }
 
+// requestContext is done when ctx is done, or when the shard stops.
+func (s *shard) requestContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(ctx)
+	if s.ctx.Err() != nil {
+		cancel()
+		return ctx, cancel
+	}
+	go func() {
+		select {
+		case <-s.ctx.Done():
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+	return ctx, cancel
+}
+
 func (s *shard) shutdownNode() {
 
 	glog.V(1).Infof("shutdownNode: %+v", s)
@@ -89,6 +127,10 @@ func (s *shard) shutdownNode() {
 
 	close(s.nodeFinishChan)
 
+	if s.untrackFollowers != nil {
+		s.untrackFollowers()
+	}
+
 	if s.lm != nil {
 		s.lm.Shutdown()
 	}
@@ -101,6 +143,20 @@ func (s *shard) setCompactionFilterClusterSize(clusterSize int) {
 
 }
 
+// setTtlCompaction purges expired entries during compaction.
+// The purged entries are logged as deletes, so that the followers also drop them.
+// The deletes are logged every second, outside of the compaction.
+func (s *shard) setTtlCompaction(enabled bool, logTombstones bool) {
+
+	if !enabled || !logTombstones {
+		s.db.SetTtlCompaction(enabled, nil)
+		return
+	}
+
+	s.db.SetTtlCompaction(true, s.compactedExpired.add)
+
+}
+
 func (s *shard) startWithBootstrapPlan(bootstrapPlan *topology.BootstrapPlan, selfAdminAddress string, existingPrimaryShards []*pb.ClusterNode) error {
 
 	if len(existingPrimaryShards) == 0 {
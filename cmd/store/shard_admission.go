@@ -0,0 +1,37 @@
+package store
+
+import (
+	"sync/atomic"
+)
+
+// admission bounds the number of requests being processed on one shard,
+// so that an overloaded hot shard does not slow down the other shards on the same store.
+// A limit not greater than 0 admits all requests.
+type admission struct {
+	limit         int32
+	inFlight      int32
+	rejectedCount uint64
+}
+
+func (a *admission) setLimit(limit int) {
+	atomic.StoreInt32(&a.limit, int32(limit))
+}
+
+// acquire returns false if the shard is busy. Every successful acquire must be followed by a release.
+func (a *admission) acquire() bool {
+	n := atomic.AddInt32(&a.inFlight, 1)
+	if limit := atomic.LoadInt32(&a.limit); limit > 0 && n > limit {
+		atomic.AddInt32(&a.inFlight, -1)
+		atomic.AddUint64(&a.rejectedCount, 1)
+		return false
+	}
+	return true
+}
+
+func (a *admission) release() {
+	atomic.AddInt32(&a.inFlight, -1)
+}
+
+func (a *admission) stats() (inFlight int32, rejectedCount uint64) {
+	return atomic.LoadInt32(&a.inFlight), atomic.LoadUint64(&a.rejectedCount)
+}
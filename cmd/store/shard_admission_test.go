@@ -0,0 +1,36 @@
+package store
+
+import (
+	"testing"
+)
+
+func TestAdmission(t *testing.T) {
+
+	a := &admission{}
+	for i := 0; i < 10; i++ {
+		if !a.acquire() {
+			t.Errorf("requests should be admitted without limit")
+		}
+	}
+	for i := 0; i < 10; i++ {
+		a.release()
+	}
+
+	a.setLimit(2)
+	if !a.acquire() || !a.acquire() {
+		t.Errorf("requests within the limit should be admitted")
+	}
+	if a.acquire() {
+		t.Errorf("requests over the limit should be rejected")
+	}
+
+	a.release()
+	if !a.acquire() {
+		t.Errorf("requests should be admitted after release")
+	}
+
+	if inFlight, rejectedCount := a.stats(); inFlight != 2 || rejectedCount != 1 {
+		t.Errorf("unexpected stats: in flight %d, rejected %d", inFlight, rejectedCount)
+	}
+
+}
@@ -0,0 +1,103 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/chrislusf/glog"
+	"github.com/chrislusf/vasto/pb"
+	"github.com/chrislusf/vasto/storage/binlog"
+	"github.com/chrislusf/vasto/topology"
+	"google.golang.org/grpc"
+)
+
+const followerAckPollInterval = time.Second
+
+// checkBackpressure returns an error if the slowest follower lags too far behind the binlog,
+// so that the writes are rejected before being applied, and can be retried later.
+func (s *shard) checkBackpressure() error {
+	if s.lm == nil {
+		return nil
+	}
+	return s.lm.CheckBackpressure()
+}
+
+// untrackRemovedFollowers stops tracking the binlog position of the followers removed from the topology.
+// The followers are tracked by their shard identifiers, which is the origin of their TailBinlog requests.
+// The listener is unregistered when the shard is shut down.
+func (s *shard) untrackRemovedFollowers() {
+	if s.lm == nil || s.cluster == nil {
+		return
+	}
+	lm := s.lm
+	s.untrackFollowers = s.cluster.OnNodeRemoved(func(node *pb.ClusterNode) {
+		if node.ShardInfo != nil && int(node.ShardInfo.ShardId) == int(s.id) {
+			lm.RemoveFollower(node.ShardInfo.IdentifierOnThisServer())
+		}
+	})
+}
+
+// trackFollowerAcksEvery polls the peer replicas for the binlog positions they have consumed,
+// until the shard is shut down, so that the backpressure counts only the entries acknowledged by the followers,
+// instead of the entries sent and maybe still buffered.
+// A peer failing to answer keeps its last acknowledged position.
+func (s *shard) trackFollowerAcksEvery(interval time.Duration) {
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-ticker.C:
+			for _, peer := range s.peerShards() {
+				if err := s.trackFollowerAck(peer); err != nil {
+					glog.V(2).Infof("%s track follower %s ack: %v", s, peer, err)
+				}
+			}
+		}
+	}
+
+}
+
+// trackFollowerAck asks the peer for its consumed position of this shard's binlog, and tracks it for the backpressure.
+func (s *shard) trackFollowerAck(peer topology.ClusterShard) error {
+
+	self, found := s.cluster.GetNode(int(s.serverId), 0)
+	if !found {
+		return fmt.Errorf("server %d not found in cluster %v", s.serverId, s.cluster)
+	}
+
+	ctx, cancel := context.WithTimeout(s.ctx, followerAckPollInterval)
+	defer cancel()
+
+	return s.cluster.WithConnectionContext(ctx, fmt.Sprintf("%s track follower %s", s, peer), peer.ServerId,
+		func(ctx context.Context, node *pb.ClusterNode, grpcConnection *grpc.ClientConn) error {
+
+			resp, err := pb.NewVastoStoreClient(grpcConnection).Acked(ctx, &pb.AckedRequest{
+				Keyspace:     s.keyspace,
+				ShardId:      uint32(s.id),
+				AdminAddress: self.StoreResource.GetAdminAddress(),
+			})
+			if err != nil {
+				return err
+			}
+			if resp.Error != "" {
+				return fmt.Errorf("%s", resp.Error)
+			}
+
+			follower := fmt.Sprintf("%s.%d.%d", s.keyspace, peer.ServerId, peer.ShardId)
+			s.lm.SetFollowerPosition(follower, binlog.LogPosition{Segment: resp.NextSegment, Offset: int64(resp.NextOffset)})
+			return nil
+
+		})
+
+}
+
+func isWriteRequest(command *pb.Request) bool {
+	return command.GetPut() != nil || command.GetMerge() != nil || command.GetDelete() != nil ||
+		command.GetBatchDelete() != nil || command.GetDeleteByPrefix() != nil ||
+		command.GetPrepareDelete() != nil || command.GetCommitDelete() != nil
+}
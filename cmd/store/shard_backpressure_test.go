@@ -0,0 +1,59 @@
+package store
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/chrislusf/vasto/pb"
+	"github.com/chrislusf/vasto/storage/binlog"
+	"github.com/chrislusf/vasto/topology"
+)
+
+func TestBackpressureFollowerTracking(t *testing.T) {
+
+	dir, err := ioutil.TempDir("", "backpressure_followers")
+	if err != nil {
+		t.Fatalf("temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	s := newShard("ks1", dir, 0, 0, nil, nil, 2, 1, 2, false)
+	defer s.db.Close()
+	defer s.shutdownNode()
+
+	follower := &pb.ShardInfo{KeyspaceName: "ks1", ServerId: 1, ShardId: 0, ClusterSize: 2, ReplicationFactor: 2}
+	followerStore := &pb.StoreResource{Address: "localhost:7001"}
+	s.cluster = topology.NewCluster("ks1", 2, 2)
+	s.cluster.SetShard(followerStore, follower)
+	s.untrackRemovedFollowers()
+
+	disableBinLog := false
+	ss := &storeServer{option: &StoreOption{DisableBinLog: &disableBinLog}, keyspaceShards: newKeyspaceShards()}
+	ss.keyspaceShards.addShards("ks1", s)
+
+	// the follower disconnected after reading nothing
+	s.lm.SetFollowerPosition(follower.IdentifierOnThisServer(), binlog.LogPosition{})
+	s.lm.SetHighWatermark(1)
+	for _, key := range []string{"k0", "k1"} {
+		if resp := ss.processRequest(context.Background(), "ks1", &pb.Request{Put: &pb.PutRequest{Key: []byte(key), Value: []byte("v1")}}); !resp.Write.Ok {
+			t.Errorf("put before the follower lags: %+v", resp.Write)
+		}
+	}
+	resp := ss.processRequest(context.Background(), "ks1", &pb.Request{Put: &pb.PutRequest{Key: []byte("k2"), Value: []byte("v2")}})
+	if resp.Write.Ok || !strings.HasPrefix(resp.Write.Status, "log backpressure") {
+		t.Errorf("put with a lagging follower: %+v", resp.Write)
+	}
+	if b, _ := s.db.Get([]byte("k2")); len(b) != 0 {
+		t.Errorf("put rejected by backpressure is applied")
+	}
+
+	// the follower is removed from the topology
+	s.cluster.RemoveShard(followerStore, follower)
+	if resp := ss.processRequest(context.Background(), "ks1", &pb.Request{Put: &pb.PutRequest{Key: []byte("k2"), Value: []byte("v2")}}); !resp.Write.Ok {
+		t.Errorf("put after the follower is removed: %+v", resp.Write)
+	}
+
+}
@@ -8,7 +8,6 @@ import (
 	"time"
 
 	"github.com/chrislusf/glog"
-	"github.com/chrislusf/gorocksdb"
 	"github.com/chrislusf/vasto/pb"
 	"github.com/chrislusf/vasto/storage/codec"
 	"github.com/chrislusf/vasto/topology"
@@ -102,11 +101,11 @@ func (s *shard) topoChangeBootstrap(ctx context.Context, bootstrapPlan *topology
 			if !s.hasBackfilled {
 				s.hasBackfilled = true
 				glog.V(1).Infof("bootstrap %v via sst ...", s.String())
-				return s.db.AddSstByWriter(fmt.Sprintf("%s bootstrapCopy write", s.String()),
-					func(w *gorocksdb.SSTFileWriter) (int64, error) {
+				return s.db.AddSorted(fmt.Sprintf("%s bootstrapCopy write", s.String()),
+					func(add func(key, value []byte) error) (int64, error) {
 						counter, err := pb.MergeSorted(sourceRowChans, 0, func(keyValue *pb.RawKeyValue) error {
 
-							if err := w.Add(keyValue.Key, keyValue.Value); err != nil {
+							if err := add(keyValue.Key, keyValue.Value); err != nil {
 								return fmt.Errorf("add to sst: %v", err)
 							}
 							return nil
@@ -297,9 +296,9 @@ func (s *shard) writeToSst(ctx context.Context, grpcConnection *grpc.ClientConn,
 		return 0, 0, 0, fmt.Errorf("client.BootstrapCopy: %v", err)
 	}
 
-	err = s.db.AddSstByWriter(fmt.Sprintf("bootstrap %s from %s %d/%d", s.String(), sourceShardInfo.IdentifierOnThisServer(), targetShardId, targetClusterSize),
+	err = s.db.AddSorted(fmt.Sprintf("bootstrap %s from %s %d/%d", s.String(), sourceShardInfo.IdentifierOnThisServer(), targetShardId, targetClusterSize),
 
-		func(w *gorocksdb.SSTFileWriter) (int64, error) {
+		func(add func(key, value []byte) error) (int64, error) {
 
 			for {
 
@@ -317,7 +316,7 @@ func (s *shard) writeToSst(ctx context.Context, grpcConnection *grpc.ClientConn,
 
 					// fmt.Printf("%s add to sst: %v\n", sourceShardInfo.IdentifierOnThisServer(), string(keyValue.Key))
 
-					err = w.Add(keyValue.Key, keyValue.Value)
+					err = add(keyValue.Key, keyValue.Value)
 					if err != nil {
 						return counter, fmt.Errorf("add to sst: %v", err)
 					}
@@ -0,0 +1,49 @@
+package store
+
+import (
+	"sync"
+
+	"github.com/chrislusf/vasto/storage/codec"
+)
+
+// compactedExpired queues the expired entries purged by the compaction filter.
+// The compaction only queues them, and their deletes are logged later by logCompactedExpired,
+// so that the compaction does not wait for a binlog append on each purged entry.
+type compactedExpired struct {
+	sync.Mutex
+	keys    [][]byte
+	entries []*codec.Entry
+}
+
+// add queues the purged entry. The key is copied, since the compaction reuses its buffer.
+// Only the fields logged for the delete are kept.
+func (c *compactedExpired) add(key []byte, entry *codec.Entry) {
+	c.Lock()
+	c.keys = append(c.keys, append([]byte(nil), key...))
+	c.entries = append(c.entries, &codec.Entry{
+		PartitionHash: entry.PartitionHash,
+		UpdatedAtNs:   entry.UpdatedAtNs,
+	})
+	c.Unlock()
+}
+
+// take returns the queued entries, and empties the queue.
+func (c *compactedExpired) take() (keys [][]byte, entries []*codec.Entry) {
+	c.Lock()
+	keys, entries = c.keys, c.entries
+	c.keys, c.entries = nil, nil
+	c.Unlock()
+	return
+}
+
+// logCompactedExpired logs the deletes of the expired entries purged by the compaction since the last call.
+// It stops at the first failed append, and the remaining deletes are not logged,
+// since the followers drop the expired entries on read and on their own compaction anyway.
+func (s *shard) logCompactedExpired() {
+	keys, entries := s.compactedExpired.take()
+	for i, key := range keys {
+		if _, err := s.logExpired(key, entries[i]); err != nil {
+			return
+		}
+	}
+}
@@ -0,0 +1,152 @@
+package store
+
+import (
+	"fmt"
+
+	"github.com/chrislusf/glog"
+	"github.com/chrislusf/vasto/pb"
+	"github.com/chrislusf/vasto/storage/codec"
+)
+
+const consistencyCheckSegmentCount = 1
+
+type consistencyReport struct {
+	checkedKeys    int
+	appliedPuts    int
+	appliedDeletes int
+}
+
+func (r consistencyReport) repairedCount() int {
+	return r.appliedPuts + r.appliedDeletes
+}
+
+func (r consistencyReport) String() string {
+	return fmt.Sprintf("checked %d keys, applied %d puts and %d deletes",
+		r.checkedKeys, r.appliedPuts, r.appliedDeletes)
+}
+
+// repairConsistency compares the latest binlog entries with the db, e.g., after an unclean shutdown,
+// or after the shard is marked for repair when the db failed to apply a logged delete.
+// Deletes are logged before being applied to the db, and puts are logged after, so a logged change
+// newer than the db row was lost by the db, and is applied to the db again.
+// The db also has the changes followed from the other shards, which are never in the local binlog,
+// so a db row newer than the binlog, or a missing row, is kept as is, and nothing is appended to the binlog.
+// Only the keys changed in the latest segments are checked, and the keys last changed by merges are skipped.
+func (s *shard) repairConsistency(segmentCount int) (report consistencyReport, err error) {
+
+	if s.lm == nil {
+		return
+	}
+
+	fromSegment := uint32(0)
+	_, latestSegment := s.lm.GetSegmentRange()
+	if latestSegment+1 > uint32(segmentCount) {
+		fromSegment = latestSegment + 1 - uint32(segmentCount)
+	}
+
+	lastEntries := make(map[string]*pb.LogEntry)
+	err = s.lm.ScanEntries(fromSegment, func(segment uint32, entry *pb.LogEntry) error {
+		if entry.GetDeleteIntent() != nil {
+			return nil
+		}
+		lastEntries[string(entry.GetKey())] = entry
+		return nil
+	})
+	if err != nil {
+		return report, fmt.Errorf("scan binlog: %v", err)
+	}
+
+	for key, entry := range lastEntries {
+		report.checkedKeys++
+		if err = s.repairKey([]byte(key), entry, &report); err != nil {
+			return report, fmt.Errorf("repair %s: %v", key, err)
+		}
+	}
+
+	return report, nil
+}
+
+func (s *shard) repairKey(key []byte, entry *pb.LogEntry, report *consistencyReport) error {
+
+	if entry.GetMerge() != nil {
+		return nil
+	}
+
+	unlock := s.keyLocks.lock(key)
+	defer unlock()
+
+	row, err := s.storedEntry(key)
+	if err != nil {
+		return err
+	}
+
+	if t := versionedEntry(entry); t != nil {
+		applied, err := s.applyVersioned(key, row, t)
+		if applied && t.IsDeleted() {
+			report.appliedDeletes++
+		} else if applied {
+			report.appliedPuts++
+		}
+		return err
+	}
+
+	if row == nil || row.UpdatedAtNs >= entry.UpdatedAtNs {
+		return nil
+	}
+
+	switch {
+	case entry.GetDelete() != nil:
+		if entry.HasFlag(pb.LogEntrySoftDelete) {
+			if row.IsDeleted() {
+				return nil
+			}
+			err = s.softDelete(key, row, entry.UpdatedAtNs)
+		} else {
+			err = s.deleteEntry(key, row)
+		}
+		if err != nil {
+			return err
+		}
+		report.appliedDeletes++
+	case entry.GetPut() != nil:
+		if err = s.putEntry(key, row, codec.NewPutEntry(entry.GetPut(), entry.UpdatedAtNs)); err != nil {
+			return err
+		}
+		report.appliedPuts++
+	}
+
+	return nil
+}
+
+// checkConsistencyOnStartup repairs the db from the binlog, since the segment marked for repair if marked,
+// and clears the mark once repaired. In-memory shards are skipped,
+// since their db always starts empty while the binlog is kept.
+func (s *shard) checkConsistencyOnStartup(inMemory bool) {
+	if inMemory {
+		s.clearRepairMark()
+		return
+	}
+	segmentCount := consistencyCheckSegmentCount
+	if markedSegment, marked := s.repairMarker.segment(); marked && s.lm != nil {
+		if _, latestSegment := s.lm.GetSegmentRange(); latestSegment >= markedSegment && int(latestSegment-markedSegment)+1 > segmentCount {
+			segmentCount = int(latestSegment-markedSegment) + 1
+		}
+	}
+	report, err := s.repairConsistency(segmentCount)
+	if err != nil {
+		glog.Errorf("%s consistency check: %v", s, err)
+		return
+	}
+	s.clearRepairMark()
+	if report.repairedCount() > 0 {
+		glog.V(0).Infof("%s repaired the db from the binlog: %v", s, report)
+		return
+	}
+	glog.V(1).Infof("%s binlog and db are consistent: %v", s, report)
+}
+
+func (s *shard) clearRepairMark() {
+	if err := s.repairMarker.clear(); err != nil {
+		glog.Errorf("%s clear repair mark: %v", s, err)
+	}
+}
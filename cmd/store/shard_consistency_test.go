@@ -0,0 +1,83 @@
+package store
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/chrislusf/vasto/pb"
+	"github.com/chrislusf/vasto/storage/codec"
+)
+
+func TestRepairConsistency(t *testing.T) {
+
+	s, _, cleanup := newTestShard(t, "consistency")
+	defer cleanup()
+
+	put := func(key string, updatedAtNs uint64, toDb, toLog bool) {
+		putRequest := &pb.PutRequest{Key: []byte(key), Value: []byte(key)}
+		if toDb {
+			s.db.Put(putRequest.Key, codec.NewPutEntry(putRequest, updatedAtNs).ToBytes())
+		}
+		if toLog {
+			s.logPut(putRequest, updatedAtNs)
+		}
+	}
+
+	// consistent
+	put("k1", 10, true, true)
+	// deleted in db by a delete followed from another shard
+	put("k2", 10, true, true)
+	s.db.Delete([]byte("k2"))
+	// put in db by a put followed from another shard
+	put("k3", 10, true, true)
+	put("k3", 20, true, false)
+	// logged, but lost by the db
+	put("k4", 10, true, true)
+	put("k4", 20, false, true)
+	// delete logged, but not applied to the db
+	put("k5", 10, true, true)
+	s.logDelete(&pb.DeleteRequest{Key: []byte("k5")}, 20)
+
+	_, logOffset := s.lm.GetSegmentOffset()
+
+	report, err := s.repairConsistency(1)
+	if err != nil {
+		t.Fatalf("repair: %v", err)
+	}
+	if report.checkedKeys != 5 || report.appliedPuts != 1 || report.appliedDeletes != 1 {
+		t.Errorf("unexpected report: %v", report)
+	}
+
+	if b, _ := s.db.Get([]byte("k2")); len(b) != 0 {
+		t.Errorf("followed delete should be kept")
+	}
+	if b, _ := s.db.Get([]byte("k3")); codec.FromBytes(b).UpdatedAtNs != 20 {
+		t.Errorf("followed put should be kept")
+	}
+	if b, _ := s.db.Get([]byte("k4")); codec.FromBytes(b).UpdatedAtNs != 20 {
+		t.Errorf("logged put should be applied to db")
+	}
+	if b, _ := s.db.Get([]byte("k5")); len(b) != 0 {
+		t.Errorf("logged delete should be applied to db")
+	}
+	if _, offset := s.lm.GetSegmentOffset(); offset != logOffset {
+		t.Errorf("repair should not append to the binlog")
+	}
+
+	report, _ = s.repairConsistency(1)
+	if report.repairedCount() != 0 {
+		t.Errorf("should be consistent after repair: %v", report)
+	}
+
+	// a shard marked for repair is repaired on startup, and the mark is cleared
+	s.logDelete(&pb.DeleteRequest{Key: []byte("k4")}, 30)
+	s.markForRepair(0, fmt.Errorf("db failed"))
+	s.checkConsistencyOnStartup(false)
+	if b, _ := s.db.Get([]byte("k4")); len(b) != 0 {
+		t.Errorf("logged delete should be applied on startup")
+	}
+	if _, marked := s.repairMarker.segment(); marked {
+		t.Errorf("repair mark should be cleared")
+	}
+
+}
@@ -0,0 +1,54 @@
+package store
+
+import (
+	"context"
+	"time"
+
+	"github.com/chrislusf/vasto/pb"
+	"github.com/chrislusf/vasto/storage/codec"
+	"github.com/chrislusf/vasto/storage/memory"
+	"github.com/chrislusf/vasto/storage/rocks"
+)
+
+// shardDb is the storage engine of a shard.
+// The local rocksdb is the default, and the in-memory db keeps the data only until the process exits.
+type shardDb interface {
+	Get(key []byte) ([]byte, error)
+	MultiGet(keys [][]byte) ([][]byte, error)
+	Put(key []byte, msg []byte) error
+	Merge(key []byte, msg []byte) error
+	Delete(key []byte) error
+	FullScanContext(ctx context.Context, batchSize uint64, limit uint64, fn func([]*pb.RawKeyValue) error) error
+	PrefixScanContext(ctx context.Context, prefix, lastKey []byte, limit int, fn func(key, value []byte) bool) error
+	// AddSorted adds the sorted key values behind the existing data, e.g., when bootstrapping from a peer.
+	AddSorted(name string, addFunc func(add func(key, value []byte) error) (int64, error)) error
+
+	SetCompactionForShard(shardId, shardCount int)
+	SetTtlCompaction(enabled bool, onExpired func(key []byte, entry *codec.Entry))
+	SetSoftDeleteRetention(retention time.Duration)
+	PrepareForClusterResize()
+	CompleteClusterResize()
+	Compact()
+
+	Destroy()
+	EnsureDirectory()
+	Reopen()
+	Close()
+	LiveFilesSize() uint64
+	// HasBackfilled checks whether AddSorted has been used, since the data can be added behind only once.
+	HasBackfilled() bool
+}
+
+var (
+	_ shardDb = (*rocks.Rocks)(nil)
+	_ shardDb = (*memory.Memory)(nil)
+)
+
+// openShardDb opens the rocksdb in the dir, or an empty in-memory db if inMemory is set.
+func openShardDb(dir string, inMemory bool) shardDb {
+	mergeOperator := NewVastoMergeOperator()
+	if inMemory {
+		return memory.NewDb(mergeOperator)
+	}
+	return rocks.NewDb(dir, mergeOperator)
+}
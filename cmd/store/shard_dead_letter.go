@@ -0,0 +1,168 @@
+package store
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/chrislusf/glog"
+	"github.com/chrislusf/vasto/pb"
+	"github.com/golang/protobuf/proto"
+)
+
+const (
+	constDeadLetterFile   = "dead_letter.log"
+	deadLetterRetryPeriod = 100 * time.Millisecond
+)
+
+// deadLetterLog keeps the followed entries that repeatedly failed to apply,
+// so that one bad entry does not stop following the whole shard.
+// The entries are saved in the same length-prefixed format as the binlog.
+type deadLetterLog struct {
+	sync.Mutex
+	fileName string
+}
+
+func newDeadLetterLog(dir string) *deadLetterLog {
+	return &deadLetterLog{
+		fileName: fmt.Sprintf("%s/%s", dir, constDeadLetterFile),
+	}
+}
+
+func (d *deadLetterLog) append(entry *pb.LogEntry) error {
+	d.Lock()
+	defer d.Unlock()
+
+	file, err := os.OpenFile(d.fileName, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0644)
+	if err != nil {
+		return fmt.Errorf("open %s: %v", d.fileName, err)
+	}
+	defer file.Close()
+
+	data, err := encodeDeadLetters([]*pb.LogEntry{entry})
+	if err != nil {
+		return err
+	}
+	_, err = file.Write(data)
+	return err
+}
+
+func (d *deadLetterLog) list() ([]*pb.LogEntry, error) {
+	d.Lock()
+	defer d.Unlock()
+
+	return d.read()
+}
+
+// reprocess applies all the dead letters with fn, and keeps only the ones still failing.
+func (d *deadLetterLog) reprocess(fn func(entry *pb.LogEntry) error) (remaining []*pb.LogEntry, reprocessedCount int, err error) {
+	d.Lock()
+	defer d.Unlock()
+
+	entries, err := d.read()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	for _, entry := range entries {
+		if fnErr := fn(entry); fnErr != nil {
+			remaining = append(remaining, entry)
+			continue
+		}
+		reprocessedCount++
+	}
+
+	if reprocessedCount == 0 {
+		return remaining, 0, nil
+	}
+
+	data, err := encodeDeadLetters(remaining)
+	if err != nil {
+		return nil, 0, err
+	}
+	if err = ioutil.WriteFile(d.fileName, data, 0644); err != nil {
+		return nil, 0, fmt.Errorf("write %s: %v", d.fileName, err)
+	}
+
+	return remaining, reprocessedCount, nil
+}
+
+func (d *deadLetterLog) read() (entries []*pb.LogEntry, err error) {
+
+	file, err := os.Open(d.fileName)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("open %s: %v", d.fileName, err)
+	}
+	defer file.Close()
+
+	reader := bufio.NewReader(file)
+	sizeBuf := make([]byte, 4)
+
+	for {
+		if _, err := io.ReadFull(reader, sizeBuf); err != nil {
+			return entries, nil
+		}
+		data := make([]byte, binary.LittleEndian.Uint32(sizeBuf))
+		if _, err := io.ReadFull(reader, data); err != nil {
+			return entries, nil
+		}
+		entry := &pb.LogEntry{}
+		if err := proto.Unmarshal(data, entry); err != nil {
+			return entries, fmt.Errorf("read %s: %v", d.fileName, err)
+		}
+		entries = append(entries, entry)
+	}
+
+}
+
+func encodeDeadLetters(entries []*pb.LogEntry) ([]byte, error) {
+	var buf []byte
+	sizeBuf := make([]byte, 4)
+	for _, entry := range entries {
+		data, err := proto.Marshal(entry)
+		if err != nil {
+			return nil, fmt.Errorf("marshal %+v: %v", entry, err)
+		}
+		binary.LittleEndian.PutUint32(sizeBuf, uint32(len(data)))
+		buf = append(buf, sizeBuf...)
+		buf = append(buf, data...)
+	}
+	return buf, nil
+}
+
+// applyEntry retries a followed entry, and moves it to the dead letter log
+// after deadLetterAfter failed attempts. If deadLetterAfter is 0, it retries until the shard is stopped.
+func (s *shard) applyEntry(entry *pb.LogEntry) {
+
+	for attempt := 1; ; attempt++ {
+		err := s.processEntry(entry)
+		if err == nil {
+			return
+		}
+
+		if s.deadLetterAfter > 0 && attempt >= s.deadLetterAfter {
+			glog.Errorf("%s moves entry %s to dead letter log after %d attempts: %v", s, string(entry.GetKey()), attempt, err)
+			if err = s.deadLetters.append(entry); err != nil {
+				glog.Errorf("%s dead letter entry %+v: %v", s, entry, err)
+			}
+			return
+		}
+
+		glog.V(1).Infof("%s apply entry %s attempt %d: %v", s, string(entry.GetKey()), attempt, err)
+
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-time.After(deadLetterRetryPeriod):
+		}
+	}
+
+}
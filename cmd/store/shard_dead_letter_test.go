@@ -0,0 +1,56 @@
+package store
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/chrislusf/vasto/pb"
+)
+
+func TestDeadLetterLog(t *testing.T) {
+
+	dir, err := ioutil.TempDir("", "dead_letter")
+	if err != nil {
+		t.Fatalf("temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	deadLetters := newDeadLetterLog(dir)
+
+	if entries, err := deadLetters.list(); err != nil || len(entries) != 0 {
+		t.Errorf("empty dead letter log: %v %v", entries, err)
+	}
+
+	for _, key := range []string{"k1", "k2", "k3"} {
+		entry := &pb.LogEntry{
+			UpdatedAtNs: 1,
+			Put:         &pb.PutRequest{Key: []byte(key)},
+		}
+		if err := deadLetters.append(entry); err != nil {
+			t.Fatalf("append %s: %v", key, err)
+		}
+	}
+
+	entries, err := deadLetters.list()
+	if err != nil || len(entries) != 3 || string(entries[1].GetKey()) != "k2" {
+		t.Errorf("list dead letters: %v %v", entries, err)
+	}
+
+	remaining, reprocessedCount, err := deadLetters.reprocess(func(entry *pb.LogEntry) error {
+		if string(entry.GetKey()) == "k2" {
+			return errors.New("still failing")
+		}
+		return nil
+	})
+	if err != nil || reprocessedCount != 2 || len(remaining) != 1 {
+		t.Errorf("reprocess dead letters: %d %v %v", reprocessedCount, remaining, err)
+	}
+
+	entries, _ = deadLetters.list()
+	if len(entries) != 1 || string(entries[0].GetKey()) != "k2" {
+		t.Errorf("dead letters after reprocess: %v", entries)
+	}
+
+}
@@ -0,0 +1,98 @@
+package store
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/chrislusf/glog"
+	"github.com/chrislusf/vasto/util"
+)
+
+// epochFence rejects writes from a stale primary.
+// Every binlog entry is stamped with the writer's epoch, which is increased when a shard is promoted.
+// A follower remembers the highest epoch applied from each source shard,
+// and skips entries with older epochs, e.g., from a partitioned old primary.
+type epochFence struct {
+	sync.Mutex
+	shardId VastoShardId
+	epoch   uint64
+	highest map[VastoShardId]uint64
+}
+
+func newEpochFence(shardId VastoShardId, epoch uint64) *epochFence {
+	return &epochFence{
+		shardId: shardId,
+		epoch:   epoch,
+		highest: map[VastoShardId]uint64{shardId: epoch},
+	}
+}
+
+// currentEpoch returns the epoch to stamp on local writes
+func (f *epochFence) currentEpoch() uint64 {
+	f.Lock()
+	defer f.Unlock()
+	return f.epoch
+}
+
+// accept checks the epoch of an entry from the source shard.
+// It returns whether the entry should be applied, and whether the current epoch is raised.
+func (f *epochFence) accept(sourceShardId VastoShardId, epoch uint64) (isAccepted bool, isRaised bool) {
+	f.Lock()
+	defer f.Unlock()
+	if epoch < f.highest[sourceShardId] {
+		return false, false
+	}
+	f.highest[sourceShardId] = epoch
+	// peers of the same shard share the epoch, so that local writes are accepted by them
+	if sourceShardId == f.shardId && epoch > f.epoch {
+		f.epoch = epoch
+		return true, true
+	}
+	return true, false
+}
+
+// advance moves to a new epoch higher than any seen for this shard
+func (f *epochFence) advance() uint64 {
+	f.Lock()
+	defer f.Unlock()
+	if f.highest[f.shardId] > f.epoch {
+		f.epoch = f.highest[f.shardId]
+	}
+	f.epoch++
+	f.highest[f.shardId] = f.epoch
+	return f.epoch
+}
+
+func genEpochKey() []byte {
+	return []byte(fmt.Sprintf("%sepoch", VastoInternalKeyPrefix))
+}
+
+func (s *shard) loadEpoch() uint64 {
+	t, err := s.db.Get(genEpochKey())
+	if err != nil || len(t) == 0 {
+		return 0
+	}
+	return util.BytesToUint64(t)
+}
+
+func (s *shard) saveEpoch(epoch uint64) {
+	if err := s.db.Put(genEpochKey(), util.Uint64toBytes(epoch)); err != nil {
+		glog.Errorf("shard %s save epoch %d: %v", s, epoch, err)
+	}
+}
+
+// promoteEpoch is called when this shard takes over writes from another server
+func (s *shard) promoteEpoch() {
+	epoch := s.fence.advance()
+	s.saveEpoch(epoch)
+	glog.V(1).Infof("shard %s moves to epoch %d", s, epoch)
+}
+
+// acceptEpoch checks whether the followed entry is from a stale writer
+func (s *shard) acceptEpoch(sourceShardId VastoShardId, epoch uint64) bool {
+	isAccepted, isRaised := s.fence.accept(sourceShardId, epoch)
+	if isRaised {
+		s.saveEpoch(epoch)
+	}
+	return isAccepted
+}
@@ -0,0 +1,44 @@
+package store
+
+import (
+	"testing"
+)
+
+func TestEpochFenceRejectsOldPrimary(t *testing.T) {
+
+	// server 0 is the primary of shard 1, server 1 is the secondary following it
+	oldPrimary := newEpochFence(1, 0)
+	secondary := newEpochFence(1, 0)
+
+	if accepted, _ := secondary.accept(1, oldPrimary.currentEpoch()); !accepted {
+		t.Errorf("entries from the current primary should be accepted")
+	}
+
+	// the secondary is promoted, and its writes are stamped with the new epoch
+	newEpoch := secondary.advance()
+	if newEpoch <= oldPrimary.currentEpoch() {
+		t.Errorf("new epoch %d should be higher than old epoch %d", newEpoch, oldPrimary.currentEpoch())
+	}
+
+	// a third replica follows the new primary first
+	replica := newEpochFence(1, 0)
+	if accepted, raised := replica.accept(1, newEpoch); !accepted || !raised {
+		t.Errorf("entries from the new primary should be accepted and raise the epoch")
+	}
+
+	// buffered or replayed writes from the partitioned old primary
+	if accepted, _ := replica.accept(1, oldPrimary.currentEpoch()); accepted {
+		t.Errorf("entries from the old primary should be rejected after promotion")
+	}
+
+	// writes on the replica carry the new epoch, so other peers accept them
+	if replica.currentEpoch() != newEpoch {
+		t.Errorf("replica epoch %d, expecting %d", replica.currentEpoch(), newEpoch)
+	}
+
+	// epochs from other source shards, e.g., during resizing, are tracked separately
+	if accepted, _ := replica.accept(2, 0); !accepted {
+		t.Errorf("entries from another shard should be accepted")
+	}
+
+}
@@ -91,6 +91,9 @@ func (s *shard) OnShardPromoteEvent(cluster *topology.Cluster, resource *pb.Stor
 
 	if int(s.id) == int(shardInfo.ShardId) {
 		glog.V(1).Infof("=> shard %v promoted in cluster %s", shardInfo.IdentifierOnThisServer(), cluster)
+		if VastoServerId(shardInfo.ServerId) == s.serverId {
+			s.promoteEpoch()
+		}
 	} else {
 	}
 
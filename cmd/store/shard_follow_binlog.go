@@ -58,11 +58,16 @@ func (s *shard) followChanges(ctx context.Context, node *pb.ClusterNode, grpcCon
 		// glog.V(2).Infof("%s follow 0 entry: %d", s, len(changes.Entries))
 
 		for _, entry := range changes.Entries {
-			s.processEntry(entry)
+			if !s.acceptEpoch(VastoShardId(sourceShardId), entry.Epoch) {
+				glog.V(1).Infof("%s skips entry from %d.%d with stale epoch %d", s, node.ShardInfo.ServerId, sourceShardId, entry.Epoch)
+				continue
+			}
+			s.applyEntry(entry)
 		}
 
 		// set the nextSegment and nextOffset
 		nextSegment, nextOffset = changes.NextSegment, changes.NextOffset
+		s.setConsumed(node.StoreResource.GetAdminAddress(), VastoShardId(sourceShardId), nextSegment, nextOffset)
 		if saveFollowProgress {
 			s.updateInMemoryFollowProgressIfPresent(node.StoreResource.GetAdminAddress(), VastoShardId(sourceShardId), nextSegment, nextOffset)
 		}
@@ -71,22 +76,35 @@ func (s *shard) followChanges(ctx context.Context, node *pb.ClusterNode, grpcCon
 
 }
 
-func (s *shard) processEntry(entry *pb.LogEntry) {
+func (s *shard) processEntry(entry *pb.LogEntry) error {
+	// the committed deletes are logged separately, so the intents are only staged
+	if intent := entry.GetDeleteIntent(); intent != nil {
+		s.followDeleteIntent(intent)
+		return nil
+	}
+
 	// process merges
 	if entry.GetMerge() != nil {
 		merge := entry.GetMerge()
 		key := merge.Key
 		t := codec.NewMergeEntry(merge, entry.UpdatedAtNs)
 
-		s.db.Merge(key, t.ToBytes())
-		return
+		return s.db.Merge(key, t.ToBytes())
 	}
 
 	// check local entry
 	b, err := s.db.Get(entry.GetKey())
 	if err != nil {
-		glog.Errorf("%s get %v: %v", s, string(entry.GetKey()), err)
-		return
+		return fmt.Errorf("%s get %v: %v", s, string(entry.GetKey()), err)
+	}
+
+	if t := versionedEntry(entry); t != nil {
+		var row *codec.Entry
+		if len(b) > 0 {
+			row = codec.FromBytes(b)
+		}
+		_, err = s.applyVersioned(entry.GetKey(), row, t)
+		return err
 	}
 
 	// process deletes
@@ -94,14 +112,20 @@ func (s *shard) processEntry(entry *pb.LogEntry) {
 		if err == nil && len(b) > 0 {
 			row := codec.FromBytes(b)
 			if row.IsExpired() {
-				return
+				return nil
 			}
 			if row.UpdatedAtNs > entry.UpdatedAtNs {
-				return
+				return nil
 			}
-			s.db.Delete(entry.GetKey())
+			if entry.HasFlag(pb.LogEntrySoftDelete) {
+				if row.IsDeleted() {
+					return nil
+				}
+				return s.softDelete(entry.GetKey(), row, entry.UpdatedAtNs)
+			}
+			return s.deleteEntry(entry.GetKey(), row)
 		}
-		return
+		return nil
 	}
 
 	// process puts
@@ -112,23 +136,23 @@ func (s *shard) processEntry(entry *pb.LogEntry) {
 
 		if len(b) == 0 {
 			// no existing data found
-			s.db.Put(key, t.ToBytes())
-			return
+			return s.putEntry(key, nil, t)
 		}
 		row := codec.FromBytes(b)
 		if row.IsExpired() {
 			if !t.IsExpired() {
 				glog.V(3).Infof("%s follow 3 entry: %v", s, string(key))
-				s.db.Put(key, t.ToBytes())
-				return
+				return s.putEntry(key, row, t)
 			}
 		} else {
 			if row.UpdatedAtNs > entry.UpdatedAtNs {
-				return
+				return nil
 			}
-			s.db.Put(key, t.ToBytes())
-			return
+			return s.putEntry(key, row, t)
 		}
 		// glog.V(2).Infof("%s follow 4 entry: %v", s, string(entry.Key))
+		return nil
 	}
+
+	return fmt.Errorf("%s unknown log entry: %+v", s, entry)
 }
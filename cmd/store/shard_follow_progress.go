@@ -45,6 +45,9 @@ func (s *shard) EverySecond() {
 		}
 	}
 	s.followProcessesLock.Unlock()
+
+	s.logCompactedExpired()
+	s.abortExpiredDeleteIntents()
 }
 
 func (s *shard) loadProgress(serverAdminAddress string, targetShardId VastoShardId) (segment uint32, offset uint64, hasProgress bool, err error) {
@@ -130,3 +133,22 @@ func (s *shard) deleteInMemoryFollowProgress(serverAdminAddress string, targetSh
 	delete(s.followProgress, progressKey{targetShardId, serverAdminAddress})
 	s.followProgressLock.Unlock()
 }
+
+// setConsumed records the next binlog position to consume from the source shard on the server,
+// for both the normal and the one-time followings.
+func (s *shard) setConsumed(serverAdminAddress string, sourceShardId VastoShardId, segment uint32, offset uint64) {
+	s.followProgressLock.Lock()
+	s.consumed[progressKey{sourceShardId, serverAdminAddress}] = progressValue{segment, offset}
+	s.followProgressLock.Unlock()
+}
+
+// hasFollowedPast checks whether the shard has consumed the binlog of the source shard on the server,
+// past the entry at the segment and offset. It returns the next position to consume,
+// and found is false if the shard has not followed the source shard on the server.
+func (s *shard) hasFollowedPast(serverAdminAddress string, sourceShardId VastoShardId, segment uint32, offset uint64) (followed bool, next progressValue, found bool) {
+	s.followProgressLock.Lock()
+	next, found = s.consumed[progressKey{sourceShardId, serverAdminAddress}]
+	s.followProgressLock.Unlock()
+	followed = next.segment > segment || next.segment == segment && next.offset > offset
+	return
+}
@@ -0,0 +1,90 @@
+package store
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sync"
+
+	"github.com/chrislusf/vasto/pb"
+	"github.com/golang/protobuf/proto"
+)
+
+const constFollowedIntentFile = "followed_intents.dat"
+
+// followedIntentFile keeps the prepare intents followed from the primary, which are not in the binlog of this shard,
+// so that the prepared transactions are recovered when the shard is opened again.
+// The intents are saved in the same length-prefixed format as the binlog, and the whole file is replaced on each save.
+type followedIntentFile struct {
+	sync.Mutex
+	fileName string
+}
+
+func newFollowedIntentFile(dir string) *followedIntentFile {
+	return &followedIntentFile{
+		fileName: fmt.Sprintf("%s/%s", dir, constFollowedIntentFile),
+	}
+}
+
+// save replaces the saved intents with the intents, or removes the file if there is none.
+func (f *followedIntentFile) save(intents []*pb.DeleteIntent) error {
+	f.Lock()
+	defer f.Unlock()
+
+	if len(intents) == 0 {
+		if err := os.Remove(f.fileName); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("remove %s: %v", f.fileName, err)
+		}
+		return nil
+	}
+
+	var buf []byte
+	sizeBuf := make([]byte, 4)
+	for _, intent := range intents {
+		data, err := proto.Marshal(intent)
+		if err != nil {
+			return fmt.Errorf("marshal %+v: %v", intent, err)
+		}
+		binary.LittleEndian.PutUint32(sizeBuf, uint32(len(data)))
+		buf = append(buf, sizeBuf...)
+		buf = append(buf, data...)
+	}
+
+	// written aside and renamed, so that a crash never leaves a partial file
+	tmpFileName := f.fileName + ".tmp"
+	if err := ioutil.WriteFile(tmpFileName, buf, 0644); err != nil {
+		return fmt.Errorf("write %s: %v", tmpFileName, err)
+	}
+	if err := os.Rename(tmpFileName, f.fileName); err != nil {
+		return fmt.Errorf("rename %s: %v", tmpFileName, err)
+	}
+	return nil
+}
+
+func (f *followedIntentFile) load() (intents []*pb.DeleteIntent, err error) {
+	f.Lock()
+	defer f.Unlock()
+
+	data, err := ioutil.ReadFile(f.fileName)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %v", f.fileName, err)
+	}
+
+	for len(data) >= 4 {
+		size := int(binary.LittleEndian.Uint32(data[0:4]))
+		if len(data) < 4+size {
+			return intents, fmt.Errorf("read %s: truncated intent", f.fileName)
+		}
+		intent := &pb.DeleteIntent{}
+		if err := proto.Unmarshal(data[4:4+size], intent); err != nil {
+			return intents, fmt.Errorf("read %s: %v", f.fileName, err)
+		}
+		intents = append(intents, intent)
+		data = data[4+size:]
+	}
+	return intents, nil
+}
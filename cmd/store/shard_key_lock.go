@@ -0,0 +1,22 @@
+package store
+
+import (
+	"sync"
+
+	"github.com/chrislusf/vasto/util"
+)
+
+const keyLockStripeCount = 256
+
+// keyLocks serializes the writes to the same key, for read-compare-write operations.
+// The keys are hashed to a fixed number of locks, so unrelated keys may share a lock.
+type keyLocks struct {
+	stripes [keyLockStripeCount]sync.Mutex
+}
+
+// lock locks the key, and returns the function to unlock it.
+func (k *keyLocks) lock(key []byte) (unlock func()) {
+	stripe := &k.stripes[util.Hash(key)%keyLockStripeCount]
+	stripe.Lock()
+	return stripe.Unlock
+}
@@ -0,0 +1,106 @@
+package store
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"github.com/chrislusf/vasto/pb"
+	"github.com/chrislusf/vasto/storage/codec"
+)
+
+const keyStatsScanBatchSize = 1024
+
+// keyStats approximately counts the live keys of a shard, and the bytes of their keys and values.
+// The counts start from a full scan when first read after the shard is opened, and are adjusted on each put and delete.
+// Writes during the scan, merges, and entries purged by compaction are not exactly accounted for,
+// so the counts drift slowly until the next scan.
+type keyStats struct {
+	keyCount int64 // accessed atomically
+	byteSize int64 // accessed atomically
+	scanLock sync.Mutex
+	scanned  bool
+}
+
+// entrySize is the bytes of the key and the value, or 0 if the entry is nil or soft deleted.
+func entrySize(key []byte, entry *codec.Entry) int64 {
+	if entry == nil || entry.IsDeleted() {
+		return 0
+	}
+	return int64(len(key) + len(entry.Value))
+}
+
+// onWrite adjusts the counts when the key changes from the existing entry to the new entry.
+// Either entry can be nil for a missing key.
+func (k *keyStats) onWrite(key []byte, existing, entry *codec.Entry) {
+	before, after := entrySize(key, existing), entrySize(key, entry)
+	switch {
+	case before == 0 && after > 0:
+		atomic.AddInt64(&k.keyCount, 1)
+	case before > 0 && after == 0:
+		atomic.AddInt64(&k.keyCount, -1)
+	}
+	atomic.AddInt64(&k.byteSize, after-before)
+}
+
+// putEntry puts the entry over the existing entry, which can be nil, and adjusts the key stats.
+func (s *shard) putEntry(key []byte, existing, entry *codec.Entry) error {
+	if err := s.db.Put(key, entry.ToBytes()); err != nil {
+		return err
+	}
+	s.keyStats.onWrite(key, existing, entry)
+	return nil
+}
+
+// deleteEntry deletes the key with the existing entry, which can be nil, and adjusts the key stats.
+func (s *shard) deleteEntry(key []byte, existing *codec.Entry) error {
+	if err := s.db.Delete(key); err != nil {
+		return err
+	}
+	s.keyStats.onWrite(key, existing, nil)
+	return nil
+}
+
+// storedEntry returns the entry of the key, even if expired or soft deleted, or nil if the key is not found.
+func (s *shard) storedEntry(key []byte) (*codec.Entry, error) {
+	b, err := s.db.Get(key)
+	if err != nil || len(b) == 0 {
+		return nil, err
+	}
+	return codec.FromBytes(b), nil
+}
+
+// getKeyStats returns the key count and the byte size, scanning the db first if not scanned yet or rescan is set.
+func (s *shard) getKeyStats(rescan bool) (keyCount, byteSize uint64, err error) {
+
+	s.keyStats.scanLock.Lock()
+	if !s.keyStats.scanned || rescan {
+		var scannedCount, scannedSize int64
+		err = s.db.FullScanContext(s.ctx, keyStatsScanBatchSize, 0, func(rows []*pb.RawKeyValue) error {
+			for _, row := range rows {
+				if size := entrySize(row.Key, codec.FromBytes(row.Value)); size > 0 {
+					scannedCount++
+					scannedSize += size
+				}
+			}
+			return nil
+		})
+		if err == nil {
+			atomic.StoreInt64(&s.keyStats.keyCount, scannedCount)
+			atomic.StoreInt64(&s.keyStats.byteSize, scannedSize)
+			s.keyStats.scanned = true
+		}
+	}
+	s.keyStats.scanLock.Unlock()
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return nonNegative(atomic.LoadInt64(&s.keyStats.keyCount)), nonNegative(atomic.LoadInt64(&s.keyStats.byteSize)), nil
+}
+
+func nonNegative(x int64) uint64 {
+	if x < 0 {
+		return 0
+	}
+	return uint64(x)
+}
@@ -0,0 +1,40 @@
+package store
+
+import (
+	"testing"
+
+	"github.com/chrislusf/vasto/pb"
+)
+
+func TestShardKeyStats(t *testing.T) {
+
+	s, ss, cleanup := newTestShard(t, "key_stats")
+	defer cleanup()
+
+	ss.processPut(s, &pb.PutRequest{Key: []byte("k1"), Value: []byte("v1")})
+	ss.processPut(s, &pb.PutRequest{Key: []byte("k2"), Value: []byte("v2")})
+
+	// counted with a full scan on the first read
+	assertKeyStats(t, s, false, 2, 8, "scanned")
+
+	ss.processPut(s, &pb.PutRequest{Key: []byte("k3"), Value: []byte("v3")})
+	assertKeyStats(t, s, false, 3, 12, "put")
+
+	ss.processPut(s, &pb.PutRequest{Key: []byte("k1"), Value: []byte("longer")})
+	assertKeyStats(t, s, false, 3, 16, "overwrite")
+
+	ss.processDelete(s, &pb.DeleteRequest{Key: []byte("k2")})
+	ss.processDelete(s, &pb.DeleteRequest{Key: []byte("k3"), Soft: true})
+	ss.processDelete(s, &pb.DeleteRequest{Key: []byte("missing")})
+	assertKeyStats(t, s, false, 1, 8, "deletes")
+
+	assertKeyStats(t, s, true, 1, 8, "rescanned")
+
+}
+
+func assertKeyStats(t *testing.T, s *shard, rescan bool, expectedCount, expectedSize uint64, name string) {
+	keyCount, byteSize, err := s.getKeyStats(rescan)
+	if err != nil || keyCount != expectedCount || byteSize != expectedSize {
+		t.Errorf("%s: key count %d byte size %d, expecting %d %d: %v", name, keyCount, byteSize, expectedCount, expectedSize, err)
+	}
+}
@@ -0,0 +1,52 @@
+package store
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/chrislusf/glog"
+	"github.com/chrislusf/vasto/pb"
+	"github.com/chrislusf/vasto/storage/binlog"
+)
+
+// logMutation appends one put, delete, merge, or delete intent to the binlog with the current epoch,
+// and returns the position of the log entry. It does nothing if the binlog is disabled.
+// Puts, deletes and merges all go through here, so the followers see them in the same format.
+func (s *shard) logMutation(entry *pb.LogEntry) (binlog.LogPosition, error) {
+	return s.logMutationContext(context.Background(), entry)
+}
+
+// logMutationContext is the same as logMutation, but gives up with the ctx error if the ctx is done before the append.
+func (s *shard) logMutationContext(ctx context.Context, entry *pb.LogEntry) (binlog.LogPosition, error) {
+
+	if s.lm == nil {
+		return binlog.LogPosition{}, nil
+	}
+
+	entry.Epoch = s.fence.currentEpoch()
+
+	position, err := s.lm.AppendEntryContext(ctx, entry)
+	if err != nil {
+		glog.Errorf("%s append %s log entry: %v", s, mutationKind(entry), err)
+	}
+	return position, err
+
+}
+
+func errWriteNotLogged(err error) error {
+	return fmt.Errorf("written but not logged: %v", err)
+}
+
+func mutationKind(entry *pb.LogEntry) string {
+	switch {
+	case entry.Put != nil:
+		return "put"
+	case entry.Delete != nil:
+		return "delete"
+	case entry.Merge != nil:
+		return "merge"
+	case entry.DeleteIntent != nil:
+		return "delete intent"
+	}
+	return "empty"
+}
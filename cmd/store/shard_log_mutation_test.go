@@ -0,0 +1,36 @@
+package store
+
+import (
+	"testing"
+
+	"github.com/chrislusf/vasto/pb"
+	"github.com/chrislusf/vasto/storage/binlog"
+	"golang.org/x/net/context"
+)
+
+func TestPutsAndDeletesAreLogged(t *testing.T) {
+
+	s, ss, cleanup := newTestShard(t, "logmutation")
+	defer cleanup()
+
+	epoch := s.fence.advance()
+	ss.processPut(s, &pb.PutRequest{Key: []byte("k1"), Value: []byte("v1"), UpdatedAtNs: 100})
+	ss.processDelete(s, &pb.DeleteRequest{Key: []byte("k1"), UpdatedAtNs: 200})
+
+	entries, err := s.lm.ReadEntriesFrom(context.Background(), binlog.LogPosition{}, 2)
+	if err != nil || len(entries) != 2 {
+		t.Fatalf("read log entries: %v %+v", err, entries)
+	}
+
+	put, del := entries[0].Entry, entries[1].Entry
+	if put.GetPut() == nil || string(put.GetPut().Value) != "v1" || put.UpdatedAtNs != 100 {
+		t.Errorf("unexpected put log entry: %+v", put)
+	}
+	if del.GetDelete() == nil || del.UpdatedAtNs != 200 || del.Flags != uint32(pb.LogEntryDelete) {
+		t.Errorf("unexpected delete log entry: %+v", del)
+	}
+	if put.Epoch != epoch || del.Epoch != epoch {
+		t.Errorf("log entry epochs %d and %d, expecting %d", put.Epoch, del.Epoch, epoch)
+	}
+
+}
@@ -0,0 +1,26 @@
+package store
+
+import (
+	"fmt"
+)
+
+// checkOwnership returns an error with the owner hint if the partition hash belongs to another shard,
+// usually because the client has a stale cluster topology.
+func (s *shard) checkOwnership(partitionHash uint64) error {
+
+	if s.cluster == nil || s.cluster.ExpectedSize() == 0 {
+		return nil
+	}
+
+	ownerShardId := s.cluster.FindShardId(partitionHash)
+	if ownerShardId == int(s.id) {
+		return nil
+	}
+
+	if owner, found := s.cluster.GetNode(ownerShardId, 0); found {
+		return fmt.Errorf("not owner, refresh topology: shard %s does not own partition hash %d, owner is shard %d on %s",
+			s, partitionHash, ownerShardId, owner.StoreResource.GetAddress())
+	}
+	return fmt.Errorf("not owner, refresh topology: shard %s does not own partition hash %d, owner is shard %d",
+		s, partitionHash, ownerShardId)
+}
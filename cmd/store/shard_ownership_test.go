@@ -0,0 +1,97 @@
+package store
+
+import (
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/chrislusf/vasto/pb"
+	"github.com/chrislusf/vasto/topology"
+)
+
+func TestWritesCheckOwnership(t *testing.T) {
+
+	s, ss, cleanup := newTestShard(t, "ownership")
+	defer cleanup()
+
+	s.cluster = topology.NewCluster("ks1", 2, 1)
+	var ownedHash, otherHash uint64
+	for hash := uint64(1); ownedHash == 0 || otherHash == 0; hash++ {
+		if s.cluster.FindShardId(hash) == 0 {
+			ownedHash = hash
+		} else {
+			otherHash = hash
+		}
+	}
+
+	if resp := ss.processPut(s, &pb.PutRequest{Key: []byte("k1"), PartitionHash: ownedHash, Value: []byte("v1")}); !resp.Ok {
+		t.Errorf("put owned key: %+v", resp)
+	}
+
+	resp := ss.processPut(s, &pb.PutRequest{Key: []byte("k2"), PartitionHash: otherHash, Value: []byte("v2")})
+	if resp.Ok || !strings.HasPrefix(resp.Status, "not owner") {
+		t.Errorf("put key of another shard: %+v", resp)
+	}
+	if b, _ := s.db.Get([]byte("k2")); len(b) != 0 {
+		t.Errorf("put key of another shard is written")
+	}
+
+	resp = ss.processMerge(s, &pb.MergeRequest{Key: []byte("k2"), PartitionHash: otherHash, Value: []byte("v2")})
+	if resp.Ok || !strings.HasPrefix(resp.Status, "not owner") {
+		t.Errorf("merge key of another shard: %+v", resp)
+	}
+
+}
+
+func TestDeletesAndReadsCheckOwnership(t *testing.T) {
+
+	dir, err := ioutil.TempDir("", "ownership")
+	if err != nil {
+		t.Fatalf("temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	s := newShard("ks1", dir, 1, 1, nil, nil, 1, 1, 2, false)
+	defer s.db.Close()
+	defer s.shutdownNode()
+
+	s.cluster = topology.NewCluster("ks1", 2, 1)
+	var ownedHash, otherHash uint64
+	for hash := uint64(1); ownedHash == 0 || otherHash == 0; hash++ {
+		if s.cluster.FindShardId(hash) == 1 {
+			ownedHash = hash
+		} else {
+			otherHash = hash
+		}
+	}
+
+	disableBinLog := false
+	ss := &storeServer{option: &StoreOption{DisableBinLog: &disableBinLog}}
+
+	ss.processPut(s, &pb.PutRequest{Key: []byte("k1"), PartitionHash: ownedHash, Value: []byte("v1")})
+
+	if resp := ss.processGet(s, &pb.GetRequest{Key: []byte("k1"), PartitionHash: ownedHash}); !resp.Ok || resp.KeyValue == nil {
+		t.Errorf("get owned key: %+v", resp)
+	}
+	getResp := ss.processGet(s, &pb.GetRequest{Key: []byte("k1"), PartitionHash: otherHash})
+	if getResp.Ok || !strings.Contains(getResp.Status, "owner is shard 0") {
+		t.Errorf("get key of another shard: %+v", getResp)
+	}
+	batchGetResp := ss.processBatchGet(s, &pb.BatchGetRequest{Keys: [][]byte{[]byte("k1")}, PartitionHash: otherHash})
+	if batchGetResp.Ok || !strings.HasPrefix(batchGetResp.Status, "not owner") {
+		t.Errorf("batch get keys of another shard: %+v", batchGetResp)
+	}
+
+	deleteResp := ss.processDelete(s, &pb.DeleteRequest{Key: []byte("k1"), PartitionHash: otherHash})
+	if deleteResp.Ok || !strings.Contains(deleteResp.Status, "owner is shard 0") {
+		t.Errorf("delete key of another shard: %+v", deleteResp)
+	}
+	if b, _ := s.db.Get([]byte("k1")); len(b) == 0 {
+		t.Errorf("delete key of another shard is applied")
+	}
+	if deleteResp = ss.processDelete(s, &pb.DeleteRequest{Key: []byte("k1"), PartitionHash: ownedHash}); !deleteResp.Ok || !deleteResp.Existed {
+		t.Errorf("delete owned key: %+v", deleteResp)
+	}
+
+}
@@ -0,0 +1,82 @@
+package store
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/chrislusf/glog"
+)
+
+const constRepairMarkerFile = "needs_repair"
+
+// repairMarker records that a logged change may be missing from the db, e.g., the db failed after the binlog append.
+// It keeps the earliest binlog segment to repair from,
+// so that the next start repairs the db from the binlog, even if repairOnStartup is not set.
+type repairMarker struct {
+	sync.Mutex
+	fileName string
+}
+
+func newRepairMarker(dir string) *repairMarker {
+	return &repairMarker{
+		fileName: fmt.Sprintf("%s/%s", dir, constRepairMarkerFile),
+	}
+}
+
+// mark records the segment, unless an earlier segment is already marked.
+func (r *repairMarker) mark(segment uint32) error {
+	r.Lock()
+	defer r.Unlock()
+
+	if marked, found := r.read(); found && marked <= segment {
+		return nil
+	}
+	if err := ioutil.WriteFile(r.fileName, []byte(strconv.FormatUint(uint64(segment), 10)), 0644); err != nil {
+		return fmt.Errorf("write %s: %v", r.fileName, err)
+	}
+	return nil
+}
+
+// segment returns the earliest marked segment, or false if the shard is not marked.
+func (r *repairMarker) segment() (segment uint32, found bool) {
+	r.Lock()
+	defer r.Unlock()
+
+	return r.read()
+}
+
+func (r *repairMarker) clear() error {
+	r.Lock()
+	defer r.Unlock()
+
+	if err := os.Remove(r.fileName); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("remove %s: %v", r.fileName, err)
+	}
+	return nil
+}
+
+func (r *repairMarker) read() (segment uint32, found bool) {
+	data, err := ioutil.ReadFile(r.fileName)
+	if err != nil {
+		return 0, false
+	}
+	parsed, err := strconv.ParseUint(strings.TrimSpace(string(data)), 10, 32)
+	if err != nil {
+		// repair from the start if the marker is corrupted
+		glog.Errorf("parse %s: %v", r.fileName, err)
+		return 0, true
+	}
+	return uint32(parsed), true
+}
+
+// markForRepair marks the shard to repair the db from the segment on the next start.
+func (s *shard) markForRepair(segment uint32, cause error) {
+	glog.Errorf("%s marked for repair from binlog segment %d: %v", s, segment, cause)
+	if err := s.repairMarker.mark(segment); err != nil {
+		glog.Errorf("%s mark for repair: %v", s, err)
+	}
+}
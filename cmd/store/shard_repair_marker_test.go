@@ -0,0 +1,41 @@
+package store
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+)
+
+func TestRepairMarker(t *testing.T) {
+
+	dir, err := ioutil.TempDir("", "repair_marker")
+	if err != nil {
+		t.Fatalf("temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	marker := newRepairMarker(dir)
+	if _, found := marker.segment(); found {
+		t.Errorf("new marker should not be marked")
+	}
+
+	for _, segment := range []uint32{3, 5, 1, 2} {
+		if err := marker.mark(segment); err != nil {
+			t.Fatalf("mark %d: %v", segment, err)
+		}
+	}
+	if segment, found := newRepairMarker(dir).segment(); !found || segment != 1 {
+		t.Errorf("earliest marked segment: %d %v", segment, found)
+	}
+
+	if err := marker.clear(); err != nil {
+		t.Errorf("clear: %v", err)
+	}
+	if _, found := marker.segment(); found {
+		t.Errorf("cleared marker should not be marked")
+	}
+	if err := marker.clear(); err != nil {
+		t.Errorf("clear again: %v", err)
+	}
+
+}
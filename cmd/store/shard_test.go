@@ -0,0 +1,24 @@
+package store
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+)
+
+// newTestShard opens a shard with a binlog in a new temp dir, and a store server logging the writes to the binlog.
+// cleanup shuts down the shard, closes the db, and removes the dir.
+func newTestShard(t *testing.T, prefix string) (s *shard, ss *storeServer, cleanup func()) {
+	dir, err := ioutil.TempDir("", prefix)
+	if err != nil {
+		t.Fatalf("temp dir: %v", err)
+	}
+	s = newShard("ks1", dir, 0, 0, nil, nil, 1, 1, 2, false)
+	disableBinLog := false
+	ss = &storeServer{option: &StoreOption{DisableBinLog: &disableBinLog}}
+	return s, ss, func() {
+		s.shutdownNode()
+		s.db.Close()
+		os.RemoveAll(dir)
+	}
+}
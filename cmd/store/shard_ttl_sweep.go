@@ -0,0 +1,104 @@
+package store
+
+import (
+	"time"
+
+	"github.com/chrislusf/glog"
+	"github.com/chrislusf/vasto/pb"
+	"github.com/chrislusf/vasto/storage/binlog"
+	"github.com/chrislusf/vasto/storage/codec"
+)
+
+const ttlSweepBatchSize = 1024
+
+// dropExpired deletes the key if its entry is still expired, and logs the delete
+// the same way as the expired entries purged during compaction.
+func (s *shard) dropExpired(key []byte, logTombstones bool) (dropped bool, err error) {
+
+	unlock := s.keyLocks.lock(key)
+	defer unlock()
+
+	b, err := s.db.Get(key)
+	if err != nil || len(b) == 0 {
+		return false, err
+	}
+	entry := codec.FromBytes(b)
+	if !entry.IsExpired() {
+		return false, nil
+	}
+
+	if err = s.deleteEntry(key, entry); err != nil {
+		return false, err
+	}
+	if logTombstones {
+		if _, err = s.logExpired(key, entry); err != nil {
+			return true, errNotLogged(err)
+		}
+	}
+	return true, nil
+}
+
+// logExpired logs the delete of the expired entry, flagged as expired.
+func (s *shard) logExpired(key []byte, entry *codec.Entry) (binlog.LogPosition, error) {
+	return s.logMutation(&pb.LogEntry{
+		UpdatedAtNs: entry.UpdatedAtNs,
+		Delete: &pb.DeleteRequest{
+			Key:           key,
+			PartitionHash: entry.PartitionHash,
+			UpdatedAtNs:   entry.UpdatedAtNs,
+		},
+		Flags: uint32(pb.LogEntryDelete | pb.LogEntryExpired),
+	})
+}
+
+// sweepExpired scans the whole shard and drops the expired entries.
+func (s *shard) sweepExpired(logTombstones bool) (droppedCount int, err error) {
+
+	var expiredKeys [][]byte
+	err = s.db.FullScanContext(s.ctx, ttlSweepBatchSize, 0, func(rows []*pb.RawKeyValue) error {
+		for _, row := range rows {
+			if codec.FromBytes(row.Value).IsExpired() {
+				expiredKeys = append(expiredKeys, row.Key)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	for _, key := range expiredKeys {
+		dropped, err := s.dropExpired(key, logTombstones)
+		if err != nil {
+			return droppedCount, err
+		}
+		if dropped {
+			droppedCount++
+		}
+	}
+	return droppedCount, nil
+}
+
+// sweepExpiredEvery drops the expired entries periodically until the shard is shut down.
+func (s *shard) sweepExpiredEvery(interval time.Duration, logTombstones bool) {
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-ticker.C:
+			droppedCount, err := s.sweepExpired(logTombstones)
+			if err != nil {
+				glog.Errorf("%s sweep expired entries: %v", s, err)
+				continue
+			}
+			if droppedCount > 0 {
+				glog.V(1).Infof("%s dropped %d expired entries", s, droppedCount)
+			}
+		}
+	}
+
+}
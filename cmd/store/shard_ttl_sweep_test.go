@@ -0,0 +1,97 @@
+package store
+
+import (
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/chrislusf/vasto/pb"
+	"github.com/chrislusf/vasto/storage/codec"
+)
+
+func TestSweepExpired(t *testing.T) {
+
+	s, ss, cleanup := newTestShard(t, "ttl_sweep")
+	defer cleanup()
+
+	longAgo := uint64(time.Now().Add(-time.Hour).UnixNano())
+	for _, key := range []string{"expired1", "expired2", "live"} {
+		putRequest := &pb.PutRequest{Key: []byte(key), Value: []byte(key), TtlSecond: 60}
+		updatedAtNs := longAgo
+		if key == "live" {
+			updatedAtNs = uint64(time.Now().UnixNano())
+		}
+		s.db.Put(putRequest.Key, codec.NewPutEntry(putRequest, updatedAtNs).ToBytes())
+	}
+
+	// lazily dropped on read
+	resp := ss.processGet(s, &pb.GetRequest{Key: []byte("expired1")})
+	if !resp.Ok || resp.KeyValue != nil {
+		t.Errorf("get expired key: %+v", resp)
+	}
+	if b, _ := s.db.Get([]byte("expired1")); len(b) != 0 {
+		t.Errorf("expired key not dropped on read")
+	}
+
+	droppedCount, err := s.sweepExpired(true)
+	if err != nil || droppedCount != 1 {
+		t.Errorf("sweep expired: %d %v", droppedCount, err)
+	}
+	if b, _ := s.db.Get([]byte("live")); len(b) == 0 {
+		t.Errorf("live key dropped")
+	}
+
+	var deletedKeys []string
+	s.lm.ScanEntries(0, func(segment uint32, entry *pb.LogEntry) error {
+		if entry.HasFlag(pb.LogEntryDelete) && entry.HasFlag(pb.LogEntryExpired) {
+			deletedKeys = append(deletedKeys, string(entry.GetKey()))
+		}
+		return nil
+	})
+	if len(deletedKeys) != 2 {
+		t.Errorf("logged deletes: %v", deletedKeys)
+	}
+
+}
+
+func TestCompactionLogsExpiredLater(t *testing.T) {
+
+	s, _, cleanup := newTestShard(t, "compacted_expired")
+	defer cleanup()
+
+	longAgo := uint64(time.Now().Add(-time.Hour).UnixNano())
+	for _, key := range []string{"expired1", "expired2"} {
+		putRequest := &pb.PutRequest{Key: []byte(key), Value: []byte(key), TtlSecond: 60}
+		s.db.Put(putRequest.Key, codec.NewPutEntry(putRequest, longAgo).ToBytes())
+	}
+
+	s.setTtlCompaction(true, true)
+	s.db.SetCompactionForShard(0, 1)
+	s.db.Compact()
+
+	if b, _ := s.db.Get([]byte("expired1")); len(b) != 0 {
+		t.Errorf("expired key not purged")
+	}
+	if deletedKeys := loggedExpiredKeys(s); len(deletedKeys) != 0 {
+		t.Errorf("deletes logged during the compaction: %v", deletedKeys)
+	}
+
+	s.EverySecond()
+	// the compaction may purge the keys in any order
+	deletedKeys := loggedExpiredKeys(s)
+	sort.Strings(deletedKeys)
+	if len(deletedKeys) != 2 || deletedKeys[0] != "expired1" || deletedKeys[1] != "expired2" {
+		t.Errorf("logged deletes: %v", deletedKeys)
+	}
+
+}
+
+func loggedExpiredKeys(s *shard) (deletedKeys []string) {
+	s.lm.ScanEntries(0, func(segment uint32, entry *pb.LogEntry) error {
+		if entry.HasFlag(pb.LogEntryDelete) && entry.HasFlag(pb.LogEntryExpired) {
+			deletedKeys = append(deletedKeys, string(entry.GetKey()))
+		}
+		return nil
+	})
+	return
+}
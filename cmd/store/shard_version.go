@@ -0,0 +1,85 @@
+package store
+
+import (
+	"bytes"
+
+	"github.com/chrislusf/vasto/pb"
+	"github.com/chrislusf/vasto/storage/codec"
+)
+
+// orderVersioned returns the updated_at_ns of a versioned write, moved after the existing entry if the write has seen it,
+// so that the writes ordered by the versions are also ordered by the times, even with the writers' clocks skewed.
+func orderVersioned(existing *codec.Entry, version codec.VersionVector, updatedAtNs uint64) uint64 {
+	if existing == nil || existing.UpdatedAtNs < updatedAtNs {
+		return updatedAtNs
+	}
+	if existing.Version == nil || version.Compare(existing.Version) == codec.VersionAfter {
+		return existing.UpdatedAtNs + 1
+	}
+	return updatedAtNs
+}
+
+// resolveVersion returns the entry to store when the versioned entry is written over the existing entry, which can be nil,
+// or nil if the existing version has already seen the write.
+// A write after the existing version, or over an entry without a version, replaces it.
+// Of two concurrent writes, the one with the later updated_at_ns, then the deleted one, then the greater value,
+// is kept with the version merging both, so that the replicas converge in whatever order they apply the writes.
+func resolveVersion(existing, entry *codec.Entry) (resolved *codec.Entry, concurrent bool) {
+	if existing == nil || existing.Version == nil {
+		return entry, false
+	}
+	switch entry.Version.Compare(existing.Version) {
+	case codec.VersionEqual, codec.VersionBefore:
+		return nil, false
+	case codec.VersionAfter:
+		return entry, false
+	}
+	winner := *existing
+	if isLaterEntry(entry, existing) {
+		winner = *entry
+	}
+	winner.Version = existing.Version.Merge(entry.Version)
+	return &winner, true
+}
+
+func isLaterEntry(a, b *codec.Entry) bool {
+	if a.UpdatedAtNs != b.UpdatedAtNs {
+		return a.UpdatedAtNs > b.UpdatedAtNs
+	}
+	if a.IsDeleted() != b.IsDeleted() {
+		return a.IsDeleted()
+	}
+	return bytes.Compare(a.Value, b.Value) > 0
+}
+
+// newDeletedEntry returns the soft deleted entry of a versioned delete, which keeps only the version,
+// so that a concurrent write seen later is still ordered with the delete.
+func newDeletedEntry(deleteRequest *pb.DeleteRequest, updatedAtNs uint64) *codec.Entry {
+	return &codec.Entry{
+		PartitionHash: deleteRequest.PartitionHash,
+		UpdatedAtNs:   updatedAtNs,
+		DeletedAtNs:   updatedAtNs,
+		Version:       codec.NewVersionVector(deleteRequest.Version),
+	}
+}
+
+// versionedEntry returns the entry of a versioned put or delete in the binlog, or nil if the change has no version.
+func versionedEntry(entry *pb.LogEntry) *codec.Entry {
+	if put := entry.GetPut(); put != nil && put.Version != nil {
+		return codec.NewPutEntry(put, entry.UpdatedAtNs)
+	}
+	if del := entry.GetDelete(); del != nil && del.Version != nil {
+		return newDeletedEntry(del, entry.UpdatedAtNs)
+	}
+	return nil
+}
+
+// applyVersioned writes the versioned entry over the existing entry, which can be nil, the same way as the primary,
+// and returns whether the stored entry changed.
+func (s *shard) applyVersioned(key []byte, existing, entry *codec.Entry) (applied bool, err error) {
+	resolved, _ := resolveVersion(existing, entry)
+	if resolved == nil {
+		return false, nil
+	}
+	return true, s.putEntry(key, existing, resolved)
+}
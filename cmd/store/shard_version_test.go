@@ -0,0 +1,108 @@
+package store
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/chrislusf/vasto/pb"
+	"github.com/chrislusf/vasto/storage/codec"
+)
+
+func testVersion(clocks map[string]uint64) *pb.VersionVector {
+	return codec.VersionVector(clocks).ToPb()
+}
+
+func TestProcessVersionedWrites(t *testing.T) {
+
+	s, ss, cleanup := newTestShard(t, "versioned_writes")
+	defer cleanup()
+
+	get := func() *pb.KeyTypeValue {
+		return ss.processGet(s, &pb.GetRequest{Key: []byte("k1"), IncludeDeleted: true}).KeyValue
+	}
+
+	resp := ss.processPut(s, &pb.PutRequest{Key: []byte("k1"), Value: []byte("v1"), UpdatedAtNs: 100, Version: testVersion(map[string]uint64{"a": 1})})
+	if !resp.Ok || resp.Concurrent || codec.NewVersionVector(resp.Version).Compare(codec.VersionVector{"a": 1}) != codec.VersionEqual {
+		t.Fatalf("versioned put: %+v", resp)
+	}
+
+	// a later version wins even with an earlier clock
+	resp = ss.processPut(s, &pb.PutRequest{Key: []byte("k1"), Value: []byte("v2"), UpdatedAtNs: 50, Version: testVersion(map[string]uint64{"a": 2})})
+	if !resp.Ok || resp.Concurrent || resp.UpdatedAtNs <= 100 || !bytes.Equal(get().Value, []byte("v2")) {
+		t.Errorf("put after the stored version: %+v, stored %+v", resp, get())
+	}
+
+	// an already seen version is dropped
+	resp = ss.processPut(s, &pb.PutRequest{Key: []byte("k1"), Value: []byte("v0"), UpdatedAtNs: 200, Version: testVersion(map[string]uint64{"a": 1})})
+	if !resp.Ok || resp.Concurrent || !bytes.Equal(get().Value, []byte("v2")) ||
+		codec.NewVersionVector(resp.Version).Compare(codec.VersionVector{"a": 2}) != codec.VersionEqual {
+		t.Errorf("put before the stored version: %+v, stored %+v", resp, get())
+	}
+
+	// a concurrent write with an earlier clock loses, but the versions are merged
+	resp = ss.processPut(s, &pb.PutRequest{Key: []byte("k1"), Value: []byte("v3"), UpdatedAtNs: 60, Version: testVersion(map[string]uint64{"b": 1})})
+	if !resp.Ok || !resp.Concurrent || !bytes.Equal(get().Value, []byte("v2")) ||
+		codec.NewVersionVector(resp.Version).Compare(codec.VersionVector{"a": 2, "b": 1}) != codec.VersionEqual {
+		t.Errorf("concurrent put: %+v, stored %+v", resp, get())
+	}
+
+	// a versioned delete keeps the version
+	resp = ss.processDelete(s, &pb.DeleteRequest{Key: []byte("k1"), UpdatedAtNs: 10, Version: testVersion(map[string]uint64{"a": 3, "b": 1})})
+	if !resp.Ok || !resp.Existed || resp.Concurrent {
+		t.Errorf("versioned delete: %+v", resp)
+	}
+	if kv := ss.processGet(s, &pb.GetRequest{Key: []byte("k1")}).KeyValue; kv != nil {
+		t.Errorf("get after versioned delete: %+v", kv)
+	}
+	if kv := get(); kv == nil || kv.DeletedAtNs == 0 || codec.NewVersionVector(kv.Version).Compare(codec.VersionVector{"a": 3, "b": 1}) != codec.VersionEqual {
+		t.Errorf("deleted entry: %+v", kv)
+	}
+
+	// a put not seeing the delete does not bring the key back
+	resp = ss.processPut(s, &pb.PutRequest{Key: []byte("k1"), Value: []byte("v4"), UpdatedAtNs: 1, Version: testVersion(map[string]uint64{"a": 2, "b": 2})})
+	if !resp.Ok || !resp.Concurrent || get().DeletedAtNs == 0 {
+		t.Errorf("put concurrent with the delete: %+v, stored %+v", resp, get())
+	}
+
+}
+
+func TestFollowVersionedWritesConverge(t *testing.T) {
+
+	entries := []*pb.LogEntry{
+		{UpdatedAtNs: 10, Put: &pb.PutRequest{Key: []byte("k1"), Value: []byte("x"), Version: testVersion(map[string]uint64{"a": 1})}},
+		{UpdatedAtNs: 20, Put: &pb.PutRequest{Key: []byte("k1"), Value: []byte("y"), Version: testVersion(map[string]uint64{"b": 1})}},
+		{UpdatedAtNs: 15, Delete: &pb.DeleteRequest{Key: []byte("k1"), Version: testVersion(map[string]uint64{"a": 2})}},
+		{UpdatedAtNs: 20, Put: &pb.PutRequest{Key: []byte("k1"), Value: []byte("z"), Version: testVersion(map[string]uint64{"c": 1})}},
+	}
+
+	var expected []byte
+	for i, order := range [][]int{{0, 1, 2, 3}, {3, 2, 1, 0}, {2, 0, 3, 1}, {1, 3, 0, 2, 1}} {
+		dir, err := ioutil.TempDir("", "follow_versioned")
+		if err != nil {
+			t.Fatalf("temp dir: %v", err)
+		}
+		s := newShard("ks1", dir, 0, 0, nil, nil, 1, 0, 0, false)
+		for _, j := range order {
+			if err = s.processEntry(entries[j]); err != nil {
+				t.Errorf("order %v apply %d: %v", order, j, err)
+			}
+		}
+		b, _ := s.db.Get([]byte("k1"))
+		if i == 0 {
+			expected = b
+		} else if !bytes.Equal(b, expected) {
+			t.Errorf("order %v: %+v, expected %+v", order, codec.FromBytes(b), codec.FromBytes(expected))
+		}
+		s.shutdownNode()
+		s.db.Close()
+		os.RemoveAll(dir)
+	}
+
+	if entry := codec.FromBytes(expected); !bytes.Equal(entry.Value, []byte("z")) ||
+		entry.Version.Compare(codec.VersionVector{"a": 2, "b": 1, "c": 1}) != codec.VersionEqual {
+		t.Errorf("converged entry: %+v", entry)
+	}
+
+}
@@ -0,0 +1,99 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/chrislusf/vasto/pb"
+	"github.com/chrislusf/vasto/storage/binlog"
+	"github.com/chrislusf/vasto/topology"
+	"google.golang.org/grpc"
+)
+
+const (
+	statusNotReplicated       = "not replicated"
+	defaultReplicaWaitTimeout = time.Second
+	replicaAckPollInterval    = 10 * time.Millisecond
+)
+
+// waitForReplicas blocks until at least n peer replicas have consumed the binlog past the position.
+// It returns an error if ctx is done first, or if the shard has fewer than n peer replicas.
+func (s *shard) waitForReplicas(ctx context.Context, position binlog.LogPosition, n int) error {
+
+	if s.cluster == nil {
+		return fmt.Errorf("no cluster to find %d replicas", n)
+	}
+
+	peers := s.peerShards()
+	if len(peers) < n {
+		return fmt.Errorf("only %d replicas, fewer than %d", len(peers), n)
+	}
+
+	self, found := s.cluster.GetNode(int(s.serverId), 0)
+	if !found {
+		return fmt.Errorf("server %d not found in cluster %v", s.serverId, s.cluster)
+	}
+
+	request := &pb.AckedRequest{
+		Keyspace:     s.keyspace,
+		ShardId:      uint32(s.id),
+		AdminAddress: self.StoreResource.GetAdminAddress(),
+		Segment:      position.Segment,
+		Offset:       uint64(position.Offset),
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	ackChan := make(chan topology.ClusterShard, len(peers))
+	for _, peer := range peers {
+		go func(peer topology.ClusterShard) {
+			if err := s.waitForPeerAck(ctx, peer, request); err == nil {
+				ackChan <- peer
+			}
+		}(peer)
+	}
+
+	for acked := 0; acked < n; acked++ {
+		select {
+		case <-ackChan:
+		case <-ctx.Done():
+			return fmt.Errorf("%d of %d replicas acked: %v", acked, n, ctx.Err())
+		}
+	}
+
+	return nil
+}
+
+// waitForPeerAck polls the peer until it has consumed the binlog past the position in the request.
+// A peer not following this shard yet, e.g., just restarted, is polled again.
+func (s *shard) waitForPeerAck(ctx context.Context, peer topology.ClusterShard, request *pb.AckedRequest) error {
+
+	return s.cluster.WithConnectionContext(ctx, fmt.Sprintf("%s wait for replica %s", s, peer), peer.ServerId,
+		func(ctx context.Context, node *pb.ClusterNode, grpcConnection *grpc.ClientConn) error {
+
+			client := pb.NewVastoStoreClient(grpcConnection)
+
+			for {
+				resp, err := client.Acked(ctx, request)
+				if err != nil {
+					return err
+				}
+				if resp.Acked {
+					return nil
+				}
+				select {
+				case <-ctx.Done():
+					return ctx.Err()
+				case <-time.After(replicaAckPollInterval):
+				}
+			}
+
+		})
+
+}
+
+func errNotReplicated(err error) error {
+	return fmt.Errorf("%s: %v", statusNotReplicated, err)
+}
@@ -0,0 +1,111 @@
+package store
+
+import (
+	"io/ioutil"
+	"net"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/chrislusf/vasto/pb"
+	"github.com/chrislusf/vasto/topology"
+	"google.golang.org/grpc"
+)
+
+func TestDeleteWaitForReplicas(t *testing.T) {
+
+	dir, err := ioutil.TempDir("", "wait_replicas")
+	if err != nil {
+		t.Fatalf("temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	// server 1 serves the replica of shard 0
+	listener, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	replica := newShard("ks1", dir+"/replica", 1, 0, nil, nil, 1, 0, 0, true)
+	defer replica.shutdownNode()
+	replicaServer := &storeServer{keyspaceShards: newKeyspaceShards()}
+	replicaServer.keyspaceShards.addShards("ks1", replica)
+	grpcServer := grpc.NewServer()
+	pb.RegisterVastoStoreServer(grpcServer, replicaServer)
+	go grpcServer.Serve(listener)
+	defer grpcServer.Stop()
+
+	cluster := topology.NewCluster("ks1", 2, 2)
+	stores := []*pb.StoreResource{
+		{Network: "tcp", Address: "localhost:7000", AdminAddress: "localhost:8000"},
+		{Network: "tcp", Address: "localhost:7001", AdminAddress: listener.Addr().String()},
+	}
+	for serverId, store := range stores {
+		for _, shardId := range []int{serverId, 1 - serverId} {
+			cluster.SetShard(store, &pb.ShardInfo{
+				KeyspaceName:      "ks1",
+				ServerId:          uint32(serverId),
+				ShardId:           uint32(shardId),
+				ClusterSize:       2,
+				ReplicationFactor: 2,
+			})
+		}
+	}
+
+	leader := newShard("ks1", dir, 0, 0, cluster, nil, 2, 1, 2, false)
+	defer leader.db.Close()
+	defer leader.shutdownNode()
+
+	disableBinLog := false
+	ss := &storeServer{option: &StoreOption{DisableBinLog: &disableBinLog}}
+	ownedHash := uint64(1)
+	for cluster.FindShardId(ownedHash) != 0 {
+		ownedHash++
+	}
+
+	for _, key := range []string{"k1", "k2", "k3"} {
+		ss.processPut(leader, &pb.PutRequest{Key: []byte(key), PartitionHash: ownedHash, Value: []byte("v")})
+	}
+
+	// the replica has not consumed the delete
+	resp := ss.processDelete(leader, &pb.DeleteRequest{Key: []byte("k1"), PartitionHash: ownedHash, WaitForReplicas: 1, ReplicaWaitMs: 100})
+	if resp.Ok || !resp.Existed || !strings.HasPrefix(resp.Status, statusNotReplicated) {
+		t.Errorf("delete not consumed by the replica: %+v", resp)
+	}
+
+	// more replicas than the replication factor
+	resp = ss.processDelete(leader, &pb.DeleteRequest{Key: []byte("k2"), PartitionHash: ownedHash, WaitForReplicas: 2})
+	if resp.Ok || !strings.Contains(resp.Status, "fewer than 2") {
+		t.Errorf("delete waiting for 2 replicas: %+v", resp)
+	}
+
+	// the replica catches up while the delete waits
+	nextSegment := resp.LogSegment + 1
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		replica.setConsumed("localhost:8000", 0, nextSegment, 0)
+	}()
+	resp = ss.processDelete(leader, &pb.DeleteRequest{Key: []byte("k3"), PartitionHash: ownedHash, WaitForReplicas: 1})
+	if !resp.Ok || !resp.Existed {
+		t.Errorf("delete consumed by the replica: %+v", resp)
+	}
+
+	// the key is not locked while the delete waits for the replicas
+	ss.processPut(leader, &pb.PutRequest{Key: []byte("k4"), PartitionHash: ownedHash, Value: []byte("v")})
+	waiting := make(chan *pb.WriteResponse)
+	go func() {
+		waiting <- ss.processDelete(leader, &pb.DeleteRequest{Key: []byte("k4"), PartitionHash: ownedHash, WaitForReplicas: 1, ReplicaWaitMs: 500})
+	}()
+	time.Sleep(50 * time.Millisecond)
+	startTime := time.Now()
+	if resp := ss.processPut(leader, &pb.PutRequest{Key: []byte("k4"), PartitionHash: ownedHash, Value: []byte("v2")}); !resp.Ok {
+		t.Errorf("put while the delete waits: %+v", resp)
+	}
+	if elapsed := time.Since(startTime); elapsed > 200*time.Millisecond {
+		t.Errorf("put blocked by the waiting delete for %v", elapsed)
+	}
+	if resp := <-waiting; !resp.Existed {
+		t.Errorf("waiting delete: %+v", resp)
+	}
+
+}
@@ -0,0 +1,110 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/chrislusf/glog"
+)
+
+const shuttingDown = "store is shutting down"
+
+// drain counts the requests being processed, so that Shutdown can wait for them to finish.
+// Once closing, new requests are rejected.
+type drain struct {
+	lock     sync.Mutex
+	closing  bool
+	inFlight int
+	idle     chan struct{} // closed when closing and no requests are in flight
+}
+
+// enter returns false if closing. Every successful enter must be followed by an exit.
+func (d *drain) enter() bool {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	if d.closing {
+		return false
+	}
+	d.inFlight++
+	return true
+}
+
+func (d *drain) exit() {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	d.inFlight--
+	if d.closing && d.inFlight == 0 && d.idle != nil {
+		close(d.idle)
+		d.idle = nil
+	}
+}
+
+// close rejects the new requests, and returns a channel closed once the requests in flight are finished.
+func (d *drain) close() <-chan struct{} {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	idle := make(chan struct{})
+	d.closing = true
+	if d.inFlight == 0 {
+		close(idle)
+	} else {
+		d.idle = idle
+	}
+	return idle
+}
+
+func (d *drain) inFlightCount() int {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	return d.inFlight
+}
+
+// Shutdown stops accepting new requests, and waits for the requests in flight to finish until ctx is done.
+// Then it saves the follow progress, flushes the binlog, and closes the db of all shards.
+// If ctx is done first, the error reports the unfinished requests, and the shards are closed
+// only after the requests in flight finish, since they may still read or write the db.
+func (ss *storeServer) Shutdown(ctx context.Context) (err error) {
+
+	glog.V(0).Infof("%s shutting down", ss.storeName)
+
+	idle := ss.drain.close()
+	select {
+	case <-idle:
+	case <-ctx.Done():
+		err = fmt.Errorf("%d requests still in flight: %v", ss.drain.inFlightCount(), ctx.Err())
+		glog.Errorf("%s shutdown: %v", ss.storeName, err)
+	}
+
+	shards := ss.keyspaceShards.allShards()
+	for _, shard := range shards {
+		shard.EverySecond()
+		if shard.lm != nil {
+			if flushErr := shard.lm.Flush(); flushErr != nil {
+				glog.Errorf("%s flush binlog: %v", shard, flushErr)
+			}
+		}
+	}
+
+	if err != nil {
+		go func() {
+			<-idle
+			closeShards(shards)
+			glog.V(0).Infof("%s shut down after the requests in flight", ss.storeName)
+		}()
+		return err
+	}
+
+	closeShards(shards)
+
+	glog.V(0).Infof("%s shut down", ss.storeName)
+
+	return nil
+}
+
+func closeShards(shards []*shard) {
+	for _, shard := range shards {
+		shard.shutdownNode()
+		shard.db.Close()
+	}
+}
@@ -0,0 +1,96 @@
+package store
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/chrislusf/vasto/pb"
+	"github.com/chrislusf/vasto/storage/rocks"
+)
+
+func TestShutdownDrainsRequests(t *testing.T) {
+
+	dir, err := ioutil.TempDir("", "drain")
+	if err != nil {
+		t.Fatalf("temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	disableBinLog := false
+	ss := &storeServer{option: &StoreOption{DisableBinLog: &disableBinLog}, keyspaceShards: newKeyspaceShards()}
+	s := newShard("ks1", dir, 0, 0, nil, nil, 1, 1, 2, false)
+	ss.keyspaceShards.addShards("ks1", s)
+
+	put := &pb.Request{Put: &pb.PutRequest{Key: []byte("k1"), Value: []byte("v1")}}
+	if resp := ss.processRequest(context.Background(), "ks1", put); !resp.Write.Ok {
+		t.Fatalf("put: %+v", resp)
+	}
+
+	// a request in flight finishes while shutting down
+	if !ss.drain.enter() {
+		t.Fatalf("enter before shutting down")
+	}
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		ss.drain.exit()
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := ss.Shutdown(ctx); err != nil {
+		t.Errorf("shutdown: %v", err)
+	}
+
+	if resp := ss.processRequest(context.Background(), "ks1", put); resp.Write.Ok || !strings.Contains(resp.Write.Status, "shutting down") {
+		t.Errorf("put after shutdown: %+v", resp)
+	}
+	if _, err := s.db.Get([]byte("k1")); err == nil {
+		t.Errorf("db is not closed after shutdown")
+	}
+	if resp, _ := ss.CompactKeyspace(context.Background(), &pb.CompactKeyspaceRequest{Keyspace: "ks1"}); resp.Error != shuttingDown {
+		t.Errorf("compact keyspace after shutdown: %+v", resp)
+	}
+
+}
+
+func TestShutdownTimeout(t *testing.T) {
+
+	dir, err := ioutil.TempDir("", "drain")
+	if err != nil {
+		t.Fatalf("temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	ss := &storeServer{keyspaceShards: newKeyspaceShards()}
+	s := newShard("ks1", dir, 0, 0, nil, nil, 1, 0, 0, false)
+	ss.keyspaceShards.addShards("ks1", s)
+
+	ss.drain.enter()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if err := ss.Shutdown(ctx); err == nil || !strings.Contains(err.Error(), "1 requests still in flight") {
+		t.Errorf("shutdown with a request in flight: %v", err)
+	}
+
+	// the request in flight can still use the db
+	if _, err := s.db.Get([]byte("k1")); err == rocks.ErrorShutdownInProgress {
+		t.Errorf("db is closed with a request in flight: %v", err)
+	}
+
+	ss.drain.exit()
+	for i := 0; ; i++ {
+		if _, err := s.db.Get([]byte("k1")); err == rocks.ErrorShutdownInProgress {
+			break
+		}
+		if i >= 100 {
+			t.Fatalf("db is not closed after the request in flight finished")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+}
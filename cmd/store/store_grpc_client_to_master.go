@@ -52,6 +52,7 @@ func (ss *storeServer) registerAtMasterServer() error {
 			AdminAddress: ss.selfAdminAddress(),
 			DiskSizeGb:   uint32(*ss.option.DiskSizeGb),
 			Tags:         strings.Split(*ss.option.Tags, ","),
+			DataCenter:   *ss.option.DataCenter,
 		},
 	}
 
@@ -67,7 +68,7 @@ func (ss *storeServer) registerAtMasterServer() error {
 		// this is async, because
 		// each sendShardInfoToMaster is listening on the ss.ShardInfoChan
 		for _, storeStatus := range ss.statusInCluster {
-			for _, shardInfo := range storeStatus.ShardMap {
+			for _, shardInfo := range storeStatus.GetShardInfos() {
 				ss.sendShardInfoToMaster(shardInfo, pb.ShardInfo_READY)
 			}
 		}
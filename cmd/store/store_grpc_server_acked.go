@@ -0,0 +1,34 @@
+package store
+
+import (
+	"fmt"
+
+	"github.com/chrislusf/vasto/pb"
+	"golang.org/x/net/context"
+)
+
+// Acked checks whether the replica of the shard on this server has consumed the binlog of the shard
+// on the server at the admin address, past the binlog position returned by a delete.
+func (ss *storeServer) Acked(ctx context.Context, request *pb.AckedRequest) (*pb.AckedResponse, error) {
+
+	shard, found := ss.keyspaceShards.getShard(request.Keyspace, VastoShardId(request.ShardId))
+	if !found || shard.isShutdown {
+		return &pb.AckedResponse{
+			Error: fmt.Sprintf("shard: %s.%d not found", request.Keyspace, request.ShardId),
+		}, nil
+	}
+
+	acked, next, found := shard.hasFollowedPast(request.AdminAddress, VastoShardId(request.ShardId), request.Segment, request.Offset)
+	if !found {
+		return &pb.AckedResponse{
+			Error: fmt.Sprintf("shard %s is not following %s", shard, request.AdminAddress),
+		}, nil
+	}
+
+	return &pb.AckedResponse{
+		Acked:       acked,
+		NextSegment: next.segment,
+		NextOffset:  next.offset,
+	}, nil
+
+}
@@ -0,0 +1,62 @@
+package store
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/chrislusf/vasto/pb"
+	"github.com/chrislusf/vasto/storage/binlog"
+	"golang.org/x/net/context"
+)
+
+func TestDeleteAcked(t *testing.T) {
+
+	leader, ss, cleanup := newTestShard(t, "acked")
+	defer cleanup()
+
+	ss.processPut(leader, &pb.PutRequest{Key: []byte("k1"), Value: []byte("v1")})
+	resp := ss.processDelete(leader, &pb.DeleteRequest{Key: []byte("k1")})
+	if !resp.Ok || resp.LogOffset == 0 {
+		t.Fatalf("delete: %+v", resp)
+	}
+
+	entries, err := leader.lm.ReadEntriesFrom(context.Background(), binlog.LogPosition{Segment: resp.LogSegment, Offset: int64(resp.LogOffset)}, 1)
+	if err != nil || len(entries) != 1 || entries[0].Entry.GetDelete() == nil {
+		t.Fatalf("read delete entry: %v %+v", err, entries)
+	}
+
+	replicaDir, err := ioutil.TempDir("", "acked_replica")
+	if err != nil {
+		t.Fatalf("temp dir: %v", err)
+	}
+	defer os.RemoveAll(replicaDir)
+	replica := newShard("ks1", replicaDir, 1, 0, nil, nil, 1, 0, 0, true)
+	defer replica.shutdownNode()
+	replicaServer := &storeServer{keyspaceShards: newKeyspaceShards()}
+	replicaServer.keyspaceShards.addShards("ks1", replica)
+
+	request := &pb.AckedRequest{
+		Keyspace:     "ks1",
+		ShardId:      0,
+		AdminAddress: "localhost:8000",
+		Segment:      resp.LogSegment,
+		Offset:       resp.LogOffset,
+	}
+
+	if ackedResp, _ := replicaServer.Acked(context.Background(), request); ackedResp.Error == "" {
+		t.Errorf("acked without following: %+v", ackedResp)
+	}
+
+	replica.setConsumed("localhost:8000", 0, resp.LogSegment, resp.LogOffset)
+	if ackedResp, _ := replicaServer.Acked(context.Background(), request); ackedResp.Error != "" || ackedResp.Acked {
+		t.Errorf("acked before consuming the delete: %+v", ackedResp)
+	}
+
+	next := entries[0].Next
+	replica.setConsumed("localhost:8000", 0, next.Segment, uint64(next.Offset))
+	if ackedResp, _ := replicaServer.Acked(context.Background(), request); ackedResp.Error != "" || !ackedResp.Acked {
+		t.Errorf("not acked after consuming the delete: %+v", ackedResp)
+	}
+
+}
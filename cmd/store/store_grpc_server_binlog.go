@@ -4,12 +4,21 @@ import (
 	"fmt"
 	"github.com/chrislusf/glog"
 	"github.com/chrislusf/vasto/pb"
+	"github.com/chrislusf/vasto/storage/binlog"
 	"github.com/dgryski/go-jump"
 	"golang.org/x/net/context"
 	"io"
 	"time"
 )
 
+// defaultBatchWindow and defaultBatchBytes are used when the store options do not set them, e.g., in tests
+const (
+	defaultBatchWindow      = 100 * time.Millisecond
+	defaultBatchBytes       = 64 * 1024
+	smallBatchEntriesCount  = 100
+	batchWindowPollInterval = 5 * time.Millisecond
+)
+
 // TailBinlog sends all data if PullUpdateRequest's TargetClusterSize==0,
 // or sends all data belong to TargetShardId in cluster of TargetClusterSize
 func (ss *storeServer) TailBinlog(request *pb.PullUpdateRequest, stream pb.VastoStore_TailBinlogServer) error {
@@ -53,6 +62,13 @@ func (ss *storeServer) TailBinlog(request *pb.PullUpdateRequest, stream pb.Vasto
 		glog.V(1).Infof("TailBinlog completed shard %v for %v", shard.String(), request.Origin)
 	}()
 
+	// the follower resumes from the position it has consumed, to slow down the writes if the follower lags too far behind.
+	// Later positions are tracked as the follower acknowledges them, by trackFollowerAcksEvery.
+	// The follower stays tracked after disconnecting, until it is removed from the topology.
+	if request.Origin != "" {
+		shard.lm.SetFollowerPosition(request.Origin, binlog.LogPosition{Segment: segment, Offset: offset})
+	}
+
 	for {
 
 		// println("TailBinlog server reading entries, segment", segment, "offset", offset, "limit", limit)
@@ -63,8 +79,8 @@ func (ss *storeServer) TailBinlog(request *pb.PullUpdateRequest, stream pb.Vasto
 			segment += 1
 		} else if err != nil {
 			return fmt.Errorf("failed to read segment %d offset %d: %v", segment, offset, err)
-		} else if len(entries) <= 100 {
-			time.Sleep(100 * time.Millisecond)
+		} else if shard.batchWindow > 0 && len(entries) <= smallBatchEntriesCount {
+			shard.waitForBatch(segment, offset)
 			entries, nextOffset, err = shard.lm.ReadEntries(segment, offset, limit)
 			if err == io.EOF {
 				segment += 1
@@ -104,6 +120,30 @@ func (ss *storeServer) TailBinlog(request *pb.PullUpdateRequest, stream pb.Vasto
 
 }
 
+// waitForBatch waits up to the batch window for more binlog entries after the offset to send together to the followers.
+// It returns early once the batch bytes are available, the segment is rotated, or the shard is shut down.
+func (s *shard) waitForBatch(segment uint32, offset int64) {
+	deadline := time.Now().Add(s.batchWindow)
+	for {
+		latestSegment, latestOffset := s.lm.GetSegmentOffset()
+		if latestSegment != segment || latestOffset-offset >= s.batchBytes {
+			return
+		}
+		wait := time.Until(deadline)
+		if wait <= 0 {
+			return
+		}
+		if wait > batchWindowPollInterval {
+			wait = batchWindowPollInterval
+		}
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-time.After(wait):
+		}
+	}
+}
+
 func (ss *storeServer) CheckBinlog(ctx context.Context, request *pb.CheckBinlogRequest) (*pb.CheckBinlogResponse, error) {
 
 	node, found := ss.keyspaceShards.getShard(request.Keyspace, VastoShardId(request.ShardId))
@@ -0,0 +1,83 @@
+package store
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/chrislusf/vasto/pb"
+	"google.golang.org/grpc"
+)
+
+type tailBinlogStream struct {
+	grpc.ServerStream
+	sent chan *pb.PullUpdateResponse
+}
+
+var errStopTailing = errors.New("stop tailing")
+
+func (s *tailBinlogStream) Send(resp *pb.PullUpdateResponse) error {
+	s.sent <- resp
+	return errStopTailing
+}
+
+func TestTailBinlogBatchWindow(t *testing.T) {
+
+	s, _, cleanup := newTestShard(t, "tail_binlog")
+	defer cleanup()
+
+	if err := s.logPut(&pb.PutRequest{Key: []byte("k1"), Value: []byte("v1")}, 1); err != nil {
+		t.Fatalf("log put: %v", err)
+	}
+
+	ss := &storeServer{keyspaceShards: newKeyspaceShards()}
+	ss.keyspaceShards.addShards("ks1", s)
+
+	for _, c := range []struct {
+		batchWindowMs int
+		batchBytes    int64
+		minWait       time.Duration
+		maxWait       time.Duration
+	}{
+		{0, defaultBatchBytes, 0, 100 * time.Millisecond},
+		{200, defaultBatchBytes, 200 * time.Millisecond, time.Second},
+		// the logged entry is already over the batch bytes
+		{200, 1, 0, 100 * time.Millisecond},
+	} {
+		s.batchWindow = time.Duration(c.batchWindowMs) * time.Millisecond
+		s.batchBytes = c.batchBytes
+
+		stream := &tailBinlogStream{sent: make(chan *pb.PullUpdateResponse, 1)}
+		startTime := time.Now()
+		if err := ss.TailBinlog(&pb.PullUpdateRequest{Keyspace: "ks1", Limit: 10}, stream); err != errStopTailing {
+			t.Errorf("tail binlog: %v", err)
+		}
+		elapsed := time.Since(startTime)
+
+		resp := <-stream.sent
+		if len(resp.Entries) != 1 {
+			t.Errorf("batch window %dms %d bytes sent %d entries", c.batchWindowMs, c.batchBytes, len(resp.Entries))
+		}
+		if elapsed < c.minWait || elapsed > c.maxWait {
+			t.Errorf("batch window %dms %d bytes sent after %v", c.batchWindowMs, c.batchBytes, elapsed)
+		}
+	}
+
+}
+
+func TestBatchWindowOf(t *testing.T) {
+
+	batchWindowMs, batchWindows := 100, "ks1:5, ks2:x,ks3:0"
+	option := &StoreOption{BatchWindowMs: &batchWindowMs, BatchWindows: &batchWindows}
+	for keyspace, expected := range map[string]time.Duration{
+		"ks1": 5 * time.Millisecond,
+		"ks2": 100 * time.Millisecond,
+		"ks3": 0,
+		"ks4": 100 * time.Millisecond,
+	} {
+		if got := option.BatchWindowOf(keyspace); got != expected {
+			t.Errorf("batch window of %s: %v, expected %v", keyspace, got, expected)
+		}
+	}
+
+}
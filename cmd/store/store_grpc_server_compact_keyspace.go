@@ -12,6 +12,13 @@ import (
 // 1. if the shard is already created, do nothing
 func (ss *storeServer) CompactKeyspace(ctx context.Context, request *pb.CompactKeyspaceRequest) (*pb.CompactKeyspaceResponse, error) {
 
+	if !ss.drain.enter() {
+		return &pb.CompactKeyspaceResponse{
+			Error: shuttingDown,
+		}, nil
+	}
+	defer ss.drain.exit()
+
 	glog.V(1).Infof("compact keyspace %v", request)
 	err := ss.compactShards(request.Keyspace)
 	if err != nil {
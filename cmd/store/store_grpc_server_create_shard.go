@@ -7,12 +7,20 @@ import (
 	"github.com/chrislusf/vasto/topology"
 	"golang.org/x/net/context"
 	"os"
+	"time"
 )
 
 // CreateShard
 // 1. if the shard is already created, do nothing
 func (ss *storeServer) CreateShard(ctx context.Context, request *pb.CreateShardRequest) (*pb.CreateShardResponse, error) {
 
+	if !ss.drain.enter() {
+		return &pb.CreateShardResponse{
+			Error: shuttingDown,
+		}, nil
+	}
+	defer ss.drain.exit()
+
 	glog.V(1).Infof("%s create shard %v", ss.storeName, request)
 	err := ss.createShards(request.Keyspace, int(request.ServerId), int(request.ClusterSize), int(request.ReplicationFactor), false, func(shardId int) *topology.BootstrapPlan {
 		return &topology.BootstrapPlan{
@@ -116,11 +124,8 @@ func (ss *storeServer) startExistingNodes(keyspaceName string, storeStatus *pb.L
 			return fmt.Errorf("%s open %s: %v", ss.storeName, shardInfo.IdentifierOnThisServer(), shardOpenError)
 		}
 
-		for fileId, meta := range shard.db.GetLiveFilesMetaData() {
-			glog.V(1).Infof("%s %d name:%s, level:%d size:%d SmallestKey:%s LargestKey:%s", ss.storeName, fileId, meta.Name, meta.Level, meta.Size, string(meta.SmallestKey), string(meta.LargestKey))
-			if meta.Level >= 6 {
-				shard.hasBackfilled = true
-			}
+		if shard.db.HasBackfilled() {
+			shard.hasBackfilled = true
 		}
 
 		if err := shard.startWithBootstrapPlan(&topology.BootstrapPlan{
@@ -145,8 +150,41 @@ func (ss *storeServer) openShard(shardInfo *pb.ShardInfo) (shard *shard, err err
 	}
 
 	shard = newShard(shardInfo.KeyspaceName, dir, int(shardInfo.ServerId), int(shardInfo.ShardId), cluster, ss.clusterListener,
-		int(shardInfo.ReplicationFactor), *ss.option.LogFileSizeMb, *ss.option.LogFileCount)
+		int(shardInfo.ReplicationFactor), *ss.option.LogFileSizeMb, *ss.option.LogFileCount, ss.option.InMemory != nil && *ss.option.InMemory)
 	shard.setCompactionFilterClusterSize(int(shardInfo.ClusterSize))
+	shard.setTtlCompaction(ss.option.IsTtlCompactionEnabled(shardInfo.KeyspaceName), !*ss.option.DisableBinLog)
+	if ss.option.DeadLetterAfter != nil {
+		shard.deadLetterAfter = *ss.option.DeadLetterAfter
+	}
+	shard.batchWindow = ss.option.BatchWindowOf(shardInfo.KeyspaceName)
+	if ss.option.BatchWindowKb != nil {
+		shard.batchBytes = int64(*ss.option.BatchWindowKb) * 1024
+	}
+	if ss.option.IntentTtlSeconds != nil {
+		shard.intentTtl = time.Duration(*ss.option.IntentTtlSeconds) * time.Second
+	}
+	if ss.option.SoftDeleteHours != nil {
+		shard.db.SetSoftDeleteRetention(time.Duration(*ss.option.SoftDeleteHours) * time.Hour)
+	}
+	if ss.option.LogBackpressureMb != nil && shard.lm != nil {
+		shard.lm.SetHighWatermark(int64(*ss.option.LogBackpressureMb) * 1024 * 1024)
+		if *ss.option.LogBackpressureMb > 0 {
+			go shard.trackFollowerAcksEvery(followerAckPollInterval)
+		}
+	}
+	shard.untrackRemovedFollowers()
+	if ss.option.ShardMaxInFlight != nil {
+		shard.admission.setLimit(*ss.option.ShardMaxInFlight)
+	}
+	if _, marked := shard.repairMarker.segment(); marked || (ss.option.RepairOnStartup != nil && *ss.option.RepairOnStartup) {
+		shard.checkConsistencyOnStartup(ss.option.InMemory != nil && *ss.option.InMemory)
+	}
+	if err := shard.recoverDeleteIntents(); err != nil {
+		glog.Errorf("%s recover delete intents: %v", shard, err)
+	}
+	if ss.option.TtlSweepSeconds != nil && *ss.option.TtlSweepSeconds > 0 {
+		go shard.sweepExpiredEvery(time.Duration(*ss.option.TtlSweepSeconds)*time.Second, !*ss.option.DisableBinLog)
+	}
 	// println("loading shard", shard.String())
 	ss.keyspaceShards.addShards(shardInfo.KeyspaceName, shard)
 	ss.RegisterPeriodicTask(shard)
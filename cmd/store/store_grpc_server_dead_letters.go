@@ -0,0 +1,55 @@
+package store
+
+import (
+	"fmt"
+
+	"github.com/chrislusf/glog"
+	"github.com/chrislusf/vasto/pb"
+	"golang.org/x/net/context"
+)
+
+// DeadLetters lists the followed binlog entries that failed to apply to the shard.
+// With reprocess set, the entries are applied again, and only the still failing ones are kept and returned.
+func (ss *storeServer) DeadLetters(ctx context.Context, request *pb.DeadLettersRequest) (*pb.DeadLettersResponse, error) {
+
+	if !ss.drain.enter() {
+		return &pb.DeadLettersResponse{
+			Error: shuttingDown,
+		}, nil
+	}
+	defer ss.drain.exit()
+
+	shard, found := ss.keyspaceShards.getShard(request.Keyspace, VastoShardId(request.ShardId))
+	if !found || shard.isShutdown {
+		return &pb.DeadLettersResponse{
+			Error: fmt.Sprintf("shard: %s.%d not found", request.Keyspace, request.ShardId),
+		}, nil
+	}
+
+	if !request.Reprocess {
+		entries, err := shard.deadLetters.list()
+		if err != nil {
+			return &pb.DeadLettersResponse{
+				Error: err.Error(),
+			}, nil
+		}
+		return &pb.DeadLettersResponse{
+			Entries: entries,
+		}, nil
+	}
+
+	entries, reprocessedCount, err := shard.deadLetters.reprocess(shard.processEntry)
+	if err != nil {
+		glog.Errorf("%s reprocess dead letters: %v", shard, err)
+		return &pb.DeadLettersResponse{
+			Error: err.Error(),
+		}, nil
+	}
+	glog.V(1).Infof("%s reprocessed %d dead letters, %d remaining", shard, reprocessedCount, len(entries))
+
+	return &pb.DeadLettersResponse{
+		Entries:          entries,
+		ReprocessedCount: uint32(reprocessedCount),
+	}, nil
+
+}
@@ -24,7 +24,7 @@ func (ss *storeServer) debug() {
 	for keyspace, localShards := range ss.statusInCluster {
 		fmt.Printf("  * %s server:%d, clusterSize:%d replicationFactor:%d\n",
 			keyspace, localShards.Id, localShards.ClusterSize, localShards.ReplicationFactor)
-		for _, shardInfo := range localShards.ShardMap {
+		for _, shardInfo := range localShards.GetShardInfos() {
 			fmt.Printf("      * %+v clusterSize:%d replicationFactor:%d isCandidate:%v\n",
 				shardInfo.IdentifierOnThisServer(), shardInfo.ClusterSize, shardInfo.ReplicationFactor, shardInfo.IsCandidate)
 		}
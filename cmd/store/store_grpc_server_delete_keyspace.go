@@ -12,6 +12,13 @@ import (
 // 1. if the shard is already created, do nothing
 func (ss *storeServer) DeleteKeyspace(ctx context.Context, request *pb.DeleteKeyspaceRequest) (*pb.DeleteKeyspaceResponse, error) {
 
+	if !ss.drain.enter() {
+		return &pb.DeleteKeyspaceResponse{
+			Error: shuttingDown,
+		}, nil
+	}
+	defer ss.drain.exit()
+
 	glog.V(1).Infof("delete keyspace %v", request)
 	err := ss.deleteShards(request.Keyspace, true)
 	if err != nil {
@@ -0,0 +1,84 @@
+package store
+
+import (
+	"sort"
+
+	"github.com/chrislusf/vasto/pb"
+	"golang.org/x/net/context"
+)
+
+// Diagnostics returns this server's view of each keyspace: the cluster topology fingerprint,
+// the local shard statuses, the latest binlog position, and the progress of following other shards.
+// The results are sorted so that the outputs from different servers can be compared directly.
+func (ss *storeServer) Diagnostics(ctx context.Context, request *pb.DiagnosticsRequest) (*pb.DiagnosticsResponse, error) {
+
+	resp := &pb.DiagnosticsResponse{}
+
+	ss.statusInClusterLock.RLock()
+	for keyspace, localShards := range ss.statusInCluster {
+		ksDiagnostics := &pb.DiagnosticsResponse_KeyspaceDiagnostics{
+			Keyspace:          keyspace,
+			ServerId:          localShards.Id,
+			ClusterSize:       localShards.ClusterSize,
+			ReplicationFactor: localShards.ReplicationFactor,
+		}
+		if cluster, found := ss.clusterListener.GetCluster(keyspace); found {
+			ksDiagnostics.ClusterFingerprint = cluster.Fingerprint()
+		}
+		for _, shardInfo := range localShards.GetShardInfos() {
+			ksDiagnostics.Shards = append(ksDiagnostics.Shards, ss.shardDiagnostics(keyspace, shardInfo))
+		}
+		resp.Keyspaces = append(resp.Keyspaces, ksDiagnostics)
+	}
+	ss.statusInClusterLock.RUnlock()
+
+	sort.Slice(resp.Keyspaces, func(i, j int) bool {
+		return resp.Keyspaces[i].Keyspace < resp.Keyspaces[j].Keyspace
+	})
+
+	return resp, nil
+
+}
+
+func (ss *storeServer) shardDiagnostics(keyspace string, shardInfo *pb.ShardInfo) *pb.DiagnosticsResponse_ShardDiagnostics {
+
+	t := &pb.DiagnosticsResponse_ShardDiagnostics{
+		ShardId:   shardInfo.ShardId,
+		ShardInfo: shardInfo,
+	}
+
+	shard, found := ss.keyspaceShards.getShard(keyspace, VastoShardId(shardInfo.ShardId))
+	if !found {
+		return t
+	}
+
+	if shard.lm != nil {
+		segment, offset := shard.lm.GetSegmentOffset()
+		t.LatestSegment, t.LatestOffset = segment, uint64(offset)
+		t.ChecksumFailures = shard.lm.ChecksumFailures()
+	}
+
+	inFlight, rejectedCount := shard.admission.stats()
+	t.InFlightRequests, t.RejectedRequests = uint32(inFlight), rejectedCount
+
+	shard.followProgressLock.Lock()
+	for pk, pv := range shard.followProgress {
+		t.FollowProgresses = append(t.FollowProgresses, &pb.DiagnosticsResponse_FollowProgress{
+			ServerAdminAddress: pk.serverAdminAddress,
+			ShardId:            uint32(pk.shardId),
+			NextSegment:        pv.segment,
+			NextOffset:         pv.offset,
+		})
+	}
+	shard.followProgressLock.Unlock()
+
+	sort.Slice(t.FollowProgresses, func(i, j int) bool {
+		x, y := t.FollowProgresses[i], t.FollowProgresses[j]
+		if x.ServerAdminAddress != y.ServerAdminAddress {
+			return x.ServerAdminAddress < y.ServerAdminAddress
+		}
+		return x.ShardId < y.ShardId
+	})
+
+	return t
+}
@@ -0,0 +1,46 @@
+package store
+
+import (
+	"fmt"
+
+	"github.com/chrislusf/glog"
+	"github.com/chrislusf/vasto/pb"
+	"golang.org/x/net/context"
+)
+
+// FlushBinlog syncs the binlog of the shard to the disk, and returns the binlog position
+// the entries before which are flushed, e.g., for a controller to check before promoting a replica.
+func (ss *storeServer) FlushBinlog(ctx context.Context, request *pb.FlushBinlogRequest) (*pb.FlushBinlogResponse, error) {
+
+	if !ss.drain.enter() {
+		return &pb.FlushBinlogResponse{
+			Error: shuttingDown,
+		}, nil
+	}
+	defer ss.drain.exit()
+
+	shard, found := ss.keyspaceShards.getShard(request.Keyspace, VastoShardId(request.ShardId))
+	if !found || shard.isShutdown {
+		return &pb.FlushBinlogResponse{
+			Error: fmt.Sprintf("shard: %s.%d not found", request.Keyspace, request.ShardId),
+		}, nil
+	}
+	if shard.lm == nil {
+		return &pb.FlushBinlogResponse{
+			Error: fmt.Sprintf("shard %s has no binlog", shard),
+		}, nil
+	}
+
+	segment, offset := shard.lm.GetSegmentOffset()
+	if err := shard.lm.Flush(); err != nil {
+		glog.Errorf("%s flush binlog: %v", shard, err)
+		return &pb.FlushBinlogResponse{
+			Error: err.Error(),
+		}, nil
+	}
+
+	return &pb.FlushBinlogResponse{
+		Segment: segment,
+		Offset:  uint64(offset),
+	}, nil
+}
@@ -0,0 +1,37 @@
+package store
+
+import (
+	"testing"
+
+	"github.com/chrislusf/vasto/pb"
+	"golang.org/x/net/context"
+)
+
+func TestFlushBinlog(t *testing.T) {
+
+	s, ss, cleanup := newTestShard(t, "flush_binlog")
+	defer cleanup()
+
+	ss.keyspaceShards = newKeyspaceShards()
+	ss.keyspaceShards.addShards("ks1", s)
+
+	ss.processPut(s, &pb.PutRequest{Key: []byte("k1"), Value: []byte("v1")})
+	resp := ss.processDelete(s, &pb.DeleteRequest{Key: []byte("k1"), SyncLog: true})
+	if !resp.Ok {
+		t.Errorf("delete with synced log: %+v", resp)
+	}
+
+	flushed, err := ss.FlushBinlog(context.Background(), &pb.FlushBinlogRequest{Keyspace: "ks1", ShardId: 0})
+	if err != nil || flushed.Error != "" {
+		t.Fatalf("flush binlog: %v %+v", err, flushed)
+	}
+	if flushed.Segment != resp.LogSegment || flushed.Offset <= resp.LogOffset {
+		t.Errorf("flushed %d:%d, expecting past the delete at %d:%d", flushed.Segment, flushed.Offset, resp.LogSegment, resp.LogOffset)
+	}
+
+	missing, _ := ss.FlushBinlog(context.Background(), &pb.FlushBinlogRequest{Keyspace: "ks1", ShardId: 1})
+	if missing.Error == "" {
+		t.Errorf("flush binlog of missing shard")
+	}
+
+}
@@ -0,0 +1,168 @@
+package store
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/chrislusf/glog"
+	"github.com/chrislusf/vasto/pb"
+	"github.com/chrislusf/vasto/storage/codec"
+	"golang.org/x/net/context"
+)
+
+const (
+	defaultKeyHistoryLimit        = 16
+	defaultKeyHistorySegmentCount = 2
+	defaultGetAsOfSegmentCount    = 2
+)
+
+// KeyHistory lists the recent puts, merges, and deletes of one key, reconstructed from the binlog.
+// Only the latest segments are scanned, so older changes may be missing.
+func (ss *storeServer) KeyHistory(ctx context.Context, request *pb.KeyHistoryRequest) (*pb.KeyHistoryResponse, error) {
+
+	shard, found := ss.keyspaceShards.getShard(request.Keyspace, VastoShardId(request.ShardId))
+	if !found || shard.isShutdown {
+		return &pb.KeyHistoryResponse{
+			Error: fmt.Sprintf("shard: %s.%d not found", request.Keyspace, request.ShardId),
+		}, nil
+	}
+
+	entries, err := shard.keyHistory(ctx, request.Key, request.PartitionHash, int(request.Limit), int(request.SegmentCount))
+	if err != nil {
+		glog.Errorf("key history %s %s: %v", shard, string(request.Key), err)
+		return &pb.KeyHistoryResponse{
+			Error: err.Error(),
+		}, nil
+	}
+
+	return &pb.KeyHistoryResponse{
+		Entries: entries,
+	}, nil
+
+}
+
+func (s *shard) keyHistory(ctx context.Context, key []byte, partitionHash uint64, limit int, segmentCount int) (entries []*pb.LogEntry, err error) {
+
+	if s.lm == nil {
+		return nil, fmt.Errorf("shard %s has no binlog", s)
+	}
+
+	if limit <= 0 {
+		limit = defaultKeyHistoryLimit
+	}
+	if segmentCount <= 0 {
+		segmentCount = defaultKeyHistorySegmentCount
+	}
+
+	err = s.lm.ScanEntries(s.fromLatestSegments(segmentCount), func(segment uint32, entry *pb.LogEntry) error {
+		if entry.GetPartitionHash() != partitionHash || !bytes.Equal(entry.GetKey(), key) {
+			return ctx.Err()
+		}
+		entries = append(entries, entry)
+		if len(entries) > limit {
+			entries = entries[1:]
+		}
+		return ctx.Err()
+	})
+
+	return entries, err
+}
+
+// GetAsOf reads the value of one key at a past time, by replaying its changes in the binlog.
+// Only the latest segments are scanned, and it is an error if the time is older than them.
+func (ss *storeServer) GetAsOf(ctx context.Context, request *pb.GetAsOfRequest) (*pb.GetAsOfResponse, error) {
+
+	shard, found := ss.keyspaceShards.getShard(request.Keyspace, VastoShardId(request.ShardId))
+	if !found || shard.isShutdown {
+		return &pb.GetAsOfResponse{
+			Error: fmt.Sprintf("shard: %s.%d not found", request.Keyspace, request.ShardId),
+		}, nil
+	}
+
+	entry, isComplete, err := shard.getAsOf(ctx, request.Key, request.PartitionHash, request.AsOfNs, int(request.SegmentCount))
+	if err != nil {
+		return &pb.GetAsOfResponse{
+			Error: err.Error(),
+		}, nil
+	}
+
+	resp := &pb.GetAsOfResponse{
+		IsComplete: isComplete,
+	}
+	if entry != nil {
+		resp.KeyValue = &pb.KeyTypeValue{
+			Key:           request.Key,
+			PartitionHash: entry.PartitionHash,
+			DataType:      pb.OpAndDataType(entry.OpAndDataType),
+			Value:         entry.Value,
+		}
+	}
+
+	return resp, nil
+
+}
+
+// getAsOf replays the changes of the key up to asOfNs, in the latest segmentCount segments.
+// isComplete is false if the first replayed change is not a put or delete,
+// or there are no changes, since the value may be from before the scanned segments.
+func (s *shard) getAsOf(ctx context.Context, key []byte, partitionHash uint64, asOfNs uint64, segmentCount int) (entry *codec.Entry, isComplete bool, err error) {
+
+	if s.lm == nil {
+		return nil, false, fmt.Errorf("shard %s has no binlog", s)
+	}
+
+	if segmentCount <= 0 {
+		segmentCount = defaultGetAsOfSegmentCount
+	}
+	fromSegment := s.fromLatestSegments(segmentCount)
+
+	isFirst, hasEarlierEntries := true, false
+	err = s.lm.ScanEntries(fromSegment, func(segment uint32, logEntry *pb.LogEntry) error {
+		if isFirst {
+			isFirst = false
+			hasEarlierEntries = logEntry.UpdatedAtNs <= asOfNs
+		}
+		if logEntry.UpdatedAtNs > asOfNs || logEntry.GetPartitionHash() != partitionHash || !bytes.Equal(logEntry.GetKey(), key) {
+			return ctx.Err()
+		}
+		switch {
+		case logEntry.GetPut() != nil:
+			entry, isComplete = codec.NewPutEntry(logEntry.GetPut(), logEntry.UpdatedAtNs), true
+		case logEntry.GetDelete() != nil:
+			entry, isComplete = nil, true
+		case logEntry.GetMerge() != nil:
+			mergeEntry := codec.NewMergeEntry(logEntry.GetMerge(), logEntry.UpdatedAtNs)
+			if entry == nil {
+				entry = mergeEntry
+			} else {
+				entry.MergeWith(mergeEntry.ToBytes())
+			}
+		}
+		return ctx.Err()
+	})
+	if err != nil {
+		return nil, false, err
+	}
+
+	if !hasEarlierEntries {
+		if earliestSegment, _ := s.lm.GetSegmentRange(); earliestSegment > fromSegment {
+			fromSegment = earliestSegment
+		}
+		return nil, false, fmt.Errorf("time %d predates the scanned binlog from segment %d", asOfNs, fromSegment)
+	}
+
+	if entry != nil && entry.IsExpiredAt(asOfNs) {
+		entry = nil
+	}
+
+	return entry, isComplete, nil
+}
+
+// fromLatestSegments returns the first one of the latest segmentCount binlog segments.
+func (s *shard) fromLatestSegments(segmentCount int) uint32 {
+	_, latestSegment := s.lm.GetSegmentRange()
+	if latestSegment+1 > uint32(segmentCount) {
+		return latestSegment + 1 - uint32(segmentCount)
+	}
+	return 0
+}
@@ -0,0 +1,65 @@
+package store
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/chrislusf/vasto/pb"
+	"github.com/chrislusf/vasto/storage/binlog"
+)
+
+func TestGetAsOfScansLatestSegments(t *testing.T) {
+
+	dir, err := ioutil.TempDir("", "get_as_of")
+	if err != nil {
+		t.Fatalf("temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	s := newShard("ks1", dir, 0, 0, nil, nil, 1, 0, 0, false)
+	defer s.db.Close()
+	defer s.shutdownNode()
+	s.lm = binlog.NewLogManager(dir, 0, 1024, 10)
+	s.lm.Initialze()
+
+	if err = s.logPut(&pb.PutRequest{Key: []byte("k1"), Value: []byte("v1")}, 100); err != nil {
+		t.Fatalf("log put: %v", err)
+	}
+	// fill more segments with the other keys
+	for i := 0; i < 60; i++ {
+		if err = s.logPut(&pb.PutRequest{Key: []byte(fmt.Sprintf("other%d", i)), Value: make([]byte, 100)}, uint64(200+i)); err != nil {
+			t.Fatalf("log put: %v", err)
+		}
+	}
+	if err = s.logPut(&pb.PutRequest{Key: []byte("k1"), Value: []byte("v2")}, 1000); err != nil {
+		t.Fatalf("log put: %v", err)
+	}
+	if _, latestSegment := s.lm.GetSegmentRange(); latestSegment < 3 {
+		t.Fatalf("latest segment %d, expected more segments", latestSegment)
+	}
+
+	entry, isComplete, err := s.getAsOf(context.Background(), []byte("k1"), 0, 2000, 0)
+	if err != nil || !isComplete || !bytes.Equal(entry.Value, []byte("v2")) {
+		t.Errorf("get as of the latest put: %+v %v %v", entry, isComplete, err)
+	}
+
+	// the first put is not in the latest segments
+	entry, isComplete, err = s.getAsOf(context.Background(), []byte("k1"), 0, 500, 0)
+	if err != nil || isComplete || entry != nil {
+		t.Errorf("get as of the put before the latest segments: %+v %v %v", entry, isComplete, err)
+	}
+	if _, _, err = s.getAsOf(context.Background(), []byte("k1"), 0, 150, 0); err == nil || !strings.Contains(err.Error(), "predates") {
+		t.Errorf("get as of before the latest segments: %v", err)
+	}
+
+	entry, isComplete, err = s.getAsOf(context.Background(), []byte("k1"), 0, 500, 100)
+	if err != nil || !isComplete || !bytes.Equal(entry.Value, []byte("v1")) {
+		t.Errorf("get as of the first put: %+v %v %v", entry, isComplete, err)
+	}
+
+}
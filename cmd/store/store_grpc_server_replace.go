@@ -13,6 +13,13 @@ import (
 // 1. create the new shard and follow the old shard and its peers
 func (ss *storeServer) ReplicateNodePrepare(ctx context.Context, request *pb.ReplicateNodePrepareRequest) (*pb.ReplicateNodePrepareResponse, error) {
 
+	if !ss.drain.enter() {
+		return &pb.ReplicateNodePrepareResponse{
+			Error: shuttingDown,
+		}, nil
+	}
+	defer ss.drain.exit()
+
 	glog.V(1).Infof("replicate shard prepare %v", request)
 	err := ss.replicateNode(request)
 	if err != nil {
@@ -31,6 +38,13 @@ func (ss *storeServer) ReplicateNodePrepare(ctx context.Context, request *pb.Rep
 // 2. let the server to promote the new shard from CANDIDATE to READY
 func (ss *storeServer) ReplicateNodeCommit(ctx context.Context, request *pb.ReplicateNodeCommitRequest) (*pb.ReplicateNodeCommitResponse, error) {
 
+	if !ss.drain.enter() {
+		return &pb.ReplicateNodeCommitResponse{
+			Error: shuttingDown,
+		}, nil
+	}
+	defer ss.drain.exit()
+
 	glog.V(1).Infof("replicate shard commit %v", request)
 	err := ss.setShardStatus(request)
 	if err != nil {
@@ -49,6 +63,13 @@ func (ss *storeServer) ReplicateNodeCommit(ctx context.Context, request *pb.Repl
 // 4. let the server to remove the old shard
 func (ss *storeServer) ReplicateNodeCleanup(ctx context.Context, request *pb.ReplicateNodeCleanupRequest) (*pb.ReplicateNodeCleanupResponse, error) {
 
+	if !ss.drain.enter() {
+		return &pb.ReplicateNodeCleanupResponse{
+			Error: shuttingDown,
+		}, nil
+	}
+	defer ss.drain.exit()
+
 	glog.V(1).Infof("cleanup shard %v", request)
 	err := ss.deleteShards(request.Keyspace, false)
 	if err != nil {
@@ -12,6 +12,13 @@ import (
 // 1. create the new or missing shards, bootstrap the data, one-time follows, and regular follows.
 func (ss *storeServer) ResizePrepare(ctx context.Context, request *pb.ResizeCreateShardRequest) (*pb.ResizeCreateShardResponse, error) {
 
+	if !ss.drain.enter() {
+		return &pb.ResizeCreateShardResponse{
+			Error: shuttingDown,
+		}, nil
+	}
+	defer ss.drain.exit()
+
 	glog.V(1).Infof("resize prepare %v", request)
 	err := ss.resizeCreateShards(ctx, request)
 	if err != nil {
@@ -30,6 +37,13 @@ func (ss *storeServer) ResizePrepare(ctx context.Context, request *pb.ResizeCrea
 // 2. commit the new shards, adjust local cluster size, status, etc, not informing the master of shard info changes
 func (ss *storeServer) ResizeCommit(ctx context.Context, request *pb.ResizeCommitRequest) (*pb.ResizeCommitResponse, error) {
 
+	if !ss.drain.enter() {
+		return &pb.ResizeCommitResponse{
+			Error: shuttingDown,
+		}, nil
+	}
+	defer ss.drain.exit()
+
 	glog.V(1).Infof("resize commit %v", request)
 	err := ss.resizeCommitShardInfoNewCluster(ctx, request)
 	if err != nil {
@@ -48,6 +62,13 @@ func (ss *storeServer) ResizeCommit(ctx context.Context, request *pb.ResizeCommi
 // 3. cleanup old shards, and stop one-time follows
 func (ss *storeServer) ResizeCleanup(ctx context.Context, request *pb.ResizeCleanupRequest) (*pb.ResizeCleanupResponse, error) {
 
+	if !ss.drain.enter() {
+		return &pb.ResizeCleanupResponse{
+			Error: shuttingDown,
+		}, nil
+	}
+	defer ss.drain.exit()
+
 	glog.V(1).Infof("cleanup old shards %v", request)
 	err := ss.deleteOldShardsInNewCluster(ctx, request)
 	if err != nil {
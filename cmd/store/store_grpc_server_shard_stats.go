@@ -0,0 +1,42 @@
+package store
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/chrislusf/vasto/pb"
+	"golang.org/x/net/context"
+)
+
+// GetShardStats returns the approximate key counts and byte sizes of the local shards, sorted by keyspace and shard id.
+func (ss *storeServer) GetShardStats(ctx context.Context, request *pb.ShardStatsRequest) (*pb.ShardStatsResponse, error) {
+
+	resp := &pb.ShardStatsResponse{}
+
+	for _, shard := range ss.keyspaceShards.allShards() {
+		if request.Keyspace != "" && shard.keyspace != request.Keyspace {
+			continue
+		}
+		keyCount, byteSize, err := shard.getKeyStats(request.Rescan)
+		if err != nil {
+			resp.Error = fmt.Sprintf("%s key stats: %v", shard, err)
+			return resp, nil
+		}
+		resp.Shards = append(resp.Shards, &pb.ShardStats{
+			Keyspace: shard.keyspace,
+			ShardId:  uint32(shard.id),
+			KeyCount: keyCount,
+			ByteSize: byteSize,
+		})
+	}
+
+	sort.Slice(resp.Shards, func(i, j int) bool {
+		x, y := resp.Shards[i], resp.Shards[j]
+		if x.Keyspace != y.Keyspace {
+			return x.Keyspace < y.Keyspace
+		}
+		return x.ShardId < y.ShardId
+	})
+
+	return resp, nil
+}
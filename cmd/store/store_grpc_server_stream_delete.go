@@ -0,0 +1,99 @@
+package store
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/chrislusf/glog"
+	"github.com/chrislusf/vasto/pb"
+)
+
+const streamDeleteProgressInterval = time.Second
+
+// StreamDelete applies the streamed batches of deletes, and reports the progress periodically and when the client finishes.
+// Each delete is applied and logged the same way as a single delete.
+// The client can resume from the batch after the reported last sequence.
+// A delete failing to be logged stops the stream with the error, and the batch is not counted as applied.
+func (ss *storeServer) StreamDelete(stream pb.VastoStore_StreamDeleteServer) error {
+
+	progress := &pb.StreamDeleteProgress{}
+	startTime, lastReportTime := time.Now(), time.Now()
+
+	sendProgress := func() error {
+		if elapsed := time.Since(startTime).Seconds(); elapsed > 0 {
+			progress.DeletesPerSecond = float64(progress.DeletedCount) / elapsed
+		}
+		lastReportTime = time.Now()
+		return stream.Send(progress)
+	}
+
+	if !ss.drain.enter() {
+		progress.Error = shuttingDown
+		return sendProgress()
+	}
+	defer ss.drain.exit()
+
+	for {
+		request, err := stream.Recv()
+		if err == io.EOF {
+			return sendProgress()
+		}
+		if err != nil {
+			return err
+		}
+		if err = stream.Context().Err(); err != nil {
+			return err
+		}
+
+		shard, found := ss.keyspaceShards.getShard(request.Keyspace, VastoShardId(request.ShardId))
+		if !found || shard.isShutdown {
+			progress.Error = fmt.Sprintf("shard: %s.%d not found", request.Keyspace, request.ShardId)
+			return sendProgress()
+		}
+		if err = shard.checkBackpressure(); err != nil {
+			progress.Error = err.Error()
+			return sendProgress()
+		}
+
+		deletedCount, failedCount, err := ss.deleteBatch(shard, request.Deletes)
+		progress.DeletedCount += uint64(deletedCount)
+		progress.FailedCount += uint64(failedCount)
+		if err != nil {
+			progress.Error = err.Error()
+			return sendProgress()
+		}
+		progress.LastSequence = request.Sequence
+
+		if time.Since(lastReportTime) >= streamDeleteProgressInterval {
+			if err = sendProgress(); err != nil {
+				return err
+			}
+		}
+	}
+
+}
+
+// deleteBatch deletes the keys one by one, the same way as processDelete.
+// The keys not found, or already deleted, are neither deleted nor failed.
+// It stops at the first delete failing to be logged, and returns the error.
+func (ss *storeServer) deleteBatch(shard *shard, deleteRequests []*pb.DeleteRequest) (deletedCount, failedCount int, err error) {
+
+	for _, deleteRequest := range deleteRequests {
+		resp, logErr := ss.deleteKey(shard, deleteRequest, "")
+		if logErr != nil {
+			failedCount++
+			return deletedCount, failedCount, fmt.Errorf("delete %s: %s", string(deleteRequest.Key), resp.Status)
+		}
+		if !resp.Ok {
+			glog.V(1).Infof("%s delete %s: %s", shard, string(deleteRequest.Key), resp.Status)
+			failedCount++
+			continue
+		}
+		if resp.Existed {
+			deletedCount++
+		}
+	}
+
+	return
+}
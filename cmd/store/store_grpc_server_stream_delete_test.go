@@ -0,0 +1,55 @@
+package store
+
+import (
+	"testing"
+
+	"github.com/chrislusf/vasto/pb"
+)
+
+func TestDeleteBatch(t *testing.T) {
+
+	s, ss, cleanup := newTestShard(t, "stream_delete")
+	defer cleanup()
+
+	for _, key := range []string{"k1", "k2", "k3"} {
+		ss.processPut(s, &pb.PutRequest{Key: []byte(key), Value: []byte(key)})
+	}
+
+	deletedCount, failedCount, err := ss.deleteBatch(s, []*pb.DeleteRequest{
+		{Key: []byte("k1")},
+		{Key: []byte("k2"), Soft: true},
+		{Key: []byte("k3"), ExpectedValue: []byte("other")},
+		{Key: []byte("missing")},
+	})
+	if err != nil || deletedCount != 2 || failedCount != 1 {
+		t.Errorf("delete batch: deleted %d failed %d: %v", deletedCount, failedCount, err)
+	}
+
+	if entry, _ := s.storedEntry([]byte("k1")); entry != nil {
+		t.Errorf("k1 should be deleted: %+v", entry)
+	}
+	if entry, _ := s.storedEntry([]byte("k2")); entry == nil || !entry.IsDeleted() {
+		t.Errorf("k2 should be soft deleted: %+v", entry)
+	}
+	if entry, _ := s.getLiveEntry([]byte("k3")); entry == nil {
+		t.Errorf("k3 should be kept by its condition")
+	}
+
+	softDeletes := make(map[string]bool)
+	s.lm.ScanEntries(0, func(segment uint32, entry *pb.LogEntry) error {
+		if entry.GetDelete() != nil {
+			softDeletes[string(entry.GetKey())] = entry.HasFlag(pb.LogEntrySoftDelete)
+		}
+		return nil
+	})
+	if len(softDeletes) != 2 || softDeletes["k1"] || !softDeletes["k2"] {
+		t.Errorf("logged deletes, and whether soft: %v", softDeletes)
+	}
+
+	// the deleted keys are not deleted again
+	deletedCount, failedCount, err = ss.deleteBatch(s, []*pb.DeleteRequest{{Key: []byte("k1")}, {Key: []byte("k2")}})
+	if err != nil || deletedCount != 0 || failedCount != 0 {
+		t.Errorf("delete again: deleted %d failed %d: %v", deletedCount, failedCount, err)
+	}
+
+}
@@ -11,6 +11,7 @@ import (
 
 const (
 	constClusterConfigFile = "cluster.config"
+	constShardInfoFile     = "shard.info"
 )
 
 func (ss *storeServer) listExistingClusters() error {
@@ -28,6 +29,7 @@ func (ss *storeServer) listExistingClusters() error {
 		txt, err := ioutil.ReadFile(fullPath)
 		if err != nil {
 			glog.Errorf("read file %s: %v", fullPath, err)
+			ss.rebuildClusterConfig(keyspaceName)
 			continue
 		}
 		glog.V(1).Infof("%s load cluster %s config from %s", ss.storeName, keyspaceName, fullPath)
@@ -36,6 +38,7 @@ func (ss *storeServer) listExistingClusters() error {
 
 		if err = proto.UnmarshalText(string(txt), status); err != nil {
 			glog.Errorf("parse file %s: %v", fullPath, err)
+			ss.rebuildClusterConfig(keyspaceName)
 			continue
 		}
 
@@ -64,10 +67,72 @@ func (ss *storeServer) saveClusterConfig(status *pb.LocalShardsInCluster, keyspa
 		return errors.Errorf("save cluster %s to %s : %v", keyspaceName, fullPath, err)
 	}
 
+	// save a copy of each shard info along with the shard data, to rebuild the cluster config if it is lost
+	for _, shardInfo := range status.ShardMap {
+		shardInfoPath := fmt.Sprintf("%s/%s/%d/%s", *ss.option.Dir, keyspaceName, shardInfo.ShardId, constShardInfoFile)
+		if err := ioutil.WriteFile(shardInfoPath, []byte(proto.MarshalTextString(shardInfo)), 0640); err != nil {
+			glog.Errorf("save shard %s to %s: %v", shardInfo.IdentifierOnThisServer(), shardInfoPath, err)
+		}
+	}
+
 	return nil
 
 }
 
+// rebuildClusterConfig recovers the cluster config from the shard info files in the shard folders,
+// so that the shards on disk are reported to the master right after restarting.
+func (ss *storeServer) rebuildClusterConfig(keyspaceName string) {
+
+	keyspaceDir := fmt.Sprintf("%s/%s", *ss.option.Dir, keyspaceName)
+	files, err := ioutil.ReadDir(keyspaceDir)
+	if err != nil {
+		glog.Errorf("read dir %s: %v", keyspaceDir, err)
+		return
+	}
+
+	var status *pb.LocalShardsInCluster
+	for _, f := range files {
+		if !f.IsDir() {
+			continue
+		}
+		fullPath := fmt.Sprintf("%s/%s/%s", keyspaceDir, f.Name(), constShardInfoFile)
+		txt, err := ioutil.ReadFile(fullPath)
+		if err != nil {
+			continue
+		}
+		shardInfo := &pb.ShardInfo{}
+		if err = proto.UnmarshalText(string(txt), shardInfo); err != nil {
+			glog.Errorf("parse file %s: %v", fullPath, err)
+			continue
+		}
+		if status == nil {
+			status = &pb.LocalShardsInCluster{
+				Id:                shardInfo.ServerId,
+				ShardMap:          make(map[uint32]*pb.ShardInfo),
+				ClusterSize:       shardInfo.ClusterSize,
+				ReplicationFactor: shardInfo.ReplicationFactor,
+			}
+		}
+		if shardInfo.ServerId != status.Id {
+			glog.Errorf("%s skip shard %s: server id %d, expecting %d", ss.storeName, shardInfo.IdentifierOnThisServer(), shardInfo.ServerId, status.Id)
+			continue
+		}
+		status.ShardMap[shardInfo.ShardId] = shardInfo
+	}
+
+	if status == nil {
+		glog.V(1).Infof("%s found no shard info to rebuild cluster %s config", ss.storeName, keyspaceName)
+		return
+	}
+
+	glog.V(0).Infof("%s rebuilt cluster %s config with %d shards", ss.storeName, keyspaceName, len(status.ShardMap))
+
+	if err := ss.saveClusterConfig(status, keyspaceName); err != nil {
+		glog.Errorf("%s save rebuilt cluster %s config: %v", ss.storeName, keyspaceName, err)
+	}
+
+}
+
 func (ss *storeServer) getServerStatusInCluster(keyspace string) (statusInCluster *pb.LocalShardsInCluster, found bool) {
 
 	ss.statusInClusterLock.RLock()
@@ -0,0 +1,120 @@
+package store
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/chrislusf/glog"
+	"github.com/chrislusf/vasto/pb"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const (
+	metricsOpGet    = "get"
+	metricsOpPut    = "put"
+	metricsOpDelete = "delete"
+)
+
+// storeMetrics counts the operations and their errors, and observes the db latency of each operation.
+// A nil *storeMetrics is valid, and records nothing, for stores without the metrics address.
+type storeMetrics struct {
+	registry   *prometheus.Registry
+	operations *prometheus.CounterVec
+	errors     *prometheus.CounterVec
+	latency    *prometheus.HistogramVec
+	slowOps    *prometheus.CounterVec
+}
+
+func newStoreMetrics() *storeMetrics {
+	m := &storeMetrics{
+		registry: prometheus.NewRegistry(),
+		operations: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "vasto",
+			Subsystem: "store",
+			Name:      "operations_total",
+			Help:      "Number of operations processed by the store.",
+		}, []string{"op"}),
+		errors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "vasto",
+			Subsystem: "store",
+			Name:      "operation_errors_total",
+			Help:      "Number of failed operations, by the failed status.",
+		}, []string{"op", "status"}),
+		latency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: "vasto",
+			Subsystem: "store",
+			Name:      "operation_duration_seconds",
+			Help:      "Latency of the db calls of the operations.",
+			Buckets:   prometheus.ExponentialBuckets(0.00001, 4, 10),
+		}, []string{"op"}),
+		slowOps: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "vasto",
+			Subsystem: "store",
+			Name:      "slow_operations_total",
+			Help:      "Number of operations taking longer than the slow operation threshold.",
+		}, []string{"op"}),
+	}
+	m.registry.MustRegister(m.operations, m.errors, m.latency, m.slowOps)
+	return m
+}
+
+// serveMetrics exposes the metrics at /metrics on the address.
+func (m *storeMetrics) serveMetrics(address string) {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{}))
+	glog.V(0).Infof("metrics on http://%s/metrics", address)
+	if err := http.ListenAndServe(address, mux); err != nil {
+		glog.Errorf("serve metrics on %s: %v", address, err)
+	}
+}
+
+// record counts the operation, and its error if not ok.
+func (m *storeMetrics) record(op string, ok bool, status string) {
+	if m == nil {
+		return
+	}
+	m.operations.WithLabelValues(op).Inc()
+	if !ok {
+		m.errors.WithLabelValues(op, statusLabel(status)).Inc()
+	}
+}
+
+// observe records the latency of the db call of the operation since the start time.
+func (m *storeMetrics) observe(op string, startTime time.Time) {
+	if m == nil {
+		return
+	}
+	m.latency.WithLabelValues(op).Observe(time.Since(startTime).Seconds())
+}
+
+// recordSlow counts the operation taking longer than the slow operation threshold.
+func (m *storeMetrics) recordSlow(op string) {
+	if m == nil {
+		return
+	}
+	m.slowOps.WithLabelValues(op).Inc()
+}
+
+// statusLabel maps the failed status to a few known kinds, since the status usually has the key or the db error in it.
+func statusLabel(status string) string {
+	switch {
+	case status == pb.StatusPreconditionFailed:
+		return "precondition_failed"
+	case strings.HasPrefix(status, statusInvalidKey):
+		return "invalid_key"
+	case strings.HasPrefix(status, "not owner"):
+		return "not_owner"
+	case strings.HasPrefix(status, "deleted but not logged"), strings.HasPrefix(status, "not logged nor deleted"),
+		strings.HasPrefix(status, "written but not logged"):
+		return "not_logged"
+	case strings.HasPrefix(status, statusTimeout):
+		return "timeout"
+	case strings.HasPrefix(status, statusNotReplicated):
+		return "not_replicated"
+	case strings.HasPrefix(status, "log backpressure"):
+		return "backpressure"
+	}
+	return "error"
+}
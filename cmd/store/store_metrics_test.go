@@ -0,0 +1,67 @@
+package store
+
+import (
+	"testing"
+
+	"github.com/chrislusf/vasto/pb"
+	"github.com/chrislusf/vasto/topology"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestStoreMetrics(t *testing.T) {
+
+	s, ss, cleanup := newTestShard(t, "store_metrics")
+	defer cleanup()
+
+	s.cluster = topology.NewCluster("ks1", 2, 1)
+	var ownedHash, otherHash uint64
+	for hash := uint64(1); ownedHash == 0 || otherHash == 0; hash++ {
+		if s.cluster.FindShardId(hash) == 0 {
+			ownedHash = hash
+		} else {
+			otherHash = hash
+		}
+	}
+
+	disableBinLog := true
+	ss.option.DisableBinLog = &disableBinLog
+	ss.metrics = newStoreMetrics()
+
+	ss.processPut(s, &pb.PutRequest{Key: []byte("k1"), PartitionHash: ownedHash, Value: []byte("v1")})
+	ss.processGet(s, &pb.GetRequest{Key: []byte("k1"), PartitionHash: ownedHash})
+	ss.processDelete(s, &pb.DeleteRequest{Key: []byte("k1"), PartitionHash: ownedHash})
+	ss.processDelete(s, &pb.DeleteRequest{Key: []byte("k1"), PartitionHash: otherHash})
+	ss.processDelete(s, &pb.DeleteRequest{Key: []byte("k1"), PartitionHash: ownedHash, ExpectedValue: []byte("v1")})
+
+	for op, expected := range map[string]float64{metricsOpPut: 1, metricsOpGet: 1, metricsOpDelete: 3} {
+		if count := testutil.ToFloat64(ss.metrics.operations.WithLabelValues(op)); count != expected {
+			t.Errorf("%s count %v, expecting %v", op, count, expected)
+		}
+	}
+	for status, expected := range map[string]float64{"not_owner": 1, "precondition_failed": 1, "error": 0} {
+		if count := testutil.ToFloat64(ss.metrics.errors.WithLabelValues(metricsOpDelete, status)); count != expected {
+			t.Errorf("delete %s errors %v, expecting %v", status, count, expected)
+		}
+	}
+
+	// only the delete of the existing key reached the db
+	families, err := ss.metrics.registry.Gather()
+	if err != nil {
+		t.Fatalf("gather: %v", err)
+	}
+	for _, family := range families {
+		if family.GetName() != "vasto_store_operation_duration_seconds" {
+			continue
+		}
+		for _, metric := range family.Metric {
+			if sampleCount := metric.GetHistogram().GetSampleCount(); sampleCount != 1 {
+				t.Errorf("%v latency samples %d, expecting 1", metric.GetLabel(), sampleCount)
+			}
+		}
+	}
+
+	// no metrics address
+	ss.metrics = nil
+	ss.processDelete(s, &pb.DeleteRequest{Key: []byte("k1"), PartitionHash: ownedHash})
+
+}
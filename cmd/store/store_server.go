@@ -4,16 +4,23 @@ import (
 	"fmt"
 	"net"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	"context"
 	"github.com/chrislusf/glog"
 	"github.com/chrislusf/vasto/pb"
+	"github.com/chrislusf/vasto/topology"
 	"github.com/chrislusf/vasto/topology/clusterlistener"
 	"github.com/chrislusf/vasto/util"
 	"github.com/chrislusf/vasto/util/interrupt"
 	"sync"
 )
 
+// shutdownTimeout is the max wait for the requests in flight when the store is interrupted.
+const shutdownTimeout = 10 * time.Second
+
 // StoreOption has options to run a data store
 type StoreOption struct {
 	Dir               *string
@@ -29,6 +36,22 @@ type StoreOption struct {
 	Tags              *string
 	DisableUseEventIo *bool
 	DisableBinLog     *bool
+	TtlCompaction     *string
+	BatchWindowMs     *int
+	BatchWindows      *string
+	BatchWindowKb     *int
+	DataCenter        *string
+	DeadLetterAfter   *int
+	ShardMaxInFlight  *int
+	TtlSweepSeconds   *int
+	MetricsAddress    *string
+	InMemory          *bool
+	SoftDeleteHours   *int
+	LogBackpressureMb *int
+	MaxKeyLength      *int
+	SlowDeleteMs      *int
+	RepairOnStartup   *bool
+	IntentTtlSeconds  *int
 }
 
 // GetAdminPort returns the admin port of the store, which is the data port plus 10000
@@ -36,6 +59,39 @@ func (o *StoreOption) GetAdminPort() int32 {
 	return *o.TcpPort + 10000
 }
 
+// IsTtlCompactionEnabled checks whether the keyspace purges expired entries during compaction
+func (o *StoreOption) IsTtlCompactionEnabled(keyspace string) bool {
+	if o.TtlCompaction == nil {
+		return false
+	}
+	for _, ks := range strings.Split(*o.TtlCompaction, ",") {
+		if strings.TrimSpace(ks) == keyspace {
+			return true
+		}
+	}
+	return false
+}
+
+// BatchWindowOf returns the batch window of the keyspace shards, set by BatchWindows or else by BatchWindowMs.
+// BatchWindows is comma separated keyspace:milliseconds, and malformed ones are ignored.
+func (o *StoreOption) BatchWindowOf(keyspace string) time.Duration {
+	if o.BatchWindows != nil {
+		for _, kv := range strings.Split(*o.BatchWindows, ",") {
+			parts := strings.SplitN(strings.TrimSpace(kv), ":", 2)
+			if len(parts) != 2 || parts[0] != keyspace {
+				continue
+			}
+			if ms, err := strconv.Atoi(parts[1]); err == nil && ms >= 0 {
+				return time.Duration(ms) * time.Millisecond
+			}
+		}
+	}
+	if o.BatchWindowMs == nil {
+		return defaultBatchWindow
+	}
+	return time.Duration(*o.BatchWindowMs) * time.Millisecond
+}
+
 type storeServer struct {
 	option              *StoreOption
 	clusterListener     *clusterlistener.ClusterListener
@@ -45,6 +101,8 @@ type storeServer struct {
 	periodTasks         []periodicTask
 	keyspaceShards      *keyspaceShards
 	storeName           string
+	metrics             *storeMetrics
+	drain               drain
 }
 
 // RunStore starts a store process
@@ -54,6 +112,7 @@ func RunStore(option *StoreOption) {
 
 	ctx := context.Background()
 	clusterListener := clusterlistener.NewClusterListener(storeName)
+	clusterListener.SetLocalDataCenter(*option.DataCenter)
 
 	var ss = &storeServer{
 		option:          option,
@@ -63,6 +122,10 @@ func RunStore(option *StoreOption) {
 		keyspaceShards:  newKeyspaceShards(),
 		storeName:       storeName,
 	}
+	if option.MetricsAddress != nil && *option.MetricsAddress != "" {
+		ss.metrics = newStoreMetrics()
+		go ss.metrics.serveMetrics(*option.MetricsAddress)
+	}
 	go ss.startPeriodTasks()
 
 	// ss.clusterListener.RegisterShardEventProcessor(&clusterlistener.ClusterEventLogger{})
@@ -107,9 +170,10 @@ func RunStore(option *StoreOption) {
 		go ss.serveTcp(tcpListener)
 	}
 
+	var unixSocket string
 	if !*option.DisableUnixSocket {
 		tcpAddress := fmt.Sprintf("%s:%d", *option.ListenHost, *option.TcpPort)
-		if unixSocket, _ := util.GetUnixSocketFile(tcpAddress); unixSocket != "" {
+		if unixSocket, _ = util.GetUnixSocketFile(tcpAddress); unixSocket != "" {
 			if util.FileExists(unixSocket) {
 				os.Remove(unixSocket)
 			}
@@ -118,14 +182,22 @@ func RunStore(option *StoreOption) {
 				glog.Fatal(err)
 			}
 			glog.V(2).Infof("listens on socket %s", unixSocket)
-			interrupt.OnInterrupt(func() {
-				os.Remove(unixSocket)
-			}, nil)
 			defer os.Remove(unixSocket)
 			go ss.serveTcp(unixSocketListener)
 		}
 	}
 
+	// one handler for all, since the process exits once any interrupt handler returns
+	interrupt.OnInterrupt(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		ss.Shutdown(ctx)
+		cancel()
+		if unixSocket != "" {
+			os.Remove(unixSocket)
+		}
+		topology.CloseAllConnections()
+	}, nil)
+
 	glog.V(2).Infof("%s Vasto store starts on %s", ss.storeName, *option.Dir)
 
 	select {}
@@ -2,6 +2,7 @@ package store
 
 import (
 	"bufio"
+	"context"
 	"fmt"
 	"io"
 	"net"
@@ -54,7 +55,7 @@ func (ss *storeServer) handleConnection(conn net.Conn) {
 
 }
 
-func (ss *storeServer) handleRequest(reader io.Reader, writer io.Writer) error {
+func (ss *storeServer) handleRequest(reader *bufio.Reader, writer io.Writer) error {
 
 	var input, output []byte
 	var err error
@@ -68,18 +69,33 @@ func (ss *storeServer) handleRequest(reader io.Reader, writer io.Writer) error {
 		return fmt.Errorf("read message: %v", err)
 	}
 
-	output, err = ss.handleInputOutput(input)
+	// the client sends the next requests only after reading the responses,
+	// so the connection failing to read while processing means the client has gone away
+	ctx, cancel := context.WithCancel(context.Background())
+	peeked := make(chan struct{})
+	go func() {
+		if _, peekErr := reader.Peek(1); peekErr != nil {
+			cancel()
+		}
+		close(peeked)
+	}()
+
+	output, err = ss.handleInputOutput(ctx, input)
+	cancel()
 
 	err = util.WriteMessage(writer, output)
 	if err != nil {
 		return fmt.Errorf("write message: %v", err)
 	}
 
+	// the next read must wait for the peek to finish
+	<-peeked
+
 	return nil
 
 }
 
-func (ss *storeServer) handleInputOutput(input []byte) (output []byte, err error) {
+func (ss *storeServer) handleInputOutput(ctx context.Context, input []byte) (output []byte, err error) {
 
 	requests := &pb.Requests{}
 	if err = proto.Unmarshal(input, requests); err != nil {
@@ -88,7 +104,7 @@ func (ss *storeServer) handleInputOutput(input []byte) (output []byte, err error
 
 	responses := &pb.Responses{}
 	for _, request := range requests.Requests {
-		response := ss.processRequest(requests.Keyspace, request)
+		response := ss.processRequest(ctx, requests.Keyspace, request)
 		responses.Responses = append(responses.Responses, response)
 	}
 
@@ -101,46 +117,27 @@ func (ss *storeServer) handleInputOutput(input []byte) (output []byte, err error
 
 }
 
-func (ss *storeServer) processRequest(keyspace string, command *pb.Request) *pb.Response {
+func (ss *storeServer) processRequest(ctx context.Context, keyspace string, command *pb.Request) *pb.Response {
+
+	if !ss.drain.enter() {
+		return failedResponse(command, shuttingDown)
+	}
+	defer ss.drain.exit()
 
 	shard, found := ss.keyspaceShards.getShard(keyspace, VastoShardId(command.ShardId))
 
 	if !found {
-		if command.GetGet() != nil {
-			return &pb.Response{
-				Get: &pb.GetResponse{
-					Ok:     false,
-					Status: fmt.Sprintf("keyspace %s not found", keyspace),
-				},
-			}
-		} else if command.GetPut() != nil {
-			return &pb.Response{
-				Write: &pb.WriteResponse{
-					Ok:     false,
-					Status: fmt.Sprintf("keyspace %s not found", keyspace),
-				},
-			}
-		} else if command.GetMerge() != nil {
-			return &pb.Response{
-				Write: &pb.WriteResponse{
-					Ok:     false,
-					Status: fmt.Sprintf("keyspace %s not found", keyspace),
-				},
-			}
-		} else if command.GetDelete() != nil {
-			return &pb.Response{
-				Write: &pb.WriteResponse{
-					Ok:     false,
-					Status: fmt.Sprintf("keyspace %s not found", keyspace),
-				},
-			}
-		} else if command.GetGetByPrefix() != nil {
-			return &pb.Response{
-				GetByPrefix: &pb.GetByPrefixResponse{
-					Ok:     false,
-					Status: fmt.Sprintf("keyspace %s not found", keyspace),
-				},
-			}
+		return failedResponse(command, fmt.Sprintf("shard %s.%d not found", keyspace, command.ShardId))
+	}
+
+	if !shard.admission.acquire() {
+		return failedResponse(command, fmt.Sprintf("shard %s busy", shard))
+	}
+	defer shard.admission.release()
+
+	if isWriteRequest(command) {
+		if err := shard.checkBackpressure(); err != nil {
+			return failedResponse(command, err.Error())
 		}
 	}
 
@@ -162,7 +159,31 @@ func (ss *storeServer) processRequest(keyspace string, command *pb.Request) *pb.
 		}
 	} else if command.GetGetByPrefix() != nil {
 		return &pb.Response{
-			GetByPrefix: ss.processPrefix(shard, command.GetByPrefix),
+			GetByPrefix: ss.processPrefix(ctx, shard, command.GetByPrefix),
+		}
+	} else if command.GetBatchGet() != nil {
+		return &pb.Response{
+			BatchGet: ss.processBatchGet(shard, command.BatchGet),
+		}
+	} else if command.GetBatchDelete() != nil {
+		return &pb.Response{
+			BatchDelete: ss.processBatchDelete(shard, command.BatchDelete),
+		}
+	} else if command.GetDeleteByPrefix() != nil {
+		return &pb.Response{
+			DeleteByPrefix: ss.processDeleteByPrefix(ctx, shard, command.DeleteByPrefix),
+		}
+	} else if command.GetPrepareDelete() != nil {
+		return &pb.Response{
+			Write: ss.processPrepareDelete(shard, command.PrepareDelete),
+		}
+	} else if command.GetCommitDelete() != nil {
+		return &pb.Response{
+			Write: ss.processCommitDelete(shard, command.CommitDelete),
+		}
+	} else if command.GetAbortDelete() != nil {
+		return &pb.Response{
+			Write: ss.processAbortDelete(shard, command.AbortDelete),
 		}
 	}
 	return &pb.Response{
@@ -171,3 +192,49 @@ func (ss *storeServer) processRequest(keyspace string, command *pb.Request) *pb.
 		},
 	}
 }
+
+// failedResponse replies the request with the status, in the response type matching the request.
+func failedResponse(command *pb.Request, status string) *pb.Response {
+	if command.GetGet() != nil {
+		return &pb.Response{
+			Get: &pb.GetResponse{
+				Ok:     false,
+				Status: status,
+			},
+		}
+	} else if command.GetGetByPrefix() != nil {
+		return &pb.Response{
+			GetByPrefix: &pb.GetByPrefixResponse{
+				Ok:     false,
+				Status: status,
+			},
+		}
+	} else if command.GetBatchGet() != nil {
+		return &pb.Response{
+			BatchGet: &pb.BatchGetResponse{
+				Ok:     false,
+				Status: status,
+			},
+		}
+	} else if command.GetBatchDelete() != nil {
+		return &pb.Response{
+			BatchDelete: &pb.BatchDeleteResponse{
+				Ok:     false,
+				Status: status,
+			},
+		}
+	} else if command.GetDeleteByPrefix() != nil {
+		return &pb.Response{
+			DeleteByPrefix: &pb.DeleteByPrefixResponse{
+				Ok:     false,
+				Status: status,
+			},
+		}
+	}
+	return &pb.Response{
+		Write: &pb.WriteResponse{
+			Ok:     false,
+			Status: status,
+		},
+	}
+}
@@ -35,6 +35,15 @@ func (c *ClusterClient) GetCluster() (*topology.Cluster, error) {
 	return cluster, nil
 }
 
+// GetClusterView get read only access to the cluster topology, e.g., to route the keys.
+func (c *ClusterClient) GetClusterView() (topology.ClusterView, error) {
+	cluster, err := c.GetCluster()
+	if err != nil {
+		return nil, err
+	}
+	return cluster.View(), nil
+}
+
 // sendRequestsToOneShard send the requests to one partition
 // assuming the requests going to the same shard
 func (c *ClusterClient) sendRequestsToOneShard(shardId int, requests []*pb.Request) (results []*pb.Response, err error) {
@@ -66,18 +75,21 @@ func (c *ClusterClient) sendRequestsToOneShard(shardId int, requests []*pb.Reque
 func (c *ClusterClient) BatchProcess(requests []*pb.Request,
 	processResultFunc func([]*pb.Response, error) error) error {
 
-	cluster, err := c.GetCluster()
-	if err != nil {
+	if _, err := c.GetCluster(); err != nil {
 		return err
 	}
 
 	shardIdToRequests := make(map[uint32][]*pb.Request)
 	for _, req := range requests {
-		req.ShardId = uint32(cluster.FindShardId(req.GetPartitionHash()))
+		shardId := c.ClusterListener.FindShardId(c.keyspace, req.GetPartitionHash())
+		if shardId < 0 {
+			return fmt.Errorf("keyspace %s has no shards yet", c.keyspace)
+		}
+		req.ShardId = uint32(shardId)
 		shardIdToRequests[req.ShardId] = append(shardIdToRequests[req.ShardId], req)
 	}
 
-	err = mapEachShard(shardIdToRequests, func(shardId uint32, requests []*pb.Request) error {
+	err := mapEachShard(shardIdToRequests, func(shardId uint32, requests []*pb.Request) error {
 
 		responses, err := c.sendRequestsToOneShard(int(shardId), requests)
 
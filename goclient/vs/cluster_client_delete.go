@@ -9,12 +9,27 @@ import (
 
 // Delete deletes one entry by the key.
 func (c *ClusterClient) Delete(key *KeyObject) error {
+	return c.delete(key, false)
+}
+
+// SoftDelete marks one entry as deleted, keeping the value until the stores purge it during compaction.
+// The entry reads as not found, except by GetDeleted, and is restored by putting the value again.
+func (c *ClusterClient) SoftDelete(key *KeyObject) error {
+	return c.delete(key, true)
+}
+
+func (c *ClusterClient) delete(key *KeyObject, soft bool) error {
 
 	request := &pb.Request{
 		Delete: &pb.DeleteRequest{
-			Key:           key.GetKey(),
-			PartitionHash: key.GetPartitionHash(),
-			UpdatedAtNs:   c.UpdatedAtNs,
+			Key:             key.GetKey(),
+			PartitionHash:   key.GetPartitionHash(),
+			UpdatedAtNs:     c.UpdatedAtNs,
+			Soft:            soft,
+			WaitForReplicas: c.WaitForReplicas,
+			ReplicaWaitMs:   c.ReplicaWaitMs,
+			SyncLog:         c.SyncLog,
+			TimeoutMs:       c.TimeoutMs,
 		},
 	}
 
@@ -0,0 +1,60 @@
+package vs
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/chrislusf/vasto/pb"
+)
+
+// BatchDeleteInPartition deletes the keys in the same partition with one request.
+// The errors are in the same order as the keys, and the error is nil if the key is deleted.
+func (c *ClusterClient) BatchDeleteInPartition(keys []*KeyObject) (errs []error, err error) {
+
+	if len(keys) == 0 {
+		return nil, nil
+	}
+
+	batchDeleteRequest := &pb.BatchDeleteRequest{
+		PartitionHash: keys[0].GetPartitionHash(),
+	}
+	for _, key := range keys {
+		if key.GetPartitionHash() != batchDeleteRequest.PartitionHash {
+			return nil, fmt.Errorf("key %s is not in partition %d", string(key.GetKey()), batchDeleteRequest.PartitionHash)
+		}
+		batchDeleteRequest.Deletes = append(batchDeleteRequest.Deletes, &pb.DeleteRequest{
+			Key:           key.GetKey(),
+			PartitionHash: key.GetPartitionHash(),
+			UpdatedAtNs:   c.UpdatedAtNs,
+		})
+	}
+
+	var response *pb.Response
+	err = c.BatchProcess([]*pb.Request{{BatchDelete: batchDeleteRequest}}, func(responses []*pb.Response, err error) error {
+		if err != nil {
+			return err
+		}
+		if len(responses) == 0 || responses[0].BatchDelete == nil {
+			return fmt.Errorf("missing batch delete response")
+		}
+		response = responses[0]
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("batch delete error: %v", err)
+	}
+
+	if !response.BatchDelete.Ok {
+		return nil, fmt.Errorf(response.BatchDelete.Status)
+	}
+
+	for _, result := range response.BatchDelete.Results {
+		if !result.Ok {
+			errs = append(errs, errors.New(result.Status))
+			continue
+		}
+		errs = append(errs, nil)
+	}
+
+	return errs, nil
+}
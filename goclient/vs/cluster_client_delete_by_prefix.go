@@ -0,0 +1,37 @@
+package vs
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/chrislusf/vasto/pb"
+)
+
+// DeleteByPrefix deletes the entries keyed with the prefix on the shard of the partitionKey.
+// limit: max number of entries to delete in this call, 0 for no limit
+// hasMore is true if the limit is reached, and the call should be repeated to delete the rest.
+func (c *ClusterClient) DeleteByPrefix(partitionKey, prefix []byte, limit uint32) (deletedCount int, hasMore bool, err error) {
+
+	shardId, _ := c.ClusterListener.GetShardId(c.keyspace, partitionKey)
+
+	responses, err := c.sendRequestsToOneShard(shardId, []*pb.Request{{
+		ShardId: uint32(shardId),
+		DeleteByPrefix: &pb.DeleteByPrefixRequest{
+			Prefix:      prefix,
+			Limit:       limit,
+			UpdatedAtNs: c.UpdatedAtNs,
+		},
+	}})
+	if err != nil {
+		return 0, false, fmt.Errorf("delete by prefix error: %v", err)
+	}
+	if len(responses) == 0 || responses[0].DeleteByPrefix == nil {
+		return 0, false, fmt.Errorf("missing delete by prefix response")
+	}
+
+	response := responses[0].DeleteByPrefix
+	if !response.Ok {
+		return int(response.DeletedCount), response.HasMore, errors.New(response.Status)
+	}
+	return int(response.DeletedCount), response.HasMore, nil
+}
@@ -60,6 +60,7 @@ func (c *ClusterClient) PutFloat64(key *KeyObject, value float64) error {
 			PartitionHash: key.GetPartitionHash(),
 			UpdatedAtNs:   c.UpdatedAtNs,
 			TtlSecond:     c.TtlSecond,
+			ExpireAtNs:    c.ExpireAtNs,
 			OpAndDataType: pb.OpAndDataType_FLOAT64,
 			Value:         util.Float64ToBytes(value),
 		},
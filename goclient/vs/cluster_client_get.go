@@ -17,10 +17,33 @@ var (
 // Get gets the value bytes by the key
 func (c *ClusterClient) Get(key *KeyObject) ([]byte, pb.OpAndDataType, error) {
 
+	kv, err := c.get(key, false)
+	if err != nil {
+		return nil, pb.OpAndDataType_BYTES, err
+	}
+
+	return kv.Value, kv.DataType, nil
+}
+
+// GetDeleted gets the value bytes by the key, even if the entry is soft deleted.
+// The deletedAtNs is the time of the soft delete, or 0 if the entry is not deleted.
+func (c *ClusterClient) GetDeleted(key *KeyObject) (value []byte, dataType pb.OpAndDataType, deletedAtNs uint64, err error) {
+
+	kv, err := c.get(key, true)
+	if err != nil {
+		return nil, pb.OpAndDataType_BYTES, 0, err
+	}
+
+	return kv.Value, kv.DataType, kv.DeletedAtNs, nil
+}
+
+func (c *ClusterClient) get(key *KeyObject, includeDeleted bool) (*pb.KeyTypeValue, error) {
+
 	request := &pb.Request{
 		Get: &pb.GetRequest{
-			Key:           key.GetKey(),
-			PartitionHash: key.GetPartitionHash(),
+			Key:            key.GetKey(),
+			PartitionHash:  key.GetPartitionHash(),
+			IncludeDeleted: includeDeleted,
 		},
 	}
 
@@ -37,17 +60,17 @@ func (c *ClusterClient) Get(key *KeyObject) ([]byte, pb.OpAndDataType, error) {
 	})
 
 	if err != nil {
-		return nil, pb.OpAndDataType_BYTES, fmt.Errorf("get error: %v", err)
+		return nil, fmt.Errorf("get error: %v", err)
 	}
 
 	if response.Get.Status != "" {
-		return nil, pb.OpAndDataType_BYTES, fmt.Errorf(response.Get.Status)
+		return nil, fmt.Errorf(response.Get.Status)
 	}
 
 	kv := response.Get.KeyValue
 	if kv == nil {
-		return nil, pb.OpAndDataType_BYTES, ErrorNotFound
+		return nil, ErrorNotFound
 	}
 
-	return kv.Value, kv.DataType, nil
+	return kv, nil
 }
@@ -1,6 +1,8 @@
 package vs
 
 import (
+	"fmt"
+
 	"github.com/chrislusf/vasto/pb"
 )
 
@@ -53,3 +55,51 @@ func (c *ClusterClient) BatchGet(keys []*KeyObject) (ret []*KeyValue, err error)
 
 	return ret, err
 }
+
+// BatchGetInPartition gets the key value pairs of the keys in the same partition with one request.
+// The results are in the same order as the keys, and the result is nil if the key is not found.
+func (c *ClusterClient) BatchGetInPartition(keys []*KeyObject) (ret []*KeyValue, err error) {
+
+	if len(keys) == 0 {
+		return nil, nil
+	}
+
+	batchGetRequest := &pb.BatchGetRequest{
+		PartitionHash: keys[0].GetPartitionHash(),
+	}
+	for _, key := range keys {
+		if key.GetPartitionHash() != batchGetRequest.PartitionHash {
+			return nil, fmt.Errorf("key %s is not in partition %d", string(key.GetKey()), batchGetRequest.PartitionHash)
+		}
+		batchGetRequest.Keys = append(batchGetRequest.Keys, key.GetKey())
+	}
+
+	var response *pb.Response
+	err = c.BatchProcess([]*pb.Request{{BatchGet: batchGetRequest}}, func(responses []*pb.Response, err error) error {
+		if err != nil {
+			return err
+		}
+		if len(responses) == 0 || responses[0].BatchGet == nil {
+			return fmt.Errorf("missing batch get response")
+		}
+		response = responses[0]
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("batch get error: %v", err)
+	}
+
+	if !response.BatchGet.Ok {
+		return nil, fmt.Errorf(response.BatchGet.Status)
+	}
+
+	for _, result := range response.BatchGet.Results {
+		if result.KeyValue == nil {
+			ret = append(ret, nil)
+			continue
+		}
+		ret = append(ret, fromPbKeyTypeValue(result.KeyValue))
+	}
+
+	return ret, nil
+}
@@ -15,6 +15,7 @@ func (c *ClusterClient) Put(key *KeyObject, value []byte) error {
 			PartitionHash: key.GetPartitionHash(),
 			UpdatedAtNs:   c.UpdatedAtNs,
 			TtlSecond:     c.TtlSecond,
+			ExpireAtNs:    c.ExpireAtNs,
 			OpAndDataType: pb.OpAndDataType_BYTES,
 			Value:         value,
 		},
@@ -26,6 +27,67 @@ func (c *ClusterClient) Put(key *KeyObject, value []byte) error {
 	})
 }
 
+// CompareAndPut puts the value only if the current value is expectedValue, which can not be empty.
+// If the current value does not match, swapped is false, and currentValue is the current value to retry with,
+// which is nil if the key is not found. Use PutIfAbsent to put only if the key is not found.
+func (c *ClusterClient) CompareAndPut(key *KeyObject, expectedValue, value []byte) (currentValue []byte, swapped bool, err error) {
+
+	if len(expectedValue) == 0 {
+		return nil, false, errors.New("empty expected value")
+	}
+
+	return c.conditionalPut(&pb.PutRequest{
+		Key:           key.GetKey(),
+		PartitionHash: key.GetPartitionHash(),
+		UpdatedAtNs:   c.UpdatedAtNs,
+		TtlSecond:     c.TtlSecond,
+		ExpireAtNs:    c.ExpireAtNs,
+		OpAndDataType: pb.OpAndDataType_BYTES,
+		Value:         value,
+		ExpectedValue: expectedValue,
+	})
+}
+
+// PutIfAbsent puts the value only if the key is not found, expired, or deleted.
+// If the key exists, swapped is false, and currentValue is the current value.
+func (c *ClusterClient) PutIfAbsent(key *KeyObject, value []byte) (currentValue []byte, swapped bool, err error) {
+
+	return c.conditionalPut(&pb.PutRequest{
+		Key:           key.GetKey(),
+		PartitionHash: key.GetPartitionHash(),
+		UpdatedAtNs:   c.UpdatedAtNs,
+		TtlSecond:     c.TtlSecond,
+		ExpireAtNs:    c.ExpireAtNs,
+		OpAndDataType: pb.OpAndDataType_BYTES,
+		Value:         value,
+		ExpectAbsent:  true,
+	})
+}
+
+func (c *ClusterClient) conditionalPut(putRequest *pb.PutRequest) (currentValue []byte, swapped bool, err error) {
+
+	err = c.BatchProcess([]*pb.Request{{Put: putRequest}}, func(responses []*pb.Response, err error) error {
+		if err != nil {
+			return err
+		}
+		if len(responses) == 0 {
+			return ErrorNotFound
+		}
+		response := responses[0]
+		if response.Write.Ok {
+			swapped = true
+			return nil
+		}
+		if response.Write.Status == pb.StatusPreconditionFailed {
+			currentValue = response.Write.CurrentValue
+			return nil
+		}
+		return errors.New(response.Write.Status)
+	})
+
+	return currentValue, swapped, err
+}
+
 // Append appends []byte to existing value
 func (c *ClusterClient) Append(key *KeyObject, value []byte) error {
 
@@ -67,6 +129,7 @@ func (c *ClusterClient) BatchPut(rows []*KeyValue) error {
 				PartitionHash: row.KeyObject.GetPartitionHash(),
 				UpdatedAtNs:   c.UpdatedAtNs,
 				TtlSecond:     c.TtlSecond,
+				ExpireAtNs:    c.ExpireAtNs,
 				OpAndDataType: pb.OpAndDataType_BYTES,
 				Value:         row.GetValue(),
 			},
@@ -0,0 +1,84 @@
+package vs
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/chrislusf/vasto/pb"
+)
+
+// GetVersioned gets the value bytes and the version of the entry, to write the key again after it.
+// A deleted entry has a nil value, but still a version. The version is nil if the entry is written without versions.
+func (c *ClusterClient) GetVersioned(key *KeyObject) (value []byte, version *pb.VersionVector, err error) {
+
+	kv, err := c.get(key, true)
+	if err != nil {
+		return nil, nil, err
+	}
+	if kv.DeletedAtNs > 0 {
+		return nil, kv.Version, nil
+	}
+
+	return kv.Value, kv.Version, nil
+}
+
+// PutVersioned puts the value as the writer's next write after the version, e.g., from GetVersioned, which is nil for a new key.
+// The stored version is the version to write the key again after. The writes are ordered by the versions instead of the time,
+// and concurrent is true if another writer wrote the key without seeing this version, when the later write by time is kept.
+func (c *ClusterClient) PutVersioned(key *KeyObject, value []byte, writer string, version *pb.VersionVector) (stored *pb.VersionVector, concurrent bool, err error) {
+
+	return c.versionedWrite(&pb.Request{
+		Put: &pb.PutRequest{
+			Key:           key.GetKey(),
+			PartitionHash: key.GetPartitionHash(),
+			UpdatedAtNs:   c.UpdatedAtNs,
+			TtlSecond:     c.TtlSecond,
+			ExpireAtNs:    c.ExpireAtNs,
+			OpAndDataType: pb.OpAndDataType_BYTES,
+			Value:         value,
+			Version:       version.Increment(writer),
+		},
+	})
+}
+
+// DeleteVersioned deletes the entry as the writer's next write after the version, the same as PutVersioned.
+// The stores keep the deleted entry with its version until purged by compaction, so that the writes concurrent with the delete are still ordered with it.
+func (c *ClusterClient) DeleteVersioned(key *KeyObject, writer string, version *pb.VersionVector) (stored *pb.VersionVector, concurrent bool, err error) {
+
+	return c.versionedWrite(&pb.Request{
+		Delete: &pb.DeleteRequest{
+			Key:             key.GetKey(),
+			PartitionHash:   key.GetPartitionHash(),
+			UpdatedAtNs:     c.UpdatedAtNs,
+			WaitForReplicas: c.WaitForReplicas,
+			ReplicaWaitMs:   c.ReplicaWaitMs,
+			SyncLog:         c.SyncLog,
+			TimeoutMs:       c.TimeoutMs,
+			Version:         version.Increment(writer),
+		},
+	})
+}
+
+func (c *ClusterClient) versionedWrite(request *pb.Request) (stored *pb.VersionVector, concurrent bool, err error) {
+
+	err = c.BatchProcess([]*pb.Request{request}, func(responses []*pb.Response, err error) error {
+		if err != nil {
+			return err
+		}
+		if len(responses) == 0 {
+			return ErrorNotFound
+		}
+		response := responses[0]
+		if !response.Write.Ok {
+			return errors.New(response.Write.Status)
+		}
+		stored, concurrent = response.Write.Version, response.Write.Concurrent
+		return nil
+	})
+
+	if err != nil {
+		return nil, false, fmt.Errorf("versioned write error: %v", err)
+	}
+
+	return stored, concurrent, nil
+}
@@ -4,6 +4,12 @@ package vs
 type WriteConfig struct {
 	UpdatedAtNs uint64 // the update timestamp in nano seconds. Newer entries overwrite older ones. O means now.
 	TtlSecond   uint32 // TTL in seconds. Updated_at + TTL determines the life of the entry. 0 means no TTL.
+	ExpireAtNs  uint64 // hard expiry time in nano seconds, which later updates can not extend. 0 means no hard expiry.
+	// for deletes, the number of replicas to consume the delete before it returns. 0 means not waiting.
+	WaitForReplicas uint32
+	ReplicaWaitMs   uint32 // max wait for the replicas in milli seconds. 0 means 1 second.
+	SyncLog         bool   // for deletes, flush the store's binlog to the disk before it returns.
+	TimeoutMs       uint32 // for deletes, max wait for the store's binlog in milli seconds. 0 means no timeout.
 }
 
 // AccessConfig stores options for reading and writing
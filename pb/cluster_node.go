@@ -0,0 +1,6 @@
+package pb
+
+// GetDataCenter returns the data center of the node's store, or an empty string if unknown.
+func (node *ClusterNode) GetDataCenter() string {
+	return node.GetStoreResource().GetDataCenter()
+}
@@ -0,0 +1,14 @@
+package pb
+
+import "sort"
+
+// GetShardInfos returns the shards sorted by shard id, so that the output is stable across calls.
+func (s *LocalShardsInCluster) GetShardInfos() (shardInfos []*ShardInfo) {
+	for _, shardInfo := range s.GetShardMap() {
+		shardInfos = append(shardInfos, shardInfo)
+	}
+	sort.Slice(shardInfos, func(i, j int) bool {
+		return shardInfos[i].ShardId < shardInfos[j].ShardId
+	})
+	return
+}
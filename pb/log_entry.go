@@ -15,10 +15,67 @@ func (entry *LogEntry) GetKey() []byte {
 	return entry.getWriteRequest().GetKey()
 }
 
-func (entry *LogEntry) getWriteRequest() (request writeRequest) {
-	request = entry.GetPut()
-	if request == nil {
-		request = entry.GetDelete()
+func (entry *LogEntry) getWriteRequest() writeRequest {
+	if put := entry.GetPut(); put != nil {
+		return put
 	}
-	return request
+	if del := entry.GetDelete(); del != nil {
+		return del
+	}
+	return entry.GetMerge()
+}
+
+// LogEntryFlag is one bit of LogEntry.Flags, describing the change beyond the put, delete, or merge request.
+type LogEntryFlag uint32
+
+const (
+	// LogEntryDelete is set for deletes.
+	LogEntryDelete LogEntryFlag = 1 << iota
+	// LogEntryExpired is set for deletes of expired entries, dropped on read or by compaction.
+	LogEntryExpired
+	// LogEntrySoftDelete is set for deletes keeping the value until purged by compaction.
+	LogEntrySoftDelete
+	// LogEntryConditional is set for changes applied only if the existing entry matched the expectation.
+	LogEntryConditional
+)
+
+// SetFlag sets the flag on the entry.
+func (entry *LogEntry) SetFlag(flag LogEntryFlag) {
+	entry.Flags |= uint32(flag)
+}
+
+// HasFlag checks whether the flag is set on the entry.
+// Entries without any flags, e.g., written by an older version, have the flags derived from the request.
+func (entry *LogEntry) HasFlag(flag LogEntryFlag) bool {
+	flags := LogEntryFlag(entry.GetFlags())
+	if flags == 0 {
+		flags = DeleteFlags(entry.GetDelete()) | PutFlags(entry.GetPut())
+	}
+	return flags&flag != 0
+}
+
+// DeleteFlags returns the flags of the delete request, or 0 if it is nil.
+func DeleteFlags(deleteRequest *DeleteRequest) (flags LogEntryFlag) {
+	if deleteRequest == nil {
+		return 0
+	}
+	flags = LogEntryDelete
+	if deleteRequest.Soft {
+		flags |= LogEntrySoftDelete
+	}
+	if len(deleteRequest.ExpectedValue) > 0 || deleteRequest.ExpectedUpdatedAtNs != 0 {
+		flags |= LogEntryConditional
+	}
+	return flags
+}
+
+// PutFlags returns the flags of the put request, or 0 if it is nil.
+func PutFlags(putRequest *PutRequest) (flags LogEntryFlag) {
+	if putRequest == nil {
+		return 0
+	}
+	if len(putRequest.ExpectedValue) > 0 || putRequest.ExpectedUpdatedAtNs != 0 || putRequest.ExpectAbsent {
+		flags |= LogEntryConditional
+	}
+	return flags
 }
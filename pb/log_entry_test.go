@@ -0,0 +1,50 @@
+package pb
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+)
+
+func TestLogEntryFlags(t *testing.T) {
+
+	entry := &LogEntry{Delete: &DeleteRequest{Key: []byte("k1")}}
+	entry.SetFlag(LogEntryDelete)
+	entry.SetFlag(LogEntryExpired)
+	if !entry.HasFlag(LogEntryDelete) || !entry.HasFlag(LogEntryExpired) || entry.HasFlag(LogEntrySoftDelete) {
+		t.Errorf("unexpected flags %b", entry.Flags)
+	}
+
+	data, err := proto.Marshal(entry)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	decoded := &LogEntry{}
+	if err = proto.Unmarshal(data, decoded); err != nil || decoded.Flags != entry.Flags {
+		t.Errorf("decoded flags %b, expecting %b: %v", decoded.Flags, entry.Flags, err)
+	}
+
+	// entries written before the flags field have the flags derived from the request
+	old := &LogEntry{Delete: &DeleteRequest{Key: []byte("k1"), Soft: true, ExpectedUpdatedAtNs: 1}}
+	for _, flag := range []LogEntryFlag{LogEntryDelete, LogEntrySoftDelete, LogEntryConditional} {
+		if !old.HasFlag(flag) {
+			t.Errorf("old delete entry should have flag %b", flag)
+		}
+	}
+	if old.HasFlag(LogEntryExpired) {
+		t.Errorf("old delete entry should not be expired")
+	}
+	put := &LogEntry{Put: &PutRequest{Key: []byte("k1")}}
+	if put.HasFlag(LogEntryDelete) || put.HasFlag(LogEntryConditional) {
+		t.Errorf("put entry should not be a delete nor conditional")
+	}
+	conditionalPut := &LogEntry{Put: &PutRequest{Key: []byte("k1"), ExpectedValue: []byte("v1")}}
+	if conditionalPut.HasFlag(LogEntryDelete) || !conditionalPut.HasFlag(LogEntryConditional) {
+		t.Errorf("conditional put entry should only be conditional")
+	}
+	putIfAbsent := &LogEntry{Put: &PutRequest{Key: []byte("k1"), ExpectAbsent: true}}
+	if !putIfAbsent.HasFlag(LogEntryConditional) {
+		t.Errorf("put if absent entry should be conditional")
+	}
+
+}
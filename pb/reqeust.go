@@ -4,11 +4,14 @@ import (
 	"github.com/chrislusf/glog"
 )
 
-// GetPartitionHash returns the partition hash of Get, Put, Delete, and Merge requests
+// GetPartitionHash returns the partition hash of Get, BatchGet, Put, Delete, BatchDelete, and Merge requests
 func (r *Request) GetPartitionHash() uint64 {
 	if r.Get != nil {
 		return r.Get.PartitionHash
 	}
+	if r.BatchGet != nil {
+		return r.BatchGet.PartitionHash
+	}
 	if r.GetByPrefix != nil {
 		// TODO change the caller function batchProcess to batchWriteProcess
 		glog.Fatalf("unexpected r.GetByPrefix")
@@ -20,6 +23,9 @@ func (r *Request) GetPartitionHash() uint64 {
 	if r.Delete != nil {
 		return r.Delete.PartitionHash
 	}
+	if r.BatchDelete != nil {
+		return r.BatchDelete.PartitionHash
+	}
 	if r.Merge != nil {
 		return r.Merge.PartitionHash
 	}
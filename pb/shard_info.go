@@ -1,12 +1,42 @@
 package pb
 
-import "fmt"
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
 
 // IdentifierOnThisServer prints out the keyspace_name.server_id.shard_id
 func (s *ShardInfo) IdentifierOnThisServer() string {
 	return fmt.Sprintf("%s.%d.%d", s.KeyspaceName, s.ServerId, s.ShardId)
 }
 
+// ParseShardIdentifier parses the keyspace_name.server_id.shard_id from IdentifierOnThisServer.
+// The ids are taken from the end, so the keyspace name can have dots.
+func ParseShardIdentifier(id string) (keyspace string, serverId int, shardId int, err error) {
+
+	parts := strings.Split(id, ".")
+	if len(parts) < 3 {
+		return "", 0, 0, fmt.Errorf("shard identifier %q: expecting keyspace_name.server_id.shard_id", id)
+	}
+
+	keyspace = strings.Join(parts[:len(parts)-2], ".")
+	if keyspace == "" {
+		return "", 0, 0, fmt.Errorf("shard identifier %q: empty keyspace name", id)
+	}
+
+	parsedServerId, err := strconv.ParseUint(parts[len(parts)-2], 10, 32)
+	if err != nil {
+		return "", 0, 0, fmt.Errorf("shard identifier %q: server id: %v", id, err)
+	}
+	parsedShardId, err := strconv.ParseUint(parts[len(parts)-1], 10, 32)
+	if err != nil {
+		return "", 0, 0, fmt.Errorf("shard identifier %q: shard id: %v", id, err)
+	}
+
+	return keyspace, int(parsedServerId), int(parsedShardId), nil
+}
+
 // Clone creates a new copy of ShardInfo
 func (s *ShardInfo) Clone() *ShardInfo {
 	return &ShardInfo{
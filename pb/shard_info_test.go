@@ -0,0 +1,30 @@
+package pb
+
+import (
+	"testing"
+)
+
+func TestParseShardIdentifier(t *testing.T) {
+
+	for _, shardInfo := range []*ShardInfo{
+		{KeyspaceName: "ks1", ServerId: 0, ShardId: 0},
+		{KeyspaceName: "ks1", ServerId: 3, ShardId: 12},
+		{KeyspaceName: "with.dots", ServerId: 4294967295, ShardId: 7},
+	} {
+		keyspace, serverId, shardId, err := ParseShardIdentifier(shardInfo.IdentifierOnThisServer())
+		if err != nil {
+			t.Errorf("parse %s: %v", shardInfo.IdentifierOnThisServer(), err)
+			continue
+		}
+		if keyspace != shardInfo.KeyspaceName || serverId != int(shardInfo.ServerId) || shardId != int(shardInfo.ShardId) {
+			t.Errorf("parse %s: %s %d %d", shardInfo.IdentifierOnThisServer(), keyspace, serverId, shardId)
+		}
+	}
+
+	for _, id := range []string{"", "ks1", "ks1.2", ".1.2", "ks1.a.2", "ks1.1.-2", "ks1.1.4294967296"} {
+		if _, _, _, err := ParseShardIdentifier(id); err == nil {
+			t.Errorf("parse invalid identifier %q", id)
+		}
+	}
+
+}
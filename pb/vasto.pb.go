@@ -21,6 +21,8 @@ It has these top-level messages:
 	ShardInfo
 	Empty
 	KeyTypeValue
+	VersionVector
+	VersionClock
 	Requests
 	Responses
 	Request
@@ -30,11 +32,21 @@ It has these top-level messages:
 	DeleteRequest
 	GetRequest
 	GetResponse
+	BatchGetRequest
+	BatchGetResponse
+	BatchDeleteRequest
+	BatchDeleteResponse
 	GetByPrefixRequest
 	GetByPrefixResponse
+	DeleteByPrefixRequest
+	DeleteByPrefixResponse
+	PrepareDeleteRequest
+	CommitDeleteRequest
+	AbortDeleteRequest
 	Response
 	RawKeyValue
 	LogEntry
+	DeleteIntent
 	CopyDoneMessge
 	BootstrapCopyRequest
 	BootstrapCopyResponse
@@ -42,6 +54,23 @@ It has these top-level messages:
 	PullUpdateResponse
 	CheckBinlogRequest
 	CheckBinlogResponse
+	KeyHistoryRequest
+	KeyHistoryResponse
+	GetAsOfRequest
+	GetAsOfResponse
+	DiagnosticsRequest
+	DiagnosticsResponse
+	StreamDeleteRequest
+	StreamDeleteProgress
+	DeadLettersRequest
+	DeadLettersResponse
+	AckedRequest
+	AckedResponse
+	ShardStatsRequest
+	ShardStatsResponse
+	FlushBinlogRequest
+	FlushBinlogResponse
+	ShardStats
 	DescribeRequest
 	DescribeResponse
 	CreateClusterRequest
@@ -149,6 +178,30 @@ func (x ShardInfo_Status) String() string {
 }
 func (ShardInfo_Status) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{9, 0} }
 
+type DeleteIntent_Phase int32
+
+const (
+	DeleteIntent_PREPARE DeleteIntent_Phase = 0
+	DeleteIntent_COMMIT  DeleteIntent_Phase = 1
+	DeleteIntent_ABORT   DeleteIntent_Phase = 2
+)
+
+var DeleteIntent_Phase_name = map[int32]string{
+	0: "PREPARE",
+	1: "COMMIT",
+	2: "ABORT",
+}
+var DeleteIntent_Phase_value = map[string]int32{
+	"PREPARE": 0,
+	"COMMIT":  1,
+	"ABORT":   2,
+}
+
+func (x DeleteIntent_Phase) String() string {
+	return proto.EnumName(DeleteIntent_Phase_name, int32(x))
+}
+func (DeleteIntent_Phase) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{37, 0} }
+
 // ////////////////////////////////////////////////
 // 1. master received request to balance the data
 type BalanceRequest struct {
@@ -445,6 +498,7 @@ type StoreResource struct {
 	Tags            []string `protobuf:"bytes,7,rep,name=tags" json:"tags,omitempty"`
 	DiskSizeGb      uint32   `protobuf:"varint,8,opt,name=disk_size_gb,json=diskSizeGb" json:"disk_size_gb,omitempty"`
 	AllocatedSizeGb uint32   `protobuf:"varint,9,opt,name=allocated_size_gb,json=allocatedSizeGb" json:"allocated_size_gb,omitempty"`
+	DataCenter      string   `protobuf:"bytes,10,opt,name=data_center,json=dataCenter" json:"data_center,omitempty"`
 }
 
 func (m *StoreResource) Reset()                    { *m = StoreResource{} }
@@ -494,6 +548,13 @@ func (m *StoreResource) GetAllocatedSizeGb() uint32 {
 	return 0
 }
 
+func (m *StoreResource) GetDataCenter() string {
+	if m != nil {
+		return m.DataCenter
+	}
+	return ""
+}
+
 // LocalShardsInCluster is saved to and load from disk
 type LocalShardsInCluster struct {
 	Id       uint32                `protobuf:"varint,1,opt,name=id" json:"id,omitempty"`
@@ -622,6 +683,10 @@ type KeyTypeValue struct {
 	PartitionHash uint64        `protobuf:"varint,2,opt,name=partition_hash,json=partitionHash" json:"partition_hash,omitempty"`
 	DataType      OpAndDataType `protobuf:"varint,3,opt,name=data_type,json=dataType,enum=pb.OpAndDataType" json:"data_type,omitempty"`
 	Value         []byte        `protobuf:"bytes,4,opt,name=value,proto3" json:"value,omitempty"`
+	// the time of the soft delete, or 0 if not deleted.
+	DeletedAtNs uint64 `protobuf:"varint,5,opt,name=deleted_at_ns,json=deletedAtNs" json:"deleted_at_ns,omitempty"`
+	// the version of the entry written with versions, to use as the causal context of the next write.
+	Version *VersionVector `protobuf:"bytes,6,opt,name=version" json:"version,omitempty"`
 }
 
 func (m *KeyTypeValue) Reset()                    { *m = KeyTypeValue{} }
@@ -657,6 +722,61 @@ func (m *KeyTypeValue) GetValue() []byte {
 	return nil
 }
 
+func (m *KeyTypeValue) GetDeletedAtNs() uint64 {
+	if m != nil {
+		return m.DeletedAtNs
+	}
+	return 0
+}
+
+func (m *KeyTypeValue) GetVersion() *VersionVector {
+	if m != nil {
+		return m.Version
+	}
+	return nil
+}
+
+// VersionVector is a causality token, counting the writes of each writer that a write has seen.
+type VersionVector struct {
+	Clocks []*VersionClock `protobuf:"bytes,1,rep,name=clocks" json:"clocks,omitempty"`
+}
+
+func (m *VersionVector) Reset()                    { *m = VersionVector{} }
+func (m *VersionVector) String() string            { return proto.CompactTextString(m) }
+func (*VersionVector) ProtoMessage()               {}
+func (*VersionVector) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{12} }
+
+func (m *VersionVector) GetClocks() []*VersionClock {
+	if m != nil {
+		return m.Clocks
+	}
+	return nil
+}
+
+type VersionClock struct {
+	Writer  string `protobuf:"bytes,1,opt,name=writer" json:"writer,omitempty"`
+	Counter uint64 `protobuf:"varint,2,opt,name=counter" json:"counter,omitempty"`
+}
+
+func (m *VersionClock) Reset()                    { *m = VersionClock{} }
+func (m *VersionClock) String() string            { return proto.CompactTextString(m) }
+func (*VersionClock) ProtoMessage()               {}
+func (*VersionClock) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{13} }
+
+func (m *VersionClock) GetWriter() string {
+	if m != nil {
+		return m.Writer
+	}
+	return ""
+}
+
+func (m *VersionClock) GetCounter() uint64 {
+	if m != nil {
+		return m.Counter
+	}
+	return 0
+}
+
 // ////////////////////////////////////////////////
 // // data queries
 // ////////////////////////////////////////////////
@@ -668,7 +788,7 @@ type Requests struct {
 func (m *Requests) Reset()                    { *m = Requests{} }
 func (m *Requests) String() string            { return proto.CompactTextString(m) }
 func (*Requests) ProtoMessage()               {}
-func (*Requests) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{12} }
+func (*Requests) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{14} }
 
 func (m *Requests) GetKeyspace() string {
 	if m != nil {
@@ -691,7 +811,7 @@ type Responses struct {
 func (m *Responses) Reset()                    { *m = Responses{} }
 func (m *Responses) String() string            { return proto.CompactTextString(m) }
 func (*Responses) ProtoMessage()               {}
-func (*Responses) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{13} }
+func (*Responses) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{15} }
 
 func (m *Responses) GetResponses() []*Response {
 	if m != nil {
@@ -701,18 +821,24 @@ func (m *Responses) GetResponses() []*Response {
 }
 
 type Request struct {
-	ShardId     uint32              `protobuf:"varint,1,opt,name=shard_id,json=shardId" json:"shard_id,omitempty"`
-	Put         *PutRequest         `protobuf:"bytes,2,opt,name=put" json:"put,omitempty"`
-	Get         *GetRequest         `protobuf:"bytes,3,opt,name=get" json:"get,omitempty"`
-	GetByPrefix *GetByPrefixRequest `protobuf:"bytes,4,opt,name=get_by_prefix,json=getByPrefix" json:"get_by_prefix,omitempty"`
-	Delete      *DeleteRequest      `protobuf:"bytes,5,opt,name=delete" json:"delete,omitempty"`
-	Merge       *MergeRequest       `protobuf:"bytes,6,opt,name=merge" json:"merge,omitempty"`
+	ShardId        uint32                 `protobuf:"varint,1,opt,name=shard_id,json=shardId" json:"shard_id,omitempty"`
+	Put            *PutRequest            `protobuf:"bytes,2,opt,name=put" json:"put,omitempty"`
+	Get            *GetRequest            `protobuf:"bytes,3,opt,name=get" json:"get,omitempty"`
+	GetByPrefix    *GetByPrefixRequest    `protobuf:"bytes,4,opt,name=get_by_prefix,json=getByPrefix" json:"get_by_prefix,omitempty"`
+	Delete         *DeleteRequest         `protobuf:"bytes,5,opt,name=delete" json:"delete,omitempty"`
+	Merge          *MergeRequest          `protobuf:"bytes,6,opt,name=merge" json:"merge,omitempty"`
+	BatchGet       *BatchGetRequest       `protobuf:"bytes,7,opt,name=batch_get,json=batchGet" json:"batch_get,omitempty"`
+	BatchDelete    *BatchDeleteRequest    `protobuf:"bytes,8,opt,name=batch_delete,json=batchDelete" json:"batch_delete,omitempty"`
+	DeleteByPrefix *DeleteByPrefixRequest `protobuf:"bytes,9,opt,name=delete_by_prefix,json=deleteByPrefix" json:"delete_by_prefix,omitempty"`
+	PrepareDelete  *PrepareDeleteRequest  `protobuf:"bytes,10,opt,name=prepare_delete,json=prepareDelete" json:"prepare_delete,omitempty"`
+	CommitDelete   *CommitDeleteRequest   `protobuf:"bytes,11,opt,name=commit_delete,json=commitDelete" json:"commit_delete,omitempty"`
+	AbortDelete    *AbortDeleteRequest    `protobuf:"bytes,12,opt,name=abort_delete,json=abortDelete" json:"abort_delete,omitempty"`
 }
 
 func (m *Request) Reset()                    { *m = Request{} }
 func (m *Request) String() string            { return proto.CompactTextString(m) }
 func (*Request) ProtoMessage()               {}
-func (*Request) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{14} }
+func (*Request) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{16} }
 
 func (m *Request) GetShardId() uint32 {
 	if m != nil {
@@ -756,6 +882,48 @@ func (m *Request) GetMerge() *MergeRequest {
 	return nil
 }
 
+func (m *Request) GetBatchGet() *BatchGetRequest {
+	if m != nil {
+		return m.BatchGet
+	}
+	return nil
+}
+
+func (m *Request) GetBatchDelete() *BatchDeleteRequest {
+	if m != nil {
+		return m.BatchDelete
+	}
+	return nil
+}
+
+func (m *Request) GetDeleteByPrefix() *DeleteByPrefixRequest {
+	if m != nil {
+		return m.DeleteByPrefix
+	}
+	return nil
+}
+
+func (m *Request) GetPrepareDelete() *PrepareDeleteRequest {
+	if m != nil {
+		return m.PrepareDelete
+	}
+	return nil
+}
+
+func (m *Request) GetCommitDelete() *CommitDeleteRequest {
+	if m != nil {
+		return m.CommitDelete
+	}
+	return nil
+}
+
+func (m *Request) GetAbortDelete() *AbortDeleteRequest {
+	if m != nil {
+		return m.AbortDelete
+	}
+	return nil
+}
+
 type PutRequest struct {
 	Key           []byte        `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
 	PartitionHash uint64        `protobuf:"varint,2,opt,name=partition_hash,json=partitionHash" json:"partition_hash,omitempty"`
@@ -763,12 +931,21 @@ type PutRequest struct {
 	TtlSecond     uint32        `protobuf:"varint,4,opt,name=ttl_second,json=ttlSecond" json:"ttl_second,omitempty"`
 	OpAndDataType OpAndDataType `protobuf:"varint,5,opt,name=op_and_data_type,json=opAndDataType,enum=pb.OpAndDataType" json:"op_and_data_type,omitempty"`
 	Value         []byte        `protobuf:"bytes,6,opt,name=value,proto3" json:"value,omitempty"`
+	// hard expiry time, not extended by later puts like ttl_second. 0 means no hard expiry.
+	ExpireAtNs uint64 `protobuf:"varint,7,opt,name=expire_at_ns,json=expireAtNs" json:"expire_at_ns,omitempty"`
+	// if set, the key is only written if the current value and updated_at_ns match, the same as the conditional delete.
+	ExpectedValue       []byte `protobuf:"bytes,8,opt,name=expected_value,json=expectedValue,proto3" json:"expected_value,omitempty"`
+	ExpectedUpdatedAtNs uint64 `protobuf:"varint,9,opt,name=expected_updated_at_ns,json=expectedUpdatedAtNs" json:"expected_updated_at_ns,omitempty"`
+	// if set, the key is only written if it is not found, expired, or soft deleted.
+	ExpectAbsent bool `protobuf:"varint,10,opt,name=expect_absent,json=expectAbsent" json:"expect_absent,omitempty"`
+	// if set, the put is ordered by the version instead of updated_at_ns, see DeleteRequest.version.
+	Version *VersionVector `protobuf:"bytes,11,opt,name=version" json:"version,omitempty"`
 }
 
 func (m *PutRequest) Reset()                    { *m = PutRequest{} }
 func (m *PutRequest) String() string            { return proto.CompactTextString(m) }
 func (*PutRequest) ProtoMessage()               {}
-func (*PutRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{15} }
+func (*PutRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{17} }
 
 func (m *PutRequest) GetKey() []byte {
 	if m != nil {
@@ -812,6 +989,41 @@ func (m *PutRequest) GetValue() []byte {
 	return nil
 }
 
+func (m *PutRequest) GetExpireAtNs() uint64 {
+	if m != nil {
+		return m.ExpireAtNs
+	}
+	return 0
+}
+
+func (m *PutRequest) GetExpectedValue() []byte {
+	if m != nil {
+		return m.ExpectedValue
+	}
+	return nil
+}
+
+func (m *PutRequest) GetExpectedUpdatedAtNs() uint64 {
+	if m != nil {
+		return m.ExpectedUpdatedAtNs
+	}
+	return 0
+}
+
+func (m *PutRequest) GetExpectAbsent() bool {
+	if m != nil {
+		return m.ExpectAbsent
+	}
+	return false
+}
+
+func (m *PutRequest) GetVersion() *VersionVector {
+	if m != nil {
+		return m.Version
+	}
+	return nil
+}
+
 type MergeRequest struct {
 	Key           []byte        `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
 	PartitionHash uint64        `protobuf:"varint,2,opt,name=partition_hash,json=partitionHash" json:"partition_hash,omitempty"`
@@ -823,7 +1035,7 @@ type MergeRequest struct {
 func (m *MergeRequest) Reset()                    { *m = MergeRequest{} }
 func (m *MergeRequest) String() string            { return proto.CompactTextString(m) }
 func (*MergeRequest) ProtoMessage()               {}
-func (*MergeRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{16} }
+func (*MergeRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{18} }
 
 func (m *MergeRequest) GetKey() []byte {
 	if m != nil {
@@ -863,12 +1075,27 @@ func (m *MergeRequest) GetValue() []byte {
 type WriteResponse struct {
 	Ok     bool   `protobuf:"varint,1,opt,name=ok" json:"ok,omitempty"`
 	Status string `protobuf:"bytes,2,opt,name=status" json:"status,omitempty"`
+	// for deletes, whether the key existed and was deleted
+	Existed bool `protobuf:"varint,3,opt,name=existed" json:"existed,omitempty"`
+	// for deletes, the binlog position of the delete, to check the replicas with Acked
+	LogSegment uint32 `protobuf:"varint,4,opt,name=log_segment,json=logSegment" json:"log_segment,omitempty"`
+	LogOffset  uint64 `protobuf:"varint,5,opt,name=log_offset,json=logOffset" json:"log_offset,omitempty"`
+	// for conditional puts not matching the current entry, its value and updated_at_ns to retry with.
+	// Both are empty if the key is not found.
+	CurrentValue       []byte `protobuf:"bytes,6,opt,name=current_value,json=currentValue,proto3" json:"current_value,omitempty"`
+	CurrentUpdatedAtNs uint64 `protobuf:"varint,7,opt,name=current_updated_at_ns,json=currentUpdatedAtNs" json:"current_updated_at_ns,omitempty"`
+	// for puts, the updated_at_ns of the written entry, to use as the expected_updated_at_ns of the next conditional write
+	UpdatedAtNs uint64 `protobuf:"varint,8,opt,name=updated_at_ns,json=updatedAtNs" json:"updated_at_ns,omitempty"`
+	// for versioned writes, whether the write is concurrent with the stored entry,
+	// and the stored version merging both, to use as the causal context of the next write.
+	Concurrent bool           `protobuf:"varint,9,opt,name=concurrent" json:"concurrent,omitempty"`
+	Version    *VersionVector `protobuf:"bytes,10,opt,name=version" json:"version,omitempty"`
 }
 
 func (m *WriteResponse) Reset()                    { *m = WriteResponse{} }
 func (m *WriteResponse) String() string            { return proto.CompactTextString(m) }
 func (*WriteResponse) ProtoMessage()               {}
-func (*WriteResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{17} }
+func (*WriteResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{19} }
 
 func (m *WriteResponse) GetOk() bool {
 	if m != nil {
@@ -884,16 +1111,92 @@ func (m *WriteResponse) GetStatus() string {
 	return ""
 }
 
+func (m *WriteResponse) GetExisted() bool {
+	if m != nil {
+		return m.Existed
+	}
+	return false
+}
+
+func (m *WriteResponse) GetLogSegment() uint32 {
+	if m != nil {
+		return m.LogSegment
+	}
+	return 0
+}
+
+func (m *WriteResponse) GetLogOffset() uint64 {
+	if m != nil {
+		return m.LogOffset
+	}
+	return 0
+}
+
+func (m *WriteResponse) GetCurrentValue() []byte {
+	if m != nil {
+		return m.CurrentValue
+	}
+	return nil
+}
+
+func (m *WriteResponse) GetCurrentUpdatedAtNs() uint64 {
+	if m != nil {
+		return m.CurrentUpdatedAtNs
+	}
+	return 0
+}
+
+func (m *WriteResponse) GetUpdatedAtNs() uint64 {
+	if m != nil {
+		return m.UpdatedAtNs
+	}
+	return 0
+}
+
+func (m *WriteResponse) GetConcurrent() bool {
+	if m != nil {
+		return m.Concurrent
+	}
+	return false
+}
+
+func (m *WriteResponse) GetVersion() *VersionVector {
+	if m != nil {
+		return m.Version
+	}
+	return nil
+}
+
 type DeleteRequest struct {
 	Key           []byte `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
 	PartitionHash uint64 `protobuf:"varint,2,opt,name=partition_hash,json=partitionHash" json:"partition_hash,omitempty"`
 	UpdatedAtNs   uint64 `protobuf:"varint,3,opt,name=updated_at_ns,json=updatedAtNs" json:"updated_at_ns,omitempty"`
+	// if set, the key is only deleted if the current value and updated_at_ns match.
+	ExpectedValue       []byte `protobuf:"bytes,4,opt,name=expected_value,json=expectedValue,proto3" json:"expected_value,omitempty"`
+	ExpectedUpdatedAtNs uint64 `protobuf:"varint,5,opt,name=expected_updated_at_ns,json=expectedUpdatedAtNs" json:"expected_updated_at_ns,omitempty"`
+	// if set, the value is kept and marked deleted, until purged by compaction after the soft delete retention.
+	// It reads as not found, unless the get includes deleted values.
+	Soft bool `protobuf:"varint,6,opt,name=soft" json:"soft,omitempty"`
+	// if set, the delete returns ok only after this many replicas have consumed its binlog entry,
+	// or returns the not replicated status when replica_wait_ms, 1 second by default, passes first.
+	WaitForReplicas uint32 `protobuf:"varint,7,opt,name=wait_for_replicas,json=waitForReplicas" json:"wait_for_replicas,omitempty"`
+	ReplicaWaitMs   uint32 `protobuf:"varint,8,opt,name=replica_wait_ms,json=replicaWaitMs" json:"replica_wait_ms,omitempty"`
+	// if set, the binlog is flushed to the disk before the delete returns.
+	SyncLog bool `protobuf:"varint,9,opt,name=sync_log,json=syncLog" json:"sync_log,omitempty"`
+	// if set, the delete fails with the timeout status, and the key is kept,
+	// when the binlog append can not start within this many milli seconds after the store receives the delete.
+	// An append already started is always waited for.
+	TimeoutMs uint32 `protobuf:"varint,10,opt,name=timeout_ms,json=timeoutMs" json:"timeout_ms,omitempty"`
+	// if set, the delete is ordered by the version instead of updated_at_ns, and keeps a soft deleted entry with the version.
+	// A write already seen by the stored version is dropped, and a write after it replaces the stored entry.
+	// Of concurrent writes, the one with the later updated_at_ns wins, and the stored version merges both.
+	Version *VersionVector `protobuf:"bytes,11,opt,name=version" json:"version,omitempty"`
 }
 
 func (m *DeleteRequest) Reset()                    { *m = DeleteRequest{} }
 func (m *DeleteRequest) String() string            { return proto.CompactTextString(m) }
 func (*DeleteRequest) ProtoMessage()               {}
-func (*DeleteRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{18} }
+func (*DeleteRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{20} }
 
 func (m *DeleteRequest) GetKey() []byte {
 	if m != nil {
@@ -916,15 +1219,73 @@ func (m *DeleteRequest) GetUpdatedAtNs() uint64 {
 	return 0
 }
 
+func (m *DeleteRequest) GetExpectedValue() []byte {
+	if m != nil {
+		return m.ExpectedValue
+	}
+	return nil
+}
+
+func (m *DeleteRequest) GetExpectedUpdatedAtNs() uint64 {
+	if m != nil {
+		return m.ExpectedUpdatedAtNs
+	}
+	return 0
+}
+
+func (m *DeleteRequest) GetSoft() bool {
+	if m != nil {
+		return m.Soft
+	}
+	return false
+}
+
+func (m *DeleteRequest) GetWaitForReplicas() uint32 {
+	if m != nil {
+		return m.WaitForReplicas
+	}
+	return 0
+}
+
+func (m *DeleteRequest) GetReplicaWaitMs() uint32 {
+	if m != nil {
+		return m.ReplicaWaitMs
+	}
+	return 0
+}
+
+func (m *DeleteRequest) GetSyncLog() bool {
+	if m != nil {
+		return m.SyncLog
+	}
+	return false
+}
+
+func (m *DeleteRequest) GetTimeoutMs() uint32 {
+	if m != nil {
+		return m.TimeoutMs
+	}
+	return 0
+}
+
+func (m *DeleteRequest) GetVersion() *VersionVector {
+	if m != nil {
+		return m.Version
+	}
+	return nil
+}
+
 type GetRequest struct {
 	Key           []byte `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
 	PartitionHash uint64 `protobuf:"varint,2,opt,name=partition_hash,json=partitionHash" json:"partition_hash,omitempty"`
+	// if set, a soft deleted value is also returned, with its deleted_at_ns.
+	IncludeDeleted bool `protobuf:"varint,3,opt,name=include_deleted,json=includeDeleted" json:"include_deleted,omitempty"`
 }
 
 func (m *GetRequest) Reset()                    { *m = GetRequest{} }
 func (m *GetRequest) String() string            { return proto.CompactTextString(m) }
 func (*GetRequest) ProtoMessage()               {}
-func (*GetRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{19} }
+func (*GetRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{21} }
 
 func (m *GetRequest) GetKey() []byte {
 	if m != nil {
@@ -940,6 +1301,13 @@ func (m *GetRequest) GetPartitionHash() uint64 {
 	return 0
 }
 
+func (m *GetRequest) GetIncludeDeleted() bool {
+	if m != nil {
+		return m.IncludeDeleted
+	}
+	return false
+}
+
 type GetResponse struct {
 	Ok       bool          `protobuf:"varint,1,opt,name=ok" json:"ok,omitempty"`
 	Status   string        `protobuf:"bytes,2,opt,name=status" json:"status,omitempty"`
@@ -949,7 +1317,7 @@ type GetResponse struct {
 func (m *GetResponse) Reset()                    { *m = GetResponse{} }
 func (m *GetResponse) String() string            { return proto.CompactTextString(m) }
 func (*GetResponse) ProtoMessage()               {}
-func (*GetResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{20} }
+func (*GetResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{22} }
 
 func (m *GetResponse) GetOk() bool {
 	if m != nil {
@@ -972,137 +1340,434 @@ func (m *GetResponse) GetKeyValue() *KeyTypeValue {
 	return nil
 }
 
-type GetByPrefixRequest struct {
-	Prefix      []byte `protobuf:"bytes,1,opt,name=prefix,proto3" json:"prefix,omitempty"`
-	Limit       uint32 `protobuf:"varint,2,opt,name=limit" json:"limit,omitempty"`
-	LastSeenKey []byte `protobuf:"bytes,3,opt,name=last_seen_key,json=lastSeenKey,proto3" json:"last_seen_key,omitempty"`
+// BatchGetRequest reads multiple keys in the same partition
+type BatchGetRequest struct {
+	Keys          [][]byte `protobuf:"bytes,1,rep,name=keys,proto3" json:"keys,omitempty"`
+	PartitionHash uint64   `protobuf:"varint,2,opt,name=partition_hash,json=partitionHash" json:"partition_hash,omitempty"`
+	// if set, soft deleted values are also returned, the same as GetRequest.include_deleted.
+	IncludeDeleted bool `protobuf:"varint,3,opt,name=include_deleted,json=includeDeleted" json:"include_deleted,omitempty"`
 }
 
-func (m *GetByPrefixRequest) Reset()                    { *m = GetByPrefixRequest{} }
-func (m *GetByPrefixRequest) String() string            { return proto.CompactTextString(m) }
-func (*GetByPrefixRequest) ProtoMessage()               {}
-func (*GetByPrefixRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{21} }
+func (m *BatchGetRequest) Reset()                    { *m = BatchGetRequest{} }
+func (m *BatchGetRequest) String() string            { return proto.CompactTextString(m) }
+func (*BatchGetRequest) ProtoMessage()               {}
+func (*BatchGetRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{23} }
 
-func (m *GetByPrefixRequest) GetPrefix() []byte {
+func (m *BatchGetRequest) GetKeys() [][]byte {
 	if m != nil {
-		return m.Prefix
+		return m.Keys
 	}
 	return nil
 }
 
-func (m *GetByPrefixRequest) GetLimit() uint32 {
+func (m *BatchGetRequest) GetPartitionHash() uint64 {
 	if m != nil {
-		return m.Limit
+		return m.PartitionHash
 	}
 	return 0
 }
 
-func (m *GetByPrefixRequest) GetLastSeenKey() []byte {
+func (m *BatchGetRequest) GetIncludeDeleted() bool {
 	if m != nil {
-		return m.LastSeenKey
+		return m.IncludeDeleted
 	}
-	return nil
+	return false
 }
 
-type GetByPrefixResponse struct {
-	Ok        bool            `protobuf:"varint,1,opt,name=ok" json:"ok,omitempty"`
-	Status    string          `protobuf:"bytes,2,opt,name=status" json:"status,omitempty"`
-	KeyValues []*KeyTypeValue `protobuf:"bytes,3,rep,name=key_values,json=keyValues" json:"key_values,omitempty"`
+type BatchGetResponse struct {
+	Ok     bool   `protobuf:"varint,1,opt,name=ok" json:"ok,omitempty"`
+	Status string `protobuf:"bytes,2,opt,name=status" json:"status,omitempty"`
+	// one result for each key, in the same order as the keys.
+	// key_value is empty if the key is not found.
+	Results []*GetResponse `protobuf:"bytes,3,rep,name=results" json:"results,omitempty"`
 }
 
-func (m *GetByPrefixResponse) Reset()                    { *m = GetByPrefixResponse{} }
-func (m *GetByPrefixResponse) String() string            { return proto.CompactTextString(m) }
-func (*GetByPrefixResponse) ProtoMessage()               {}
-func (*GetByPrefixResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{22} }
+func (m *BatchGetResponse) Reset()                    { *m = BatchGetResponse{} }
+func (m *BatchGetResponse) String() string            { return proto.CompactTextString(m) }
+func (*BatchGetResponse) ProtoMessage()               {}
+func (*BatchGetResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{24} }
 
-func (m *GetByPrefixResponse) GetOk() bool {
+func (m *BatchGetResponse) GetOk() bool {
 	if m != nil {
 		return m.Ok
 	}
 	return false
 }
 
-func (m *GetByPrefixResponse) GetStatus() string {
+func (m *BatchGetResponse) GetStatus() string {
 	if m != nil {
 		return m.Status
 	}
 	return ""
 }
 
-func (m *GetByPrefixResponse) GetKeyValues() []*KeyTypeValue {
+func (m *BatchGetResponse) GetResults() []*GetResponse {
 	if m != nil {
-		return m.KeyValues
+		return m.Results
 	}
 	return nil
 }
 
-type Response struct {
-	Write       *WriteResponse       `protobuf:"bytes,1,opt,name=write" json:"write,omitempty"`
-	Get         *GetResponse         `protobuf:"bytes,2,opt,name=get" json:"get,omitempty"`
-	GetByPrefix *GetByPrefixResponse `protobuf:"bytes,3,opt,name=get_by_prefix,json=getByPrefix" json:"get_by_prefix,omitempty"`
+// BatchDeleteRequest deletes multiple keys in the same partition
+type BatchDeleteRequest struct {
+	Deletes       []*DeleteRequest `protobuf:"bytes,1,rep,name=deletes" json:"deletes,omitempty"`
+	PartitionHash uint64           `protobuf:"varint,2,opt,name=partition_hash,json=partitionHash" json:"partition_hash,omitempty"`
 }
 
-func (m *Response) Reset()                    { *m = Response{} }
-func (m *Response) String() string            { return proto.CompactTextString(m) }
-func (*Response) ProtoMessage()               {}
-func (*Response) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{23} }
+func (m *BatchDeleteRequest) Reset()                    { *m = BatchDeleteRequest{} }
+func (m *BatchDeleteRequest) String() string            { return proto.CompactTextString(m) }
+func (*BatchDeleteRequest) ProtoMessage()               {}
+func (*BatchDeleteRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{25} }
 
-func (m *Response) GetWrite() *WriteResponse {
+func (m *BatchDeleteRequest) GetDeletes() []*DeleteRequest {
 	if m != nil {
-		return m.Write
+		return m.Deletes
 	}
 	return nil
 }
 
-func (m *Response) GetGet() *GetResponse {
+func (m *BatchDeleteRequest) GetPartitionHash() uint64 {
 	if m != nil {
-		return m.Get
+		return m.PartitionHash
 	}
-	return nil
+	return 0
 }
 
-func (m *Response) GetGetByPrefix() *GetByPrefixResponse {
-	if m != nil {
-		return m.GetByPrefix
-	}
-	return nil
+type BatchDeleteResponse struct {
+	Ok     bool   `protobuf:"varint,1,opt,name=ok" json:"ok,omitempty"`
+	Status string `protobuf:"bytes,2,opt,name=status" json:"status,omitempty"`
+	// one result for each delete, in the same order as the deletes.
+	Results []*WriteResponse `protobuf:"bytes,3,rep,name=results" json:"results,omitempty"`
 }
 
-type RawKeyValue struct {
-	Key   []byte `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
-	Value []byte `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
-}
+func (m *BatchDeleteResponse) Reset()                    { *m = BatchDeleteResponse{} }
+func (m *BatchDeleteResponse) String() string            { return proto.CompactTextString(m) }
+func (*BatchDeleteResponse) ProtoMessage()               {}
+func (*BatchDeleteResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{26} }
 
-func (m *RawKeyValue) Reset()                    { *m = RawKeyValue{} }
-func (m *RawKeyValue) String() string            { return proto.CompactTextString(m) }
-func (*RawKeyValue) ProtoMessage()               {}
-func (*RawKeyValue) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{24} }
+func (m *BatchDeleteResponse) GetOk() bool {
+	if m != nil {
+		return m.Ok
+	}
+	return false
+}
 
-func (m *RawKeyValue) GetKey() []byte {
+func (m *BatchDeleteResponse) GetStatus() string {
 	if m != nil {
-		return m.Key
+		return m.Status
 	}
-	return nil
+	return ""
 }
 
-func (m *RawKeyValue) GetValue() []byte {
+func (m *BatchDeleteResponse) GetResults() []*WriteResponse {
 	if m != nil {
-		return m.Value
+		return m.Results
 	}
 	return nil
 }
 
-type LogEntry struct {
-	UpdatedAtNs uint64         `protobuf:"varint,1,opt,name=updated_at_ns,json=updatedAtNs" json:"updated_at_ns,omitempty"`
-	Put         *PutRequest    `protobuf:"bytes,2,opt,name=put" json:"put,omitempty"`
+type GetByPrefixRequest struct {
+	Prefix      []byte `protobuf:"bytes,1,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	Limit       uint32 `protobuf:"varint,2,opt,name=limit" json:"limit,omitempty"`
+	LastSeenKey []byte `protobuf:"bytes,3,opt,name=last_seen_key,json=lastSeenKey,proto3" json:"last_seen_key,omitempty"`
+}
+
+func (m *GetByPrefixRequest) Reset()                    { *m = GetByPrefixRequest{} }
+func (m *GetByPrefixRequest) String() string            { return proto.CompactTextString(m) }
+func (*GetByPrefixRequest) ProtoMessage()               {}
+func (*GetByPrefixRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{27} }
+
+func (m *GetByPrefixRequest) GetPrefix() []byte {
+	if m != nil {
+		return m.Prefix
+	}
+	return nil
+}
+
+func (m *GetByPrefixRequest) GetLimit() uint32 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+func (m *GetByPrefixRequest) GetLastSeenKey() []byte {
+	if m != nil {
+		return m.LastSeenKey
+	}
+	return nil
+}
+
+type GetByPrefixResponse struct {
+	Ok        bool            `protobuf:"varint,1,opt,name=ok" json:"ok,omitempty"`
+	Status    string          `protobuf:"bytes,2,opt,name=status" json:"status,omitempty"`
+	KeyValues []*KeyTypeValue `protobuf:"bytes,3,rep,name=key_values,json=keyValues" json:"key_values,omitempty"`
+}
+
+func (m *GetByPrefixResponse) Reset()                    { *m = GetByPrefixResponse{} }
+func (m *GetByPrefixResponse) String() string            { return proto.CompactTextString(m) }
+func (*GetByPrefixResponse) ProtoMessage()               {}
+func (*GetByPrefixResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{28} }
+
+func (m *GetByPrefixResponse) GetOk() bool {
+	if m != nil {
+		return m.Ok
+	}
+	return false
+}
+
+func (m *GetByPrefixResponse) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+func (m *GetByPrefixResponse) GetKeyValues() []*KeyTypeValue {
+	if m != nil {
+		return m.KeyValues
+	}
+	return nil
+}
+
+// DeleteByPrefixRequest deletes the entries keyed with the prefix on one shard
+type DeleteByPrefixRequest struct {
+	Prefix []byte `protobuf:"bytes,1,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	// max number of entries to delete in this request, 0 for no limit
+	Limit       uint32 `protobuf:"varint,2,opt,name=limit" json:"limit,omitempty"`
+	UpdatedAtNs uint64 `protobuf:"varint,3,opt,name=updated_at_ns,json=updatedAtNs" json:"updated_at_ns,omitempty"`
+}
+
+func (m *DeleteByPrefixRequest) Reset()                    { *m = DeleteByPrefixRequest{} }
+func (m *DeleteByPrefixRequest) String() string            { return proto.CompactTextString(m) }
+func (*DeleteByPrefixRequest) ProtoMessage()               {}
+func (*DeleteByPrefixRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{29} }
+
+func (m *DeleteByPrefixRequest) GetPrefix() []byte {
+	if m != nil {
+		return m.Prefix
+	}
+	return nil
+}
+
+func (m *DeleteByPrefixRequest) GetLimit() uint32 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+func (m *DeleteByPrefixRequest) GetUpdatedAtNs() uint64 {
+	if m != nil {
+		return m.UpdatedAtNs
+	}
+	return 0
+}
+
+type DeleteByPrefixResponse struct {
+	Ok           bool   `protobuf:"varint,1,opt,name=ok" json:"ok,omitempty"`
+	Status       string `protobuf:"bytes,2,opt,name=status" json:"status,omitempty"`
+	DeletedCount uint32 `protobuf:"varint,3,opt,name=deleted_count,json=deletedCount" json:"deleted_count,omitempty"`
+	// the limit is reached, and there may be more entries to delete
+	HasMore bool `protobuf:"varint,4,opt,name=has_more,json=hasMore" json:"has_more,omitempty"`
+}
+
+func (m *DeleteByPrefixResponse) Reset()                    { *m = DeleteByPrefixResponse{} }
+func (m *DeleteByPrefixResponse) String() string            { return proto.CompactTextString(m) }
+func (*DeleteByPrefixResponse) ProtoMessage()               {}
+func (*DeleteByPrefixResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{30} }
+
+func (m *DeleteByPrefixResponse) GetOk() bool {
+	if m != nil {
+		return m.Ok
+	}
+	return false
+}
+
+func (m *DeleteByPrefixResponse) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+func (m *DeleteByPrefixResponse) GetDeletedCount() uint32 {
+	if m != nil {
+		return m.DeletedCount
+	}
+	return 0
+}
+
+func (m *DeleteByPrefixResponse) GetHasMore() bool {
+	if m != nil {
+		return m.HasMore
+	}
+	return false
+}
+
+// PrepareDeleteRequest stages the deletes on one shard for the transaction, without applying them.
+// The coordinator prepares the deletes on all the shards, and then commits or aborts the transaction on each shard.
+// Each request is replied with a write response.
+type PrepareDeleteRequest struct {
+	TxnId   string           `protobuf:"bytes,1,opt,name=txn_id,json=txnId" json:"txn_id,omitempty"`
+	Deletes []*DeleteRequest `protobuf:"bytes,2,rep,name=deletes" json:"deletes,omitempty"`
+}
+
+func (m *PrepareDeleteRequest) Reset()                    { *m = PrepareDeleteRequest{} }
+func (m *PrepareDeleteRequest) String() string            { return proto.CompactTextString(m) }
+func (*PrepareDeleteRequest) ProtoMessage()               {}
+func (*PrepareDeleteRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{31} }
+
+func (m *PrepareDeleteRequest) GetTxnId() string {
+	if m != nil {
+		return m.TxnId
+	}
+	return ""
+}
+
+func (m *PrepareDeleteRequest) GetDeletes() []*DeleteRequest {
+	if m != nil {
+		return m.Deletes
+	}
+	return nil
+}
+
+// CommitDeleteRequest applies the deletes staged for the transaction.
+type CommitDeleteRequest struct {
+	TxnId string `protobuf:"bytes,1,opt,name=txn_id,json=txnId" json:"txn_id,omitempty"`
+}
+
+func (m *CommitDeleteRequest) Reset()                    { *m = CommitDeleteRequest{} }
+func (m *CommitDeleteRequest) String() string            { return proto.CompactTextString(m) }
+func (*CommitDeleteRequest) ProtoMessage()               {}
+func (*CommitDeleteRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{32} }
+
+func (m *CommitDeleteRequest) GetTxnId() string {
+	if m != nil {
+		return m.TxnId
+	}
+	return ""
+}
+
+// AbortDeleteRequest drops the deletes staged for the transaction.
+type AbortDeleteRequest struct {
+	TxnId string `protobuf:"bytes,1,opt,name=txn_id,json=txnId" json:"txn_id,omitempty"`
+}
+
+func (m *AbortDeleteRequest) Reset()                    { *m = AbortDeleteRequest{} }
+func (m *AbortDeleteRequest) String() string            { return proto.CompactTextString(m) }
+func (*AbortDeleteRequest) ProtoMessage()               {}
+func (*AbortDeleteRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{33} }
+
+func (m *AbortDeleteRequest) GetTxnId() string {
+	if m != nil {
+		return m.TxnId
+	}
+	return ""
+}
+
+type Response struct {
+	Write          *WriteResponse          `protobuf:"bytes,1,opt,name=write" json:"write,omitempty"`
+	Get            *GetResponse            `protobuf:"bytes,2,opt,name=get" json:"get,omitempty"`
+	GetByPrefix    *GetByPrefixResponse    `protobuf:"bytes,3,opt,name=get_by_prefix,json=getByPrefix" json:"get_by_prefix,omitempty"`
+	BatchGet       *BatchGetResponse       `protobuf:"bytes,4,opt,name=batch_get,json=batchGet" json:"batch_get,omitempty"`
+	BatchDelete    *BatchDeleteResponse    `protobuf:"bytes,5,opt,name=batch_delete,json=batchDelete" json:"batch_delete,omitempty"`
+	DeleteByPrefix *DeleteByPrefixResponse `protobuf:"bytes,6,opt,name=delete_by_prefix,json=deleteByPrefix" json:"delete_by_prefix,omitempty"`
+}
+
+func (m *Response) Reset()                    { *m = Response{} }
+func (m *Response) String() string            { return proto.CompactTextString(m) }
+func (*Response) ProtoMessage()               {}
+func (*Response) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{34} }
+
+func (m *Response) GetWrite() *WriteResponse {
+	if m != nil {
+		return m.Write
+	}
+	return nil
+}
+
+func (m *Response) GetGet() *GetResponse {
+	if m != nil {
+		return m.Get
+	}
+	return nil
+}
+
+func (m *Response) GetGetByPrefix() *GetByPrefixResponse {
+	if m != nil {
+		return m.GetByPrefix
+	}
+	return nil
+}
+
+func (m *Response) GetBatchGet() *BatchGetResponse {
+	if m != nil {
+		return m.BatchGet
+	}
+	return nil
+}
+
+func (m *Response) GetBatchDelete() *BatchDeleteResponse {
+	if m != nil {
+		return m.BatchDelete
+	}
+	return nil
+}
+
+func (m *Response) GetDeleteByPrefix() *DeleteByPrefixResponse {
+	if m != nil {
+		return m.DeleteByPrefix
+	}
+	return nil
+}
+
+type RawKeyValue struct {
+	Key   []byte `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Value []byte `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+}
+
+func (m *RawKeyValue) Reset()                    { *m = RawKeyValue{} }
+func (m *RawKeyValue) String() string            { return proto.CompactTextString(m) }
+func (*RawKeyValue) ProtoMessage()               {}
+func (*RawKeyValue) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{35} }
+
+func (m *RawKeyValue) GetKey() []byte {
+	if m != nil {
+		return m.Key
+	}
+	return nil
+}
+
+func (m *RawKeyValue) GetValue() []byte {
+	if m != nil {
+		return m.Value
+	}
+	return nil
+}
+
+type LogEntry struct {
+	UpdatedAtNs uint64         `protobuf:"varint,1,opt,name=updated_at_ns,json=updatedAtNs" json:"updated_at_ns,omitempty"`
+	Put         *PutRequest    `protobuf:"bytes,2,opt,name=put" json:"put,omitempty"`
 	Delete      *DeleteRequest `protobuf:"bytes,3,opt,name=delete" json:"delete,omitempty"`
 	Merge       *MergeRequest  `protobuf:"bytes,4,opt,name=merge" json:"merge,omitempty"`
+	// the writer's epoch, increased when a shard is promoted
+	Epoch uint64 `protobuf:"varint,5,opt,name=epoch" json:"epoch,omitempty"`
+	// crc32 of the other fields, appended as the last field when written to the binlog.
+	// 0 means not checked, e.g., written by an older version.
+	Checksum uint32 `protobuf:"fixed32,6,opt,name=checksum" json:"checksum,omitempty"`
+	// bitset of LogEntryFlag, e.g., the delete is from an expired entry.
+	// 0 for entries written by an older version, which have the flags derived from the request.
+	Flags uint32 `protobuf:"varint,7,opt,name=flags" json:"flags,omitempty"`
+	// a phase of a transaction of deletes, which the followers stage without applying.
+	// The committed deletes are logged as normal deletes.
+	DeleteIntent *DeleteIntent `protobuf:"bytes,8,opt,name=delete_intent,json=deleteIntent" json:"delete_intent,omitempty"`
 }
 
 func (m *LogEntry) Reset()                    { *m = LogEntry{} }
 func (m *LogEntry) String() string            { return proto.CompactTextString(m) }
 func (*LogEntry) ProtoMessage()               {}
-func (*LogEntry) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{25} }
+func (*LogEntry) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{36} }
 
 func (m *LogEntry) GetUpdatedAtNs() uint64 {
 	if m != nil {
@@ -1132,6 +1797,79 @@ func (m *LogEntry) GetMerge() *MergeRequest {
 	return nil
 }
 
+func (m *LogEntry) GetEpoch() uint64 {
+	if m != nil {
+		return m.Epoch
+	}
+	return 0
+}
+
+func (m *LogEntry) GetChecksum() uint32 {
+	if m != nil {
+		return m.Checksum
+	}
+	return 0
+}
+
+func (m *LogEntry) GetFlags() uint32 {
+	if m != nil {
+		return m.Flags
+	}
+	return 0
+}
+
+func (m *LogEntry) GetDeleteIntent() *DeleteIntent {
+	if m != nil {
+		return m.DeleteIntent
+	}
+	return nil
+}
+
+// DeleteIntent is logged for each phase of a transaction of deletes,
+// so that the prepared transactions can be recovered by scanning the binlog.
+type DeleteIntent struct {
+	TxnId string             `protobuf:"bytes,1,opt,name=txn_id,json=txnId" json:"txn_id,omitempty"`
+	Phase DeleteIntent_Phase `protobuf:"varint,2,opt,name=phase,enum=pb.DeleteIntent_Phase" json:"phase,omitempty"`
+	// the staged deletes, only for the prepare phase
+	Deletes []*DeleteRequest `protobuf:"bytes,3,rep,name=deletes" json:"deletes,omitempty"`
+	// when the transaction is prepared, only for the prepare phase.
+	// The transaction is aborted if not committed or aborted within the delete intent ttl.
+	PreparedAtNs uint64 `protobuf:"varint,4,opt,name=prepared_at_ns,json=preparedAtNs" json:"prepared_at_ns,omitempty"`
+}
+
+func (m *DeleteIntent) Reset()                    { *m = DeleteIntent{} }
+func (m *DeleteIntent) String() string            { return proto.CompactTextString(m) }
+func (*DeleteIntent) ProtoMessage()               {}
+func (*DeleteIntent) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{37} }
+
+func (m *DeleteIntent) GetTxnId() string {
+	if m != nil {
+		return m.TxnId
+	}
+	return ""
+}
+
+func (m *DeleteIntent) GetPhase() DeleteIntent_Phase {
+	if m != nil {
+		return m.Phase
+	}
+	return DeleteIntent_PREPARE
+}
+
+func (m *DeleteIntent) GetDeletes() []*DeleteRequest {
+	if m != nil {
+		return m.Deletes
+	}
+	return nil
+}
+
+func (m *DeleteIntent) GetPreparedAtNs() uint64 {
+	if m != nil {
+		return m.PreparedAtNs
+	}
+	return 0
+}
+
 // ////////////////////////////////////////////////
 // // data copying
 // ////////////////////////////////////////////////
@@ -1143,7 +1881,7 @@ type CopyDoneMessge struct {
 func (m *CopyDoneMessge) Reset()                    { *m = CopyDoneMessge{} }
 func (m *CopyDoneMessge) String() string            { return proto.CompactTextString(m) }
 func (*CopyDoneMessge) ProtoMessage()               {}
-func (*CopyDoneMessge) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{26} }
+func (*CopyDoneMessge) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{38} }
 
 func (m *CopyDoneMessge) GetShard() int32 {
 	if m != nil {
@@ -1172,7 +1910,7 @@ type BootstrapCopyRequest struct {
 func (m *BootstrapCopyRequest) Reset()                    { *m = BootstrapCopyRequest{} }
 func (m *BootstrapCopyRequest) String() string            { return proto.CompactTextString(m) }
 func (*BootstrapCopyRequest) ProtoMessage()               {}
-func (*BootstrapCopyRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{27} }
+func (*BootstrapCopyRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{39} }
 
 func (m *BootstrapCopyRequest) GetKeyspace() string {
 	if m != nil {
@@ -1231,7 +1969,7 @@ type BootstrapCopyResponse struct {
 func (m *BootstrapCopyResponse) Reset()                    { *m = BootstrapCopyResponse{} }
 func (m *BootstrapCopyResponse) String() string            { return proto.CompactTextString(m) }
 func (*BootstrapCopyResponse) ProtoMessage()               {}
-func (*BootstrapCopyResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{28} }
+func (*BootstrapCopyResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{40} }
 
 func (m *BootstrapCopyResponse) GetKeyValues() []*RawKeyValue {
 	if m != nil {
@@ -1259,7 +1997,7 @@ func (m *BootstrapCopyResponse_BinlogTailProgress) Reset() {
 func (m *BootstrapCopyResponse_BinlogTailProgress) String() string { return proto.CompactTextString(m) }
 func (*BootstrapCopyResponse_BinlogTailProgress) ProtoMessage()    {}
 func (*BootstrapCopyResponse_BinlogTailProgress) Descriptor() ([]byte, []int) {
-	return fileDescriptor0, []int{28, 0}
+	return fileDescriptor0, []int{40, 0}
 }
 
 func (m *BootstrapCopyResponse_BinlogTailProgress) GetSegment() uint32 {
@@ -1290,7 +2028,7 @@ type PullUpdateRequest struct {
 func (m *PullUpdateRequest) Reset()                    { *m = PullUpdateRequest{} }
 func (m *PullUpdateRequest) String() string            { return proto.CompactTextString(m) }
 func (*PullUpdateRequest) ProtoMessage()               {}
-func (*PullUpdateRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{29} }
+func (*PullUpdateRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{41} }
 
 func (m *PullUpdateRequest) GetKeyspace() string {
 	if m != nil {
@@ -1341,105 +2079,881 @@ func (m *PullUpdateRequest) GetTargetClusterSize() uint32 {
 	return 0
 }
 
-func (m *PullUpdateRequest) GetOrigin() string {
+func (m *PullUpdateRequest) GetOrigin() string {
+	if m != nil {
+		return m.Origin
+	}
+	return ""
+}
+
+type PullUpdateResponse struct {
+	NextSegment uint32      `protobuf:"varint,1,opt,name=next_segment,json=nextSegment" json:"next_segment,omitempty"`
+	NextOffset  uint64      `protobuf:"varint,2,opt,name=next_offset,json=nextOffset" json:"next_offset,omitempty"`
+	Entries     []*LogEntry `protobuf:"bytes,3,rep,name=entries" json:"entries,omitempty"`
+	OutOfSync   bool        `protobuf:"varint,4,opt,name=out_of_sync,json=outOfSync" json:"out_of_sync,omitempty"`
+}
+
+func (m *PullUpdateResponse) Reset()                    { *m = PullUpdateResponse{} }
+func (m *PullUpdateResponse) String() string            { return proto.CompactTextString(m) }
+func (*PullUpdateResponse) ProtoMessage()               {}
+func (*PullUpdateResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{42} }
+
+func (m *PullUpdateResponse) GetNextSegment() uint32 {
+	if m != nil {
+		return m.NextSegment
+	}
+	return 0
+}
+
+func (m *PullUpdateResponse) GetNextOffset() uint64 {
+	if m != nil {
+		return m.NextOffset
+	}
+	return 0
+}
+
+func (m *PullUpdateResponse) GetEntries() []*LogEntry {
+	if m != nil {
+		return m.Entries
+	}
+	return nil
+}
+
+func (m *PullUpdateResponse) GetOutOfSync() bool {
+	if m != nil {
+		return m.OutOfSync
+	}
+	return false
+}
+
+type CheckBinlogRequest struct {
+	Keyspace string `protobuf:"bytes,1,opt,name=keyspace" json:"keyspace,omitempty"`
+	ShardId  uint32 `protobuf:"varint,2,opt,name=shard_id,json=shardId" json:"shard_id,omitempty"`
+}
+
+func (m *CheckBinlogRequest) Reset()                    { *m = CheckBinlogRequest{} }
+func (m *CheckBinlogRequest) String() string            { return proto.CompactTextString(m) }
+func (*CheckBinlogRequest) ProtoMessage()               {}
+func (*CheckBinlogRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{43} }
+
+func (m *CheckBinlogRequest) GetKeyspace() string {
+	if m != nil {
+		return m.Keyspace
+	}
+	return ""
+}
+
+func (m *CheckBinlogRequest) GetShardId() uint32 {
+	if m != nil {
+		return m.ShardId
+	}
+	return 0
+}
+
+type CheckBinlogResponse struct {
+	ShardId         uint32 `protobuf:"varint,1,opt,name=shard_id,json=shardId" json:"shard_id,omitempty"`
+	EarliestSegment uint32 `protobuf:"varint,2,opt,name=earliest_segment,json=earliestSegment" json:"earliest_segment,omitempty"`
+	LatestSegment   uint32 `protobuf:"varint,3,opt,name=latest_segment,json=latestSegment" json:"latest_segment,omitempty"`
+}
+
+func (m *CheckBinlogResponse) Reset()                    { *m = CheckBinlogResponse{} }
+func (m *CheckBinlogResponse) String() string            { return proto.CompactTextString(m) }
+func (*CheckBinlogResponse) ProtoMessage()               {}
+func (*CheckBinlogResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{44} }
+
+func (m *CheckBinlogResponse) GetShardId() uint32 {
+	if m != nil {
+		return m.ShardId
+	}
+	return 0
+}
+
+func (m *CheckBinlogResponse) GetEarliestSegment() uint32 {
+	if m != nil {
+		return m.EarliestSegment
+	}
+	return 0
+}
+
+func (m *CheckBinlogResponse) GetLatestSegment() uint32 {
+	if m != nil {
+		return m.LatestSegment
+	}
+	return 0
+}
+
+type KeyHistoryRequest struct {
+	Keyspace      string `protobuf:"bytes,1,opt,name=keyspace" json:"keyspace,omitempty"`
+	ShardId       uint32 `protobuf:"varint,2,opt,name=shard_id,json=shardId" json:"shard_id,omitempty"`
+	Key           []byte `protobuf:"bytes,3,opt,name=key,proto3" json:"key,omitempty"`
+	PartitionHash uint64 `protobuf:"varint,4,opt,name=partition_hash,json=partitionHash" json:"partition_hash,omitempty"`
+	// max number of changes to return, the latest ones are kept
+	Limit uint32 `protobuf:"varint,5,opt,name=limit" json:"limit,omitempty"`
+	// only scan the latest segments
+	SegmentCount uint32 `protobuf:"varint,6,opt,name=segment_count,json=segmentCount" json:"segment_count,omitempty"`
+}
+
+func (m *KeyHistoryRequest) Reset()                    { *m = KeyHistoryRequest{} }
+func (m *KeyHistoryRequest) String() string            { return proto.CompactTextString(m) }
+func (*KeyHistoryRequest) ProtoMessage()               {}
+func (*KeyHistoryRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{45} }
+
+func (m *KeyHistoryRequest) GetKeyspace() string {
+	if m != nil {
+		return m.Keyspace
+	}
+	return ""
+}
+
+func (m *KeyHistoryRequest) GetShardId() uint32 {
+	if m != nil {
+		return m.ShardId
+	}
+	return 0
+}
+
+func (m *KeyHistoryRequest) GetKey() []byte {
+	if m != nil {
+		return m.Key
+	}
+	return nil
+}
+
+func (m *KeyHistoryRequest) GetPartitionHash() uint64 {
+	if m != nil {
+		return m.PartitionHash
+	}
+	return 0
+}
+
+func (m *KeyHistoryRequest) GetLimit() uint32 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+func (m *KeyHistoryRequest) GetSegmentCount() uint32 {
+	if m != nil {
+		return m.SegmentCount
+	}
+	return 0
+}
+
+type KeyHistoryResponse struct {
+	// ordered by the time of the changes
+	Entries []*LogEntry `protobuf:"bytes,1,rep,name=entries" json:"entries,omitempty"`
+	Error   string      `protobuf:"bytes,2,opt,name=error" json:"error,omitempty"`
+}
+
+func (m *KeyHistoryResponse) Reset()                    { *m = KeyHistoryResponse{} }
+func (m *KeyHistoryResponse) String() string            { return proto.CompactTextString(m) }
+func (*KeyHistoryResponse) ProtoMessage()               {}
+func (*KeyHistoryResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{46} }
+
+func (m *KeyHistoryResponse) GetEntries() []*LogEntry {
+	if m != nil {
+		return m.Entries
+	}
+	return nil
+}
+
+func (m *KeyHistoryResponse) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+type GetAsOfRequest struct {
+	Keyspace      string `protobuf:"bytes,1,opt,name=keyspace" json:"keyspace,omitempty"`
+	ShardId       uint32 `protobuf:"varint,2,opt,name=shard_id,json=shardId" json:"shard_id,omitempty"`
+	Key           []byte `protobuf:"bytes,3,opt,name=key,proto3" json:"key,omitempty"`
+	PartitionHash uint64 `protobuf:"varint,4,opt,name=partition_hash,json=partitionHash" json:"partition_hash,omitempty"`
+	AsOfNs        uint64 `protobuf:"varint,5,opt,name=as_of_ns,json=asOfNs" json:"as_of_ns,omitempty"`
+	// only scan the latest segments, so a time older than them is an error
+	SegmentCount uint32 `protobuf:"varint,6,opt,name=segment_count,json=segmentCount" json:"segment_count,omitempty"`
+}
+
+func (m *GetAsOfRequest) Reset()                    { *m = GetAsOfRequest{} }
+func (m *GetAsOfRequest) String() string            { return proto.CompactTextString(m) }
+func (*GetAsOfRequest) ProtoMessage()               {}
+func (*GetAsOfRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{47} }
+
+func (m *GetAsOfRequest) GetKeyspace() string {
+	if m != nil {
+		return m.Keyspace
+	}
+	return ""
+}
+
+func (m *GetAsOfRequest) GetShardId() uint32 {
+	if m != nil {
+		return m.ShardId
+	}
+	return 0
+}
+
+func (m *GetAsOfRequest) GetKey() []byte {
+	if m != nil {
+		return m.Key
+	}
+	return nil
+}
+
+func (m *GetAsOfRequest) GetPartitionHash() uint64 {
+	if m != nil {
+		return m.PartitionHash
+	}
+	return 0
+}
+
+func (m *GetAsOfRequest) GetAsOfNs() uint64 {
+	if m != nil {
+		return m.AsOfNs
+	}
+	return 0
+}
+
+func (m *GetAsOfRequest) GetSegmentCount() uint32 {
+	if m != nil {
+		return m.SegmentCount
+	}
+	return 0
+}
+
+type GetAsOfResponse struct {
+	// empty if the key did not exist at that time
+	KeyValue *KeyTypeValue `protobuf:"bytes,1,opt,name=key_value,json=keyValue" json:"key_value,omitempty"`
+	// false if the value depends on changes older than the retained binlog
+	IsComplete bool   `protobuf:"varint,2,opt,name=is_complete,json=isComplete" json:"is_complete,omitempty"`
+	Error      string `protobuf:"bytes,3,opt,name=error" json:"error,omitempty"`
+}
+
+func (m *GetAsOfResponse) Reset()                    { *m = GetAsOfResponse{} }
+func (m *GetAsOfResponse) String() string            { return proto.CompactTextString(m) }
+func (*GetAsOfResponse) ProtoMessage()               {}
+func (*GetAsOfResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{48} }
+
+func (m *GetAsOfResponse) GetKeyValue() *KeyTypeValue {
+	if m != nil {
+		return m.KeyValue
+	}
+	return nil
+}
+
+func (m *GetAsOfResponse) GetIsComplete() bool {
+	if m != nil {
+		return m.IsComplete
+	}
+	return false
+}
+
+func (m *GetAsOfResponse) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+type DiagnosticsRequest struct {
+}
+
+func (m *DiagnosticsRequest) Reset()                    { *m = DiagnosticsRequest{} }
+func (m *DiagnosticsRequest) String() string            { return proto.CompactTextString(m) }
+func (*DiagnosticsRequest) ProtoMessage()               {}
+func (*DiagnosticsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{49} }
+
+type DiagnosticsResponse struct {
+	// sorted by keyspace
+	Keyspaces []*DiagnosticsResponse_KeyspaceDiagnostics `protobuf:"bytes,1,rep,name=keyspaces" json:"keyspaces,omitempty"`
+}
+
+func (m *DiagnosticsResponse) Reset()                    { *m = DiagnosticsResponse{} }
+func (m *DiagnosticsResponse) String() string            { return proto.CompactTextString(m) }
+func (*DiagnosticsResponse) ProtoMessage()               {}
+func (*DiagnosticsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{50} }
+
+func (m *DiagnosticsResponse) GetKeyspaces() []*DiagnosticsResponse_KeyspaceDiagnostics {
+	if m != nil {
+		return m.Keyspaces
+	}
+	return nil
+}
+
+type DiagnosticsResponse_FollowProgress struct {
+	ServerAdminAddress string `protobuf:"bytes,1,opt,name=server_admin_address,json=serverAdminAddress" json:"server_admin_address,omitempty"`
+	ShardId            uint32 `protobuf:"varint,2,opt,name=shard_id,json=shardId" json:"shard_id,omitempty"`
+	NextSegment        uint32 `protobuf:"varint,3,opt,name=next_segment,json=nextSegment" json:"next_segment,omitempty"`
+	NextOffset         uint64 `protobuf:"varint,4,opt,name=next_offset,json=nextOffset" json:"next_offset,omitempty"`
+}
+
+func (m *DiagnosticsResponse_FollowProgress) Reset()         { *m = DiagnosticsResponse_FollowProgress{} }
+func (m *DiagnosticsResponse_FollowProgress) String() string { return proto.CompactTextString(m) }
+func (*DiagnosticsResponse_FollowProgress) ProtoMessage()    {}
+func (*DiagnosticsResponse_FollowProgress) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{50, 0}
+}
+
+func (m *DiagnosticsResponse_FollowProgress) GetServerAdminAddress() string {
+	if m != nil {
+		return m.ServerAdminAddress
+	}
+	return ""
+}
+
+func (m *DiagnosticsResponse_FollowProgress) GetShardId() uint32 {
+	if m != nil {
+		return m.ShardId
+	}
+	return 0
+}
+
+func (m *DiagnosticsResponse_FollowProgress) GetNextSegment() uint32 {
+	if m != nil {
+		return m.NextSegment
+	}
+	return 0
+}
+
+func (m *DiagnosticsResponse_FollowProgress) GetNextOffset() uint64 {
+	if m != nil {
+		return m.NextOffset
+	}
+	return 0
+}
+
+type DiagnosticsResponse_ShardDiagnostics struct {
+	ShardId       uint32     `protobuf:"varint,1,opt,name=shard_id,json=shardId" json:"shard_id,omitempty"`
+	ShardInfo     *ShardInfo `protobuf:"bytes,2,opt,name=shard_info,json=shardInfo" json:"shard_info,omitempty"`
+	LatestSegment uint32     `protobuf:"varint,3,opt,name=latest_segment,json=latestSegment" json:"latest_segment,omitempty"`
+	LatestOffset  uint64     `protobuf:"varint,4,opt,name=latest_offset,json=latestOffset" json:"latest_offset,omitempty"`
+	// sorted by server_admin_address and shard_id
+	FollowProgresses []*DiagnosticsResponse_FollowProgress `protobuf:"bytes,5,rep,name=follow_progresses,json=followProgresses" json:"follow_progresses,omitempty"`
+	// requests being processed, and requests rejected as shard busy
+	InFlightRequests uint32 `protobuf:"varint,6,opt,name=in_flight_requests,json=inFlightRequests" json:"in_flight_requests,omitempty"`
+	RejectedRequests uint64 `protobuf:"varint,7,opt,name=rejected_requests,json=rejectedRequests" json:"rejected_requests,omitempty"`
+	// binlog entries read with a wrong checksum
+	ChecksumFailures uint64 `protobuf:"varint,8,opt,name=checksum_failures,json=checksumFailures" json:"checksum_failures,omitempty"`
+}
+
+func (m *DiagnosticsResponse_ShardDiagnostics) Reset()         { *m = DiagnosticsResponse_ShardDiagnostics{} }
+func (m *DiagnosticsResponse_ShardDiagnostics) String() string { return proto.CompactTextString(m) }
+func (*DiagnosticsResponse_ShardDiagnostics) ProtoMessage()    {}
+func (*DiagnosticsResponse_ShardDiagnostics) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{50, 1}
+}
+
+func (m *DiagnosticsResponse_ShardDiagnostics) GetShardId() uint32 {
+	if m != nil {
+		return m.ShardId
+	}
+	return 0
+}
+
+func (m *DiagnosticsResponse_ShardDiagnostics) GetShardInfo() *ShardInfo {
+	if m != nil {
+		return m.ShardInfo
+	}
+	return nil
+}
+
+func (m *DiagnosticsResponse_ShardDiagnostics) GetLatestSegment() uint32 {
+	if m != nil {
+		return m.LatestSegment
+	}
+	return 0
+}
+
+func (m *DiagnosticsResponse_ShardDiagnostics) GetLatestOffset() uint64 {
+	if m != nil {
+		return m.LatestOffset
+	}
+	return 0
+}
+
+func (m *DiagnosticsResponse_ShardDiagnostics) GetFollowProgresses() []*DiagnosticsResponse_FollowProgress {
+	if m != nil {
+		return m.FollowProgresses
+	}
+	return nil
+}
+
+func (m *DiagnosticsResponse_ShardDiagnostics) GetInFlightRequests() uint32 {
+	if m != nil {
+		return m.InFlightRequests
+	}
+	return 0
+}
+
+func (m *DiagnosticsResponse_ShardDiagnostics) GetRejectedRequests() uint64 {
+	if m != nil {
+		return m.RejectedRequests
+	}
+	return 0
+}
+
+func (m *DiagnosticsResponse_ShardDiagnostics) GetChecksumFailures() uint64 {
+	if m != nil {
+		return m.ChecksumFailures
+	}
+	return 0
+}
+
+type DiagnosticsResponse_KeyspaceDiagnostics struct {
+	Keyspace          string `protobuf:"bytes,1,opt,name=keyspace" json:"keyspace,omitempty"`
+	ServerId          uint32 `protobuf:"varint,2,opt,name=server_id,json=serverId" json:"server_id,omitempty"`
+	ClusterSize       uint32 `protobuf:"varint,3,opt,name=cluster_size,json=clusterSize" json:"cluster_size,omitempty"`
+	ReplicationFactor uint32 `protobuf:"varint,4,opt,name=replication_factor,json=replicationFactor" json:"replication_factor,omitempty"`
+	// same fingerprint means the same cluster topology view
+	ClusterFingerprint uint64 `protobuf:"varint,5,opt,name=cluster_fingerprint,json=clusterFingerprint" json:"cluster_fingerprint,omitempty"`
+	// sorted by shard_id
+	Shards []*DiagnosticsResponse_ShardDiagnostics `protobuf:"bytes,6,rep,name=shards" json:"shards,omitempty"`
+}
+
+func (m *DiagnosticsResponse_KeyspaceDiagnostics) Reset() {
+	*m = DiagnosticsResponse_KeyspaceDiagnostics{}
+}
+func (m *DiagnosticsResponse_KeyspaceDiagnostics) String() string { return proto.CompactTextString(m) }
+func (*DiagnosticsResponse_KeyspaceDiagnostics) ProtoMessage()    {}
+func (*DiagnosticsResponse_KeyspaceDiagnostics) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{50, 2}
+}
+
+func (m *DiagnosticsResponse_KeyspaceDiagnostics) GetKeyspace() string {
+	if m != nil {
+		return m.Keyspace
+	}
+	return ""
+}
+
+func (m *DiagnosticsResponse_KeyspaceDiagnostics) GetServerId() uint32 {
+	if m != nil {
+		return m.ServerId
+	}
+	return 0
+}
+
+func (m *DiagnosticsResponse_KeyspaceDiagnostics) GetClusterSize() uint32 {
+	if m != nil {
+		return m.ClusterSize
+	}
+	return 0
+}
+
+func (m *DiagnosticsResponse_KeyspaceDiagnostics) GetReplicationFactor() uint32 {
+	if m != nil {
+		return m.ReplicationFactor
+	}
+	return 0
+}
+
+func (m *DiagnosticsResponse_KeyspaceDiagnostics) GetClusterFingerprint() uint64 {
+	if m != nil {
+		return m.ClusterFingerprint
+	}
+	return 0
+}
+
+func (m *DiagnosticsResponse_KeyspaceDiagnostics) GetShards() []*DiagnosticsResponse_ShardDiagnostics {
+	if m != nil {
+		return m.Shards
+	}
+	return nil
+}
+
+type StreamDeleteRequest struct {
+	Keyspace string           `protobuf:"bytes,1,opt,name=keyspace" json:"keyspace,omitempty"`
+	ShardId  uint32           `protobuf:"varint,2,opt,name=shard_id,json=shardId" json:"shard_id,omitempty"`
+	Deletes  []*DeleteRequest `protobuf:"bytes,3,rep,name=deletes" json:"deletes,omitempty"`
+	// increasing batch number from the client. To resume, send the batches after the reported last_sequence.
+	Sequence uint64 `protobuf:"varint,4,opt,name=sequence" json:"sequence,omitempty"`
+}
+
+func (m *StreamDeleteRequest) Reset()                    { *m = StreamDeleteRequest{} }
+func (m *StreamDeleteRequest) String() string            { return proto.CompactTextString(m) }
+func (*StreamDeleteRequest) ProtoMessage()               {}
+func (*StreamDeleteRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{51} }
+
+func (m *StreamDeleteRequest) GetKeyspace() string {
+	if m != nil {
+		return m.Keyspace
+	}
+	return ""
+}
+
+func (m *StreamDeleteRequest) GetShardId() uint32 {
+	if m != nil {
+		return m.ShardId
+	}
+	return 0
+}
+
+func (m *StreamDeleteRequest) GetDeletes() []*DeleteRequest {
+	if m != nil {
+		return m.Deletes
+	}
+	return nil
+}
+
+func (m *StreamDeleteRequest) GetSequence() uint64 {
+	if m != nil {
+		return m.Sequence
+	}
+	return 0
+}
+
+type StreamDeleteProgress struct {
+	DeletedCount     uint64  `protobuf:"varint,1,opt,name=deleted_count,json=deletedCount" json:"deleted_count,omitempty"`
+	FailedCount      uint64  `protobuf:"varint,2,opt,name=failed_count,json=failedCount" json:"failed_count,omitempty"`
+	DeletesPerSecond float64 `protobuf:"fixed64,3,opt,name=deletes_per_second,json=deletesPerSecond" json:"deletes_per_second,omitempty"`
+	// the sequence of the latest applied batch
+	LastSequence uint64 `protobuf:"varint,4,opt,name=last_sequence,json=lastSequence" json:"last_sequence,omitempty"`
+	Error        string `protobuf:"bytes,5,opt,name=error" json:"error,omitempty"`
+}
+
+func (m *StreamDeleteProgress) Reset()                    { *m = StreamDeleteProgress{} }
+func (m *StreamDeleteProgress) String() string            { return proto.CompactTextString(m) }
+func (*StreamDeleteProgress) ProtoMessage()               {}
+func (*StreamDeleteProgress) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{52} }
+
+func (m *StreamDeleteProgress) GetDeletedCount() uint64 {
+	if m != nil {
+		return m.DeletedCount
+	}
+	return 0
+}
+
+func (m *StreamDeleteProgress) GetFailedCount() uint64 {
+	if m != nil {
+		return m.FailedCount
+	}
+	return 0
+}
+
+func (m *StreamDeleteProgress) GetDeletesPerSecond() float64 {
+	if m != nil {
+		return m.DeletesPerSecond
+	}
+	return 0
+}
+
+func (m *StreamDeleteProgress) GetLastSequence() uint64 {
+	if m != nil {
+		return m.LastSequence
+	}
+	return 0
+}
+
+func (m *StreamDeleteProgress) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+type DeadLettersRequest struct {
+	Keyspace string `protobuf:"bytes,1,opt,name=keyspace" json:"keyspace,omitempty"`
+	ShardId  uint32 `protobuf:"varint,2,opt,name=shard_id,json=shardId" json:"shard_id,omitempty"`
+	// apply the dead letters again, and keep only the ones still failing
+	Reprocess bool `protobuf:"varint,3,opt,name=reprocess" json:"reprocess,omitempty"`
+}
+
+func (m *DeadLettersRequest) Reset()                    { *m = DeadLettersRequest{} }
+func (m *DeadLettersRequest) String() string            { return proto.CompactTextString(m) }
+func (*DeadLettersRequest) ProtoMessage()               {}
+func (*DeadLettersRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{53} }
+
+func (m *DeadLettersRequest) GetKeyspace() string {
+	if m != nil {
+		return m.Keyspace
+	}
+	return ""
+}
+
+func (m *DeadLettersRequest) GetShardId() uint32 {
+	if m != nil {
+		return m.ShardId
+	}
+	return 0
+}
+
+func (m *DeadLettersRequest) GetReprocess() bool {
+	if m != nil {
+		return m.Reprocess
+	}
+	return false
+}
+
+type DeadLettersResponse struct {
+	Entries          []*LogEntry `protobuf:"bytes,1,rep,name=entries" json:"entries,omitempty"`
+	ReprocessedCount uint32      `protobuf:"varint,2,opt,name=reprocessed_count,json=reprocessedCount" json:"reprocessed_count,omitempty"`
+	Error            string      `protobuf:"bytes,3,opt,name=error" json:"error,omitempty"`
+}
+
+func (m *DeadLettersResponse) Reset()                    { *m = DeadLettersResponse{} }
+func (m *DeadLettersResponse) String() string            { return proto.CompactTextString(m) }
+func (*DeadLettersResponse) ProtoMessage()               {}
+func (*DeadLettersResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{54} }
+
+func (m *DeadLettersResponse) GetEntries() []*LogEntry {
+	if m != nil {
+		return m.Entries
+	}
+	return nil
+}
+
+func (m *DeadLettersResponse) GetReprocessedCount() uint32 {
+	if m != nil {
+		return m.ReprocessedCount
+	}
+	return 0
+}
+
+func (m *DeadLettersResponse) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+// AckedRequest asks a replica of the shard whether it has consumed the binlog
+// of the shard on the server at admin_address, past the entry at segment and offset.
+type AckedRequest struct {
+	Keyspace     string `protobuf:"bytes,1,opt,name=keyspace" json:"keyspace,omitempty"`
+	ShardId      uint32 `protobuf:"varint,2,opt,name=shard_id,json=shardId" json:"shard_id,omitempty"`
+	AdminAddress string `protobuf:"bytes,3,opt,name=admin_address,json=adminAddress" json:"admin_address,omitempty"`
+	Segment      uint32 `protobuf:"varint,4,opt,name=segment" json:"segment,omitempty"`
+	Offset       uint64 `protobuf:"varint,5,opt,name=offset" json:"offset,omitempty"`
+}
+
+func (m *AckedRequest) Reset()                    { *m = AckedRequest{} }
+func (m *AckedRequest) String() string            { return proto.CompactTextString(m) }
+func (*AckedRequest) ProtoMessage()               {}
+func (*AckedRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{55} }
+
+func (m *AckedRequest) GetKeyspace() string {
+	if m != nil {
+		return m.Keyspace
+	}
+	return ""
+}
+
+func (m *AckedRequest) GetShardId() uint32 {
+	if m != nil {
+		return m.ShardId
+	}
+	return 0
+}
+
+func (m *AckedRequest) GetAdminAddress() string {
+	if m != nil {
+		return m.AdminAddress
+	}
+	return ""
+}
+
+func (m *AckedRequest) GetSegment() uint32 {
+	if m != nil {
+		return m.Segment
+	}
+	return 0
+}
+
+func (m *AckedRequest) GetOffset() uint64 {
+	if m != nil {
+		return m.Offset
+	}
+	return 0
+}
+
+type AckedResponse struct {
+	Acked bool `protobuf:"varint,1,opt,name=acked" json:"acked,omitempty"`
+	// the next binlog position to consume
+	NextSegment uint32 `protobuf:"varint,2,opt,name=next_segment,json=nextSegment" json:"next_segment,omitempty"`
+	NextOffset  uint64 `protobuf:"varint,3,opt,name=next_offset,json=nextOffset" json:"next_offset,omitempty"`
+	Error       string `protobuf:"bytes,4,opt,name=error" json:"error,omitempty"`
+}
+
+func (m *AckedResponse) Reset()                    { *m = AckedResponse{} }
+func (m *AckedResponse) String() string            { return proto.CompactTextString(m) }
+func (*AckedResponse) ProtoMessage()               {}
+func (*AckedResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{56} }
+
+func (m *AckedResponse) GetAcked() bool {
+	if m != nil {
+		return m.Acked
+	}
+	return false
+}
+
+func (m *AckedResponse) GetNextSegment() uint32 {
+	if m != nil {
+		return m.NextSegment
+	}
+	return 0
+}
+
+func (m *AckedResponse) GetNextOffset() uint64 {
+	if m != nil {
+		return m.NextOffset
+	}
+	return 0
+}
+
+func (m *AckedResponse) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+// ShardStatsRequest asks for the approximate key counts and sizes of the local shards of the keyspace,
+// or of all keyspaces if keyspace is empty.
+type ShardStatsRequest struct {
+	Keyspace string `protobuf:"bytes,1,opt,name=keyspace" json:"keyspace,omitempty"`
+	// count again with a full scan, instead of the counts adjusted on each write since the last scan
+	Rescan bool `protobuf:"varint,2,opt,name=rescan" json:"rescan,omitempty"`
+}
+
+func (m *ShardStatsRequest) Reset()                    { *m = ShardStatsRequest{} }
+func (m *ShardStatsRequest) String() string            { return proto.CompactTextString(m) }
+func (*ShardStatsRequest) ProtoMessage()               {}
+func (*ShardStatsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{57} }
+
+func (m *ShardStatsRequest) GetKeyspace() string {
+	if m != nil {
+		return m.Keyspace
+	}
+	return ""
+}
+
+func (m *ShardStatsRequest) GetRescan() bool {
+	if m != nil {
+		return m.Rescan
+	}
+	return false
+}
+
+type ShardStatsResponse struct {
+	Shards []*ShardStats `protobuf:"bytes,1,rep,name=shards" json:"shards,omitempty"`
+	Error  string        `protobuf:"bytes,2,opt,name=error" json:"error,omitempty"`
+}
+
+func (m *ShardStatsResponse) Reset()                    { *m = ShardStatsResponse{} }
+func (m *ShardStatsResponse) String() string            { return proto.CompactTextString(m) }
+func (*ShardStatsResponse) ProtoMessage()               {}
+func (*ShardStatsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{58} }
+
+func (m *ShardStatsResponse) GetShards() []*ShardStats {
+	if m != nil {
+		return m.Shards
+	}
+	return nil
+}
+
+func (m *ShardStatsResponse) GetError() string {
 	if m != nil {
-		return m.Origin
+		return m.Error
 	}
 	return ""
 }
 
-type PullUpdateResponse struct {
-	NextSegment uint32      `protobuf:"varint,1,opt,name=next_segment,json=nextSegment" json:"next_segment,omitempty"`
-	NextOffset  uint64      `protobuf:"varint,2,opt,name=next_offset,json=nextOffset" json:"next_offset,omitempty"`
-	Entries     []*LogEntry `protobuf:"bytes,3,rep,name=entries" json:"entries,omitempty"`
-	OutOfSync   bool        `protobuf:"varint,4,opt,name=out_of_sync,json=outOfSync" json:"out_of_sync,omitempty"`
+// FlushBinlogRequest flushes the binlog of the shard to the disk.
+type FlushBinlogRequest struct {
+	Keyspace string `protobuf:"bytes,1,opt,name=keyspace" json:"keyspace,omitempty"`
+	ShardId  uint32 `protobuf:"varint,2,opt,name=shard_id,json=shardId" json:"shard_id,omitempty"`
 }
 
-func (m *PullUpdateResponse) Reset()                    { *m = PullUpdateResponse{} }
-func (m *PullUpdateResponse) String() string            { return proto.CompactTextString(m) }
-func (*PullUpdateResponse) ProtoMessage()               {}
-func (*PullUpdateResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{30} }
+func (m *FlushBinlogRequest) Reset()                    { *m = FlushBinlogRequest{} }
+func (m *FlushBinlogRequest) String() string            { return proto.CompactTextString(m) }
+func (*FlushBinlogRequest) ProtoMessage()               {}
+func (*FlushBinlogRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{59} }
 
-func (m *PullUpdateResponse) GetNextSegment() uint32 {
+func (m *FlushBinlogRequest) GetKeyspace() string {
 	if m != nil {
-		return m.NextSegment
+		return m.Keyspace
+	}
+	return ""
+}
+
+func (m *FlushBinlogRequest) GetShardId() uint32 {
+	if m != nil {
+		return m.ShardId
 	}
 	return 0
 }
 
-func (m *PullUpdateResponse) GetNextOffset() uint64 {
+// FlushBinlogResponse has the binlog position the entries before which are flushed.
+type FlushBinlogResponse struct {
+	Segment uint32 `protobuf:"varint,1,opt,name=segment" json:"segment,omitempty"`
+	Offset  uint64 `protobuf:"varint,2,opt,name=offset" json:"offset,omitempty"`
+	Error   string `protobuf:"bytes,3,opt,name=error" json:"error,omitempty"`
+}
+
+func (m *FlushBinlogResponse) Reset()                    { *m = FlushBinlogResponse{} }
+func (m *FlushBinlogResponse) String() string            { return proto.CompactTextString(m) }
+func (*FlushBinlogResponse) ProtoMessage()               {}
+func (*FlushBinlogResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{60} }
+
+func (m *FlushBinlogResponse) GetSegment() uint32 {
 	if m != nil {
-		return m.NextOffset
+		return m.Segment
 	}
 	return 0
 }
 
-func (m *PullUpdateResponse) GetEntries() []*LogEntry {
+func (m *FlushBinlogResponse) GetOffset() uint64 {
 	if m != nil {
-		return m.Entries
+		return m.Offset
 	}
-	return nil
+	return 0
 }
 
-func (m *PullUpdateResponse) GetOutOfSync() bool {
+func (m *FlushBinlogResponse) GetError() string {
 	if m != nil {
-		return m.OutOfSync
+		return m.Error
 	}
-	return false
+	return ""
 }
 
-type CheckBinlogRequest struct {
+type ShardStats struct {
 	Keyspace string `protobuf:"bytes,1,opt,name=keyspace" json:"keyspace,omitempty"`
 	ShardId  uint32 `protobuf:"varint,2,opt,name=shard_id,json=shardId" json:"shard_id,omitempty"`
+	KeyCount uint64 `protobuf:"varint,3,opt,name=key_count,json=keyCount" json:"key_count,omitempty"`
+	// the bytes of the keys and values
+	ByteSize uint64 `protobuf:"varint,4,opt,name=byte_size,json=byteSize" json:"byte_size,omitempty"`
 }
 
-func (m *CheckBinlogRequest) Reset()                    { *m = CheckBinlogRequest{} }
-func (m *CheckBinlogRequest) String() string            { return proto.CompactTextString(m) }
-func (*CheckBinlogRequest) ProtoMessage()               {}
-func (*CheckBinlogRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{31} }
+func (m *ShardStats) Reset()                    { *m = ShardStats{} }
+func (m *ShardStats) String() string            { return proto.CompactTextString(m) }
+func (*ShardStats) ProtoMessage()               {}
+func (*ShardStats) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{61} }
 
-func (m *CheckBinlogRequest) GetKeyspace() string {
+func (m *ShardStats) GetKeyspace() string {
 	if m != nil {
 		return m.Keyspace
 	}
 	return ""
 }
 
-func (m *CheckBinlogRequest) GetShardId() uint32 {
-	if m != nil {
-		return m.ShardId
-	}
-	return 0
-}
-
-type CheckBinlogResponse struct {
-	ShardId         uint32 `protobuf:"varint,1,opt,name=shard_id,json=shardId" json:"shard_id,omitempty"`
-	EarliestSegment uint32 `protobuf:"varint,2,opt,name=earliest_segment,json=earliestSegment" json:"earliest_segment,omitempty"`
-	LatestSegment   uint32 `protobuf:"varint,3,opt,name=latest_segment,json=latestSegment" json:"latest_segment,omitempty"`
-}
-
-func (m *CheckBinlogResponse) Reset()                    { *m = CheckBinlogResponse{} }
-func (m *CheckBinlogResponse) String() string            { return proto.CompactTextString(m) }
-func (*CheckBinlogResponse) ProtoMessage()               {}
-func (*CheckBinlogResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{32} }
-
-func (m *CheckBinlogResponse) GetShardId() uint32 {
+func (m *ShardStats) GetShardId() uint32 {
 	if m != nil {
 		return m.ShardId
 	}
 	return 0
 }
 
-func (m *CheckBinlogResponse) GetEarliestSegment() uint32 {
+func (m *ShardStats) GetKeyCount() uint64 {
 	if m != nil {
-		return m.EarliestSegment
+		return m.KeyCount
 	}
 	return 0
 }
 
-func (m *CheckBinlogResponse) GetLatestSegment() uint32 {
+func (m *ShardStats) GetByteSize() uint64 {
 	if m != nil {
-		return m.LatestSegment
+		return m.ByteSize
 	}
 	return 0
 }
@@ -1457,7 +2971,7 @@ type DescribeRequest struct {
 func (m *DescribeRequest) Reset()                    { *m = DescribeRequest{} }
 func (m *DescribeRequest) String() string            { return proto.CompactTextString(m) }
 func (*DescribeRequest) ProtoMessage()               {}
-func (*DescribeRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{33} }
+func (*DescribeRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{62} }
 
 func (m *DescribeRequest) GetDescDataCenters() *DescribeRequest_DescDataCenters {
 	if m != nil {
@@ -1494,7 +3008,7 @@ func (m *DescribeRequest_DescDataCenters) Reset()         { *m = DescribeRequest
 func (m *DescribeRequest_DescDataCenters) String() string { return proto.CompactTextString(m) }
 func (*DescribeRequest_DescDataCenters) ProtoMessage()    {}
 func (*DescribeRequest_DescDataCenters) Descriptor() ([]byte, []int) {
-	return fileDescriptor0, []int{33, 0}
+	return fileDescriptor0, []int{62, 0}
 }
 
 type DescribeRequest_DescKeyspaces struct {
@@ -1504,7 +3018,7 @@ func (m *DescribeRequest_DescKeyspaces) Reset()         { *m = DescribeRequest_D
 func (m *DescribeRequest_DescKeyspaces) String() string { return proto.CompactTextString(m) }
 func (*DescribeRequest_DescKeyspaces) ProtoMessage()    {}
 func (*DescribeRequest_DescKeyspaces) Descriptor() ([]byte, []int) {
-	return fileDescriptor0, []int{33, 1}
+	return fileDescriptor0, []int{62, 1}
 }
 
 type DescribeRequest_DescCluster struct {
@@ -1514,7 +3028,7 @@ type DescribeRequest_DescCluster struct {
 func (m *DescribeRequest_DescCluster) Reset()                    { *m = DescribeRequest_DescCluster{} }
 func (m *DescribeRequest_DescCluster) String() string            { return proto.CompactTextString(m) }
 func (*DescribeRequest_DescCluster) ProtoMessage()               {}
-func (*DescribeRequest_DescCluster) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{33, 2} }
+func (*DescribeRequest_DescCluster) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{62, 2} }
 
 func (m *DescribeRequest_DescCluster) GetKeyspace() string {
 	if m != nil {
@@ -1529,7 +3043,7 @@ type DescribeRequest_DescClients struct {
 func (m *DescribeRequest_DescClients) Reset()                    { *m = DescribeRequest_DescClients{} }
 func (m *DescribeRequest_DescClients) String() string            { return proto.CompactTextString(m) }
 func (*DescribeRequest_DescClients) ProtoMessage()               {}
-func (*DescribeRequest_DescClients) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{33, 3} }
+func (*DescribeRequest_DescClients) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{62, 3} }
 
 type DescribeResponse struct {
 	DescDataCenter *DescribeResponse_DescDataCenter `protobuf:"bytes,1,opt,name=desc_data_center,json=descDataCenter" json:"desc_data_center,omitempty"`
@@ -1541,7 +3055,7 @@ type DescribeResponse struct {
 func (m *DescribeResponse) Reset()                    { *m = DescribeResponse{} }
 func (m *DescribeResponse) String() string            { return proto.CompactTextString(m) }
 func (*DescribeResponse) ProtoMessage()               {}
-func (*DescribeResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{34} }
+func (*DescribeResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{63} }
 
 func (m *DescribeResponse) GetDescDataCenter() *DescribeResponse_DescDataCenter {
 	if m != nil {
@@ -1579,7 +3093,7 @@ func (m *DescribeResponse_DescDataCenter) Reset()         { *m = DescribeRespons
 func (m *DescribeResponse_DescDataCenter) String() string { return proto.CompactTextString(m) }
 func (*DescribeResponse_DescDataCenter) ProtoMessage()    {}
 func (*DescribeResponse_DescDataCenter) Descriptor() ([]byte, []int) {
-	return fileDescriptor0, []int{34, 0}
+	return fileDescriptor0, []int{63, 0}
 }
 
 func (m *DescribeResponse_DescDataCenter) GetDataCenter() *DescribeResponse_DescDataCenter_DataCenter {
@@ -1601,7 +3115,7 @@ func (m *DescribeResponse_DescDataCenter_DataCenter) String() string {
 }
 func (*DescribeResponse_DescDataCenter_DataCenter) ProtoMessage() {}
 func (*DescribeResponse_DescDataCenter_DataCenter) Descriptor() ([]byte, []int) {
-	return fileDescriptor0, []int{34, 0, 0}
+	return fileDescriptor0, []int{63, 0, 0}
 }
 
 func (m *DescribeResponse_DescDataCenter_DataCenter) GetStoreResources() []*StoreResource {
@@ -1619,7 +3133,7 @@ func (m *DescribeResponse_DescKeyspaces) Reset()         { *m = DescribeResponse
 func (m *DescribeResponse_DescKeyspaces) String() string { return proto.CompactTextString(m) }
 func (*DescribeResponse_DescKeyspaces) ProtoMessage()    {}
 func (*DescribeResponse_DescKeyspaces) Descriptor() ([]byte, []int) {
-	return fileDescriptor0, []int{34, 1}
+	return fileDescriptor0, []int{63, 1}
 }
 
 func (m *DescribeResponse_DescKeyspaces) GetKeyspaces() []*DescribeResponse_DescKeyspaces_Keyspace {
@@ -1641,7 +3155,7 @@ func (m *DescribeResponse_DescKeyspaces_Keyspace) Reset() {
 func (m *DescribeResponse_DescKeyspaces_Keyspace) String() string { return proto.CompactTextString(m) }
 func (*DescribeResponse_DescKeyspaces_Keyspace) ProtoMessage()    {}
 func (*DescribeResponse_DescKeyspaces_Keyspace) Descriptor() ([]byte, []int) {
-	return fileDescriptor0, []int{34, 1, 0}
+	return fileDescriptor0, []int{63, 1, 0}
 }
 
 func (m *DescribeResponse_DescKeyspaces_Keyspace) GetKeyspace() string {
@@ -1675,7 +3189,7 @@ func (m *DescribeResponse_DescCluster) Reset()         { *m = DescribeResponse_D
 func (m *DescribeResponse_DescCluster) String() string { return proto.CompactTextString(m) }
 func (*DescribeResponse_DescCluster) ProtoMessage()    {}
 func (*DescribeResponse_DescCluster) Descriptor() ([]byte, []int) {
-	return fileDescriptor0, []int{34, 2}
+	return fileDescriptor0, []int{63, 2}
 }
 
 func (m *DescribeResponse_DescCluster) GetCluster() *Cluster {
@@ -1710,7 +3224,7 @@ type CreateClusterRequest struct {
 func (m *CreateClusterRequest) Reset()                    { *m = CreateClusterRequest{} }
 func (m *CreateClusterRequest) String() string            { return proto.CompactTextString(m) }
 func (*CreateClusterRequest) ProtoMessage()               {}
-func (*CreateClusterRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{35} }
+func (*CreateClusterRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{64} }
 
 func (m *CreateClusterRequest) GetKeyspace() string {
 	if m != nil {
@@ -1755,7 +3269,7 @@ type CreateClusterResponse struct {
 func (m *CreateClusterResponse) Reset()                    { *m = CreateClusterResponse{} }
 func (m *CreateClusterResponse) String() string            { return proto.CompactTextString(m) }
 func (*CreateClusterResponse) ProtoMessage()               {}
-func (*CreateClusterResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{36} }
+func (*CreateClusterResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{65} }
 
 func (m *CreateClusterResponse) GetError() string {
 	if m != nil {
@@ -1778,7 +3292,7 @@ type DeleteClusterRequest struct {
 func (m *DeleteClusterRequest) Reset()                    { *m = DeleteClusterRequest{} }
 func (m *DeleteClusterRequest) String() string            { return proto.CompactTextString(m) }
 func (*DeleteClusterRequest) ProtoMessage()               {}
-func (*DeleteClusterRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{37} }
+func (*DeleteClusterRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{66} }
 
 func (m *DeleteClusterRequest) GetKeyspace() string {
 	if m != nil {
@@ -1794,7 +3308,7 @@ type DeleteClusterResponse struct {
 func (m *DeleteClusterResponse) Reset()                    { *m = DeleteClusterResponse{} }
 func (m *DeleteClusterResponse) String() string            { return proto.CompactTextString(m) }
 func (*DeleteClusterResponse) ProtoMessage()               {}
-func (*DeleteClusterResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{38} }
+func (*DeleteClusterResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{67} }
 
 func (m *DeleteClusterResponse) GetError() string {
 	if m != nil {
@@ -1810,7 +3324,7 @@ type CompactClusterRequest struct {
 func (m *CompactClusterRequest) Reset()                    { *m = CompactClusterRequest{} }
 func (m *CompactClusterRequest) String() string            { return proto.CompactTextString(m) }
 func (*CompactClusterRequest) ProtoMessage()               {}
-func (*CompactClusterRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{39} }
+func (*CompactClusterRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{68} }
 
 func (m *CompactClusterRequest) GetKeyspace() string {
 	if m != nil {
@@ -1826,7 +3340,7 @@ type CompactClusterResponse struct {
 func (m *CompactClusterResponse) Reset()                    { *m = CompactClusterResponse{} }
 func (m *CompactClusterResponse) String() string            { return proto.CompactTextString(m) }
 func (*CompactClusterResponse) ProtoMessage()               {}
-func (*CompactClusterResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{40} }
+func (*CompactClusterResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{69} }
 
 func (m *CompactClusterResponse) GetError() string {
 	if m != nil {
@@ -1844,7 +3358,7 @@ type ReplaceNodeRequest struct {
 func (m *ReplaceNodeRequest) Reset()                    { *m = ReplaceNodeRequest{} }
 func (m *ReplaceNodeRequest) String() string            { return proto.CompactTextString(m) }
 func (*ReplaceNodeRequest) ProtoMessage()               {}
-func (*ReplaceNodeRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{41} }
+func (*ReplaceNodeRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{70} }
 
 func (m *ReplaceNodeRequest) GetKeyspace() string {
 	if m != nil {
@@ -1874,7 +3388,7 @@ type ReplaceNodeResponse struct {
 func (m *ReplaceNodeResponse) Reset()                    { *m = ReplaceNodeResponse{} }
 func (m *ReplaceNodeResponse) String() string            { return proto.CompactTextString(m) }
 func (*ReplaceNodeResponse) ProtoMessage()               {}
-func (*ReplaceNodeResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{42} }
+func (*ReplaceNodeResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{71} }
 
 func (m *ReplaceNodeResponse) GetError() string {
 	if m != nil {
@@ -1895,7 +3409,7 @@ type CreateShardRequest struct {
 func (m *CreateShardRequest) Reset()                    { *m = CreateShardRequest{} }
 func (m *CreateShardRequest) String() string            { return proto.CompactTextString(m) }
 func (*CreateShardRequest) ProtoMessage()               {}
-func (*CreateShardRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{43} }
+func (*CreateShardRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{72} }
 
 func (m *CreateShardRequest) GetKeyspace() string {
 	if m != nil {
@@ -1939,7 +3453,7 @@ type CreateShardResponse struct {
 func (m *CreateShardResponse) Reset()                    { *m = CreateShardResponse{} }
 func (m *CreateShardResponse) String() string            { return proto.CompactTextString(m) }
 func (*CreateShardResponse) ProtoMessage()               {}
-func (*CreateShardResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{44} }
+func (*CreateShardResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{73} }
 
 func (m *CreateShardResponse) GetError() string {
 	if m != nil {
@@ -1955,7 +3469,7 @@ type DeleteKeyspaceRequest struct {
 func (m *DeleteKeyspaceRequest) Reset()                    { *m = DeleteKeyspaceRequest{} }
 func (m *DeleteKeyspaceRequest) String() string            { return proto.CompactTextString(m) }
 func (*DeleteKeyspaceRequest) ProtoMessage()               {}
-func (*DeleteKeyspaceRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{45} }
+func (*DeleteKeyspaceRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{74} }
 
 func (m *DeleteKeyspaceRequest) GetKeyspace() string {
 	if m != nil {
@@ -1971,7 +3485,7 @@ type DeleteKeyspaceResponse struct {
 func (m *DeleteKeyspaceResponse) Reset()                    { *m = DeleteKeyspaceResponse{} }
 func (m *DeleteKeyspaceResponse) String() string            { return proto.CompactTextString(m) }
 func (*DeleteKeyspaceResponse) ProtoMessage()               {}
-func (*DeleteKeyspaceResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{46} }
+func (*DeleteKeyspaceResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{75} }
 
 func (m *DeleteKeyspaceResponse) GetError() string {
 	if m != nil {
@@ -1987,7 +3501,7 @@ type CompactKeyspaceRequest struct {
 func (m *CompactKeyspaceRequest) Reset()                    { *m = CompactKeyspaceRequest{} }
 func (m *CompactKeyspaceRequest) String() string            { return proto.CompactTextString(m) }
 func (*CompactKeyspaceRequest) ProtoMessage()               {}
-func (*CompactKeyspaceRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{47} }
+func (*CompactKeyspaceRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{76} }
 
 func (m *CompactKeyspaceRequest) GetKeyspace() string {
 	if m != nil {
@@ -2003,7 +3517,7 @@ type CompactKeyspaceResponse struct {
 func (m *CompactKeyspaceResponse) Reset()                    { *m = CompactKeyspaceResponse{} }
 func (m *CompactKeyspaceResponse) String() string            { return proto.CompactTextString(m) }
 func (*CompactKeyspaceResponse) ProtoMessage()               {}
-func (*CompactKeyspaceResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{48} }
+func (*CompactKeyspaceResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{77} }
 
 func (m *CompactKeyspaceResponse) GetError() string {
 	if m != nil {
@@ -2022,7 +3536,7 @@ type ReplicateNodePrepareRequest struct {
 func (m *ReplicateNodePrepareRequest) Reset()                    { *m = ReplicateNodePrepareRequest{} }
 func (m *ReplicateNodePrepareRequest) String() string            { return proto.CompactTextString(m) }
 func (*ReplicateNodePrepareRequest) ProtoMessage()               {}
-func (*ReplicateNodePrepareRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{49} }
+func (*ReplicateNodePrepareRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{78} }
 
 func (m *ReplicateNodePrepareRequest) GetKeyspace() string {
 	if m != nil {
@@ -2059,7 +3573,7 @@ type ReplicateNodePrepareResponse struct {
 func (m *ReplicateNodePrepareResponse) Reset()                    { *m = ReplicateNodePrepareResponse{} }
 func (m *ReplicateNodePrepareResponse) String() string            { return proto.CompactTextString(m) }
 func (*ReplicateNodePrepareResponse) ProtoMessage()               {}
-func (*ReplicateNodePrepareResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{50} }
+func (*ReplicateNodePrepareResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{79} }
 
 func (m *ReplicateNodePrepareResponse) GetError() string {
 	if m != nil {
@@ -2075,7 +3589,7 @@ type ReplicateNodeCommitRequest struct {
 func (m *ReplicateNodeCommitRequest) Reset()                    { *m = ReplicateNodeCommitRequest{} }
 func (m *ReplicateNodeCommitRequest) String() string            { return proto.CompactTextString(m) }
 func (*ReplicateNodeCommitRequest) ProtoMessage()               {}
-func (*ReplicateNodeCommitRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{51} }
+func (*ReplicateNodeCommitRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{80} }
 
 func (m *ReplicateNodeCommitRequest) GetKeyspace() string {
 	if m != nil {
@@ -2091,7 +3605,7 @@ type ReplicateNodeCommitResponse struct {
 func (m *ReplicateNodeCommitResponse) Reset()                    { *m = ReplicateNodeCommitResponse{} }
 func (m *ReplicateNodeCommitResponse) String() string            { return proto.CompactTextString(m) }
 func (*ReplicateNodeCommitResponse) ProtoMessage()               {}
-func (*ReplicateNodeCommitResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{52} }
+func (*ReplicateNodeCommitResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{81} }
 
 func (m *ReplicateNodeCommitResponse) GetError() string {
 	if m != nil {
@@ -2107,7 +3621,7 @@ type ReplicateNodeCleanupRequest struct {
 func (m *ReplicateNodeCleanupRequest) Reset()                    { *m = ReplicateNodeCleanupRequest{} }
 func (m *ReplicateNodeCleanupRequest) String() string            { return proto.CompactTextString(m) }
 func (*ReplicateNodeCleanupRequest) ProtoMessage()               {}
-func (*ReplicateNodeCleanupRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{53} }
+func (*ReplicateNodeCleanupRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{82} }
 
 func (m *ReplicateNodeCleanupRequest) GetKeyspace() string {
 	if m != nil {
@@ -2123,7 +3637,7 @@ type ReplicateNodeCleanupResponse struct {
 func (m *ReplicateNodeCleanupResponse) Reset()                    { *m = ReplicateNodeCleanupResponse{} }
 func (m *ReplicateNodeCleanupResponse) String() string            { return proto.CompactTextString(m) }
 func (*ReplicateNodeCleanupResponse) ProtoMessage()               {}
-func (*ReplicateNodeCleanupResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{54} }
+func (*ReplicateNodeCleanupResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{83} }
 
 func (m *ReplicateNodeCleanupResponse) GetError() string {
 	if m != nil {
@@ -2143,7 +3657,7 @@ type ResizeCreateShardRequest struct {
 func (m *ResizeCreateShardRequest) Reset()                    { *m = ResizeCreateShardRequest{} }
 func (m *ResizeCreateShardRequest) String() string            { return proto.CompactTextString(m) }
 func (*ResizeCreateShardRequest) ProtoMessage()               {}
-func (*ResizeCreateShardRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{55} }
+func (*ResizeCreateShardRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{84} }
 
 func (m *ResizeCreateShardRequest) GetKeyspace() string {
 	if m != nil {
@@ -2187,7 +3701,7 @@ type ResizeCreateShardResponse struct {
 func (m *ResizeCreateShardResponse) Reset()                    { *m = ResizeCreateShardResponse{} }
 func (m *ResizeCreateShardResponse) String() string            { return proto.CompactTextString(m) }
 func (*ResizeCreateShardResponse) ProtoMessage()               {}
-func (*ResizeCreateShardResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{56} }
+func (*ResizeCreateShardResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{85} }
 
 func (m *ResizeCreateShardResponse) GetError() string {
 	if m != nil {
@@ -2204,7 +3718,7 @@ type ResizeCommitRequest struct {
 func (m *ResizeCommitRequest) Reset()                    { *m = ResizeCommitRequest{} }
 func (m *ResizeCommitRequest) String() string            { return proto.CompactTextString(m) }
 func (*ResizeCommitRequest) ProtoMessage()               {}
-func (*ResizeCommitRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{57} }
+func (*ResizeCommitRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{86} }
 
 func (m *ResizeCommitRequest) GetKeyspace() string {
 	if m != nil {
@@ -2227,7 +3741,7 @@ type ResizeCommitResponse struct {
 func (m *ResizeCommitResponse) Reset()                    { *m = ResizeCommitResponse{} }
 func (m *ResizeCommitResponse) String() string            { return proto.CompactTextString(m) }
 func (*ResizeCommitResponse) ProtoMessage()               {}
-func (*ResizeCommitResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{58} }
+func (*ResizeCommitResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{87} }
 
 func (m *ResizeCommitResponse) GetError() string {
 	if m != nil {
@@ -2244,7 +3758,7 @@ type ResizeCleanupRequest struct {
 func (m *ResizeCleanupRequest) Reset()                    { *m = ResizeCleanupRequest{} }
 func (m *ResizeCleanupRequest) String() string            { return proto.CompactTextString(m) }
 func (*ResizeCleanupRequest) ProtoMessage()               {}
-func (*ResizeCleanupRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{59} }
+func (*ResizeCleanupRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{88} }
 
 func (m *ResizeCleanupRequest) GetKeyspace() string {
 	if m != nil {
@@ -2267,7 +3781,7 @@ type ResizeCleanupResponse struct {
 func (m *ResizeCleanupResponse) Reset()                    { *m = ResizeCleanupResponse{} }
 func (m *ResizeCleanupResponse) String() string            { return proto.CompactTextString(m) }
 func (*ResizeCleanupResponse) ProtoMessage()               {}
-func (*ResizeCleanupResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{60} }
+func (*ResizeCleanupResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{89} }
 
 func (m *ResizeCleanupResponse) GetError() string {
 	if m != nil {
@@ -2284,7 +3798,7 @@ type ResizeRequest struct {
 func (m *ResizeRequest) Reset()                    { *m = ResizeRequest{} }
 func (m *ResizeRequest) String() string            { return proto.CompactTextString(m) }
 func (*ResizeRequest) ProtoMessage()               {}
-func (*ResizeRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{61} }
+func (*ResizeRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{90} }
 
 func (m *ResizeRequest) GetKeyspace() string {
 	if m != nil {
@@ -2307,7 +3821,7 @@ type ResizeResponse struct {
 func (m *ResizeResponse) Reset()                    { *m = ResizeResponse{} }
 func (m *ResizeResponse) String() string            { return proto.CompactTextString(m) }
 func (*ResizeResponse) ProtoMessage()               {}
-func (*ResizeResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{62} }
+func (*ResizeResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{91} }
 
 func (m *ResizeResponse) GetError() string {
 	if m != nil {
@@ -2332,6 +3846,8 @@ func init() {
 	proto.RegisterType((*ShardInfo)(nil), "pb.ShardInfo")
 	proto.RegisterType((*Empty)(nil), "pb.Empty")
 	proto.RegisterType((*KeyTypeValue)(nil), "pb.KeyTypeValue")
+	proto.RegisterType((*VersionVector)(nil), "pb.VersionVector")
+	proto.RegisterType((*VersionClock)(nil), "pb.VersionClock")
 	proto.RegisterType((*Requests)(nil), "pb.Requests")
 	proto.RegisterType((*Responses)(nil), "pb.Responses")
 	proto.RegisterType((*Request)(nil), "pb.Request")
@@ -2341,11 +3857,21 @@ func init() {
 	proto.RegisterType((*DeleteRequest)(nil), "pb.DeleteRequest")
 	proto.RegisterType((*GetRequest)(nil), "pb.GetRequest")
 	proto.RegisterType((*GetResponse)(nil), "pb.GetResponse")
+	proto.RegisterType((*BatchGetRequest)(nil), "pb.BatchGetRequest")
+	proto.RegisterType((*BatchGetResponse)(nil), "pb.BatchGetResponse")
+	proto.RegisterType((*BatchDeleteRequest)(nil), "pb.BatchDeleteRequest")
+	proto.RegisterType((*BatchDeleteResponse)(nil), "pb.BatchDeleteResponse")
 	proto.RegisterType((*GetByPrefixRequest)(nil), "pb.GetByPrefixRequest")
 	proto.RegisterType((*GetByPrefixResponse)(nil), "pb.GetByPrefixResponse")
+	proto.RegisterType((*DeleteByPrefixRequest)(nil), "pb.DeleteByPrefixRequest")
+	proto.RegisterType((*DeleteByPrefixResponse)(nil), "pb.DeleteByPrefixResponse")
+	proto.RegisterType((*PrepareDeleteRequest)(nil), "pb.PrepareDeleteRequest")
+	proto.RegisterType((*CommitDeleteRequest)(nil), "pb.CommitDeleteRequest")
+	proto.RegisterType((*AbortDeleteRequest)(nil), "pb.AbortDeleteRequest")
 	proto.RegisterType((*Response)(nil), "pb.Response")
 	proto.RegisterType((*RawKeyValue)(nil), "pb.RawKeyValue")
 	proto.RegisterType((*LogEntry)(nil), "pb.LogEntry")
+	proto.RegisterType((*DeleteIntent)(nil), "pb.DeleteIntent")
 	proto.RegisterType((*CopyDoneMessge)(nil), "pb.CopyDoneMessge")
 	proto.RegisterType((*BootstrapCopyRequest)(nil), "pb.BootstrapCopyRequest")
 	proto.RegisterType((*BootstrapCopyResponse)(nil), "pb.BootstrapCopyResponse")
@@ -2354,6 +3880,26 @@ func init() {
 	proto.RegisterType((*PullUpdateResponse)(nil), "pb.PullUpdateResponse")
 	proto.RegisterType((*CheckBinlogRequest)(nil), "pb.CheckBinlogRequest")
 	proto.RegisterType((*CheckBinlogResponse)(nil), "pb.CheckBinlogResponse")
+	proto.RegisterType((*KeyHistoryRequest)(nil), "pb.KeyHistoryRequest")
+	proto.RegisterType((*KeyHistoryResponse)(nil), "pb.KeyHistoryResponse")
+	proto.RegisterType((*GetAsOfRequest)(nil), "pb.GetAsOfRequest")
+	proto.RegisterType((*GetAsOfResponse)(nil), "pb.GetAsOfResponse")
+	proto.RegisterType((*DiagnosticsRequest)(nil), "pb.DiagnosticsRequest")
+	proto.RegisterType((*DiagnosticsResponse)(nil), "pb.DiagnosticsResponse")
+	proto.RegisterType((*DiagnosticsResponse_FollowProgress)(nil), "pb.DiagnosticsResponse.FollowProgress")
+	proto.RegisterType((*DiagnosticsResponse_ShardDiagnostics)(nil), "pb.DiagnosticsResponse.ShardDiagnostics")
+	proto.RegisterType((*DiagnosticsResponse_KeyspaceDiagnostics)(nil), "pb.DiagnosticsResponse.KeyspaceDiagnostics")
+	proto.RegisterType((*StreamDeleteRequest)(nil), "pb.StreamDeleteRequest")
+	proto.RegisterType((*StreamDeleteProgress)(nil), "pb.StreamDeleteProgress")
+	proto.RegisterType((*DeadLettersRequest)(nil), "pb.DeadLettersRequest")
+	proto.RegisterType((*DeadLettersResponse)(nil), "pb.DeadLettersResponse")
+	proto.RegisterType((*AckedRequest)(nil), "pb.AckedRequest")
+	proto.RegisterType((*AckedResponse)(nil), "pb.AckedResponse")
+	proto.RegisterType((*ShardStatsRequest)(nil), "pb.ShardStatsRequest")
+	proto.RegisterType((*ShardStatsResponse)(nil), "pb.ShardStatsResponse")
+	proto.RegisterType((*FlushBinlogRequest)(nil), "pb.FlushBinlogRequest")
+	proto.RegisterType((*FlushBinlogResponse)(nil), "pb.FlushBinlogResponse")
+	proto.RegisterType((*ShardStats)(nil), "pb.ShardStats")
 	proto.RegisterType((*DescribeRequest)(nil), "pb.DescribeRequest")
 	proto.RegisterType((*DescribeRequest_DescDataCenters)(nil), "pb.DescribeRequest.DescDataCenters")
 	proto.RegisterType((*DescribeRequest_DescKeyspaces)(nil), "pb.DescribeRequest.DescKeyspaces")
@@ -2395,6 +3941,7 @@ func init() {
 	proto.RegisterType((*ResizeResponse)(nil), "pb.ResizeResponse")
 	proto.RegisterEnum("pb.OpAndDataType", OpAndDataType_name, OpAndDataType_value)
 	proto.RegisterEnum("pb.ShardInfo_Status", ShardInfo_Status_name, ShardInfo_Status_value)
+	proto.RegisterEnum("pb.DeleteIntent_Phase", DeleteIntent_Phase_name, DeleteIntent_Phase_value)
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -2804,6 +4351,8 @@ type VastoStoreClient interface {
 	BootstrapCopy(ctx context.Context, in *BootstrapCopyRequest, opts ...grpc.CallOption) (VastoStore_BootstrapCopyClient, error)
 	TailBinlog(ctx context.Context, in *PullUpdateRequest, opts ...grpc.CallOption) (VastoStore_TailBinlogClient, error)
 	CheckBinlog(ctx context.Context, in *CheckBinlogRequest, opts ...grpc.CallOption) (*CheckBinlogResponse, error)
+	KeyHistory(ctx context.Context, in *KeyHistoryRequest, opts ...grpc.CallOption) (*KeyHistoryResponse, error)
+	GetAsOf(ctx context.Context, in *GetAsOfRequest, opts ...grpc.CallOption) (*GetAsOfResponse, error)
 	CreateShard(ctx context.Context, in *CreateShardRequest, opts ...grpc.CallOption) (*CreateShardResponse, error)
 	DeleteKeyspace(ctx context.Context, in *DeleteKeyspaceRequest, opts ...grpc.CallOption) (*DeleteKeyspaceResponse, error)
 	CompactKeyspace(ctx context.Context, in *CompactKeyspaceRequest, opts ...grpc.CallOption) (*CompactKeyspaceResponse, error)
@@ -2814,6 +4363,12 @@ type VastoStoreClient interface {
 	ResizeCommit(ctx context.Context, in *ResizeCommitRequest, opts ...grpc.CallOption) (*ResizeCommitResponse, error)
 	ResizeCleanup(ctx context.Context, in *ResizeCleanupRequest, opts ...grpc.CallOption) (*ResizeCleanupResponse, error)
 	DebugStore(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Empty, error)
+	Diagnostics(ctx context.Context, in *DiagnosticsRequest, opts ...grpc.CallOption) (*DiagnosticsResponse, error)
+	DeadLetters(ctx context.Context, in *DeadLettersRequest, opts ...grpc.CallOption) (*DeadLettersResponse, error)
+	StreamDelete(ctx context.Context, opts ...grpc.CallOption) (VastoStore_StreamDeleteClient, error)
+	Acked(ctx context.Context, in *AckedRequest, opts ...grpc.CallOption) (*AckedResponse, error)
+	GetShardStats(ctx context.Context, in *ShardStatsRequest, opts ...grpc.CallOption) (*ShardStatsResponse, error)
+	FlushBinlog(ctx context.Context, in *FlushBinlogRequest, opts ...grpc.CallOption) (*FlushBinlogResponse, error)
 }
 
 type vastoStoreClient struct {
@@ -2897,6 +4452,24 @@ func (c *vastoStoreClient) CheckBinlog(ctx context.Context, in *CheckBinlogReque
 	return out, nil
 }
 
+func (c *vastoStoreClient) KeyHistory(ctx context.Context, in *KeyHistoryRequest, opts ...grpc.CallOption) (*KeyHistoryResponse, error) {
+	out := new(KeyHistoryResponse)
+	err := grpc.Invoke(ctx, "/pb.VastoStore/KeyHistory", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vastoStoreClient) GetAsOf(ctx context.Context, in *GetAsOfRequest, opts ...grpc.CallOption) (*GetAsOfResponse, error) {
+	out := new(GetAsOfResponse)
+	err := grpc.Invoke(ctx, "/pb.VastoStore/GetAsOf", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *vastoStoreClient) CreateShard(ctx context.Context, in *CreateShardRequest, opts ...grpc.CallOption) (*CreateShardResponse, error) {
 	out := new(CreateShardResponse)
 	err := grpc.Invoke(ctx, "/pb.VastoStore/CreateShard", in, out, c.cc, opts...)
@@ -2987,12 +4560,90 @@ func (c *vastoStoreClient) DebugStore(ctx context.Context, in *Empty, opts ...gr
 	return out, nil
 }
 
+func (c *vastoStoreClient) Diagnostics(ctx context.Context, in *DiagnosticsRequest, opts ...grpc.CallOption) (*DiagnosticsResponse, error) {
+	out := new(DiagnosticsResponse)
+	err := grpc.Invoke(ctx, "/pb.VastoStore/Diagnostics", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vastoStoreClient) DeadLetters(ctx context.Context, in *DeadLettersRequest, opts ...grpc.CallOption) (*DeadLettersResponse, error) {
+	out := new(DeadLettersResponse)
+	err := grpc.Invoke(ctx, "/pb.VastoStore/DeadLetters", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vastoStoreClient) StreamDelete(ctx context.Context, opts ...grpc.CallOption) (VastoStore_StreamDeleteClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_VastoStore_serviceDesc.Streams[2], c.cc, "/pb.VastoStore/StreamDelete", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &vastoStoreStreamDeleteClient{stream}
+	return x, nil
+}
+
+type VastoStore_StreamDeleteClient interface {
+	Send(*StreamDeleteRequest) error
+	Recv() (*StreamDeleteProgress, error)
+	grpc.ClientStream
+}
+
+type vastoStoreStreamDeleteClient struct {
+	grpc.ClientStream
+}
+
+func (x *vastoStoreStreamDeleteClient) Send(m *StreamDeleteRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *vastoStoreStreamDeleteClient) Recv() (*StreamDeleteProgress, error) {
+	m := new(StreamDeleteProgress)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *vastoStoreClient) Acked(ctx context.Context, in *AckedRequest, opts ...grpc.CallOption) (*AckedResponse, error) {
+	out := new(AckedResponse)
+	err := grpc.Invoke(ctx, "/pb.VastoStore/Acked", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vastoStoreClient) GetShardStats(ctx context.Context, in *ShardStatsRequest, opts ...grpc.CallOption) (*ShardStatsResponse, error) {
+	out := new(ShardStatsResponse)
+	err := grpc.Invoke(ctx, "/pb.VastoStore/GetShardStats", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vastoStoreClient) FlushBinlog(ctx context.Context, in *FlushBinlogRequest, opts ...grpc.CallOption) (*FlushBinlogResponse, error) {
+	out := new(FlushBinlogResponse)
+	err := grpc.Invoke(ctx, "/pb.VastoStore/FlushBinlog", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // Server API for VastoStore service
 
 type VastoStoreServer interface {
 	BootstrapCopy(*BootstrapCopyRequest, VastoStore_BootstrapCopyServer) error
 	TailBinlog(*PullUpdateRequest, VastoStore_TailBinlogServer) error
 	CheckBinlog(context.Context, *CheckBinlogRequest) (*CheckBinlogResponse, error)
+	KeyHistory(context.Context, *KeyHistoryRequest) (*KeyHistoryResponse, error)
+	GetAsOf(context.Context, *GetAsOfRequest) (*GetAsOfResponse, error)
 	CreateShard(context.Context, *CreateShardRequest) (*CreateShardResponse, error)
 	DeleteKeyspace(context.Context, *DeleteKeyspaceRequest) (*DeleteKeyspaceResponse, error)
 	CompactKeyspace(context.Context, *CompactKeyspaceRequest) (*CompactKeyspaceResponse, error)
@@ -3003,6 +4654,12 @@ type VastoStoreServer interface {
 	ResizeCommit(context.Context, *ResizeCommitRequest) (*ResizeCommitResponse, error)
 	ResizeCleanup(context.Context, *ResizeCleanupRequest) (*ResizeCleanupResponse, error)
 	DebugStore(context.Context, *Empty) (*Empty, error)
+	Diagnostics(context.Context, *DiagnosticsRequest) (*DiagnosticsResponse, error)
+	DeadLetters(context.Context, *DeadLettersRequest) (*DeadLettersResponse, error)
+	StreamDelete(VastoStore_StreamDeleteServer) error
+	Acked(context.Context, *AckedRequest) (*AckedResponse, error)
+	GetShardStats(context.Context, *ShardStatsRequest) (*ShardStatsResponse, error)
+	FlushBinlog(context.Context, *FlushBinlogRequest) (*FlushBinlogResponse, error)
 }
 
 func RegisterVastoStoreServer(s *grpc.Server, srv VastoStoreServer) {
@@ -3069,6 +4726,42 @@ func _VastoStore_CheckBinlog_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _VastoStore_KeyHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(KeyHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VastoStoreServer).KeyHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.VastoStore/KeyHistory",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VastoStoreServer).KeyHistory(ctx, req.(*KeyHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VastoStore_GetAsOf_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAsOfRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VastoStoreServer).GetAsOf(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.VastoStore/GetAsOf",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VastoStoreServer).GetAsOf(ctx, req.(*GetAsOfRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _VastoStore_CreateShard_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(CreateShardRequest)
 	if err := dec(in); err != nil {
@@ -3249,6 +4942,122 @@ func _VastoStore_DebugStore_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _VastoStore_Diagnostics_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DiagnosticsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VastoStoreServer).Diagnostics(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.VastoStore/Diagnostics",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VastoStoreServer).Diagnostics(ctx, req.(*DiagnosticsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VastoStore_DeadLetters_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeadLettersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VastoStoreServer).DeadLetters(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.VastoStore/DeadLetters",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VastoStoreServer).DeadLetters(ctx, req.(*DeadLettersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VastoStore_StreamDelete_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(VastoStoreServer).StreamDelete(&vastoStoreStreamDeleteServer{stream})
+}
+
+type VastoStore_StreamDeleteServer interface {
+	Send(*StreamDeleteProgress) error
+	Recv() (*StreamDeleteRequest, error)
+	grpc.ServerStream
+}
+
+type vastoStoreStreamDeleteServer struct {
+	grpc.ServerStream
+}
+
+func (x *vastoStoreStreamDeleteServer) Send(m *StreamDeleteProgress) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *vastoStoreStreamDeleteServer) Recv() (*StreamDeleteRequest, error) {
+	m := new(StreamDeleteRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _VastoStore_Acked_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AckedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VastoStoreServer).Acked(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.VastoStore/Acked",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VastoStoreServer).Acked(ctx, req.(*AckedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VastoStore_GetShardStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ShardStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VastoStoreServer).GetShardStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.VastoStore/GetShardStats",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VastoStoreServer).GetShardStats(ctx, req.(*ShardStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VastoStore_FlushBinlog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FlushBinlogRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VastoStoreServer).FlushBinlog(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.VastoStore/FlushBinlog",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VastoStoreServer).FlushBinlog(ctx, req.(*FlushBinlogRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _VastoStore_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "pb.VastoStore",
 	HandlerType: (*VastoStoreServer)(nil),
@@ -3257,6 +5066,14 @@ var _VastoStore_serviceDesc = grpc.ServiceDesc{
 			MethodName: "CheckBinlog",
 			Handler:    _VastoStore_CheckBinlog_Handler,
 		},
+		{
+			MethodName: "KeyHistory",
+			Handler:    _VastoStore_KeyHistory_Handler,
+		},
+		{
+			MethodName: "GetAsOf",
+			Handler:    _VastoStore_GetAsOf_Handler,
+		},
 		{
 			MethodName: "CreateShard",
 			Handler:    _VastoStore_CreateShard_Handler,
@@ -3297,6 +5114,26 @@ var _VastoStore_serviceDesc = grpc.ServiceDesc{
 			MethodName: "DebugStore",
 			Handler:    _VastoStore_DebugStore_Handler,
 		},
+		{
+			MethodName: "Diagnostics",
+			Handler:    _VastoStore_Diagnostics_Handler,
+		},
+		{
+			MethodName: "DeadLetters",
+			Handler:    _VastoStore_DeadLetters_Handler,
+		},
+		{
+			MethodName: "Acked",
+			Handler:    _VastoStore_Acked_Handler,
+		},
+		{
+			MethodName: "GetShardStats",
+			Handler:    _VastoStore_GetShardStats_Handler,
+		},
+		{
+			MethodName: "FlushBinlog",
+			Handler:    _VastoStore_FlushBinlog_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -3309,6 +5146,12 @@ var _VastoStore_serviceDesc = grpc.ServiceDesc{
 			Handler:       _VastoStore_TailBinlog_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "StreamDelete",
+			Handler:       _VastoStore_StreamDelete_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
 	},
 	Metadata: "vasto.proto",
 }
@@ -3316,191 +5159,292 @@ var _VastoStore_serviceDesc = grpc.ServiceDesc{
 func init() { proto.RegisterFile("vasto.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 2970 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0xcc, 0x5a, 0x4b, 0x73, 0x1b, 0xc7,
-	0xf1, 0xe7, 0xe2, 0x41, 0x00, 0xbd, 0x04, 0x40, 0x0e, 0x49, 0x89, 0x5a, 0xd9, 0x16, 0xb5, 0xfe,
-	0x4b, 0xa6, 0x2d, 0x09, 0xd6, 0x9f, 0x76, 0x62, 0x45, 0xae, 0x8a, 0x4d, 0x82, 0x94, 0xc5, 0x48,
-	0x14, 0x59, 0x0b, 0xda, 0xb1, 0xcb, 0xa9, 0xda, 0x5a, 0x62, 0x87, 0xd0, 0x86, 0xc0, 0xee, 0x66,
-	0x67, 0x60, 0x1a, 0x39, 0xfa, 0x90, 0x54, 0x0e, 0xb9, 0x24, 0x97, 0x5c, 0x52, 0x95, 0x4a, 0x2e,
-	0xa9, 0xca, 0x67, 0xc8, 0x21, 0x87, 0x5c, 0xf2, 0xba, 0xa5, 0x2a, 0x95, 0x5b, 0x3e, 0x40, 0xae,
-	0xf1, 0x35, 0x35, 0xaf, 0x7d, 0x00, 0x0b, 0x90, 0xb4, 0xe3, 0x2a, 0xdf, 0x30, 0xdd, 0x3d, 0x3d,
-	0xfd, 0xf8, 0x4d, 0x4f, 0xcf, 0x60, 0x41, 0xff, 0xc4, 0x21, 0x34, 0x68, 0x85, 0x51, 0x40, 0x03,
-	0x54, 0x08, 0x8f, 0x4d, 0x0b, 0x1a, 0xdb, 0x4e, 0xdf, 0xf1, 0xbb, 0xd8, 0xc2, 0x3f, 0x18, 0x62,
-	0x42, 0xd1, 0x0d, 0xd0, 0x09, 0x0d, 0x22, 0x6c, 0xf7, 0xa2, 0x60, 0x18, 0xae, 0x15, 0xd6, 0xb5,
-	0x8d, 0x9a, 0x05, 0x9c, 0xf4, 0x1e, 0xa3, 0x24, 0x02, 0xdd, 0x60, 0xe8, 0xd3, 0xb5, 0xe2, 0xba,
-	0xb6, 0x51, 0x97, 0x02, 0x6d, 0x46, 0x31, 0xcf, 0xa0, 0xd1, 0x61, 0xa3, 0xc7, 0xd8, 0x89, 0xe8,
-	0x31, 0x76, 0x28, 0x7a, 0x00, 0x0d, 0x31, 0x25, 0xc2, 0x24, 0x18, 0x46, 0x5d, 0xbc, 0xa6, 0xad,
-	0x6b, 0x1b, 0xfa, 0xe6, 0x52, 0x2b, 0x3c, 0x6e, 0x71, 0x59, 0x4b, 0x32, 0xac, 0x3a, 0x49, 0x0f,
-	0xd1, 0x1d, 0xa8, 0x75, 0x9e, 0x3b, 0x91, 0xbb, 0xe7, 0x9f, 0x04, 0xdc, 0x16, 0x7d, 0xb3, 0xce,
-	0x27, 0x29, 0xa2, 0x95, 0xf0, 0xcd, 0x06, 0x2c, 0x70, 0x65, 0xfb, 0x98, 0x10, 0xa7, 0x87, 0xcd,
-	0x7f, 0x68, 0xd0, 0x6c, 0xf7, 0x3d, 0xec, 0xd3, 0xc4, 0x94, 0x1b, 0xa0, 0x77, 0x39, 0xc9, 0xf6,
-	0x9d, 0x01, 0x56, 0xee, 0x09, 0xd2, 0x33, 0x67, 0x80, 0xd1, 0x01, 0x34, 0xba, 0xfd, 0x21, 0xa1,
-	0x38, 0xb2, 0x4f, 0x82, 0x7e, 0x3f, 0x38, 0xe3, 0x1e, 0xea, 0x9b, 0x1b, 0x6c, 0xd9, 0x31, 0x6d,
-	0xad, 0xb6, 0x90, 0x7c, 0xc4, 0x05, 0xe5, 0xb2, 0x56, 0xbd, 0x9b, 0xa6, 0x1a, 0x1d, 0x58, 0xc9,
-	0x13, 0x43, 0x06, 0x54, 0x4f, 0xf1, 0x88, 0x84, 0x8e, 0x0c, 0x47, 0xcd, 0x8a, 0xc7, 0xcc, 0x4a,
-	0x8f, 0xd8, 0x43, 0x5f, 0x5a, 0xc0, 0xac, 0xac, 0x5a, 0xe0, 0x91, 0xf7, 0x25, 0xc5, 0xfc, 0x6b,
-	0x11, 0xea, 0xc2, 0x18, 0xa5, 0xee, 0x16, 0x54, 0xe4, 0xba, 0x32, 0xb8, 0xba, 0x30, 0x98, 0x93,
-	0x2c, 0xc5, 0x43, 0xef, 0x40, 0x65, 0x18, 0xba, 0x0e, 0xc5, 0x44, 0x86, 0xf3, 0x56, 0xe2, 0x97,
-	0x54, 0x95, 0xcd, 0xc8, 0xfb, 0x5c, 0xda, 0x52, 0xb3, 0xd0, 0x7d, 0x98, 0x8f, 0x30, 0xf1, 0x7e,
-	0x88, 0x65, 0x5c, 0xd6, 0x26, 0xe7, 0x5b, 0x9c, 0x6f, 0x49, 0x39, 0xe3, 0x17, 0x1a, 0x2c, 0xe7,
-	0xa8, 0x44, 0xb7, 0xa0, 0xec, 0x07, 0x2e, 0x26, 0x6b, 0xda, 0x7a, 0x71, 0x43, 0xdf, 0x6c, 0xa6,
-	0xec, 0x7d, 0x16, 0xb8, 0xd8, 0x12, 0x5c, 0x74, 0x1d, 0x6a, 0x1e, 0xb1, 0x5d, 0xdc, 0xc7, 0x14,
-	0xcb, 0x48, 0x54, 0x3d, 0xb2, 0xc3, 0xc7, 0x99, 0x20, 0x16, 0xc7, 0x82, 0x78, 0x13, 0x16, 0x3c,
-	0x62, 0x87, 0x51, 0x30, 0x08, 0xa8, 0x17, 0xf8, 0x6b, 0x25, 0x3e, 0x57, 0xf7, 0xc8, 0xa1, 0x22,
-	0x19, 0x3f, 0xd2, 0x60, 0x5e, 0x58, 0x8b, 0xee, 0xc3, 0x4a, 0x77, 0x18, 0x45, 0x0c, 0x19, 0x2a,
-	0xff, 0xdc, 0x4b, 0x8d, 0xe3, 0x1b, 0x49, 0x9e, 0xb4, 0xaf, 0xc3, 0x66, 0xb4, 0x60, 0x99, 0x3a,
-	0x51, 0x0f, 0x8f, 0x4d, 0x28, 0xf0, 0x09, 0x4b, 0x82, 0x95, 0x96, 0x9f, 0x61, 0xab, 0xf9, 0x2f,
-	0x0d, 0x2a, 0x52, 0x76, 0x26, 0x30, 0xe2, 0x98, 0x15, 0x67, 0xc6, 0x6c, 0x13, 0x56, 0xf1, 0xa7,
-	0x21, 0xee, 0x52, 0xec, 0x66, 0x8d, 0x2b, 0x71, 0xe3, 0x96, 0x15, 0x33, 0x6d, 0xde, 0xb4, 0x00,
-	0x94, 0xa7, 0x06, 0xe0, 0x1e, 0xa0, 0x08, 0x87, 0x7d, 0xaf, 0xeb, 0xb0, 0x60, 0xda, 0x27, 0x4e,
-	0x97, 0x06, 0xd1, 0xda, 0xbc, 0xf0, 0x3f, 0xc5, 0x79, 0xc4, 0x19, 0xe6, 0x10, 0xf4, 0x94, 0xa9,
-	0x5f, 0xa2, 0x28, 0xdc, 0x05, 0x20, 0x6c, 0xd3, 0xdb, 0xde, 0xf4, 0xaa, 0x40, 0xe2, 0xaa, 0xf0,
-	0x27, 0x0d, 0xea, 0x19, 0x75, 0x68, 0x0d, 0x2a, 0x3e, 0xa6, 0x67, 0x41, 0x74, 0x2a, 0xf7, 0xbf,
-	0x1a, 0x32, 0x8e, 0xe3, 0xba, 0x11, 0x26, 0x44, 0x66, 0x48, 0x0d, 0xd1, 0xcb, 0x50, 0x77, 0xdc,
-	0x81, 0xe7, 0xdb, 0x8a, 0x5f, 0xe2, 0xfc, 0x05, 0x4e, 0xdc, 0x92, 0x42, 0x08, 0x4a, 0xd4, 0xe9,
-	0x91, 0xb5, 0xca, 0x7a, 0x71, 0xa3, 0x66, 0xf1, 0xdf, 0x68, 0x1d, 0x16, 0x5c, 0x8f, 0x9c, 0xf2,
-	0x58, 0xda, 0xbd, 0xe3, 0xb5, 0xaa, 0xa8, 0x97, 0x8c, 0xc6, 0x82, 0xf8, 0xde, 0x31, 0x7a, 0x0d,
-	0x96, 0x9c, 0x7e, 0x3f, 0xe8, 0x3a, 0x2c, 0x5b, 0x4a, 0xac, 0xc6, 0xc5, 0x9a, 0x31, 0x43, 0xc8,
-	0x9a, 0x3f, 0x29, 0xc0, 0xca, 0xd3, 0xa0, 0xeb, 0xf4, 0xb9, 0xab, 0x64, 0xcf, 0x57, 0xa0, 0x69,
-	0x40, 0xc1, 0x73, 0x25, 0x58, 0x0b, 0x9e, 0x8b, 0xda, 0x20, 0x42, 0x60, 0x0f, 0x1c, 0x56, 0xc4,
-	0x19, 0x58, 0x6e, 0xb3, 0x10, 0xe5, 0x4d, 0x16, 0x71, 0xdb, 0x77, 0xc2, 0x5d, 0x9f, 0x46, 0x23,
-	0xab, 0x4a, 0xe4, 0x90, 0xed, 0xa0, 0x0c, 0x14, 0x44, 0xad, 0xd7, 0xbb, 0xe7, 0x62, 0xa0, 0x34,
-	0x05, 0x03, 0xc6, 0x77, 0xa0, 0x9e, 0x59, 0x0c, 0x2d, 0x42, 0xf1, 0x14, 0x8f, 0xa4, 0xe1, 0xec,
-	0x27, 0x7a, 0x19, 0xca, 0x9f, 0x38, 0xfd, 0x21, 0xce, 0x4f, 0xac, 0xe0, 0x3d, 0x2c, 0x3c, 0xd0,
-	0xcc, 0xcf, 0x0b, 0xa9, 0xc3, 0x81, 0x25, 0x48, 0xed, 0x12, 0x51, 0xda, 0xc5, 0xd6, 0x59, 0x50,
-	0x44, 0x5e, 0xdc, 0xaf, 0x43, 0x8d, 0xe0, 0xe8, 0x13, 0x1c, 0xd9, 0x9e, 0x2b, 0x37, 0x6a, 0x55,
-	0x10, 0xf6, 0x5c, 0x74, 0x0d, 0xaa, 0x12, 0x56, 0xae, 0xf4, 0xb4, 0x22, 0x50, 0xe4, 0x4e, 0x04,
-	0xa2, 0x74, 0xd1, 0x40, 0x94, 0xa7, 0x04, 0x02, 0xdd, 0x85, 0x79, 0x42, 0x1d, 0x3a, 0x24, 0x7c,
-	0xbf, 0x34, 0x36, 0x57, 0x32, 0x6e, 0xb6, 0x3a, 0x9c, 0x67, 0x49, 0x19, 0x59, 0xca, 0xba, 0x8e,
-	0xef, 0x7a, 0xac, 0x74, 0xae, 0x55, 0x54, 0x29, 0x6b, 0x2b, 0x12, 0xab, 0x46, 0xac, 0xda, 0xe1,
-	0x68, 0xe0, 0xf8, 0x6c, 0x0f, 0xcb, 0x82, 0x59, 0xe5, 0x92, 0x4b, 0x1e, 0x39, 0x54, 0x1c, 0x51,
-	0x39, 0xcd, 0x87, 0x30, 0x2f, 0x16, 0x41, 0x35, 0x28, 0xef, 0xee, 0x1f, 0x1e, 0x7d, 0xb4, 0x38,
-	0x87, 0xea, 0x50, 0xdb, 0x3e, 0x38, 0x38, 0xea, 0x1c, 0x59, 0x5b, 0x87, 0x8b, 0x1a, 0xe3, 0x58,
-	0xbb, 0x5b, 0x3b, 0x1f, 0x2d, 0x16, 0x90, 0x0e, 0x95, 0x9d, 0xdd, 0xa7, 0xbb, 0x47, 0xbb, 0x3b,
-	0x8b, 0x45, 0xb3, 0x02, 0xe5, 0xdd, 0x41, 0x48, 0x47, 0xe6, 0x4f, 0x35, 0x58, 0x78, 0x82, 0x47,
-	0x47, 0xa3, 0x10, 0x7f, 0xc0, 0xf2, 0x92, 0x4e, 0xe7, 0x82, 0x48, 0xe7, 0x2d, 0x68, 0x84, 0x4e,
-	0x44, 0x3d, 0x1e, 0x95, 0xe7, 0x0e, 0x79, 0xce, 0xe3, 0x5e, 0xb2, 0xea, 0x31, 0xf5, 0xb1, 0x43,
-	0x9e, 0xa3, 0x16, 0xd4, 0x5c, 0x87, 0x3a, 0x36, 0x1d, 0x85, 0x02, 0x67, 0x0d, 0x51, 0x08, 0x0e,
-	0xc2, 0x2d, 0xdf, 0xdd, 0x71, 0xa8, 0xc3, 0xd6, 0xb0, 0xaa, 0xae, 0xfc, 0x85, 0x56, 0x14, 0x4a,
-	0x4a, 0x7c, 0x29, 0x31, 0x30, 0x0f, 0xa0, 0x2a, 0xfb, 0x18, 0x32, 0xb3, 0x8c, 0xbe, 0x02, 0xd5,
-	0x48, 0xca, 0xc9, 0xcd, 0xc1, 0x4f, 0x4b, 0x39, 0xd7, 0x8a, 0x99, 0xe6, 0x5b, 0x50, 0xb3, 0x30,
-	0x09, 0x03, 0x9f, 0x60, 0x82, 0x5e, 0x83, 0x5a, 0xa4, 0x06, 0xf2, 0xd0, 0x5a, 0x10, 0xd3, 0x04,
-	0xd1, 0x4a, 0xd8, 0xe6, 0xe7, 0x1a, 0x54, 0x54, 0x4b, 0x95, 0x06, 0x96, 0x96, 0x05, 0xd6, 0x3a,
-	0x14, 0xc3, 0x21, 0x95, 0x50, 0x6f, 0x30, 0x65, 0x87, 0x43, 0xaa, 0xcc, 0x60, 0x2c, 0x26, 0xd1,
-	0xc3, 0x54, 0x1e, 0xb6, 0x5c, 0xe2, 0x3d, 0x9c, 0x48, 0xf4, 0x30, 0x45, 0x0f, 0xa1, 0xce, 0x0e,
-	0xa1, 0xe3, 0x91, 0x1d, 0x46, 0xf8, 0xc4, 0xfb, 0x94, 0x87, 0x44, 0xdf, 0xbc, 0x22, 0x65, 0xb7,
-	0x47, 0x87, 0x9c, 0xac, 0xe6, 0xe8, 0xbd, 0x84, 0x86, 0x5e, 0x85, 0x79, 0x09, 0x94, 0x72, 0x52,
-	0x7c, 0x05, 0x42, 0x94, 0xbc, 0x14, 0x40, 0xb7, 0xa1, 0x3c, 0xc0, 0x51, 0x0f, 0x73, 0xc0, 0xea,
-	0x9b, 0x8b, 0x4c, 0x72, 0x9f, 0x11, 0x94, 0xa0, 0x60, 0x9b, 0xff, 0xd4, 0x00, 0x12, 0x27, 0xbe,
-	0x38, 0x22, 0x4c, 0xa8, 0x8b, 0x9e, 0xc3, 0xb5, 0x1d, 0x6a, 0xfb, 0xa2, 0x22, 0x97, 0x2c, 0x5d,
-	0x12, 0xb7, 0xe8, 0x33, 0x82, 0x5e, 0x04, 0xa0, 0xb4, 0x6f, 0x13, 0xdc, 0x0d, 0x7c, 0x57, 0xee,
-	0xca, 0x1a, 0xa5, 0xfd, 0x0e, 0x27, 0xa0, 0x87, 0xb0, 0x18, 0x84, 0xb6, 0xe3, 0xbb, 0x76, 0x82,
-	0xad, 0xf2, 0x34, 0x6c, 0xd5, 0x83, 0xf4, 0x30, 0x01, 0xd8, 0x7c, 0x1a, 0x60, 0xbf, 0xd7, 0x60,
-	0x21, 0xed, 0xf4, 0x57, 0xeb, 0x5e, 0x9e, 0xfd, 0xa5, 0xcb, 0xda, 0x5f, 0x4e, 0xdb, 0xff, 0x16,
-	0xd4, 0xbf, 0x1b, 0x79, 0x2c, 0xb9, 0x02, 0xa8, 0xec, 0xdc, 0x08, 0x4e, 0xb9, 0xf9, 0x55, 0xab,
-	0x10, 0x9c, 0xa2, 0x2b, 0x71, 0x5d, 0x12, 0x47, 0xa3, 0x1c, 0x99, 0x7d, 0xa8, 0x67, 0x60, 0xf1,
-	0x95, 0x3a, 0x6e, 0xee, 0x02, 0x24, 0x28, 0xff, 0xc2, 0x4b, 0x99, 0x2e, 0xe8, 0x5c, 0xcd, 0xe5,
-	0x7c, 0x45, 0xf7, 0xa0, 0x76, 0x8a, 0x47, 0xb6, 0x08, 0x5f, 0x31, 0x41, 0x7b, 0xba, 0xd2, 0xf1,
-	0x62, 0xc2, 0x7f, 0x99, 0x27, 0x80, 0x26, 0xb7, 0x19, 0x53, 0x2e, 0xb7, 0xa3, 0xb0, 0x5b, 0x8e,
-	0x58, 0x5e, 0xfa, 0xde, 0xc0, 0xa3, 0xf2, 0xf8, 0x11, 0x03, 0x16, 0x94, 0xbe, 0x43, 0xa8, 0x4d,
-	0x30, 0xf6, 0x6d, 0xe6, 0x6c, 0x91, 0x4f, 0xd2, 0x19, 0xb1, 0x83, 0xb1, 0xff, 0x04, 0x8f, 0x4c,
-	0x1f, 0x96, 0x33, 0xeb, 0x5c, 0xd2, 0xab, 0xd7, 0x01, 0x62, 0xaf, 0x54, 0xff, 0x38, 0xe9, 0x56,
-	0x4d, 0xb9, 0x45, 0xcc, 0x9f, 0x6b, 0xac, 0x9a, 0xca, 0x55, 0x5e, 0x81, 0xf2, 0x19, 0x03, 0x4e,
-	0xba, 0x49, 0xcb, 0x20, 0xc9, 0x12, 0x7c, 0x74, 0x53, 0xd4, 0x2b, 0x51, 0xd1, 0x9a, 0x71, 0xbd,
-	0x92, 0x42, 0xbc, 0x60, 0xbd, 0x3d, 0x5e, 0xb0, 0x44, 0x8c, 0xaf, 0x4e, 0x14, 0x2c, 0x39, 0x29,
-	0x5d, 0xb1, 0xcc, 0x6f, 0x80, 0x6e, 0x39, 0x67, 0x4f, 0xa4, 0x95, 0x39, 0xd8, 0x58, 0x49, 0xf7,
-	0x0f, 0x31, 0xf0, 0x7f, 0xa3, 0x41, 0xf5, 0x69, 0xd0, 0x13, 0x4d, 0xc7, 0x04, 0x04, 0xb5, 0xc9,
-	0xbd, 0x77, 0x7e, 0x65, 0x4e, 0x6a, 0x67, 0xf1, 0xc2, 0xb5, 0xb3, 0x34, 0xbb, 0x76, 0x76, 0xa0,
-	0xd1, 0x0e, 0xc2, 0xd1, 0x4e, 0xe0, 0xf3, 0x4b, 0x6c, 0x8f, 0x6f, 0x63, 0x7e, 0x56, 0x70, 0x13,
-	0xcb, 0x96, 0x18, 0xa0, 0x3b, 0x80, 0xba, 0x41, 0x38, 0xb2, 0x09, 0x75, 0x22, 0x6a, 0x53, 0x6f,
-	0x80, 0x99, 0x17, 0xcc, 0xd6, 0xa2, 0xd5, 0x64, 0x9c, 0x0e, 0x63, 0x1c, 0x79, 0x03, 0xfc, 0x8c,
-	0x98, 0xff, 0xd1, 0x60, 0x65, 0x3b, 0x08, 0x28, 0xa1, 0x91, 0x13, 0x32, 0xf5, 0x0a, 0xa2, 0xb3,
-	0x4e, 0xc8, 0xf4, 0x99, 0x55, 0x98, 0xdd, 0x0c, 0xe5, 0x74, 0x85, 0xb7, 0xa1, 0x29, 0xaf, 0x46,
-	0xb1, 0x12, 0x51, 0x9c, 0xeb, 0x82, 0xdc, 0x91, 0xaa, 0xa6, 0x5c, 0xa1, 0xca, 0xd3, 0xae, 0x50,
-	0x57, 0x60, 0x3e, 0x88, 0xbc, 0x9e, 0xe7, 0xf3, 0xaa, 0x5c, 0xb3, 0xe4, 0x28, 0xd9, 0x54, 0x15,
-	0x9e, 0x48, 0x31, 0x30, 0xff, 0xad, 0xc1, 0xea, 0x98, 0xe3, 0x12, 0xcd, 0xad, 0xcc, 0x5e, 0x48,
-	0xdd, 0x3f, 0x53, 0xd0, 0x4a, 0x6d, 0x05, 0xf4, 0x3d, 0x40, 0xc7, 0x9e, 0xdf, 0x0f, 0x7a, 0x47,
-	0x8e, 0xd7, 0x3f, 0x8c, 0x82, 0x1e, 0xbf, 0x02, 0x08, 0x6c, 0xdc, 0x65, 0xf3, 0x72, 0x97, 0x69,
-	0x6d, 0x4f, 0xcc, 0xb1, 0x72, 0xf4, 0x18, 0x8f, 0x00, 0x4d, 0x4a, 0xb2, 0xbb, 0x08, 0xc1, 0xbd,
-	0x01, 0xf6, 0x69, 0xdc, 0x34, 0x88, 0x21, 0x8f, 0xc2, 0xc9, 0x09, 0x91, 0xbb, 0xac, 0x64, 0xc9,
-	0x91, 0xf9, 0x59, 0x01, 0x96, 0x0e, 0x87, 0xfd, 0xbe, 0xbc, 0xb2, 0x7f, 0xb9, 0x2c, 0xa7, 0x96,
-	0x2f, 0x4e, 0x5b, 0xbe, 0x94, 0x5e, 0x3e, 0x49, 0x42, 0x39, 0x5d, 0xd9, 0x72, 0xa0, 0x30, 0x7f,
-	0x09, 0x28, 0x54, 0xce, 0x87, 0x42, 0x35, 0x0d, 0x05, 0xf3, 0x57, 0x1a, 0xa0, 0x74, 0x10, 0x64,
-	0xc6, 0x6f, 0xc2, 0x82, 0x8f, 0x3f, 0x65, 0x05, 0x36, 0x1d, 0x52, 0x9d, 0xd1, 0x3a, 0xd2, 0xaf,
-	0x1b, 0xc0, 0x87, 0x76, 0x26, 0xb6, 0xc0, 0x48, 0x07, 0xc2, 0xc1, 0xdb, 0x50, 0xc1, 0x3e, 0x8d,
-	0xbc, 0xb8, 0x7c, 0x2e, 0x88, 0x1b, 0x95, 0xa8, 0x2a, 0x96, 0x62, 0xa2, 0x97, 0x40, 0x0f, 0x86,
-	0x4c, 0x8f, 0x4d, 0x46, 0x7e, 0x57, 0xbe, 0x3b, 0xd4, 0x82, 0x21, 0x3d, 0x38, 0xe9, 0x8c, 0xfc,
-	0xae, 0xf9, 0x04, 0x50, 0xfb, 0x39, 0xee, 0x9e, 0x8a, 0xa4, 0x7f, 0xb9, 0x3c, 0x99, 0x9f, 0x69,
-	0xb0, 0x9c, 0xd1, 0x26, 0x1d, 0x9e, 0xd1, 0x74, 0xbe, 0x0a, 0x8b, 0xd8, 0x89, 0xfa, 0x1e, 0x26,
-	0x49, 0x3c, 0x84, 0xd6, 0xa6, 0xa2, 0xab, 0x98, 0xdc, 0x82, 0x46, 0xdf, 0xa1, 0x69, 0x41, 0x01,
-	0x86, 0xba, 0xa0, 0x4a, 0x31, 0xf3, 0x67, 0x45, 0x68, 0xee, 0x60, 0xd2, 0x8d, 0xbc, 0xe3, 0x18,
-	0x77, 0x07, 0xb0, 0xe4, 0x62, 0xd2, 0x15, 0xad, 0x4b, 0x17, 0xfb, 0x14, 0x47, 0x44, 0x9e, 0x1e,
-	0x2f, 0x8b, 0x4a, 0x99, 0x91, 0xe7, 0x63, 0xd6, 0xbd, 0xb4, 0x85, 0xa8, 0xd5, 0x74, 0xb3, 0x04,
-	0xf4, 0x18, 0x1a, 0x5c, 0xa1, 0x8a, 0x8a, 0xda, 0x80, 0x37, 0xa7, 0x69, 0x7b, 0xa2, 0x04, 0xad,
-	0xba, 0x9b, 0x1e, 0xa2, 0x6d, 0x58, 0xe0, 0x9a, 0xd4, 0x83, 0x99, 0xa8, 0xdf, 0x37, 0xa6, 0xe9,
-	0x51, 0x8f, 0x68, 0xba, 0x9b, 0x0c, 0x52, 0x3a, 0x3c, 0xec, 0x53, 0x22, 0x2b, 0xfb, 0x0c, 0x1d,
-	0x5c, 0x4c, 0xe9, 0xe0, 0x03, 0x63, 0x49, 0x44, 0x2d, 0xe5, 0xa4, 0xd1, 0x64, 0x7d, 0x56, 0xca,
-	0x56, 0xe3, 0x55, 0xd0, 0x53, 0x36, 0xcc, 0x42, 0x89, 0x51, 0x57, 0xa2, 0x5c, 0xbb, 0xf9, 0xcb,
-	0x79, 0x58, 0x4c, 0x4c, 0x91, 0xb0, 0xd8, 0x87, 0xc5, 0xf1, 0xac, 0xe4, 0x27, 0x45, 0x96, 0xb0,
-	0xac, 0x7d, 0x56, 0x23, 0x9b, 0x14, 0xb4, 0x37, 0x25, 0x27, 0xe6, 0x54, 0x65, 0x53, 0x93, 0xd2,
-	0xce, 0x4d, 0xca, 0xfa, 0x54, 0x45, 0xb9, 0x59, 0xe1, 0x67, 0x13, 0x7f, 0xde, 0x15, 0xaf, 0xd3,
-	0xf1, 0x45, 0x9d, 0xd1, 0xf8, 0xf3, 0xb4, 0xf1, 0x3b, 0x0d, 0x1a, 0x59, 0xaf, 0xd0, 0x01, 0xe8,
-	0x93, 0xf1, 0x68, 0x5d, 0x20, 0x1e, 0xad, 0x54, 0x68, 0xc0, 0x8d, 0x7f, 0x1b, 0x8f, 0x01, 0x52,
-	0xea, 0x1f, 0x42, 0x33, 0xfb, 0xd2, 0xa5, 0x2e, 0x9d, 0x39, 0x4f, 0x5d, 0x8d, 0xcc, 0x53, 0x17,
-	0x31, 0xfe, 0xa6, 0x8d, 0x01, 0x02, 0xed, 0xf1, 0xee, 0x54, 0x46, 0x5b, 0x1c, 0x5d, 0x77, 0xce,
-	0x8f, 0x76, 0x4b, 0xfd, 0xb2, 0x92, 0xd9, 0x46, 0x04, 0x55, 0x45, 0x3e, 0xef, 0xba, 0x2c, 0xb3,
-	0x92, 0xb9, 0x2e, 0xab, 0x0c, 0xc4, 0xcc, 0x89, 0xf0, 0x17, 0x27, 0xc3, 0xff, 0x63, 0x2d, 0x0b,
-	0xe8, 0x0b, 0xbe, 0x5b, 0xb7, 0x64, 0xfd, 0x56, 0xb2, 0x85, 0x49, 0x59, 0x5e, 0xbd, 0xa7, 0x01,
-	0x61, 0xd2, 0x12, 0xf3, 0x8f, 0x1a, 0xac, 0xb4, 0x23, 0xec, 0x50, 0xac, 0x34, 0xe4, 0x54, 0xe2,
-	0xc2, 0xe4, 0xa3, 0xf2, 0xff, 0xf6, 0x49, 0x8c, 0xf5, 0x72, 0x34, 0xa0, 0x4e, 0xdf, 0xce, 0x3c,
-	0x13, 0x8a, 0x33, 0xb4, 0xc9, 0x39, 0x3b, 0xc9, 0x5b, 0xa1, 0x7a, 0x61, 0x9c, 0x4f, 0x5e, 0x18,
-	0xcd, 0x23, 0x58, 0x1d, 0x73, 0x43, 0xee, 0xf5, 0x15, 0x28, 0xe3, 0x28, 0x0a, 0x22, 0x99, 0x4f,
-	0x31, 0x48, 0x07, 0xbc, 0x30, 0x3d, 0xe0, 0xe6, 0x26, 0xac, 0x88, 0x5e, 0xf6, 0xe2, 0xc1, 0x31,
-	0xef, 0xc1, 0xea, 0xd8, 0x9c, 0x59, 0x96, 0x98, 0x6f, 0xc0, 0x6a, 0x3b, 0x18, 0x84, 0x4e, 0x97,
-	0x5e, 0x62, 0x8d, 0x16, 0x5c, 0x19, 0x9f, 0x34, 0x73, 0x91, 0xef, 0x03, 0xb2, 0x70, 0xd8, 0x77,
-	0xba, 0x98, 0x3f, 0x90, 0x5f, 0x20, 0xc5, 0x57, 0xa1, 0xe2, 0x07, 0x2e, 0x4e, 0x9e, 0x01, 0xe7,
-	0xd9, 0x70, 0xcf, 0x15, 0x0d, 0xc2, 0xd9, 0xd8, 0x0b, 0x30, 0xf8, 0xf8, 0x4c, 0xbe, 0xff, 0x9a,
-	0x77, 0x60, 0x39, 0xb3, 0xd6, 0x4c, 0xc3, 0xfe, 0xa2, 0x01, 0x12, 0x79, 0xe3, 0x2d, 0xd0, 0x45,
-	0xda, 0x80, 0x99, 0xcf, 0x97, 0x5f, 0x09, 0x32, 0x45, 0x0b, 0x91, 0x87, 0x4c, 0xce, 0x49, 0x90,
-	0xc9, 0x7c, 0xcf, 0x78, 0x73, 0x5e, 0xe6, 0x05, 0x50, 0xe2, 0xaa, 0x74, 0xbe, 0xf7, 0x2c, 0xf3,
-	0xe3, 0x93, 0x66, 0x2e, 0xf2, 0x66, 0x8c, 0x94, 0xcb, 0xac, 0xf2, 0x3a, 0x5c, 0x9d, 0x98, 0x35,
-	0x73, 0x99, 0xdf, 0x6a, 0x70, 0xdd, 0x92, 0xb1, 0xe3, 0x79, 0x3f, 0x8c, 0x70, 0xe8, 0x44, 0xf8,
-	0xeb, 0x97, 0x50, 0xf3, 0x4d, 0x78, 0x21, 0xdf, 0xd2, 0x99, 0x0e, 0x3e, 0x00, 0x23, 0x33, 0xab,
-	0x1d, 0x0c, 0x06, 0x1e, 0xbd, 0x48, 0x2c, 0xdf, 0x18, 0x8b, 0x8c, 0x9a, 0x39, 0x73, 0xb9, 0x6f,
-	0x8d, 0x4f, 0xea, 0x63, 0xc7, 0x1f, 0x86, 0x17, 0x59, 0x6f, 0xdc, 0xbf, 0x78, 0xea, 0xcc, 0x05,
-	0xff, 0xae, 0xc1, 0x9a, 0xf8, 0x13, 0xf0, 0xeb, 0xbd, 0x1d, 0x2f, 0x79, 0x59, 0x36, 0xff, 0x1f,
-	0xae, 0xe5, 0xb8, 0x35, 0x33, 0x14, 0x0e, 0x2b, 0x60, 0x7c, 0xca, 0x45, 0x73, 0x7c, 0xd9, 0x7f,
-	0x41, 0xcd, 0xbb, 0xb0, 0x92, 0x5d, 0x62, 0xa6, 0x41, 0xc7, 0xb1, 0xf4, 0x85, 0x51, 0x70, 0x69,
-	0x8b, 0xee, 0xc1, 0xea, 0xd8, 0x1a, 0x33, 0x4d, 0xfa, 0x18, 0xea, 0xf2, 0x0f, 0xee, 0x0b, 0x9c,
-	0x25, 0x53, 0x6c, 0x29, 0x4e, 0xb3, 0xe5, 0x36, 0x34, 0x94, 0xf2, 0x59, 0x46, 0xbc, 0xb6, 0x07,
-	0xf5, 0xcc, 0xeb, 0x2f, 0xaa, 0x41, 0x79, 0xfb, 0xa3, 0xa3, 0xdd, 0xce, 0xe2, 0x1c, 0xd2, 0xa1,
-	0xf2, 0xe8, 0xe9, 0xc1, 0xd6, 0xd1, 0x37, 0xdf, 0x5c, 0xd4, 0x50, 0x13, 0xf4, 0xfd, 0xad, 0x0f,
-	0x6d, 0x45, 0x28, 0x70, 0xc2, 0xde, 0xb3, 0x98, 0x50, 0xdc, 0xfc, 0x43, 0x09, 0xf4, 0x0f, 0x1c,
-	0x42, 0x83, 0x7d, 0x87, 0x77, 0x4e, 0x6f, 0x33, 0xff, 0x7a, 0x1e, 0x37, 0x89, 0xf5, 0xa2, 0x08,
-	0xc5, 0x5d, 0x6a, 0xfc, 0xe1, 0x83, 0xb1, 0x18, 0xd3, 0xd4, 0xc7, 0x16, 0x73, 0x1b, 0xda, 0x7d,
-	0x0d, 0x7d, 0x9b, 0xd9, 0x2f, 0x26, 0x8b, 0x6b, 0x08, 0x5a, 0xce, 0xf9, 0x6e, 0xc2, 0x58, 0x9a,
-	0xf8, 0x68, 0x40, 0xce, 0x7f, 0x0b, 0xaa, 0xaa, 0x8f, 0x15, 0x33, 0xc7, 0xee, 0x52, 0xc6, 0x4a,
-	0x5e, 0xab, 0x6b, 0xce, 0xa1, 0x47, 0x50, 0xcf, 0x34, 0x41, 0x48, 0x7c, 0x97, 0x90, 0xd3, 0xde,
-	0x19, 0xd7, 0x72, 0x38, 0x69, 0x3d, 0x99, 0x16, 0x46, 0xe8, 0xc9, 0xeb, 0x84, 0x84, 0x9e, 0xdc,
-	0x7e, 0xc7, 0x9c, 0x63, 0x17, 0xa3, 0x6c, 0x9b, 0x82, 0xc4, 0xb2, 0x79, 0xfd, 0x8e, 0x61, 0xe4,
-	0xb1, 0x62, 0x55, 0x0f, 0x14, 0xe0, 0x94, 0xa6, 0x25, 0xf9, 0xa7, 0x53, 0x82, 0x41, 0x03, 0xa5,
-	0x49, 0xf1, 0xcc, 0x77, 0x41, 0x4f, 0xf5, 0x23, 0xe8, 0x8a, 0x10, 0x1a, 0x6f, 0x86, 0x8c, 0xab,
-	0x13, 0xf4, 0x58, 0xc3, 0x2d, 0xd6, 0xac, 0x1f, 0x0f, 0x7b, 0x12, 0x1b, 0x35, 0x26, 0xc9, 0xff,
-	0xfa, 0x33, 0x92, 0x9f, 0xe6, 0xdc, 0xe6, 0x9f, 0x2b, 0x00, 0x1c, 0x43, 0x02, 0x31, 0x8f, 0xa1,
-	0x9e, 0x79, 0x10, 0x13, 0x41, 0xcc, 0x7b, 0x83, 0x14, 0x41, 0xcc, 0x7d, 0x3d, 0x33, 0xe7, 0xee,
-	0x6b, 0xe8, 0x1d, 0x80, 0x23, 0xc7, 0xeb, 0x8b, 0xb7, 0x0d, 0xb4, 0x2a, 0x9e, 0x61, 0xc7, 0x5e,
-	0xb8, 0x8c, 0x2b, 0xe3, 0xe4, 0x94, 0x82, 0x77, 0x41, 0x4f, 0xbd, 0x8e, 0x88, 0x10, 0x4c, 0x3e,
-	0xbe, 0x88, 0x10, 0xe4, 0x3c, 0xa3, 0x88, 0x20, 0xa6, 0x0a, 0xa8, 0xd4, 0x30, 0x71, 0x50, 0x48,
-	0x0d, 0x93, 0x95, 0x56, 0x60, 0x21, 0xdb, 0xb8, 0xa0, 0x14, 0x74, 0xc6, 0x7a, 0x13, 0x81, 0x85,
-	0xfc, 0x3e, 0xc7, 0x9c, 0x43, 0x4f, 0xa1, 0x39, 0xd6, 0x9d, 0xa0, 0x34, 0x78, 0xc6, 0x95, 0x5d,
-	0xcf, 0xe5, 0xc5, 0xda, 0x3e, 0x66, 0xd5, 0x75, 0xb2, 0x1f, 0x40, 0x37, 0x14, 0x20, 0xa6, 0xf4,
-	0x34, 0xc6, 0xfa, 0x74, 0x81, 0x58, 0xf9, 0x87, 0xa2, 0x19, 0x1e, 0x3b, 0xfc, 0xd1, 0x4b, 0x13,
-	0x53, 0x33, 0x67, 0x8d, 0x71, 0x63, 0x2a, 0x7f, 0xaa, 0xd9, 0xb2, 0x6e, 0xe7, 0x98, 0x9d, 0x3d,
-	0x35, 0x72, 0xcc, 0x1e, 0x2b, 0xf9, 0xe6, 0x1c, 0x7a, 0xa6, 0x76, 0x9b, 0x0a, 0xc6, 0x0b, 0xc9,
-	0xd6, 0xca, 0x49, 0xfb, 0x8b, 0x53, 0xb8, 0xb1, 0xbe, 0x36, 0x2c, 0xa4, 0xcf, 0x3b, 0x74, 0x35,
-	0x35, 0x21, 0xe3, 0xf8, 0xda, 0x24, 0x23, 0x5d, 0x95, 0x32, 0x47, 0x14, 0x4a, 0x0b, 0x67, 0x7d,
-	0xbc, 0x96, 0xc3, 0x89, 0xf5, 0xfc, 0x1f, 0x00, 0xdf, 0xce, 0x62, 0x9b, 0x4e, 0xd9, 0xcd, 0xdb,
-	0x2f, 0x42, 0xd5, 0x0b, 0x5a, 0xfc, 0x53, 0xc1, 0x6d, 0xb1, 0xad, 0x0f, 0xa3, 0x80, 0x06, 0x87,
-	0xda, 0xaf, 0x0b, 0x85, 0x0f, 0x3a, 0xc7, 0xf3, 0xfc, 0xf3, 0xc1, 0x37, 0xfe, 0x1b, 0x00, 0x00,
-	0xff, 0xff, 0xad, 0xac, 0x3e, 0xc9, 0x4d, 0x28, 0x00, 0x00,
+	// 4583 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xcc, 0x7c, 0xdd, 0x8f, 0x1c, 0x49,
+	0x52, 0xb8, 0xab, 0xbf, 0x3b, 0xfa, 0x63, 0x7a, 0x72, 0x3e, 0x3c, 0x2e, 0xef, 0xae, 0xc7, 0xe5,
+	0xb3, 0xd7, 0xbb, 0xe3, 0x9d, 0xf5, 0xce, 0xee, 0xfd, 0x6e, 0xd7, 0xf7, 0x83, 0xdd, 0xf9, 0xb4,
+	0x07, 0x7b, 0x3c, 0xa3, 0x9a, 0x59, 0xdf, 0x2d, 0x07, 0x2a, 0xd5, 0x54, 0x65, 0xf7, 0xd4, 0xba,
+	0xbb, 0xaa, 0xa9, 0xac, 0xde, 0xf1, 0x20, 0x21, 0xd0, 0x09, 0x81, 0x78, 0x05, 0x21, 0x78, 0x00,
+	0x89, 0xbb, 0x27, 0x04, 0x7f, 0x03, 0xa0, 0x7b, 0x40, 0xf0, 0x00, 0xbc, 0x20, 0x24, 0xc4, 0x03,
+	0x12, 0xe2, 0x11, 0xf1, 0x0a, 0xaf, 0x28, 0xbf, 0xaa, 0xb2, 0xba, 0xaa, 0x7b, 0xa6, 0xd7, 0x7b,
+	0x70, 0x6f, 0x95, 0x11, 0x91, 0x91, 0x91, 0x11, 0x91, 0x91, 0x11, 0x99, 0xd9, 0x0d, 0x8d, 0xaf,
+	0x6c, 0x12, 0x05, 0xeb, 0xc3, 0x30, 0x88, 0x02, 0x54, 0x18, 0x9e, 0x1a, 0x26, 0xb4, 0xb7, 0xec,
+	0xbe, 0xed, 0x3b, 0xd8, 0xc4, 0xbf, 0x32, 0xc2, 0x24, 0x42, 0xb7, 0xa0, 0x41, 0xa2, 0x20, 0xc4,
+	0x56, 0x2f, 0x0c, 0x46, 0xc3, 0x95, 0xc2, 0xaa, 0x76, 0xbf, 0x6e, 0x02, 0x03, 0x3d, 0xa6, 0x90,
+	0x84, 0xc0, 0x09, 0x46, 0x7e, 0xb4, 0x52, 0x5c, 0xd5, 0xee, 0xb7, 0x04, 0xc1, 0x36, 0x85, 0x18,
+	0xe7, 0xd0, 0x3e, 0xa6, 0xad, 0x27, 0xd8, 0x0e, 0xa3, 0x53, 0x6c, 0x47, 0xe8, 0x63, 0x68, 0xf3,
+	0x2e, 0x21, 0x26, 0xc1, 0x28, 0x74, 0xf0, 0x8a, 0xb6, 0xaa, 0xdd, 0x6f, 0x6c, 0xcc, 0xaf, 0x0f,
+	0x4f, 0xd7, 0x19, 0xad, 0x29, 0x10, 0x66, 0x8b, 0xa8, 0x4d, 0xb4, 0x06, 0xf5, 0xe3, 0x33, 0x3b,
+	0x74, 0xf7, 0xfd, 0x6e, 0xc0, 0x64, 0x69, 0x6c, 0xb4, 0x58, 0x27, 0x09, 0x34, 0x13, 0xbc, 0xd1,
+	0x86, 0x26, 0x63, 0x76, 0x80, 0x09, 0xb1, 0x7b, 0xd8, 0xf8, 0x67, 0x0d, 0xe6, 0xb6, 0xfb, 0x1e,
+	0xf6, 0xa3, 0x44, 0x94, 0x5b, 0xd0, 0x70, 0x18, 0xc8, 0xf2, 0xed, 0x01, 0x96, 0xd3, 0xe3, 0xa0,
+	0xe7, 0xf6, 0x00, 0xa3, 0x43, 0x68, 0x3b, 0xfd, 0x11, 0x89, 0x70, 0x68, 0x75, 0x83, 0x7e, 0x3f,
+	0x38, 0x67, 0x33, 0x6c, 0x6c, 0xdc, 0xa7, 0xc3, 0x8e, 0x71, 0x5b, 0xdf, 0xe6, 0x94, 0x7b, 0x8c,
+	0x50, 0x0c, 0x6b, 0xb6, 0x1c, 0x15, 0xaa, 0x1f, 0xc3, 0x62, 0x1e, 0x19, 0xd2, 0xa1, 0xf6, 0x12,
+	0x5f, 0x90, 0xa1, 0x2d, 0xd4, 0x51, 0x37, 0xe3, 0x36, 0x95, 0xd2, 0x23, 0xd6, 0xc8, 0x17, 0x12,
+	0x50, 0x29, 0x6b, 0x26, 0x78, 0xe4, 0x73, 0x01, 0x31, 0xfe, 0xbe, 0x08, 0x2d, 0x2e, 0x8c, 0x64,
+	0x77, 0x17, 0xaa, 0x62, 0x5c, 0xa1, 0xdc, 0x06, 0x17, 0x98, 0x81, 0x4c, 0x89, 0x43, 0x9f, 0x42,
+	0x75, 0x34, 0x74, 0xed, 0x08, 0x13, 0xa1, 0xce, 0xbb, 0xc9, 0xbc, 0x04, 0xab, 0xb4, 0x45, 0x3e,
+	0x67, 0xd4, 0xa6, 0xec, 0x85, 0x1e, 0x42, 0x25, 0xc4, 0xc4, 0xfb, 0x55, 0x2c, 0xf4, 0xb2, 0x92,
+	0xed, 0x6f, 0x32, 0xbc, 0x29, 0xe8, 0xf4, 0x3f, 0xd4, 0x60, 0x21, 0x87, 0x25, 0xba, 0x0b, 0x65,
+	0x3f, 0x70, 0x31, 0x59, 0xd1, 0x56, 0x8b, 0xf7, 0x1b, 0x1b, 0x73, 0x8a, 0xbc, 0xcf, 0x03, 0x17,
+	0x9b, 0x1c, 0x8b, 0x6e, 0x42, 0xdd, 0x23, 0x96, 0x8b, 0xfb, 0x38, 0xc2, 0x42, 0x13, 0x35, 0x8f,
+	0xec, 0xb0, 0x76, 0x4a, 0x89, 0xc5, 0x31, 0x25, 0xde, 0x86, 0xa6, 0x47, 0xac, 0x61, 0x18, 0x0c,
+	0x82, 0xc8, 0x0b, 0xfc, 0x95, 0x12, 0xeb, 0xdb, 0xf0, 0xc8, 0x91, 0x04, 0xe9, 0xbf, 0xa5, 0x41,
+	0x85, 0x4b, 0x8b, 0x1e, 0xc2, 0xa2, 0x33, 0x0a, 0x43, 0xea, 0x19, 0xd2, 0xfe, 0x6c, 0x96, 0x1a,
+	0xf3, 0x6f, 0x24, 0x70, 0x42, 0xbe, 0x63, 0xda, 0x63, 0x1d, 0x16, 0x22, 0x3b, 0xec, 0xe1, 0xb1,
+	0x0e, 0x05, 0xd6, 0x61, 0x9e, 0xa3, 0x54, 0xfa, 0x29, 0xb2, 0x1a, 0xff, 0xa6, 0x41, 0x55, 0xd0,
+	0x4e, 0x75, 0x8c, 0x58, 0x67, 0xc5, 0xa9, 0x3a, 0xdb, 0x80, 0x25, 0xfc, 0x6a, 0x88, 0x9d, 0x08,
+	0xbb, 0x69, 0xe1, 0x4a, 0x4c, 0xb8, 0x05, 0x89, 0x54, 0xc5, 0x9b, 0xa4, 0x80, 0xf2, 0x44, 0x05,
+	0xbc, 0x07, 0x28, 0xc4, 0xc3, 0xbe, 0xe7, 0xd8, 0x54, 0x99, 0x56, 0xd7, 0x76, 0xa2, 0x20, 0x5c,
+	0xa9, 0xf0, 0xf9, 0x2b, 0x98, 0x3d, 0x86, 0x30, 0x46, 0xd0, 0x50, 0x44, 0x7d, 0x8d, 0xa0, 0xf0,
+	0x00, 0x80, 0xd0, 0x45, 0x6f, 0x79, 0x93, 0xa3, 0x02, 0x91, 0x9f, 0xc6, 0x7f, 0x68, 0xd0, 0x4a,
+	0xb1, 0x43, 0x2b, 0x50, 0xf5, 0x71, 0x74, 0x1e, 0x84, 0x2f, 0xc5, 0xfa, 0x97, 0x4d, 0x8a, 0xb1,
+	0x5d, 0x37, 0xc4, 0x84, 0x08, 0x0b, 0xc9, 0x26, 0xba, 0x03, 0x2d, 0xdb, 0x1d, 0x78, 0xbe, 0x25,
+	0xf1, 0x25, 0x86, 0x6f, 0x32, 0xe0, 0xa6, 0x20, 0x42, 0x50, 0x8a, 0xec, 0x1e, 0x59, 0xa9, 0xae,
+	0x16, 0xef, 0xd7, 0x4d, 0xf6, 0x8d, 0x56, 0xa1, 0xe9, 0x7a, 0xe4, 0x25, 0xd3, 0xa5, 0xd5, 0x3b,
+	0x5d, 0xa9, 0xf1, 0x78, 0x49, 0x61, 0x54, 0x89, 0x8f, 0x4f, 0xd1, 0xbb, 0x30, 0x6f, 0xf7, 0xfb,
+	0x81, 0x63, 0x53, 0x6b, 0x49, 0xb2, 0x3a, 0x23, 0x9b, 0x8b, 0x11, 0x82, 0xf6, 0x16, 0x34, 0x5c,
+	0x3b, 0xb2, 0x2d, 0x07, 0xfb, 0x74, 0xa5, 0x03, 0x0f, 0x5f, 0x14, 0xb4, 0xcd, 0x20, 0xc6, 0xef,
+	0x14, 0x60, 0xf1, 0x59, 0xe0, 0xd8, 0x7d, 0xa6, 0x0b, 0xb2, 0xef, 0x4b, 0xaf, 0x6a, 0x43, 0xc1,
+	0x73, 0x85, 0x37, 0x17, 0x3c, 0x17, 0x6d, 0x03, 0xd7, 0x91, 0x35, 0xb0, 0x69, 0x94, 0xa7, 0xde,
+	0x74, 0x8f, 0xea, 0x30, 0xaf, 0x33, 0x57, 0xec, 0x81, 0x3d, 0xdc, 0xf5, 0xa3, 0xf0, 0xc2, 0xac,
+	0x11, 0xd1, 0xa4, 0x4b, 0x2c, 0xe5, 0x2b, 0x7c, 0x33, 0x68, 0x38, 0x97, 0x3a, 0x49, 0x69, 0x82,
+	0x93, 0xe8, 0xbf, 0x00, 0xad, 0xd4, 0x60, 0xa8, 0x03, 0xc5, 0x97, 0xf8, 0x42, 0x08, 0x4e, 0x3f,
+	0xd1, 0x1d, 0x28, 0x7f, 0x65, 0xf7, 0x47, 0x38, 0xdf, 0xf2, 0x1c, 0xf7, 0xa8, 0xf0, 0xb1, 0x66,
+	0xfc, 0x77, 0x41, 0xd9, 0x3d, 0xa8, 0x05, 0xe5, 0x32, 0xe2, 0xb1, 0x9f, 0xaf, 0xad, 0xa6, 0x04,
+	0xb2, 0xe8, 0x7f, 0x13, 0xea, 0x04, 0x87, 0x5f, 0xe1, 0xd0, 0xf2, 0x5c, 0xb1, 0x92, 0x6b, 0x1c,
+	0xb0, 0xef, 0xa2, 0x1b, 0x50, 0x13, 0x7e, 0xe7, 0x8a, 0x99, 0x56, 0xb9, 0x9b, 0xb9, 0x19, 0x45,
+	0x94, 0xae, 0xaa, 0x88, 0xf2, 0x04, 0x45, 0xa0, 0x07, 0x50, 0x21, 0x91, 0x1d, 0x8d, 0x08, 0x5b,
+	0x50, 0xed, 0x8d, 0xc5, 0xd4, 0x34, 0xd7, 0x8f, 0x19, 0xce, 0x14, 0x34, 0x22, 0xd6, 0x39, 0xb6,
+	0xef, 0x7a, 0x34, 0xb6, 0xae, 0x54, 0x65, 0xac, 0xdb, 0x96, 0x20, 0x1a, 0xae, 0x68, 0x38, 0xc4,
+	0xe1, 0xc0, 0xf6, 0xe9, 0x22, 0x17, 0x11, 0xb5, 0xc6, 0x28, 0xe7, 0x3d, 0x72, 0x24, 0x31, 0x3c,
+	0xb4, 0x1a, 0x8f, 0xa0, 0xc2, 0x07, 0x41, 0x75, 0x28, 0xef, 0x1e, 0x1c, 0x9d, 0x7c, 0xd1, 0xb9,
+	0x86, 0x5a, 0x50, 0xdf, 0x3a, 0x3c, 0x3c, 0x39, 0x3e, 0x31, 0x37, 0x8f, 0x3a, 0x1a, 0xc5, 0x98,
+	0xbb, 0x9b, 0x3b, 0x5f, 0x74, 0x0a, 0xa8, 0x01, 0xd5, 0x9d, 0xdd, 0x67, 0xbb, 0x27, 0xbb, 0x3b,
+	0x9d, 0xa2, 0x51, 0x85, 0xf2, 0xee, 0x60, 0x18, 0x5d, 0x18, 0xff, 0xaa, 0x41, 0xf3, 0x29, 0xbe,
+	0x38, 0xb9, 0x18, 0xe2, 0x17, 0xd4, 0x2e, 0xaa, 0x39, 0x9b, 0xdc, 0x9c, 0x77, 0xa1, 0x3d, 0xb4,
+	0xc3, 0xc8, 0x63, 0x5a, 0x39, 0xb3, 0xc9, 0x19, 0xd3, 0x7b, 0xc9, 0x6c, 0xc5, 0xd0, 0x27, 0x36,
+	0x39, 0x43, 0xeb, 0x50, 0x67, 0x9e, 0x1f, 0x5d, 0x0c, 0xb9, 0x9f, 0xb5, 0x79, 0xa4, 0x38, 0x1c,
+	0x6e, 0xfa, 0xee, 0x8e, 0x1d, 0xd9, 0x74, 0x0c, 0xb3, 0xe6, 0x8a, 0x2f, 0xb4, 0x28, 0xbd, 0xa4,
+	0xc4, 0x86, 0xe2, 0x0d, 0x64, 0x40, 0x8b, 0xcf, 0xdb, 0xb5, 0xec, 0xc8, 0xf2, 0x09, 0xd3, 0x7f,
+	0xc9, 0x6c, 0x08, 0xe0, 0x66, 0xf4, 0x9c, 0xa0, 0x35, 0xa8, 0x7e, 0x85, 0x43, 0x42, 0xb7, 0x8c,
+	0x4a, 0x12, 0x91, 0x5e, 0x70, 0xd0, 0x0b, 0x4c, 0xad, 0x63, 0x4a, 0x0a, 0xe3, 0x13, 0x68, 0xa5,
+	0x30, 0xe8, 0x3e, 0x54, 0x9c, 0x7e, 0xe0, 0xbc, 0x94, 0xdb, 0x5a, 0x47, 0xe9, 0xbc, 0x4d, 0x11,
+	0xa6, 0xc0, 0x1b, 0x9f, 0x41, 0x53, 0x85, 0xa3, 0x65, 0xa8, 0x9c, 0x87, 0x9e, 0xdc, 0xc0, 0xeb,
+	0xa6, 0x68, 0xd1, 0xa0, 0xc4, 0x52, 0x2d, 0x1c, 0x0a, 0xcd, 0xc8, 0xa6, 0x71, 0x08, 0x35, 0x91,
+	0xb6, 0x91, 0xa9, 0xbb, 0xc6, 0xdb, 0x50, 0x0b, 0x05, 0x9d, 0x58, 0xea, 0x2c, 0x39, 0x10, 0x7d,
+	0xcd, 0x18, 0x69, 0x7c, 0x07, 0xea, 0x26, 0x26, 0xc3, 0xc0, 0x27, 0x98, 0xa0, 0x77, 0xa1, 0x1e,
+	0xca, 0x86, 0x98, 0x4c, 0x93, 0x77, 0xe3, 0x40, 0x33, 0x41, 0x1b, 0xff, 0x5e, 0x82, 0xaa, 0x60,
+	0x97, 0x5a, 0x26, 0x5a, 0x7a, 0x99, 0xac, 0x42, 0x71, 0x38, 0x8a, 0xc4, 0xc2, 0x6d, 0x53, 0x66,
+	0x47, 0xa3, 0x48, 0x8a, 0x41, 0x51, 0x94, 0xa2, 0x87, 0xa3, 0x95, 0x62, 0x42, 0xf1, 0x18, 0x27,
+	0x14, 0x3d, 0x1c, 0xa1, 0x47, 0xd0, 0xa2, 0x7b, 0xee, 0xe9, 0x85, 0x35, 0x0c, 0x71, 0xd7, 0x7b,
+	0xc5, 0x0c, 0xdc, 0xd8, 0x58, 0x16, 0xb4, 0x5b, 0x17, 0x47, 0x0c, 0x2c, 0xfb, 0x34, 0x7a, 0x09,
+	0x0c, 0xbd, 0x03, 0x15, 0xe1, 0xf6, 0xe5, 0xc4, 0xb2, 0xdc, 0xdf, 0x25, 0xbd, 0x20, 0x40, 0xf7,
+	0xa0, 0x3c, 0xc0, 0x61, 0x0f, 0x0b, 0x1f, 0x60, 0x66, 0x3c, 0xa0, 0x00, 0x49, 0xc8, 0xd1, 0xe8,
+	0x21, 0xd4, 0x4f, 0xed, 0xc8, 0x39, 0xb3, 0xa8, 0xd8, 0x55, 0x46, 0xbb, 0x40, 0x69, 0xb7, 0x28,
+	0x50, 0x91, 0xbd, 0x76, 0x2a, 0x00, 0xe8, 0x13, 0x68, 0xf2, 0x1e, 0xca, 0x0a, 0x14, 0xf2, 0xb3,
+	0x4e, 0x69, 0x79, 0x1a, 0xa7, 0x09, 0x0c, 0x6d, 0x43, 0x87, 0x77, 0x52, 0xa6, 0x5f, 0x67, 0xdd,
+	0x6f, 0x24, 0x33, 0x19, 0xd7, 0x40, 0xdb, 0x4d, 0x81, 0xd1, 0xa7, 0xd0, 0x1e, 0x86, 0x78, 0x68,
+	0x87, 0x58, 0x4a, 0x00, 0x49, 0x26, 0x77, 0xc4, 0x31, 0x69, 0x19, 0x5a, 0x43, 0x15, 0x8a, 0xfe,
+	0x3f, 0xb4, 0x9c, 0x60, 0x30, 0xf0, 0xe2, 0x18, 0xd2, 0x60, 0xfd, 0xaf, 0xb3, 0x64, 0x84, 0x21,
+	0xd2, 0xdd, 0x9b, 0x8e, 0x02, 0xa4, 0xd3, 0xb7, 0x4f, 0x83, 0x30, 0xee, 0xdc, 0x4c, 0xa6, 0xbf,
+	0x49, 0xe1, 0x63, 0xd3, 0xb7, 0x13, 0x98, 0xf1, 0x67, 0x45, 0x80, 0xc4, 0x61, 0xbe, 0x7e, 0x2c,
+	0x31, 0xa0, 0xc5, 0xd3, 0x59, 0x19, 0x05, 0x8a, 0x3c, 0x0a, 0x08, 0x20, 0x8b, 0x02, 0x6f, 0x02,
+	0x44, 0x51, 0xdf, 0x22, 0xd8, 0x09, 0x7c, 0x57, 0xc4, 0xf3, 0x7a, 0x14, 0xf5, 0x8f, 0x19, 0x00,
+	0x3d, 0x82, 0x4e, 0x30, 0xb4, 0x6c, 0xdf, 0xb5, 0x92, 0xa8, 0x54, 0x9e, 0x14, 0x95, 0x5a, 0x81,
+	0xda, 0x4c, 0x42, 0x53, 0x45, 0x0d, 0x4d, 0xab, 0xd0, 0xc4, 0xaf, 0x86, 0x5e, 0x88, 0x85, 0x4c,
+	0x55, 0x26, 0x13, 0x70, 0x18, 0x13, 0xe9, 0x2e, 0xb4, 0xe3, 0xac, 0x8e, 0x33, 0xa8, 0x31, 0x06,
+	0x2d, 0x09, 0xe5, 0x21, 0xf6, 0x43, 0x58, 0x8e, 0xc9, 0xd2, 0xd3, 0xac, 0x33, 0x96, 0x71, 0xf6,
+	0xf7, 0xb9, 0x32, 0xdd, 0x3b, 0x20, 0xb8, 0x58, 0xf6, 0x29, 0xc1, 0x7e, 0xc4, 0x7c, 0xa2, 0x66,
+	0x36, 0x39, 0x70, 0x93, 0xc1, 0xd4, 0xc8, 0xd8, 0xb8, 0x34, 0x32, 0xfe, 0x85, 0x06, 0x4d, 0x75,
+	0xc1, 0xfc, 0x74, 0xcd, 0x95, 0x67, 0x8f, 0xd2, 0xac, 0xf6, 0x28, 0x2b, 0xf6, 0x30, 0xfe, 0xa5,
+	0x00, 0xad, 0xef, 0xd1, 0x08, 0x2c, 0xe3, 0x1d, 0x4d, 0xa1, 0x82, 0x97, 0x4c, 0xfe, 0x9a, 0x59,
+	0x08, 0x58, 0xc0, 0x16, 0x5b, 0x34, 0x4f, 0x23, 0x45, 0x8b, 0x06, 0x6c, 0xfc, 0xca, 0x23, 0x11,
+	0xe6, 0x69, 0x42, 0xcd, 0x94, 0x4d, 0x9a, 0xbe, 0xf5, 0x83, 0x9e, 0x45, 0x70, 0x6f, 0x40, 0x75,
+	0xcc, 0xbd, 0x0a, 0xfa, 0x41, 0xef, 0x98, 0x43, 0xa8, 0xd7, 0x51, 0x82, 0xa0, 0xdb, 0x25, 0x38,
+	0x12, 0x9b, 0x53, 0xbd, 0x1f, 0xf4, 0x0e, 0x19, 0x80, 0x5a, 0x49, 0xe6, 0xe8, 0xaa, 0x07, 0x35,
+	0x05, 0x90, 0xdb, 0xff, 0x03, 0x58, 0x92, 0x44, 0x69, 0xb5, 0x71, 0x8f, 0x92, 0x99, 0xbc, 0x6a,
+	0xfd, 0x8c, 0x86, 0x6b, 0x59, 0x0d, 0xbf, 0x05, 0xe0, 0x04, 0xbe, 0xe8, 0xcc, 0x5c, 0xa9, 0x66,
+	0x2a, 0x10, 0xd5, 0x39, 0xe0, 0x52, 0xe7, 0xf8, 0x83, 0x22, 0xb4, 0x52, 0x0b, 0xfd, 0xa7, 0xeb,
+	0x1d, 0xd9, 0x95, 0x53, 0x9a, 0x6d, 0xe5, 0x94, 0x27, 0xaf, 0x1c, 0x04, 0x25, 0x12, 0x74, 0x23,
+	0x66, 0x8a, 0x9a, 0xc9, 0xbe, 0x69, 0x4a, 0x7f, 0x6e, 0x7b, 0x91, 0xd5, 0x0d, 0x42, 0x4b, 0xa4,
+	0x76, 0x5c, 0xfd, 0x2d, 0x73, 0x8e, 0x22, 0xf6, 0x82, 0xd0, 0x14, 0x60, 0x74, 0x0f, 0xe6, 0x04,
+	0x89, 0xc5, 0xfa, 0x0c, 0x88, 0xa8, 0x11, 0x5a, 0x02, 0xfc, 0x3d, 0xdb, 0x8b, 0x0e, 0x08, 0xdb,
+	0x56, 0x2f, 0x7c, 0xc7, 0xea, 0x07, 0x3d, 0xa1, 0xfd, 0x2a, 0x6d, 0x3f, 0x0b, 0x7a, 0x2c, 0x56,
+	0x79, 0x03, 0x1c, 0x8c, 0x58, 0x6f, 0x10, 0xb1, 0x8a, 0x43, 0x0e, 0xc8, 0x6c, 0xcb, 0xd6, 0x07,
+	0x48, 0x76, 0xad, 0xaf, 0x6f, 0x95, 0xb7, 0x61, 0xce, 0xf3, 0x9d, 0xfe, 0xc8, 0x95, 0x9b, 0x8c,
+	0x5c, 0x0b, 0x6d, 0x01, 0xe6, 0xd6, 0x77, 0x0d, 0x17, 0x1a, 0x6c, 0xbc, 0x19, 0xd7, 0xd8, 0x7b,
+	0x50, 0x7f, 0x89, 0x2f, 0x84, 0x31, 0x8b, 0xc9, 0x16, 0xad, 0x26, 0x9b, 0x2c, 0x03, 0x62, 0x5f,
+	0xc6, 0x08, 0xe6, 0xc6, 0x36, 0x64, 0x6a, 0x37, 0x9a, 0x20, 0xb1, 0xcc, 0xa6, 0x69, 0xb2, 0xef,
+	0x6f, 0x7c, 0x72, 0x18, 0x3a, 0xc9, 0xb0, 0x33, 0xce, 0xf0, 0x1d, 0xa8, 0x86, 0x98, 0x8c, 0xfa,
+	0x51, 0xaa, 0xd8, 0x57, 0x38, 0x99, 0x12, 0x6f, 0x9c, 0x01, 0xca, 0x66, 0x0e, 0xd4, 0xec, 0x5c,
+	0x3a, 0x99, 0xbd, 0xe5, 0x64, 0x3b, 0x92, 0xe2, 0x8a, 0x33, 0x37, 0xbe, 0x84, 0x85, 0xd4, 0x48,
+	0x33, 0xce, 0x69, 0x6d, 0x7c, 0x4e, 0x4c, 0xa4, 0x54, 0x94, 0x4d, 0x66, 0xd5, 0x05, 0x94, 0xcd,
+	0xe7, 0x28, 0x6b, 0x91, 0xf8, 0x70, 0xa7, 0x14, 0x2d, 0x1a, 0xc4, 0xfb, 0xde, 0xc0, 0x8b, 0x44,
+	0xd5, 0xc6, 0x1b, 0x34, 0x38, 0xf4, 0x6d, 0x12, 0x59, 0x04, 0x63, 0xdf, 0xa2, 0x9e, 0x5c, 0x64,
+	0x9d, 0x1a, 0x14, 0x78, 0x8c, 0xb1, 0xff, 0x14, 0x5f, 0x18, 0x3e, 0x2c, 0xa4, 0xc6, 0x99, 0x71,
+	0x4e, 0xef, 0x03, 0xc4, 0x9e, 0x28, 0xa7, 0x95, 0x75, 0xc5, 0xba, 0x74, 0x45, 0x62, 0x78, 0xb0,
+	0x94, 0x9b, 0xa8, 0xcd, 0x3e, 0xb5, 0xcb, 0xe2, 0x9e, 0xf1, 0x1b, 0x1a, 0x2c, 0x8f, 0x8f, 0x35,
+	0xe3, 0xf4, 0xee, 0x24, 0x15, 0x93, 0x7a, 0xe0, 0xdb, 0x14, 0x40, 0x76, 0xe4, 0x4b, 0x63, 0xd3,
+	0x99, 0x4d, 0xac, 0x41, 0x10, 0x62, 0x71, 0xcc, 0x56, 0x3d, 0xb3, 0xc9, 0x41, 0x10, 0x62, 0xe3,
+	0x17, 0x61, 0x31, 0x2f, 0xa7, 0x44, 0x4b, 0x50, 0x89, 0x5e, 0xf9, 0xb2, 0x46, 0xa8, 0x9b, 0xe5,
+	0xe8, 0x95, 0xbf, 0xef, 0xaa, 0x4e, 0x5b, 0xb8, 0xcc, 0x69, 0x8d, 0x07, 0xb0, 0x90, 0x93, 0x6f,
+	0x4e, 0x60, 0x6d, 0xac, 0x01, 0xca, 0x26, 0x98, 0x93, 0x88, 0xff, 0xb6, 0x00, 0xb5, 0x58, 0x57,
+	0x6f, 0x43, 0x99, 0xd5, 0x62, 0xea, 0x09, 0x55, 0xda, 0x69, 0x39, 0x1e, 0xdd, 0xe6, 0xd5, 0x0b,
+	0xaf, 0x6f, 0x32, 0xeb, 0x95, 0xe2, 0xd0, 0x77, 0xc7, 0xcb, 0x97, 0x62, 0x92, 0x3c, 0xe7, 0xb8,
+	0x61, 0xba, 0x7e, 0xf9, 0x40, 0x2d, 0x36, 0x78, 0xdd, 0xb3, 0x98, 0x2e, 0x36, 0x44, 0xaf, 0xa4,
+	0xda, 0x78, 0x34, 0x56, 0x6d, 0x94, 0x93, 0xe1, 0x72, 0x56, 0x72, 0xba, 0xdc, 0xd8, 0xc9, 0x29,
+	0x37, 0x78, 0x39, 0xa4, 0xe7, 0x95, 0x1b, 0x82, 0xc5, 0x58, 0xbd, 0x61, 0x7c, 0x1b, 0x1a, 0xa6,
+	0x7d, 0xfe, 0x54, 0xf8, 0x7f, 0xce, 0x96, 0xb2, 0xa8, 0x1e, 0xe8, 0xc4, 0xf9, 0xd7, 0x8f, 0x0b,
+	0x50, 0x7b, 0x16, 0xf4, 0xf8, 0x29, 0x50, 0xc6, 0xd9, 0xb5, 0xec, 0x26, 0x7f, 0x79, 0x71, 0x99,
+	0x94, 0x7f, 0xc5, 0x2b, 0x97, 0x7f, 0xa5, 0xe9, 0xe5, 0xdf, 0x22, 0x94, 0xf1, 0x30, 0x70, 0xce,
+	0x44, 0x86, 0xc0, 0x1b, 0xb4, 0x18, 0x77, 0xce, 0xb0, 0xf3, 0x92, 0x8c, 0x06, 0x4c, 0x61, 0x55,
+	0x33, 0x6e, 0xd3, 0x1e, 0xdd, 0x3e, 0x3f, 0x25, 0x64, 0xab, 0x99, 0x35, 0xd0, 0xb7, 0xe5, 0x32,
+	0xb3, 0x3c, 0x3f, 0xa2, 0x09, 0x56, 0x2d, 0x19, 0x97, 0x4b, 0xb8, 0xcf, 0xe0, 0x72, 0xe1, 0xf1,
+	0x96, 0xf1, 0x8f, 0x1a, 0x34, 0x55, 0xf4, 0xa4, 0x65, 0xf5, 0x00, 0xca, 0xc3, 0x33, 0x9b, 0x70,
+	0x15, 0xb7, 0x79, 0xb5, 0xa5, 0xf6, 0x5b, 0x3f, 0xa2, 0x58, 0x93, 0x13, 0xa9, 0x8b, 0xb0, 0x78,
+	0xe9, 0xce, 0xf1, 0xad, 0xb8, 0x9c, 0x94, 0xb6, 0x29, 0x31, 0x55, 0x34, 0x25, 0x94, 0x45, 0xa2,
+	0x35, 0x28, 0xb3, 0x21, 0xe8, 0x39, 0xd1, 0x91, 0xb9, 0x7b, 0xb4, 0x69, 0xee, 0x76, 0xae, 0x21,
+	0x80, 0xca, 0xf6, 0xe1, 0xc1, 0xc1, 0xfe, 0x09, 0x3f, 0x4b, 0xda, 0xdc, 0x3a, 0x34, 0x4f, 0x3a,
+	0x05, 0xe3, 0x18, 0xda, 0xdb, 0xc1, 0xf0, 0x62, 0x27, 0xf0, 0xd9, 0x5d, 0x0e, 0x57, 0x33, 0x3b,
+	0x43, 0x60, 0xb3, 0x2a, 0x9b, 0xbc, 0x81, 0xd6, 0x00, 0x39, 0xc1, 0xf0, 0xc2, 0x22, 0x91, 0x1d,
+	0x46, 0x16, 0x4d, 0x78, 0xe8, 0xf0, 0x74, 0x8a, 0x45, 0x73, 0x8e, 0x62, 0x8e, 0x29, 0xe2, 0xc4,
+	0x1b, 0xe0, 0xe7, 0xc4, 0xf8, 0x2f, 0x0d, 0x16, 0xb7, 0x82, 0x20, 0x22, 0x51, 0x68, 0x0f, 0x29,
+	0x7b, 0x19, 0x01, 0xa6, 0x9d, 0x9c, 0xa8, 0x67, 0x19, 0x85, 0xe9, 0x47, 0x7e, 0x39, 0x67, 0x9f,
+	0xf7, 0x60, 0x4e, 0xdc, 0x10, 0xc4, 0x4c, 0x78, 0xca, 0xdf, 0xe2, 0xe0, 0x63, 0xc1, 0x6a, 0xc2,
+	0x4d, 0x42, 0x79, 0xd2, 0x4d, 0xc2, 0x32, 0x54, 0x82, 0xd0, 0xeb, 0x79, 0xfc, 0x80, 0xaa, 0x6e,
+	0x8a, 0x56, 0xb2, 0x51, 0xf0, 0x4c, 0x9f, 0x37, 0x8c, 0xff, 0xd4, 0x60, 0x69, 0x6c, 0xe2, 0x22,
+	0xae, 0xad, 0xa7, 0xb6, 0x2e, 0xe5, 0x1a, 0x46, 0x59, 0xaf, 0xca, 0xce, 0x85, 0x7e, 0x09, 0xd0,
+	0xa9, 0xe7, 0xf7, 0x83, 0xde, 0x89, 0xed, 0xf5, 0x8f, 0xc2, 0xa0, 0xc7, 0x4e, 0xc2, 0xf9, 0x82,
+	0x7b, 0xc0, 0x22, 0x4a, 0xde, 0x30, 0xeb, 0x5b, 0x99, 0x3e, 0x66, 0x0e, 0x1f, 0x7d, 0x0f, 0x50,
+	0x96, 0x92, 0x16, 0x53, 0xb2, 0x5c, 0x92, 0x87, 0x49, 0xbc, 0xc9, 0xb4, 0xc0, 0xeb, 0x24, 0x9e,
+	0xaa, 0x88, 0x96, 0xf1, 0xc3, 0x02, 0xcc, 0x1f, 0x8d, 0xfa, 0x7d, 0x71, 0x73, 0xf5, 0x7a, 0x56,
+	0x56, 0x86, 0x2f, 0x4e, 0x1a, 0xbe, 0xa4, 0x0e, 0x9f, 0x18, 0xa1, 0xac, 0xee, 0xd6, 0x39, 0xae,
+	0x50, 0x99, 0xc1, 0x15, 0xaa, 0x97, 0xbb, 0x42, 0x4d, 0x75, 0x05, 0xe3, 0x4f, 0x34, 0x40, 0xaa,
+	0x12, 0x84, 0xc5, 0x6f, 0x43, 0xd3, 0xc7, 0xaf, 0x22, 0x2b, 0xad, 0xd2, 0x06, 0x85, 0xc9, 0x12,
+	0xf4, 0x16, 0xb0, 0xa6, 0x95, 0xd2, 0x2d, 0x50, 0x90, 0x28, 0x42, 0xef, 0x41, 0x15, 0xfb, 0x51,
+	0xe8, 0xc5, 0xd1, 0xa1, 0xc9, 0xef, 0x0d, 0x78, 0xa8, 0x36, 0x25, 0x12, 0xbd, 0x05, 0x0d, 0x5a,
+	0x91, 0x04, 0x5d, 0x8b, 0xd6, 0x29, 0x22, 0x2f, 0xa8, 0x07, 0xa3, 0xe8, 0xb0, 0x7b, 0x7c, 0xe1,
+	0x3b, 0xc6, 0x53, 0x40, 0xdb, 0x34, 0x28, 0x72, 0xa3, 0xbf, 0x9e, 0x9d, 0x8c, 0x1f, 0x6a, 0xb0,
+	0x90, 0xe2, 0x26, 0x26, 0x3c, 0xe5, 0x30, 0xf2, 0x1d, 0xe8, 0x60, 0x3b, 0xec, 0x7b, 0x98, 0x24,
+	0xfa, 0xe0, 0x5c, 0xe7, 0x24, 0x5c, 0xea, 0xe4, 0x2e, 0xb4, 0xfb, 0x76, 0xa4, 0x12, 0x72, 0x67,
+	0x68, 0x71, 0xa8, 0x20, 0x33, 0xfe, 0x52, 0x83, 0xf9, 0xa7, 0xf8, 0xe2, 0x89, 0x47, 0xa2, 0x20,
+	0x7c, 0xdd, 0xf8, 0x22, 0xf6, 0xc9, 0xe2, 0xb4, 0xd2, 0xab, 0x94, 0x57, 0x9d, 0xe4, 0x3b, 0xe0,
+	0x1d, 0x68, 0x09, 0xd9, 0x45, 0x1e, 0xc7, 0xdd, 0xaf, 0x29, 0x80, 0xfc, 0xea, 0xde, 0x04, 0xa4,
+	0xca, 0x2f, 0x74, 0xa8, 0x18, 0x5c, 0x9b, 0x66, 0x70, 0xba, 0x17, 0x86, 0x61, 0x10, 0x8a, 0x0c,
+	0x92, 0x37, 0x8c, 0xbf, 0xd2, 0xa0, 0xfd, 0x18, 0x47, 0x9b, 0xe4, 0xb0, 0xfb, 0x7f, 0xa5, 0x91,
+	0x15, 0xa8, 0xd9, 0x84, 0x3a, 0x62, 0x5c, 0xc9, 0x57, 0x6c, 0x72, 0xd8, 0xe5, 0xc7, 0x5e, 0x97,
+	0x6b, 0xe5, 0x1c, 0xe6, 0xe2, 0x09, 0x08, 0x95, 0xa4, 0xca, 0x4f, 0xed, 0xb2, 0xf2, 0x53, 0xdc,
+	0xe7, 0x3b, 0xc1, 0x60, 0xa8, 0xdc, 0x62, 0x83, 0x47, 0xb6, 0x05, 0x24, 0x51, 0x5d, 0x51, 0x55,
+	0xdd, 0x22, 0xa0, 0x1d, 0xcf, 0xee, 0xf9, 0x01, 0x89, 0x3c, 0x87, 0x08, 0xed, 0x19, 0x3f, 0xaa,
+	0xc2, 0x42, 0x0a, 0x2c, 0x64, 0xda, 0x87, 0xba, 0xd4, 0xa2, 0x34, 0xd4, 0x1a, 0xdb, 0xb7, 0xb3,
+	0xb4, 0xeb, 0x4f, 0x05, 0xa1, 0x8a, 0x4b, 0x7a, 0xeb, 0x3f, 0xd2, 0xa0, 0xcd, 0x5f, 0x2b, 0xc4,
+	0x71, 0xf8, 0x21, 0x2c, 0x8a, 0x9b, 0xb1, 0xf4, 0x3d, 0x28, 0xb7, 0x1f, 0xe2, 0xb8, 0x4d, 0xf5,
+	0x36, 0x74, 0xfa, 0xde, 0x99, 0x0a, 0x43, 0xc5, 0x4b, 0xc3, 0x50, 0x69, 0x3c, 0x0c, 0xe9, 0xbf,
+	0x59, 0x84, 0x0e, 0x8b, 0x9a, 0xca, 0x1c, 0xa6, 0x2d, 0xf7, 0x99, 0x6e, 0x8d, 0xaf, 0xb8, 0xe2,
+	0xa9, 0xff, 0x08, 0xb2, 0x94, 0x9c, 0x4d, 0x0e, 0x14, 0x01, 0xf3, 0x18, 0xe6, 0xf9, 0xb3, 0x0d,
+	0x6b, 0x28, 0xb4, 0x89, 0xa9, 0x1f, 0xc6, 0x57, 0xae, 0x79, 0x06, 0x4a, 0x6b, 0xdf, 0xec, 0x74,
+	0x53, 0x6d, 0x4c, 0xd0, 0x03, 0x40, 0x9e, 0x6f, 0x75, 0xfb, 0x5e, 0xef, 0x2c, 0xb2, 0xe2, 0xdb,
+	0x1d, 0xee, 0xbe, 0x1d, 0xcf, 0xdf, 0x63, 0x88, 0xf8, 0x76, 0x68, 0x0d, 0xe6, 0x43, 0xfc, 0x25,
+	0x3f, 0xd9, 0x8a, 0x89, 0x79, 0x96, 0xd0, 0x91, 0x08, 0x95, 0x58, 0x66, 0xab, 0x56, 0xd7, 0xf6,
+	0xfa, 0xa3, 0x10, 0xcb, 0x13, 0xc1, 0x8e, 0x44, 0xec, 0x09, 0xb8, 0xfe, 0x7b, 0x05, 0x58, 0xc8,
+	0xf1, 0xa6, 0xa9, 0x6b, 0x7c, 0xea, 0x2d, 0xeb, 0x37, 0x7e, 0xa7, 0x8c, 0xde, 0x87, 0x05, 0xc9,
+	0xb1, 0xeb, 0xf9, 0x3d, 0x1c, 0x0e, 0x43, 0xcf, 0x97, 0xa7, 0xab, 0x48, 0xa0, 0xf6, 0x12, 0x0c,
+	0xfa, 0x0c, 0x2a, 0xcc, 0x13, 0xa8, 0x3e, 0x8b, 0xf2, 0xed, 0x4f, 0x9e, 0x95, 0xc6, 0xfd, 0xcf,
+	0x14, 0xfd, 0x8c, 0xdf, 0x67, 0x6f, 0x5e, 0x42, 0x6c, 0x0f, 0xd2, 0xd5, 0xe6, 0xd7, 0x8c, 0x7c,
+	0x33, 0x25, 0xe4, 0x3a, 0xd4, 0x08, 0x85, 0xf9, 0x0e, 0x16, 0xee, 0x18, 0xb7, 0x8d, 0xbf, 0xd1,
+	0x60, 0x51, 0x95, 0x2b, 0x5e, 0xde, 0x99, 0x32, 0x9f, 0x17, 0x58, 0xe9, 0x32, 0xff, 0x36, 0x34,
+	0xa9, 0x3f, 0xc4, 0x34, 0x3c, 0x37, 0x68, 0x70, 0x18, 0x27, 0x79, 0x00, 0x48, 0xc8, 0x41, 0xaf,
+	0x9a, 0xe5, 0xf5, 0x09, 0xb5, 0xa1, 0x66, 0x8a, 0x62, 0x92, 0xde, 0x34, 0x8b, 0x5b, 0x94, 0x3b,
+	0xf1, 0xf1, 0x4c, 0x4a, 0xde, 0x26, 0x3f, 0x9e, 0xe1, 0xb0, 0x24, 0x36, 0x96, 0xd5, 0xd8, 0xe8,
+	0x01, 0xda, 0xc1, 0xb6, 0xfb, 0x0c, 0x47, 0x11, 0x0e, 0xc9, 0x6b, 0xea, 0xf7, 0x0d, 0x7a, 0xd5,
+	0x39, 0x0c, 0x03, 0x47, 0xbe, 0xfc, 0xa8, 0x99, 0x09, 0x80, 0x9e, 0xa2, 0x2c, 0xa4, 0xc6, 0x9a,
+	0x71, 0x5f, 0x64, 0x8b, 0x4f, 0x30, 0x4b, 0xe9, 0xae, 0x65, 0x76, 0x14, 0x04, 0x57, 0x60, 0xfe,
+	0x4e, 0xf0, 0x47, 0x1a, 0x34, 0x37, 0x9d, 0x97, 0xd8, 0x7d, 0xcd, 0x89, 0x66, 0x9e, 0xb1, 0x14,
+	0x73, 0x9e, 0xb1, 0x28, 0x39, 0x6f, 0x69, 0x52, 0xce, 0x5b, 0x4e, 0xa5, 0xdc, 0xbf, 0x0e, 0x2d,
+	0x21, 0x9d, 0x50, 0xcd, 0x22, 0x94, 0x6d, 0x0a, 0x10, 0x07, 0x4c, 0xbc, 0x91, 0x09, 0xfb, 0x85,
+	0x4b, 0xc3, 0x7e, 0x31, 0x93, 0x7d, 0xc6, 0xfa, 0x29, 0xa9, 0xfa, 0x79, 0x0c, 0xf3, 0x6c, 0x2d,
+	0xd2, 0x17, 0x0b, 0x57, 0x72, 0x86, 0x65, 0xf6, 0x8c, 0xcd, 0xb1, 0x7d, 0xb1, 0x19, 0x8b, 0x16,
+	0xcd, 0x80, 0x54, 0x46, 0xb1, 0xa5, 0x65, 0x40, 0xe0, 0x86, 0x6e, 0xc7, 0xfb, 0x06, 0xa7, 0x13,
+	0xd8, 0x09, 0x19, 0xd0, 0x53, 0x40, 0x7b, 0xfd, 0x11, 0x39, 0xfb, 0x46, 0x12, 0xdd, 0x5f, 0x86,
+	0x85, 0x14, 0x33, 0x21, 0xe1, 0xcc, 0x65, 0xd2, 0x04, 0x47, 0xfb, 0x35, 0x80, 0x64, 0x5e, 0x5f,
+	0xd7, 0xcb, 0x6e, 0xf2, 0xec, 0x28, 0x39, 0x2f, 0x2c, 0xb1, 0x7e, 0xdc, 0xc1, 0x6f, 0x42, 0xfd,
+	0xf4, 0x22, 0xc2, 0xc9, 0x3b, 0x99, 0x92, 0x59, 0xa3, 0x00, 0x1a, 0xd9, 0x8d, 0xdf, 0x2d, 0xc2,
+	0xdc, 0x0e, 0x26, 0x4e, 0xe8, 0x9d, 0xc6, 0x31, 0xf3, 0x10, 0xe6, 0x5d, 0x4c, 0x1c, 0x4b, 0x79,
+	0xf8, 0x44, 0x44, 0xce, 0x75, 0x87, 0x87, 0xc1, 0x14, 0x3d, 0x6b, 0xef, 0xc4, 0x2f, 0xa2, 0x88,
+	0x39, 0xe7, 0xa6, 0x01, 0xe8, 0x09, 0xb4, 0x19, 0xc3, 0x24, 0x5b, 0xe2, 0xd9, 0xc0, 0xed, 0x49,
+	0xdc, 0xe4, 0xfe, 0x46, 0xcc, 0x96, 0xab, 0x36, 0xd1, 0x16, 0x34, 0x19, 0x27, 0xf9, 0xf2, 0x92,
+	0x1f, 0x2b, 0xdd, 0x9a, 0xc4, 0x47, 0xbe, 0xc6, 0x6c, 0xb8, 0x49, 0x43, 0xe1, 0xe1, 0x61, 0x3f,
+	0x22, 0x2b, 0xa5, 0xcb, 0x78, 0x30, 0x32, 0xc9, 0x83, 0x35, 0xf4, 0x79, 0xae, 0x35, 0x65, 0x92,
+	0xfa, 0x1c, 0xbd, 0x60, 0x53, 0x64, 0xd5, 0xdf, 0x81, 0x86, 0x22, 0xc3, 0x34, 0xd3, 0xea, 0x2d,
+	0x49, 0xca, 0xb8, 0x1b, 0x7f, 0x5c, 0x81, 0x4e, 0x22, 0x8a, 0x70, 0xb8, 0x03, 0xe8, 0x8c, 0x5b,
+	0x25, 0xdf, 0x28, 0x62, 0xab, 0x4c, 0xcb, 0x67, 0xb6, 0xd3, 0x46, 0x41, 0xfb, 0x13, 0x6c, 0x62,
+	0x4c, 0x64, 0x36, 0xd1, 0x28, 0xdb, 0xb9, 0x46, 0x59, 0x9d, 0xc8, 0x28, 0xd7, 0x2a, 0x2c, 0x0b,
+	0xf1, 0x92, 0xba, 0x20, 0x7e, 0xd0, 0xe5, 0xc9, 0xb2, 0x40, 0xff, 0x73, 0x0d, 0xda, 0xe9, 0x59,
+	0xa1, 0x43, 0x68, 0x64, 0xf5, 0xb1, 0x7e, 0x05, 0x7d, 0xac, 0x27, 0x9f, 0xea, 0x73, 0x3e, 0xfd,
+	0x09, 0x80, 0xc2, 0xfe, 0x11, 0xcc, 0xa5, 0x9f, 0x4c, 0xa6, 0x0e, 0xc9, 0xd3, 0x6f, 0x26, 0xdb,
+	0xa9, 0x37, 0x93, 0x44, 0xff, 0x07, 0x6d, 0xcc, 0x21, 0x26, 0xd7, 0x0b, 0x53, 0xb5, 0x1d, 0x97,
+	0x0e, 0x6a, 0xbd, 0x10, 0x42, 0x4d, 0x82, 0x2f, 0x7b, 0x88, 0x24, 0xac, 0x92, 0x7a, 0x88, 0x24,
+	0x2d, 0x10, 0x23, 0x33, 0xea, 0x2f, 0x66, 0xd5, 0xff, 0xdb, 0x5a, 0xda, 0xa1, 0xaf, 0xf8, 0x00,
+	0x7a, 0x5d, 0xec, 0x41, 0x92, 0xb6, 0x90, 0xa5, 0x65, 0x3b, 0xd0, 0x24, 0x47, 0xc8, 0x4a, 0x62,
+	0xfc, 0xb5, 0x06, 0x8b, 0xdb, 0x21, 0xb6, 0x23, 0x2c, 0x39, 0xe4, 0x84, 0xf8, 0x42, 0xf6, 0x75,
+	0xf2, 0x37, 0x9c, 0xe6, 0xae, 0x01, 0x8a, 0x82, 0xc8, 0xee, 0x5b, 0xa9, 0xf7, 0xa6, 0xfc, 0x10,
+	0x60, 0x8e, 0x61, 0x76, 0x92, 0x47, 0xa7, 0xf2, 0xa9, 0x6a, 0x25, 0x79, 0xaa, 0x6a, 0x9c, 0xc0,
+	0xd2, 0xd8, 0x34, 0x92, 0xdd, 0x9c, 0x6f, 0x15, 0x9a, 0xb2, 0x55, 0xa8, 0x0a, 0x2f, 0x4c, 0x56,
+	0xb8, 0xb1, 0x01, 0x8b, 0x3c, 0xd7, 0xbc, 0xba, 0x72, 0x8c, 0xf7, 0x60, 0x69, 0xac, 0xcf, 0x34,
+	0x49, 0x8c, 0x0f, 0x61, 0x89, 0x56, 0xd2, 0xb6, 0x13, 0xcd, 0x30, 0xc6, 0x3a, 0x2c, 0x8f, 0x77,
+	0x9a, 0x3a, 0xc8, 0x97, 0x80, 0x4c, 0x3c, 0xec, 0xd3, 0x97, 0xa2, 0x81, 0x8b, 0xaf, 0x62, 0xe2,
+	0xeb, 0x50, 0xf5, 0x03, 0x17, 0x27, 0xcf, 0x45, 0x2b, 0xb4, 0xb9, 0xef, 0xf2, 0x24, 0xe7, 0x7c,
+	0xec, 0x29, 0x31, 0xf8, 0xf8, 0x5c, 0x64, 0x60, 0xc6, 0x1a, 0x2c, 0xa4, 0xc6, 0x9a, 0x2a, 0xd8,
+	0xdf, 0x69, 0x80, 0xb8, 0xdd, 0xd8, 0xce, 0x7d, 0x95, 0xfc, 0xe2, 0x7f, 0xb9, 0x00, 0x5b, 0x03,
+	0xc4, 0x53, 0x85, 0x3c, 0xcf, 0x24, 0xbc, 0x86, 0x92, 0x9e, 0x49, 0xe7, 0x9e, 0x9a, 0xcd, 0x65,
+	0x96, 0xe7, 0x8e, 0x12, 0x47, 0xa5, 0xcb, 0x67, 0x4f, 0x2d, 0x3f, 0xde, 0x69, 0xea, 0x20, 0x1f,
+	0xc5, 0x9e, 0x32, 0xcb, 0x28, 0xef, 0xc3, 0xf5, 0x4c, 0xaf, 0xa9, 0xc3, 0xfc, 0xa9, 0x06, 0x37,
+	0xc5, 0xab, 0x90, 0x88, 0xd9, 0x5d, 0xdc, 0x9b, 0xfe, 0xec, 0x19, 0xd4, 0xf8, 0x08, 0xde, 0xc8,
+	0x97, 0x74, 0xea, 0x04, 0x3f, 0x06, 0x3d, 0xd5, 0x8b, 0xdf, 0xdd, 0x5e, 0x45, 0x97, 0x1f, 0xc2,
+	0xcd, 0xdc, 0x9e, 0x53, 0x87, 0xfb, 0x64, 0xbc, 0x53, 0x1f, 0xdb, 0xfe, 0x68, 0x78, 0x95, 0xf1,
+	0xc6, 0xe7, 0x17, 0x77, 0x9d, 0x3a, 0xe0, 0x3f, 0x69, 0xb0, 0xc2, 0x7f, 0x4d, 0xf2, 0xb3, 0xbd,
+	0x1c, 0x67, 0xbc, 0x6e, 0x32, 0x3e, 0x80, 0x1b, 0x39, 0xd3, 0x9a, 0xaa, 0x0a, 0x1b, 0x16, 0x44,
+	0x97, 0xab, 0xda, 0x78, 0xd6, 0x9f, 0xd3, 0x18, 0x0f, 0x60, 0x31, 0x3d, 0xc4, 0x54, 0x81, 0x4e,
+	0x63, 0xea, 0x2b, 0x7b, 0xc1, 0xcc, 0x12, 0xbd, 0x07, 0x4b, 0x63, 0x63, 0x4c, 0x15, 0xe9, 0x07,
+	0xd0, 0xe2, 0xe4, 0x57, 0xd9, 0x4b, 0x26, 0xc8, 0x52, 0x9c, 0x24, 0xcb, 0x3d, 0x68, 0x4b, 0xe6,
+	0xd3, 0x84, 0x78, 0x77, 0x1f, 0x5a, 0xa9, 0xb7, 0x91, 0xf4, 0x1a, 0x76, 0xeb, 0x8b, 0x93, 0xdd,
+	0xe3, 0xce, 0x35, 0x7a, 0x55, 0xbb, 0xf7, 0xec, 0x70, 0xf3, 0xe4, 0xff, 0x7d, 0xd4, 0xd1, 0xd0,
+	0x1c, 0x34, 0x0e, 0x36, 0xbf, 0x6f, 0x49, 0x40, 0x81, 0x01, 0xf6, 0x9f, 0xc7, 0x80, 0xe2, 0xc6,
+	0x4f, 0x4a, 0xd0, 0x78, 0x61, 0x93, 0x28, 0x38, 0xb0, 0x59, 0xe6, 0xf4, 0x5d, 0x3a, 0xbf, 0x9e,
+	0xc7, 0x44, 0x8a, 0x82, 0x10, 0x23, 0x14, 0x67, 0xa9, 0xf1, 0x2f, 0xe8, 0xf4, 0x4e, 0x0c, 0x93,
+	0xbf, 0xda, 0xbb, 0x76, 0x5f, 0x7b, 0xa8, 0xa1, 0x9f, 0x87, 0xb6, 0xec, 0xcc, 0xcb, 0x10, 0xb4,
+	0x90, 0xf3, 0x03, 0x3c, 0x7d, 0x3e, 0xf3, 0xeb, 0x33, 0xd1, 0xff, 0x3b, 0x50, 0x93, 0x79, 0x2c,
+	0xef, 0x39, 0x56, 0x4b, 0xe9, 0x8b, 0x79, 0xa9, 0xae, 0x71, 0x0d, 0xed, 0x41, 0x2b, 0x95, 0x04,
+	0x21, 0xfe, 0x03, 0xb7, 0x9c, 0xf4, 0x4e, 0xbf, 0x91, 0x83, 0x51, 0xf9, 0xa4, 0x52, 0x18, 0xce,
+	0x27, 0x2f, 0x13, 0xd2, 0x6f, 0xe4, 0x60, 0x62, 0x3e, 0xfb, 0xd0, 0x16, 0xdb, 0x88, 0x64, 0x74,
+	0x43, 0xbc, 0xb3, 0xce, 0xe6, 0x3b, 0xba, 0x9e, 0x87, 0x8a, 0x59, 0x7d, 0x2c, 0x1d, 0x4e, 0x72,
+	0x9a, 0x17, 0xcf, 0xf9, 0x13, 0x1f, 0xd4, 0x91, 0x0a, 0x8a, 0x7b, 0x7e, 0x06, 0x0d, 0x25, 0x1f,
+	0x41, 0xcb, 0x9c, 0x68, 0x3c, 0x19, 0xd2, 0xaf, 0x67, 0xe0, 0x31, 0x87, 0xbb, 0x34, 0x59, 0x3f,
+	0x1d, 0xf5, 0x84, 0x6f, 0xd4, 0x29, 0x25, 0xfb, 0x89, 0x88, 0x9e, 0x7c, 0x1a, 0xd7, 0x36, 0x7e,
+	0xd2, 0x00, 0x60, 0x3e, 0xc4, 0x3d, 0xe6, 0x09, 0xb4, 0x52, 0x57, 0xca, 0x5c, 0x89, 0x79, 0xb7,
+	0xf8, 0xfa, 0x8d, 0x1c, 0x8c, 0x1c, 0xfd, 0xa1, 0x86, 0x3e, 0x05, 0xa0, 0xd7, 0xca, 0xfc, 0xd4,
+	0x04, 0x2d, 0xf1, 0xd7, 0x21, 0x63, 0x77, 0xc4, 0xfa, 0xf2, 0x38, 0x58, 0x61, 0xf0, 0x19, 0x34,
+	0x94, 0xfb, 0x45, 0xae, 0x82, 0xec, 0xf5, 0xa5, 0x7e, 0x3d, 0x03, 0x8f, 0x55, 0xf0, 0x73, 0x00,
+	0xc9, 0xe5, 0x1a, 0x17, 0x21, 0x73, 0x59, 0xa8, 0x2f, 0x8f, 0x83, 0xe3, 0xee, 0x1f, 0x41, 0x55,
+	0xdc, 0x42, 0xf1, 0x85, 0x94, 0xbe, 0x53, 0xd3, 0x17, 0x52, 0x30, 0xd5, 0x72, 0x4a, 0xd4, 0x16,
+	0x62, 0x67, 0x76, 0x27, 0xfd, 0x7a, 0x06, 0xae, 0x3a, 0x60, 0x3a, 0x5b, 0x42, 0x8a, 0xbf, 0x8e,
+	0x25, 0x44, 0xba, 0x9e, 0x87, 0x8a, 0x59, 0x3d, 0x83, 0xb9, 0xb1, 0x94, 0x08, 0xa9, 0x1e, 0x3b,
+	0xce, 0xec, 0x66, 0x2e, 0x2e, 0xe6, 0xf6, 0x03, 0x1a, 0xd2, 0xb3, 0x49, 0x08, 0xba, 0x25, 0xbd,
+	0x70, 0x42, 0x22, 0xa5, 0xaf, 0x4e, 0x26, 0x88, 0x99, 0x7f, 0x1f, 0x16, 0x52, 0x14, 0x7c, 0x93,
+	0x41, 0x6f, 0x65, 0xba, 0xa6, 0x36, 0x38, 0xfd, 0xd6, 0x44, 0xfc, 0x44, 0xb1, 0xc5, 0x66, 0x91,
+	0x23, 0x76, 0x7a, 0xab, 0xd2, 0x57, 0x27, 0x13, 0xc4, 0xcc, 0x9f, 0xcb, 0x25, 0x2e, 0x95, 0xf1,
+	0x46, 0xb2, 0x9e, 0x73, 0xcc, 0xfe, 0xe6, 0x04, 0x6c, 0xcc, 0x6f, 0x1b, 0x9a, 0xea, 0x26, 0x8b,
+	0xae, 0x2b, 0x1d, 0x52, 0x13, 0x5f, 0xc9, 0x22, 0xd4, 0x50, 0x98, 0xda, 0x17, 0x91, 0x4a, 0x9c,
+	0x9e, 0xe3, 0x8d, 0x1c, 0x4c, 0xcc, 0xe7, 0x5b, 0x00, 0x2c, 0x86, 0xf0, 0xd8, 0x30, 0x21, 0x84,
+	0x50, 0x8f, 0x57, 0xef, 0xa1, 0x96, 0x33, 0x77, 0x37, 0x8a, 0xc7, 0xe7, 0xdc, 0xe9, 0x08, 0x0e,
+	0xc9, 0x71, 0xbf, 0xe0, 0x90, 0xb9, 0x6b, 0xd0, 0xaf, 0x67, 0xe0, 0x31, 0x87, 0xc7, 0xd0, 0x54,
+	0x6f, 0x59, 0xb8, 0xda, 0x72, 0xee, 0x83, 0xf4, 0x95, 0x71, 0x84, 0xbc, 0x90, 0x11, 0xdb, 0xd8,
+	0x3a, 0x94, 0xd9, 0xc1, 0x3a, 0x62, 0xfb, 0xa4, 0x7a, 0x03, 0xa0, 0xcf, 0x2b, 0x10, 0x45, 0xf4,
+	0xd6, 0x63, 0x1c, 0x29, 0x27, 0xb8, 0x4b, 0x63, 0x27, 0xd5, 0x6a, 0x98, 0xc9, 0x1e, 0x74, 0xf3,
+	0xc9, 0x2b, 0xe7, 0xcb, 0x7c, 0xf2, 0xd9, 0xd3, 0x6b, 0xfd, 0x7a, 0x06, 0x2e, 0x39, 0x6c, 0xbd,
+	0x09, 0x35, 0x2f, 0x58, 0x67, 0xff, 0x34, 0xb0, 0xc5, 0x83, 0xf9, 0x51, 0x18, 0x44, 0xc1, 0x91,
+	0xf6, 0xe3, 0x42, 0xe1, 0xc5, 0xf1, 0x69, 0x85, 0xfd, 0xfb, 0xc0, 0x87, 0xff, 0x33, 0x00, 0x3c,
+	0xf0, 0xa7, 0xe2, 0x8c, 0x40, 0x00, 0x00,
 }
@@ -0,0 +1,20 @@
+package pb
+
+// Increment returns a copy of the version with the counter of the writer incremented, as the version of the writer's next write.
+// The version can be nil for the first write.
+func (v *VersionVector) Increment(writer string) *VersionVector {
+	next := &VersionVector{}
+	found := false
+	for _, clock := range v.GetClocks() {
+		counter := clock.Counter
+		if clock.Writer == writer {
+			counter++
+			found = true
+		}
+		next.Clocks = append(next.Clocks, &VersionClock{Writer: clock.Writer, Counter: counter})
+	}
+	if !found {
+		next.Clocks = append(next.Clocks, &VersionClock{Writer: writer, Counter: 1})
+	}
+	return next
+}
@@ -0,0 +1,5 @@
+package pb
+
+// StatusPreconditionFailed is the WriteResponse status of a conditional write not matching the current entry.
+// The response has the current value and updated_at_ns to retry with.
+const StatusPreconditionFailed = "precondition failed"
@@ -0,0 +1,59 @@
+package binlog
+
+import (
+	"context"
+
+	"github.com/chrislusf/vasto/pb"
+)
+
+// appendSemaphore is a mutex which can also be waited for until a context is done.
+type appendSemaphore chan struct{}
+
+func newAppendSemaphore() appendSemaphore {
+	return make(appendSemaphore, 1)
+}
+
+func (s appendSemaphore) Lock() {
+	s <- struct{}{}
+}
+
+func (s appendSemaphore) Unlock() {
+	<-s
+}
+
+// lockContext locks the semaphore, or returns the context error if the context is done first.
+func (s appendSemaphore) lockContext(ctx context.Context) error {
+	select {
+	case s <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// AppendEntryContext is the same as AppendEntry, but gives up when the ctx is done before the entry is written,
+// e.g., while waiting behind an append stuck on a slow disk, and returns the ctx error,
+// context.DeadlineExceeded or context.Canceled. The entry is then not appended.
+// Once the write starts, it always waits for the write, and returns its result,
+// so that the caller knows whether the entry is logged.
+func (m *LogManager) AppendEntryContext(ctx context.Context, entry *pb.LogEntry) (LogPosition, error) {
+	if err := m.appendLock.lockContext(ctx); err != nil {
+		return LogPosition{}, err
+	}
+	defer m.appendLock.Unlock()
+	if err := ctx.Err(); err != nil {
+		return LogPosition{}, err
+	}
+	m.rotateIfFull()
+
+	return m.appendTo(m.lastLogFile, entry)
+}
+
+func (m *LogManager) appendTo(logFile *logSegmentFile, entry *pb.LogEntry) (LogPosition, error) {
+	offset, err := logFile.appendEntry(entry)
+	position := LogPosition{Segment: logFile.segment, Offset: offset}
+	if err == nil {
+		m.setWritten(position)
+	}
+	return position, err
+}
@@ -0,0 +1,68 @@
+package binlog
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/chrislusf/vasto/pb"
+	"github.com/magiconair/properties/assert"
+)
+
+func TestAppendEntryContext(t *testing.T) {
+
+	dir, err := ioutil.TempDir("", "vasto_append_context")
+	if err != nil {
+		t.Fatalf("temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	m := NewLogManager(dir, 0, 1024, 10)
+	m.Initialze()
+	defer m.Shutdown()
+
+	entry := &pb.LogEntry{
+		UpdatedAtNs: 1,
+		Put:         &pb.PutRequest{Key: []byte("k1"), Value: []byte("v1")},
+	}
+
+	_, err = m.AppendEntryContext(context.Background(), entry)
+	assert.Equal(t, err, nil, "append")
+	segment, offset := m.GetSegmentOffset()
+
+	// an append stuck on the disk holds the lock
+	m.appendLock.Lock()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	_, err = m.AppendEntryContext(ctx, entry)
+	cancel()
+	m.appendLock.Unlock()
+	assert.Equal(t, err, context.DeadlineExceeded, "append behind a stuck append")
+
+	ctx, cancel = context.WithCancel(context.Background())
+	cancel()
+	_, err = m.AppendEntryContext(ctx, entry)
+	assert.Equal(t, err, context.Canceled, "append with canceled context")
+
+	latestSegment, latestOffset := m.GetSegmentOffset()
+	assert.Equal(t, latestSegment, segment, "nothing appended")
+	assert.Equal(t, latestOffset, offset, "nothing appended")
+
+	// a write stuck on the disk is waited for past the deadline, and the binlog keeps working
+	m.lastLogFile.accessLock.Lock()
+	ctx, cancel = context.WithTimeout(context.Background(), 10*time.Millisecond)
+	go func() {
+		<-ctx.Done()
+		m.lastLogFile.accessLock.Unlock()
+	}()
+	position, err := m.AppendEntryContext(ctx, entry)
+	cancel()
+	assert.Equal(t, err, nil, "write outlasting the deadline")
+	assert.Equal(t, position.Segment, segment, "segment written")
+	assert.Equal(t, position.Offset, int64(offset), "entry written")
+
+	_, err = m.AppendEntryContext(context.Background(), entry)
+	assert.Equal(t, err, nil, "append after the write outlasting the deadline")
+
+}
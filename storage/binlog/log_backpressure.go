@@ -0,0 +1,96 @@
+package binlog
+
+import (
+	"fmt"
+	"sync"
+)
+
+// backpressure tracks how far the followers lag behind the latest appended entry.
+// It is disabled if highWatermark is not greater than 0, which is the default.
+type backpressure struct {
+	sync.Mutex
+	highWatermark int64
+	written       LogPosition
+	followers     map[string]LogPosition
+}
+
+// backpressureError is returned when the slowest follower lags behind over the high watermark.
+// The write can be retried after the follower catches up.
+type backpressureError struct {
+	follower      string
+	lag           int64
+	highWatermark int64
+}
+
+func (e backpressureError) Error() string {
+	return fmt.Sprintf("log backpressure: follower %s lags %d bytes behind, over the high watermark %d bytes",
+		e.follower, e.lag, e.highWatermark)
+}
+
+// IsBackpressure checks whether the error is from the followers lagging behind over the high watermark.
+func IsBackpressure(err error) bool {
+	_, ok := err.(backpressureError)
+	return ok
+}
+
+// SetHighWatermark sets the max bytes the slowest follower can lag behind the latest appended entry.
+// Beyond it, CheckBackpressure returns an error checked by IsBackpressure. 0 or less disables the backpressure.
+func (m *LogManager) SetHighWatermark(highWatermark int64) {
+	m.backpressure.Lock()
+	defer m.backpressure.Unlock()
+	m.backpressure.highWatermark = highWatermark
+}
+
+// SetFollowerPosition records the position the follower has read up to.
+func (m *LogManager) SetFollowerPosition(follower string, position LogPosition) {
+	m.backpressure.Lock()
+	defer m.backpressure.Unlock()
+	if m.backpressure.followers == nil {
+		m.backpressure.followers = make(map[string]LogPosition)
+	}
+	m.backpressure.followers[follower] = position
+}
+
+// RemoveFollower stops tracking the follower, when it is removed from the topology.
+// A disconnected follower is still tracked, so that a replica outage also slows down the writes.
+func (m *LogManager) RemoveFollower(follower string) {
+	m.backpressure.Lock()
+	defer m.backpressure.Unlock()
+	delete(m.backpressure.followers, follower)
+}
+
+// CheckBackpressure returns an error if the slowest follower lags behind over the high watermark.
+func (m *LogManager) CheckBackpressure() error {
+	m.backpressure.Lock()
+	defer m.backpressure.Unlock()
+
+	if m.backpressure.highWatermark <= 0 {
+		return nil
+	}
+	for follower, position := range m.backpressure.followers {
+		lag := m.lag(position)
+		if lag > m.backpressure.highWatermark {
+			return backpressureError{follower: follower, lag: lag, highWatermark: m.backpressure.highWatermark}
+		}
+	}
+	return nil
+}
+
+// lag estimates the bytes from the position to the latest appended entry, assuming full segments in between.
+func (m *LogManager) lag(position LogPosition) int64 {
+	written := m.backpressure.written
+	if position.Segment > written.Segment {
+		return 0
+	}
+	lag := int64(written.Segment-position.Segment)*m.logFileMaxSize + written.Offset - position.Offset
+	if lag < 0 {
+		return 0
+	}
+	return lag
+}
+
+func (m *LogManager) setWritten(position LogPosition) {
+	m.backpressure.Lock()
+	defer m.backpressure.Unlock()
+	m.backpressure.written = position
+}
@@ -0,0 +1,58 @@
+package binlog
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/chrislusf/vasto/pb"
+	"github.com/magiconair/properties/assert"
+)
+
+func TestBackpressure(t *testing.T) {
+
+	dir, err := ioutil.TempDir("", "vasto_backpressure")
+	if err != nil {
+		t.Fatalf("temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	m := NewLogManager(dir, 0, 1024, 10)
+	m.Initialze()
+	defer m.Shutdown()
+
+	appendEntry := func(i int) (LogPosition, error) {
+		return m.AppendEntry(&pb.LogEntry{
+			UpdatedAtNs: uint64(i),
+			Put: &pb.PutRequest{
+				Key:   []byte(fmt.Sprintf("key %4d", i)),
+				Value: []byte(fmt.Sprintf("value %4d", i)),
+			},
+		})
+	}
+
+	// disabled by default
+	m.SetFollowerPosition("slow", LogPosition{})
+	for i := 0; i < 10; i++ {
+		_, err := appendEntry(i)
+		assert.Equal(t, err, nil, "append without high watermark")
+	}
+
+	m.SetHighWatermark(100)
+	assert.Equal(t, IsBackpressure(m.CheckBackpressure()), true, "slow follower over the high watermark")
+
+	// the writes are checked before being applied, so an append already admitted never fails on backpressure
+	_, err = appendEntry(10)
+	assert.Equal(t, err, nil, "append under backpressure")
+
+	// the follower catches up
+	segment, offset := m.GetSegmentOffset()
+	m.SetFollowerPosition("slow", LogPosition{Segment: segment, Offset: offset})
+	assert.Equal(t, m.CheckBackpressure(), nil, "caught up follower")
+
+	m.SetFollowerPosition("slow", LogPosition{})
+	m.RemoveFollower("slow")
+	assert.Equal(t, m.CheckBackpressure(), nil, "removed follower")
+
+}
@@ -0,0 +1,81 @@
+package binlog
+
+import (
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+	"sync/atomic"
+
+	"github.com/chrislusf/vasto/pb"
+	"github.com/golang/protobuf/proto"
+)
+
+// the protobuf key of LogEntry.checksum, field 6 with the fixed32 wire type
+const checksumFieldKey = 6<<3 | 5
+
+// checksumMismatchError is returned when a binlog entry fails the checksum, e.g., partially written during an unclean shutdown.
+type checksumMismatchError struct {
+	expected, actual uint32
+}
+
+func (e checksumMismatchError) Error() string {
+	return fmt.Sprintf("log entry checksum %x, expecting %x", e.actual, e.expected)
+}
+
+// IsChecksumMismatch checks whether the error is from a binlog entry failing the checksum.
+func IsChecksumMismatch(err error) bool {
+	_, ok := err.(checksumMismatchError)
+	return ok
+}
+
+// encodeEntry marshals the entry, and appends the crc32 of the marshaled data as the checksum field.
+// The entry itself is not changed.
+func encodeEntry(entry *pb.LogEntry) ([]byte, error) {
+	if entry == nil {
+		return nil, fmt.Errorf("nil log entry")
+	}
+
+	unchecked := *entry
+	unchecked.Checksum = 0
+	data, err := proto.Marshal(&unchecked)
+	if err != nil {
+		return nil, err
+	}
+
+	checksum := make([]byte, 4)
+	binary.LittleEndian.PutUint32(checksum, crc32.ChecksumIEEE(data))
+	data = append(data, checksumFieldKey)
+	return append(data, checksum...), nil
+}
+
+// decodeEntry unmarshals the entry, and verifies the checksum if it is set.
+func decodeEntry(data []byte) (*pb.LogEntry, error) {
+	entry := &pb.LogEntry{}
+	if err := proto.Unmarshal(data, entry); err != nil {
+		return nil, err
+	}
+	if entry.Checksum == 0 {
+		return entry, nil
+	}
+
+	if len(data) < 5 || data[len(data)-5] != checksumFieldKey {
+		return nil, checksumMismatchError{expected: entry.Checksum}
+	}
+	if actual := crc32.ChecksumIEEE(data[:len(data)-5]); actual != entry.Checksum {
+		return nil, checksumMismatchError{expected: entry.Checksum, actual: actual}
+	}
+	return entry, nil
+}
+
+// ChecksumFailures returns the number of binlog entry reads that failed the checksum.
+func (m *LogManager) ChecksumFailures() uint64 {
+	return atomic.LoadUint64(&m.checksumFailures)
+}
+
+// countChecksumFailure counts the error if it is a checksum mismatch, and returns the error.
+func (m *LogManager) countChecksumFailure(err error) error {
+	if IsChecksumMismatch(err) {
+		atomic.AddUint64(&m.checksumFailures, 1)
+	}
+	return err
+}
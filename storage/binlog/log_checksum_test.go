@@ -0,0 +1,92 @@
+package binlog
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/chrislusf/vasto/pb"
+	"github.com/golang/protobuf/proto"
+	"github.com/magiconair/properties/assert"
+	"golang.org/x/net/context"
+)
+
+func TestEntryChecksum(t *testing.T) {
+
+	entry := &pb.LogEntry{
+		UpdatedAtNs: 123,
+		Put:         &pb.PutRequest{Key: []byte("k1"), Value: []byte("v1")},
+	}
+
+	data, err := encodeEntry(entry)
+	assert.Equal(t, err, nil, "encode")
+	assert.Equal(t, entry.Checksum, uint32(0), "entry unchanged")
+
+	decoded, err := decodeEntry(data)
+	assert.Equal(t, err, nil, "decode")
+	assert.Equal(t, string(decoded.GetPut().Value), "v1", "decoded value")
+	assert.Equal(t, decoded.Checksum != 0, true, "decoded checksum")
+
+	data[len(data)-6] ^= 0xff
+	_, err = decodeEntry(data)
+	assert.Equal(t, IsChecksumMismatch(err), true, "corrupted entry")
+
+	// entries written without the checksum are not checked
+	data, _ = proto.Marshal(entry)
+	_, err = decodeEntry(data)
+	assert.Equal(t, err, nil, "entry without checksum")
+
+}
+
+func TestReadCorruptedEntries(t *testing.T) {
+
+	dir, err := ioutil.TempDir("", "vasto_checksum")
+	if err != nil {
+		t.Fatalf("temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	m := NewLogManager(dir, 0, 1024*1024, 3)
+	m.Initialze()
+	defer m.Shutdown()
+
+	var positions []int64
+	for i := 0; i < 5; i++ {
+		_, offset := m.GetSegmentOffset()
+		positions = append(positions, offset)
+		m.AppendEntry(&pb.LogEntry{
+			UpdatedAtNs: uint64(i),
+			Put:         &pb.PutRequest{Key: []byte(fmt.Sprintf("key %d", i)), Value: []byte("value")},
+		})
+	}
+
+	// flip one byte of the value of the 3rd entry
+	file, err := os.OpenFile(m.getFileName(0), os.O_RDWR, 0644)
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	file.WriteAt([]byte{'X'}, positions[3]-10)
+	file.Close()
+
+	entries, nextOffset, err := m.ReadEntries(0, 0, 10)
+	assert.Equal(t, err, nil, "read until the corrupted entry")
+	assert.Equal(t, len(entries), 2, "valid entries")
+	assert.Equal(t, nextOffset, positions[2], "stop at the corrupted entry")
+
+	_, _, err = m.ReadEntries(0, nextOffset, 10)
+	assert.Equal(t, IsChecksumMismatch(err), true, "read the corrupted entry")
+
+	positioned, err := m.ReadEntriesFrom(context.Background(), LogPosition{}, 10)
+	assert.Equal(t, err, nil, "follow until the corrupted entry")
+	assert.Equal(t, len(positioned), 2, "valid followed entries")
+
+	_, err = m.ReadEntriesFrom(context.Background(), positioned[1].Next, 10)
+	assert.Equal(t, IsChecksumMismatch(err), true, "follow the corrupted entry")
+
+	err = m.ScanEntries(0, func(segment uint32, entry *pb.LogEntry) error { return nil })
+	assert.Equal(t, IsChecksumMismatch(err), true, "scan the corrupted entry")
+
+	assert.Equal(t, m.ChecksumFailures(), uint64(3), "checksum failures")
+
+}
@@ -0,0 +1,125 @@
+package binlog
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/chrislusf/glog"
+	"github.com/chrislusf/vasto/pb"
+)
+
+// Compact rewrites the segments already consumed by all replicas, i.e., the segments before minAcked.Segment.
+// In these segments, an entry is dropped if a later put or delete of the same key is found in the binlog.
+// Merges are never dropped, and neither are the entries they depend on.
+// Delete intents are never dropped either, since they have no key, and are scanned to recover the prepared transactions.
+// The leading segments left empty are removed.
+// The segments since minAcked.Segment are not changed, so the positions held by the replicas stay valid.
+func (m *LogManager) Compact(minAcked LogPosition) (droppedCount int, err error) {
+
+	m.followerCond.L.Lock()
+	currentSegment := m.segment
+	m.followerCond.L.Unlock()
+
+	earliestSegment, _ := m.GetSegmentRange()
+	stopSegment := minAcked.Segment
+	if stopSegment > currentSegment {
+		stopSegment = currentSegment
+	}
+	if earliestSegment >= stopSegment {
+		return 0, nil
+	}
+
+	// the order of the last put or delete of each key, as the segment and the index in the segment
+	lastOverwrites := make(map[string]uint64)
+	var lastSegment uint32
+	index := uint64(0)
+	err = m.ScanEntries(earliestSegment, func(segment uint32, entry *pb.LogEntry) error {
+		if segment != lastSegment {
+			lastSegment, index = segment, 0
+		}
+		if entry.GetMerge() == nil && entry.GetDeleteIntent() == nil {
+			lastOverwrites[string(entry.GetKey())] = uint64(segment)<<32 | index
+		}
+		index++
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("scan binlog: %v", err)
+	}
+
+	removeEmpty := true
+	for segment := earliestSegment; segment < stopSegment; segment++ {
+		var kept []*pb.LogEntry
+		segmentDroppedCount := 0
+		index = 0
+		err = scanSegmentFile(m.getFileName(segment), func(entry *pb.LogEntry) error {
+			order := uint64(segment)<<32 | index
+			if last, found := lastOverwrites[string(entry.GetKey())]; !found || order >= last || entry.GetDeleteIntent() != nil {
+				kept = append(kept, entry)
+			} else {
+				segmentDroppedCount++
+			}
+			index++
+			return nil
+		})
+		if err != nil {
+			return droppedCount, m.countChecksumFailure(err)
+		}
+
+		removeEmpty = removeEmpty && len(kept) == 0
+		if segmentDroppedCount == 0 && !removeEmpty {
+			continue
+		}
+		if err = m.rewriteSegment(segment, kept, removeEmpty); err != nil {
+			return droppedCount, err
+		}
+		droppedCount += segmentDroppedCount
+	}
+
+	glog.V(1).Infof("%s compacted segments [%d,%d), dropped %d entries", m.dir, earliestSegment, stopSegment, droppedCount)
+
+	return droppedCount, nil
+}
+
+// rewriteSegment replaces the segment file with the entries, or removes it if remove is set.
+func (m *LogManager) rewriteSegment(segment uint32, entries []*pb.LogEntry, remove bool) error {
+
+	m.filesLock.Lock()
+	defer m.filesLock.Unlock()
+
+	oneLogFile, found := m.files[segment]
+	if !found {
+		// already purged
+		return nil
+	}
+
+	if remove {
+		oneLogFile.purge()
+		delete(m.files, segment)
+		return nil
+	}
+
+	var buf []byte
+	sizeBuf := make([]byte, 4)
+	for _, entry := range entries {
+		data, err := encodeEntry(entry)
+		if err != nil {
+			return fmt.Errorf("compact segment %d: %v", segment, err)
+		}
+		binary.LittleEndian.PutUint32(sizeBuf, uint32(len(data)))
+		buf = append(buf, sizeBuf...)
+		buf = append(buf, data...)
+	}
+
+	fileName := m.getFileName(segment)
+	if err := ioutil.WriteFile(fileName+".compact", buf, 0644); err != nil {
+		return fmt.Errorf("compact segment %d: %v", segment, err)
+	}
+	if err := os.Rename(fileName+".compact", fileName); err != nil {
+		return fmt.Errorf("compact segment %d: %v", segment, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,69 @@
+package binlog
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/chrislusf/vasto/pb"
+	"github.com/magiconair/properties/assert"
+)
+
+func TestCompact(t *testing.T) {
+
+	dir, err := ioutil.TempDir("", "vasto_compact")
+	if err != nil {
+		t.Fatalf("temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	m := NewLogManager(dir, 0, 1024*1024, 10)
+	m.Initialze()
+	defer m.Shutdown()
+
+	key := []byte("k1")
+	m.AppendEntry(&pb.LogEntry{UpdatedAtNs: 1, Delete: &pb.DeleteRequest{Key: key}})
+	m.AppendEntry(&pb.LogEntry{UpdatedAtNs: 2, Put: &pb.PutRequest{Key: key, Value: []byte("v2")}})
+	m.AppendEntry(&pb.LogEntry{UpdatedAtNs: 3, Put: &pb.PutRequest{Key: []byte("k2"), Value: []byte("v3")}})
+	m.AppendEntry(&pb.LogEntry{UpdatedAtNs: 4, Merge: &pb.MergeRequest{Key: []byte("k2"), Value: []byte("v4")}})
+
+	// segment 0 is not consumed by all replicas yet
+	droppedCount, err := m.Compact(LogPosition{Segment: 0, Offset: 100})
+	assert.Equal(t, err, nil, "compact nothing")
+	assert.Equal(t, droppedCount, 0, "nothing consumed")
+
+	// start a new segment
+	m.lastLogFile.offset = m.logFileMaxSize
+	m.AppendEntry(&pb.LogEntry{UpdatedAtNs: 5, Delete: &pb.DeleteRequest{Key: []byte("k3")}})
+
+	droppedCount, err = m.Compact(LogPosition{Segment: 1})
+	assert.Equal(t, err, nil, "compact")
+	assert.Equal(t, droppedCount, 1, "delete superseded by the put")
+
+	var updatedAtNs []uint64
+	m.ScanEntries(0, func(segment uint32, entry *pb.LogEntry) error {
+		updatedAtNs = append(updatedAtNs, entry.UpdatedAtNs)
+		return nil
+	})
+	assert.Equal(t, fmt.Sprint(updatedAtNs), "[2 3 4 5]", "compacted entries")
+
+	// entries superseded by a later segment, and the emptied leading segment is removed
+	m.AppendEntry(&pb.LogEntry{UpdatedAtNs: 6, Delete: &pb.DeleteRequest{Key: key}})
+	m.AppendEntry(&pb.LogEntry{UpdatedAtNs: 7, Put: &pb.PutRequest{Key: []byte("k2"), Value: []byte("v7")}})
+	m.lastLogFile.offset = m.logFileMaxSize
+	m.AppendEntry(&pb.LogEntry{UpdatedAtNs: 8, Delete: &pb.DeleteRequest{Key: []byte("k4")}})
+
+	droppedCount, err = m.Compact(LogPosition{Segment: 2})
+	assert.Equal(t, err, nil, "compact again")
+	assert.Equal(t, droppedCount, 3, "superseded by later segments")
+	assert.Equal(t, m.HasSegment(0), false, "empty segment removed")
+
+	updatedAtNs = nil
+	m.ScanEntries(0, func(segment uint32, entry *pb.LogEntry) error {
+		updatedAtNs = append(updatedAtNs, entry.UpdatedAtNs)
+		return nil
+	})
+	assert.Equal(t, fmt.Sprint(updatedAtNs), "[5 6 7 8]", "compacted entries across segments")
+
+}
@@ -0,0 +1,195 @@
+package binlog
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/chrislusf/glog"
+	"github.com/chrislusf/vasto/pb"
+	"golang.org/x/net/context"
+)
+
+// LogPosition locates a binlog entry by the segment and the offset in the segment.
+type LogPosition struct {
+	Segment uint32
+	Offset  int64
+}
+
+// PositionedEntry is a binlog entry with its own position and the position of the entry after it.
+// A follower resumes from the Next position of the last received entry, e.g., after reconnecting.
+type PositionedEntry struct {
+	Entry    *pb.LogEntry
+	Position LogPosition
+	Next     LogPosition
+}
+
+// ReadEntriesFrom reads up to limit entries in the order they were appended, starting from the position
+// and moving on to the following segments.
+// At the tail, it waits for new entries. If ctx is done before any new entry comes in,
+// it returns no entries and no error, so the caller can read again from the same position.
+// It returns an error if the log manager is shut down, the segment is already purged,
+// or the entry at the position fails the checksum. The entries before a corrupted one are returned first.
+func (m *LogManager) ReadEntriesFrom(ctx context.Context, from LogPosition, limit int) ([]PositionedEntry, error) {
+
+	for {
+		entries, at, err := m.readEntriesFrom(from, limit)
+		if err != nil || len(entries) > 0 {
+			return entries, err
+		}
+		if ctx.Err() != nil {
+			return nil, nil
+		}
+		m.waitForEntries(ctx, at)
+		from = at
+	}
+
+}
+
+// readEntriesFrom reads the available entries without waiting.
+// If no entry is available, it returns the position to wait on, which may be in a later segment.
+func (m *LogManager) readEntriesFrom(from LogPosition, limit int) (entries []PositionedEntry, at LogPosition, err error) {
+
+	for {
+		m.followerCond.L.Lock()
+		currentSegment, lastLogFile, hasShutdown := m.segment, m.lastLogFile, m.hasShutdown
+		m.followerCond.L.Unlock()
+
+		if hasShutdown {
+			return nil, from, fmt.Errorf("%v shutdown in progress...", m.dir)
+		}
+		if from.Segment > currentSegment {
+			return nil, from, nil
+		}
+		if !m.HasSegment(from.Segment) {
+			return nil, from, fmt.Errorf("already purged segment %d", from.Segment)
+		}
+
+		// the older segments are complete, while the current one is only read up to the appended entries
+		end := int64(-1)
+		if from.Segment == currentSegment {
+			end = lastLogFile.appendedOffset()
+		}
+
+		entries, err = readSegmentEntries(m.getFileName(from.Segment), from, end, limit)
+		err = m.countChecksumFailure(err)
+		if err != nil || len(entries) > 0 || from.Segment == currentSegment {
+			return entries, from, err
+		}
+
+		from = LogPosition{Segment: from.Segment + 1}
+	}
+
+}
+
+// waitForEntries waits until an entry is appended after the position, the segment changes,
+// the log manager is shut down, or ctx is done.
+func (m *LogManager) waitForEntries(ctx context.Context, at LogPosition) {
+
+	m.followerCond.L.Lock()
+	for at.Segment > m.segment && !m.hasShutdown && ctx.Err() == nil {
+		waitWithContext(ctx, m.followerCond)
+	}
+	lastLogFile, isCurrent := m.lastLogFile, at.Segment == m.segment
+	m.followerCond.L.Unlock()
+
+	if !isCurrent || lastLogFile == nil {
+		return
+	}
+
+	lastLogFile.followerCond.L.Lock()
+	for at.Offset >= lastLogFile.offset && !lastLogFile.hasShutdown && ctx.Err() == nil {
+		waitWithContext(ctx, lastLogFile.followerCond)
+	}
+	lastLogFile.followerCond.L.Unlock()
+
+}
+
+// waitWithContext waits on the cond, whose lock is held, and also wakes up when ctx is done.
+func waitWithContext(ctx context.Context, cond *sync.Cond) {
+
+	waited := make(chan struct{})
+	defer close(waited)
+
+	go func() {
+		select {
+		case <-ctx.Done():
+			cond.L.Lock()
+			cond.Broadcast()
+			cond.L.Unlock()
+		case <-waited:
+		}
+	}()
+
+	cond.Wait()
+}
+
+// appendedOffset returns the offset after the last fully appended entry.
+func (f *logSegmentFile) appendedOffset() int64 {
+	f.followerCond.L.Lock()
+	defer f.followerCond.L.Unlock()
+	return f.offset
+}
+
+// readSegmentEntries reads up to limit entries from the file, starting from the position.
+// If end is not negative, only the entries before end are read.
+// A partially written entry at the end of the file is skipped.
+func readSegmentEntries(fileName string, from LogPosition, end int64, limit int) (entries []PositionedEntry, err error) {
+
+	file, err := os.Open(fileName)
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("already purged segment %d", from.Segment)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("open file %s: %v", fileName, err)
+	}
+	defer file.Close()
+
+	sizeBuf := make([]byte, 4)
+	offset := from.Offset
+
+	for len(entries) < limit && (end < 0 || offset < end) {
+		if _, err := file.ReadAt(sizeBuf, offset); err != nil {
+			if err == io.EOF {
+				return entries, nil
+			}
+			return entries, fmt.Errorf("read %s offset %d: %v", fileName, offset, err)
+		}
+		dataLen := int64(binary.LittleEndian.Uint32(sizeBuf))
+		next := offset + 4 + dataLen
+		if end >= 0 && next > end {
+			return entries, nil
+		}
+		data := make([]byte, dataLen)
+		if _, err := file.ReadAt(data, offset+4); err != nil {
+			if err == io.EOF {
+				return entries, nil
+			}
+			return entries, fmt.Errorf("read %s offset %d: %v", fileName, offset, err)
+		}
+
+		entry, err := decodeEntry(data)
+		if err != nil && len(entries) > 0 {
+			// stop at the last valid entry, and report the error on the next read
+			return entries, nil
+		}
+		if IsChecksumMismatch(err) {
+			glog.Errorf("read %s offset %d: %v", fileName, offset, err)
+			return nil, err
+		}
+		if err != nil {
+			return nil, fmt.Errorf("read %s offset %d unmarshal: %v", fileName, offset, err)
+		}
+
+		entries = append(entries, PositionedEntry{
+			Entry:    entry,
+			Position: LogPosition{Segment: from.Segment, Offset: offset},
+			Next:     LogPosition{Segment: from.Segment, Offset: next},
+		})
+		offset = next
+	}
+
+	return entries, nil
+}
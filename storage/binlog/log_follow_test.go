@@ -0,0 +1,107 @@
+package binlog
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/chrislusf/vasto/pb"
+	"github.com/magiconair/properties/assert"
+	"golang.org/x/net/context"
+)
+
+func TestReadEntriesFrom(t *testing.T) {
+
+	dir, err := ioutil.TempDir("", "vasto_follow")
+	if err != nil {
+		t.Fatalf("temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	m := NewLogManager(dir, 0, 256, 10)
+	m.Initialze()
+	defer m.Shutdown()
+
+	for i := 0; i < 20; i++ {
+		m.AppendEntry(&pb.LogEntry{
+			UpdatedAtNs: uint64(i),
+			Put: &pb.PutRequest{
+				Key:   []byte(fmt.Sprintf("key %4d", i)),
+				Value: []byte(fmt.Sprintf("value %4d", i)),
+			},
+		})
+	}
+	_, latestSegment := m.GetSegmentRange()
+	assert.Equal(t, latestSegment > 0, true, "entries across segments")
+
+	// read in small batches, resuming from the last next position
+	var from LogPosition
+	for i := 0; i < 20; {
+		entries, err := m.ReadEntriesFrom(context.Background(), from, 3)
+		assert.Equal(t, err, nil, "read entries")
+		for _, entry := range entries {
+			assert.Equal(t, entry.Entry.UpdatedAtNs, uint64(i), "entry order")
+			assert.Equal(t, entry.Position.Segment >= from.Segment, true, "entry segment")
+			from = entry.Next
+			i++
+		}
+	}
+
+	// at the tail, return without entries when ctx is done
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	entries, err := m.ReadEntriesFrom(ctx, from, 3)
+	cancel()
+	assert.Equal(t, len(entries), 0, "no entries at the tail")
+	assert.Equal(t, err, nil, "cancelled at the tail")
+
+	// at the tail, wait for the next entry
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		m.AppendEntry(&pb.LogEntry{
+			UpdatedAtNs: 20,
+			Put:         &pb.PutRequest{Key: []byte("key last")},
+		})
+	}()
+	entries, err = m.ReadEntriesFrom(context.Background(), from, 3)
+	assert.Equal(t, err, nil, "wait for new entries")
+	assert.Equal(t, len(entries), 1, "new entries")
+	assert.Equal(t, entries[0].Entry.UpdatedAtNs, uint64(20), "new entry")
+
+	_, err = m.ReadEntriesFrom(context.Background(), LogPosition{Segment: 0}, 3)
+	assert.Equal(t, err, nil, "segment 0 is kept")
+
+}
+
+func TestAppendEntryPosition(t *testing.T) {
+
+	dir, err := ioutil.TempDir("", "vasto_follow")
+	if err != nil {
+		t.Fatalf("temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	m := NewLogManager(dir, 0, 256, 10)
+	m.Initialze()
+	defer m.Shutdown()
+
+	var positions []LogPosition
+	for i := 0; i < 20; i++ {
+		position, err := m.AppendEntry(&pb.LogEntry{
+			UpdatedAtNs: uint64(i),
+			Delete:      &pb.DeleteRequest{Key: []byte(fmt.Sprintf("key %4d", i))},
+		})
+		assert.Equal(t, err, nil, "append entry")
+		positions = append(positions, position)
+	}
+
+	// the returned positions are where the entries are read back
+	for i, position := range positions {
+		entries, err := m.ReadEntriesFrom(context.Background(), position, 1)
+		assert.Equal(t, err, nil, "read entry")
+		assert.Equal(t, entries[0].Entry.UpdatedAtNs, uint64(i), "entry at the position")
+		assert.Equal(t, entries[0].Position, position, "position")
+	}
+
+}
@@ -1,6 +1,7 @@
 package binlog
 
 import (
+	"context"
 	"fmt"
 	"github.com/chrislusf/glog"
 	"github.com/chrislusf/vasto/pb"
@@ -21,12 +22,23 @@ type LogManager struct {
 	filesLock sync.RWMutex
 	files     map[uint32]*logSegmentFile
 
+	// serializes the appends, the segment rotation, and the flushes
+	appendLock appendSemaphore
+
 	// current actively written log file
 	lastLogFile  *logSegmentFile
 	segment      uint32
 	offset       int64
 	followerCond *sync.Cond
 	hasShutdown  bool
+
+	// entry reads failing the checksum, accessed atomically
+	checksumFailures uint64
+
+	backpressure backpressure
+
+	// keeps the segments still needed from being purged, guarded by the filesLock
+	retain RetainFunc
 }
 
 const (
@@ -44,6 +56,7 @@ func NewLogManager(dir string, id int, logFileMaxSize int64, logFileCountLimit i
 		logFileCountLimit: logFileCountLimit,
 		files:             make(map[uint32]*logSegmentFile),
 		followerCond:      &sync.Cond{L: &sync.Mutex{}},
+		appendLock:        newAppendSemaphore(),
 	}
 	return m
 }
@@ -75,25 +88,46 @@ func (m *LogManager) Shutdown() {
 	m.filesLock.RUnlock()
 }
 
-// AppendEntry appends one log to the binlog file
-func (m *LogManager) AppendEntry(entry *pb.LogEntry) error {
-	if m.lastLogFile.offset >= m.logFileMaxSize {
-		m.lastLogFile.close()
-		m.followerCond.L.Lock()
-		m.segment++
-		m.maybeRemoveOldFiles()
-		m.lastLogFile = nil
-		m.maybePrepareCurrentFileForWrite()
-		// println("broadcast segment condition change")
-		m.followerCond.Broadcast()
-		m.followerCond.L.Unlock()
+// Flush syncs the appended entries to the disk. The log files of the earlier segments are synced when rotated.
+// It is safe to call concurrently with AppendEntry, and waits for the appends in progress.
+func (m *LogManager) Flush() error {
+	m.appendLock.Lock()
+	defer m.appendLock.Unlock()
+	if m.lastLogFile == nil {
+		return nil
 	}
+	return m.lastLogFile.sync()
+}
 
-	return m.lastLogFile.appendEntry(entry)
+// rotateIfFull moves on to a new segment if the current log file is full.
+// The full log file is synced before being closed, so that a Flush() also covers it.
+func (m *LogManager) rotateIfFull() {
+	if m.lastLogFile.offset < m.logFileMaxSize {
+		return
+	}
+	if err := m.lastLogFile.sync(); err != nil {
+		glog.Errorf("rotate binlog: %v", err)
+	}
+	m.lastLogFile.close()
+	m.followerCond.L.Lock()
+	m.segment++
+	m.maybeRemoveOldFiles()
+	m.lastLogFile = nil
+	m.maybePrepareCurrentFileForWrite()
+	// println("broadcast segment condition change")
+	m.followerCond.Broadcast()
+	m.followerCond.L.Unlock()
+}
 
+// AppendEntry appends one log to the binlog file, and returns the position of the appended entry.
+// It never rejects the entry on backpressure, since the change is usually applied already.
+// The writers call CheckBackpressure before applying the change instead.
+func (m *LogManager) AppendEntry(entry *pb.LogEntry) (LogPosition, error) {
+	return m.AppendEntryContext(context.Background(), entry)
 }
 
 // ReadEntries reads a few entries from the binlog files, specified by the tuple of segment and offset.
+// The reading stops before an entry failing the checksum, and the entry is reported as an error on the next read.
 func (m *LogManager) ReadEntries(segment uint32, offset int64,
 	limit int) (entries []*pb.LogEntry, nextOffset int64, err error) {
 
@@ -121,14 +155,15 @@ func (m *LogManager) ReadEntries(segment uint32, offset int64,
 		}
 	}
 
-	return oneLogFile.readEntries(offset, limit)
+	entries, nextOffset, err = oneLogFile.readEntries(offset, limit)
+	return entries, nextOffset, m.countChecksumFailure(err)
 }
 
 func (m *LogManager) maybeRemoveOldFiles() {
 	m.filesLock.Lock()
 	defer m.filesLock.Unlock()
 	for segment, oneLogFile := range m.files {
-		if segment+uint32(m.logFileCountLimit) < m.segment {
+		if segment+uint32(m.logFileCountLimit) < m.segment && !m.isRetained(segment) {
 			oneLogFile.purge()
 			delete(m.files, segment)
 		}
@@ -227,6 +262,8 @@ func (m *LogManager) loadFilesFromDisk() error {
 
 // GetSegmentOffset returns the latest segment and offset.
 func (m *LogManager) GetSegmentOffset() (uint32, int64) {
+	m.appendLock.Lock()
+	defer m.appendLock.Unlock()
 	if m.lastLogFile == nil {
 		return m.segment, 0
 	}
@@ -34,7 +34,7 @@ func TestLogManager(t *testing.T) {
 
 	}
 
-	err := m.AppendEntry(nil)
+	_, err := m.AppendEntry(nil)
 	assert.Equal(t, err != nil, true, "nil entry")
 
 	entries, nextOffset, err := m.ReadEntries(0, 0, 10)
@@ -110,3 +110,88 @@ func TestLargeLogManager(t *testing.T) {
 	// os.RemoveAll(dir)
 
 }
+
+func TestScanEntries(t *testing.T) {
+
+	dir := path.Join(os.TempDir(), "vasto_scan_test")
+	os.RemoveAll(dir)
+	os.MkdirAll(dir, 0755)
+
+	m := NewLogManager(dir, 2, 100, 3)
+	m.Initialze()
+
+	for i := 0; i < 10; i++ {
+		m.AppendEntry(&pb.LogEntry{
+			UpdatedAtNs: uint64(i),
+			Delete: &pb.DeleteRequest{
+				Key:           []byte(fmt.Sprintf("key %4d", i%2)),
+				PartitionHash: uint64(i % 2),
+			},
+		})
+	}
+
+	var updatedAtNs []uint64
+	err := m.ScanEntries(0, func(segment uint32, entry *pb.LogEntry) error {
+		if entry.GetPartitionHash() == 1 {
+			updatedAtNs = append(updatedAtNs, entry.UpdatedAtNs)
+		}
+		return nil
+	})
+	assert.Equal(t, err, nil, "scan entries")
+
+	assert.Equal(t, len(updatedAtNs) > 0, true, "found entries")
+	for i := 1; i < len(updatedAtNs); i++ {
+		assert.Equal(t, updatedAtNs[i]-updatedAtNs[i-1], uint64(2), "entries in order")
+	}
+	assert.Equal(t, updatedAtNs[len(updatedAtNs)-1], uint64(9), "latest entry")
+
+	m.Shutdown()
+
+	os.RemoveAll(dir)
+
+}
+
+func TestFlushWhileAppending(t *testing.T) {
+
+	dir := path.Join(os.TempDir(), "vasto_flush_test")
+	os.RemoveAll(dir)
+	os.MkdirAll(dir, 0755)
+	defer os.RemoveAll(dir)
+	m := NewLogManager(dir, 0, 256, 10)
+	m.Initialze()
+	defer m.Shutdown()
+
+	done := make(chan bool)
+	go func() {
+		for i := 0; i < 50; i++ {
+			m.AppendEntry(&pb.LogEntry{
+				UpdatedAtNs: uint64(i),
+				Put: &pb.PutRequest{
+					Key:   []byte(fmt.Sprintf("key %4d", i)),
+					Value: []byte(fmt.Sprintf("value %4d", i)),
+				},
+			})
+		}
+		close(done)
+	}()
+
+	for flushing := true; flushing; {
+		select {
+		case <-done:
+			flushing = false
+		default:
+		}
+		assert.Equal(t, m.Flush(), nil, "flush while appending across segments")
+	}
+
+	segment, _ := m.GetSegmentOffset()
+	assert.Equal(t, segment > 0, true, "rotated segments")
+
+	count := 0
+	m.ScanEntries(0, func(segment uint32, entry *pb.LogEntry) error {
+		count++
+		return nil
+	})
+	assert.Equal(t, count, 50, "all entries appended")
+
+}
@@ -0,0 +1,21 @@
+package binlog
+
+// RetainFunc returns the earliest segment still needed by the caller, or false if no segment is needed.
+type RetainFunc func() (segment uint32, retained bool)
+
+// SetRetention keeps the segments since the one returned by fn from being purged when the log files rotate,
+// even if there are more log files than the limit.
+func (m *LogManager) SetRetention(fn RetainFunc) {
+	m.filesLock.Lock()
+	m.retain = fn
+	m.filesLock.Unlock()
+}
+
+// isRetained checks whether the segment is still needed. It is called with the filesLock held.
+func (m *LogManager) isRetained(segment uint32) bool {
+	if m.retain == nil {
+		return false
+	}
+	earliest, retained := m.retain()
+	return retained && segment >= earliest
+}
@@ -0,0 +1,50 @@
+package binlog
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/chrislusf/vasto/pb"
+	"github.com/magiconair/properties/assert"
+)
+
+func TestRetention(t *testing.T) {
+
+	dir, err := ioutil.TempDir("", "vasto_retain")
+	if err != nil {
+		t.Fatalf("temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	m := NewLogManager(dir, 0, 1024*1024, 1)
+	m.Initialze()
+	defer m.Shutdown()
+
+	retainedSegment, retained := uint32(1), true
+	m.SetRetention(func() (uint32, bool) {
+		return retainedSegment, retained
+	})
+
+	nextSegment := func() {
+		m.lastLogFile.offset = m.logFileMaxSize
+		m.AppendEntry(&pb.LogEntry{DeleteIntent: &pb.DeleteIntent{TxnId: "t"}})
+	}
+
+	m.AppendEntry(&pb.LogEntry{DeleteIntent: &pb.DeleteIntent{TxnId: "t"}})
+	nextSegment()
+	nextSegment()
+	nextSegment()
+	assert.Equal(t, m.HasSegment(0), false, "segment before the retained one is purged")
+	assert.Equal(t, m.HasSegment(1), true, "retained segment")
+
+	retained = false
+	nextSegment()
+	assert.Equal(t, m.HasSegment(1), false, "segment purged once released")
+
+	// intents are kept by compaction
+	droppedCount, err := m.Compact(LogPosition{Segment: 4})
+	assert.Equal(t, err, nil, "compact")
+	assert.Equal(t, droppedCount, 0, "delete intents are kept")
+
+}
@@ -0,0 +1,79 @@
+package binlog
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/chrislusf/glog"
+	"github.com/chrislusf/vasto/pb"
+)
+
+// ScanEntries reads all entries from fromSegment to the latest segment, in the order they were appended.
+// Unlike ReadEntries, it does not wait for new entries.
+// The scan stops if fn returns an error, and the error is returned.
+// The scan also stops at an entry failing the checksum, with an error.
+func (m *LogManager) ScanEntries(fromSegment uint32, fn func(segment uint32, entry *pb.LogEntry) error) error {
+
+	earliestSegment, latestSegment := m.GetSegmentRange()
+	if fromSegment < earliestSegment {
+		fromSegment = earliestSegment
+	}
+
+	for segment := fromSegment; segment <= latestSegment; segment++ {
+		if !m.HasSegment(segment) {
+			continue
+		}
+		err := scanSegmentFile(m.getFileName(segment), func(entry *pb.LogEntry) error {
+			return fn(segment, entry)
+		})
+		if err != nil {
+			return m.countChecksumFailure(err)
+		}
+	}
+
+	return nil
+}
+
+// scanSegmentFile reads the file sequentially, skipping a partially written entry at the end.
+func scanSegmentFile(fileName string, fn func(entry *pb.LogEntry) error) error {
+
+	file, err := os.Open(fileName)
+	if os.IsNotExist(err) {
+		// the segment is just purged
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("open file %s: %v", fileName, err)
+	}
+	defer file.Close()
+
+	reader := bufio.NewReader(file)
+	sizeBuf := make([]byte, 4)
+
+	for {
+		if _, err := io.ReadFull(reader, sizeBuf); err != nil {
+			return nil
+		}
+		data := make([]byte, binary.LittleEndian.Uint32(sizeBuf))
+		if _, err := io.ReadFull(reader, data); err != nil {
+			return nil
+		}
+
+		entry, err := decodeEntry(data)
+		if IsChecksumMismatch(err) {
+			glog.Errorf("scan %s: %v", fileName, err)
+			return err
+		}
+		if err != nil {
+			return fmt.Errorf("scan %s unmarshal: %v", fileName, err)
+		}
+
+		if err := fn(entry); err != nil {
+			return err
+		}
+	}
+
+}
@@ -5,7 +5,6 @@ import (
 	"fmt"
 	"github.com/chrislusf/glog"
 	"github.com/chrislusf/vasto/pb"
-	"github.com/golang/protobuf/proto"
 	"io"
 	"os"
 	"sync"
@@ -35,12 +34,12 @@ func newLogSegmentFile(fillName string, segment uint32, logFileMaxSize int64) *l
 	}
 }
 
-func (f *logSegmentFile) appendEntry(entry *pb.LogEntry) (err error) {
+func (f *logSegmentFile) appendEntry(entry *pb.LogEntry) (offset int64, err error) {
 
-	// marshal the log entry
-	encodedData, err := proto.Marshal(entry)
+	// marshal the log entry with the checksum
+	encodedData, err := encodeEntry(entry)
 	if err != nil {
-		return fmt.Errorf("appendEntry marshal log entry: %v", err)
+		return 0, fmt.Errorf("appendEntry marshal log entry: %v", err)
 	}
 
 	// lock writeBuffer, sizeBufForWrite, and file writes
@@ -48,16 +47,17 @@ func (f *logSegmentFile) appendEntry(entry *pb.LogEntry) (err error) {
 	defer f.accessLock.Unlock()
 
 	// write to disk
+	offset = f.offset
 	dataLen := len(encodedData)
 	// glog.V(0).Infof("entry size %d: %v", dataLen, entry)
 
 	binary.LittleEndian.PutUint32(f.sizeBufForWrite, uint32(dataLen))
 	if _, err := f.file.WriteAt(f.sizeBufForWrite, f.offset); err != nil {
-		return fmt.Errorf("appendEntry write log entry size: %v", err)
+		return 0, fmt.Errorf("appendEntry write log entry size: %v", err)
 	}
 	writtenDataLen, err := f.file.WriteAt(encodedData, f.offset+4)
 	if err != nil {
-		return fmt.Errorf("appendEntry write log entry data: %v", err)
+		return 0, fmt.Errorf("appendEntry write log entry data: %v", err)
 	}
 
 	if err == nil && writtenDataLen == dataLen {
@@ -70,7 +70,7 @@ func (f *logSegmentFile) appendEntry(entry *pb.LogEntry) (err error) {
 		glog.Errorf("append entry size %d, but %d: %v", dataLen, writtenDataLen, err)
 	}
 
-	return err
+	return offset, err
 }
 
 /*
@@ -137,10 +137,14 @@ func (f *logSegmentFile) readOneEntry(offset int64) (entry *pb.LogEntry, nextOff
 		return nil, 0, fmt.Errorf("read wrong data size: %d, expecting %d", n, dataLen)
 	}
 
-	// unmarshal log entry
-	entry = &pb.LogEntry{}
-	if err := proto.Unmarshal(data, entry); err != nil {
-		glog.Warningf("unmarshal pb.LogEntry size %d %v: %v", dataLen, entry, err)
+	// unmarshal log entry and verify the checksum
+	entry, err = decodeEntry(data)
+	if IsChecksumMismatch(err) {
+		glog.Errorf("reading %s offset %d size %d: %v", f.fullName, offset, dataLen, err)
+		return nil, 0, err
+	}
+	if err != nil {
+		glog.Warningf("unmarshal pb.LogEntry size %d: %v", dataLen, err)
 		return nil, 0, fmt.Errorf("readOneEntry unmarshal: %v", err)
 	}
 	return entry, offset + int64(dataLen+4), nil
@@ -170,6 +174,19 @@ func (f *logSegmentFile) open() error {
 	return nil
 }
 
+// sync flushes the written entries to the disk.
+func (f *logSegmentFile) sync() error {
+	f.accessLock.Lock()
+	defer f.accessLock.Unlock()
+	if f.file == nil {
+		return nil
+	}
+	if err := f.file.Sync(); err != nil {
+		return fmt.Errorf("sync %s: %v", f.fullName, err)
+	}
+	return nil
+}
+
 func (f *logSegmentFile) close() {
 
 	f.followerCond.L.Lock()
@@ -9,26 +9,70 @@ import (
 // OpAndDataType maps to pb.OpAndDataType
 type OpAndDataType byte
 
+// hasExpireAtNs is set on the OpAndDataType byte if the 8 bytes after it are the ExpireAtNs
+const hasExpireAtNs = 0x80
+
+// hasDeletedAtNs is set on the OpAndDataType byte if the next 8 bytes, after the ExpireAtNs if any, are the DeletedAtNs
+const hasDeletedAtNs = 0x40
+
 // Entry is the on-disk value bytes in this key-value system.
 type Entry struct {
 	PartitionHash uint64
 	UpdatedAtNs   uint64
 	TtlSecond     uint32
 	OpAndDataType OpAndDataType
-	Value         []byte
+	// ExpireAtNs is a hard expiry time, not extended by later updates like TtlSecond. 0 means no hard expiry.
+	ExpireAtNs uint64
+	// DeletedAtNs is the time of a soft delete, which keeps the value until the entry is purged by compaction.
+	// 0 means not deleted.
+	DeletedAtNs uint64
+	// Version orders the entries written with versions. nil means the entry is ordered by UpdatedAtNs only.
+	Version VersionVector
+	Value   []byte
 }
 
 // ToBytes serializes the entry into bytes
 func (e *Entry) ToBytes() []byte {
-	b := make([]byte, len(e.Value)+21)
+	headerSize := 21
+	if e.ExpireAtNs > 0 {
+		headerSize += 8
+	}
+	if e.DeletedAtNs > 0 {
+		headerSize += 8
+	}
+	var version []byte
+	if e.Version != nil {
+		version = e.Version.toBytes()
+		headerSize += len(version)
+	}
+
+	b := make([]byte, len(e.Value)+headerSize)
+	e.putHeader(b)
+	offset := 21
+	if e.ExpireAtNs > 0 {
+		b[20] |= hasExpireAtNs
+		binary.LittleEndian.PutUint64(b[offset:], e.ExpireAtNs)
+		offset += 8
+	}
+	if e.DeletedAtNs > 0 {
+		b[20] |= hasDeletedAtNs
+		binary.LittleEndian.PutUint64(b[offset:], e.DeletedAtNs)
+		offset += 8
+	}
+	if e.Version != nil {
+		b[20] |= hasVersion
+		offset += copy(b[offset:], version)
+	}
+	copy(b[offset:], e.Value)
 
+	return b
+}
+
+func (e *Entry) putHeader(b []byte) {
 	binary.LittleEndian.PutUint64(b, e.PartitionHash)
 	binary.LittleEndian.PutUint64(b[8:], e.UpdatedAtNs)
 	binary.LittleEndian.PutUint32(b[16:], e.TtlSecond)
 	b[20] = byte(e.OpAndDataType)
-	copy(b[21:], e.Value)
-
-	return b
 }
 
 // FromBytes deserialize bytes into one Entry
@@ -39,7 +83,7 @@ func FromBytes(b []byte) *Entry {
 		return nil
 	}
 
-	return &Entry{
+	entry := &Entry{
 		PartitionHash: binary.LittleEndian.Uint64(b[0:8]),
 		UpdatedAtNs:   binary.LittleEndian.Uint64(b[8:16]),
 		TtlSecond:     binary.LittleEndian.Uint32(b[16:20]),
@@ -47,6 +91,41 @@ func FromBytes(b []byte) *Entry {
 		Value:         b[21:],
 	}
 
+	if b[20]&(hasExpireAtNs|hasDeletedAtNs|hasVersion) == 0 {
+		return entry
+	}
+
+	entry.OpAndDataType = OpAndDataType(b[20] &^ (hasExpireAtNs | hasDeletedAtNs | hasVersion))
+	offset := 21
+	if b[20]&hasExpireAtNs != 0 {
+		if len(b) <= offset+8 {
+			glog.Errorf("failed to decode entry with expiry: %x", b)
+			return nil
+		}
+		entry.ExpireAtNs = binary.LittleEndian.Uint64(b[offset : offset+8])
+		offset += 8
+	}
+	if b[20]&hasDeletedAtNs != 0 {
+		if len(b) <= offset+8 {
+			glog.Errorf("failed to decode soft deleted entry: %x", b)
+			return nil
+		}
+		entry.DeletedAtNs = binary.LittleEndian.Uint64(b[offset : offset+8])
+		offset += 8
+	}
+	if b[20]&hasVersion != 0 {
+		version, n := versionFromBytes(b[offset:])
+		if n == 0 {
+			glog.Errorf("failed to decode versioned entry: %x", b)
+			return nil
+		}
+		entry.Version = version
+		offset += n
+	}
+	entry.Value = b[offset:]
+
+	return entry
+
 }
 
 // GetPartitionHashFromBytes reads the partition hash directly from bytes
@@ -54,11 +133,38 @@ func GetPartitionHashFromBytes(b []byte) uint64 {
 	return binary.LittleEndian.Uint64(b[0:8])
 }
 
-// IsExpired checks whether the entry updated_at time plus ttl time is less than current time.
-// If ttlSecond is 0, the entry will not expire.
+// IsDeleted checks whether the entry is soft deleted.
+func (e *Entry) IsDeleted() bool {
+	return e.DeletedAtNs > 0
+}
+
+// IsExpired checks whether the entry updated_at time plus ttl time, or the hard expiry time, is less than current time.
+// If ttlSecond and expireAtNs are 0, the entry will not expire.
 func (e *Entry) IsExpired() bool {
 
-	return e.TtlSecond > 0 &&
-		e.UpdatedAtNs+uint64(e.TtlSecond*1e9) < uint64(time.Now().UnixNano())
+	return e.IsExpiredAt(uint64(time.Now().UnixNano()))
+
+}
+
+// IsExpiredAt checks whether the entry has expired at the time.
+func (e *Entry) IsExpiredAt(nowNs uint64) bool {
+
+	expiresAtNs := e.ExpiresAtNs()
+	return expiresAtNs > 0 && expiresAtNs < nowNs
+
+}
+
+// ExpiresAtNs returns the earlier one of the ttl expiry and the hard expiry, or 0 if the entry does not expire.
+func (e *Entry) ExpiresAtNs() uint64 {
+
+	var ttlExpiresAtNs uint64
+	if e.TtlSecond > 0 {
+		ttlExpiresAtNs = e.UpdatedAtNs + uint64(e.TtlSecond)*uint64(time.Second)
+	}
+
+	if e.ExpireAtNs > 0 && (ttlExpiresAtNs == 0 || e.ExpireAtNs < ttlExpiresAtNs) {
+		return e.ExpireAtNs
+	}
+	return ttlExpiresAtNs
 
 }
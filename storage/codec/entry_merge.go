@@ -20,6 +20,10 @@ func MergeEntry(a, b []byte) (mergedEntry *Entry, merged bool) {
 	}
 
 	x := FromBytes(a)
+	if x.IsDeleted() {
+		// the merge starts over on a soft deleted value
+		return FromBytes(b), true
+	}
 
 	merged = x.MergeWith(b)
 
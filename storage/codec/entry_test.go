@@ -41,3 +41,83 @@ func TestPutRequestConversion(t *testing.T) {
 	}
 
 }
+
+func TestEntryWithExpireAt(t *testing.T) {
+
+	now := uint64(time.Now().UnixNano())
+
+	entry := &Entry{
+		PartitionHash: 1234,
+		UpdatedAtNs:   now,
+		TtlSecond:     60,
+		OpAndDataType: OpAndDataType(pb.OpAndDataType_BYTES),
+		ExpireAtNs:    now + uint64(10*time.Second),
+		Value:         []byte("session"),
+	}
+
+	decoded := FromBytes(entry.ToBytes())
+	if decoded.ExpireAtNs != entry.ExpireAtNs || decoded.OpAndDataType != entry.OpAndDataType || !bytes.Equal(decoded.Value, entry.Value) {
+		t.Errorf("codec expire at error: %+v", decoded)
+	}
+	if GetPartitionHashFromBytes(entry.ToBytes()) != 1234 {
+		t.Errorf("codec partition hash error with expire at")
+	}
+
+	if decoded.ExpiresAtNs() != entry.ExpireAtNs {
+		t.Errorf("hard expiry should be earlier than ttl expiry")
+	}
+	if decoded.IsExpiredAt(now+uint64(5*time.Second)) || !decoded.IsExpiredAt(now+uint64(11*time.Second)) {
+		t.Errorf("hard expiry error")
+	}
+
+	// the ttl expires earlier than the hard expiry
+	decoded.TtlSecond = 5
+	if decoded.ExpiresAtNs() != now+uint64(5*time.Second) {
+		t.Errorf("ttl expiry should be earlier than hard expiry")
+	}
+
+	decoded.ExpireAtNs = 0
+	decoded.TtlSecond = 0
+	if decoded.ExpiresAtNs() != 0 || decoded.IsExpired() {
+		t.Errorf("entry without ttl should not expire")
+	}
+
+}
+
+func TestSoftDeletedEntry(t *testing.T) {
+
+	now := uint64(time.Now().UnixNano())
+
+	for _, expireAtNs := range []uint64{0, now + uint64(time.Minute)} {
+		entry := &Entry{
+			PartitionHash: 1234,
+			UpdatedAtNs:   now,
+			OpAndDataType: OpAndDataType(pb.OpAndDataType_FLOAT64),
+			ExpireAtNs:    expireAtNs,
+			DeletedAtNs:   now,
+			Value:         util.Float64ToBytes(999),
+		}
+
+		decoded := FromBytes(entry.ToBytes())
+		if !decoded.IsDeleted() || decoded.DeletedAtNs != now || decoded.ExpireAtNs != expireAtNs ||
+			decoded.OpAndDataType != entry.OpAndDataType || !bytes.Equal(decoded.Value, entry.Value) {
+			t.Errorf("codec soft deleted error: %+v", decoded)
+		}
+	}
+
+	// a merge starts over on the soft deleted value
+	deleted := &Entry{
+		OpAndDataType: OpAndDataType(pb.OpAndDataType_FLOAT64),
+		DeletedAtNs:   now,
+		Value:         util.Float64ToBytes(999),
+	}
+	operand := &Entry{
+		OpAndDataType: OpAndDataType(pb.OpAndDataType_FLOAT64),
+		Value:         util.Float64ToBytes(1),
+	}
+	merged, _ := MergeEntry(deleted.ToBytes(), operand.ToBytes())
+	if merged.IsDeleted() || util.BytesToFloat64(merged.Value) != 1 {
+		t.Errorf("merge on soft deleted error: %+v", merged)
+	}
+
+}
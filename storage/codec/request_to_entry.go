@@ -9,6 +9,8 @@ func NewPutEntry(put *pb.PutRequest, updatedAtNs uint64) *Entry {
 		UpdatedAtNs:   updatedAtNs,
 		TtlSecond:     put.TtlSecond,
 		OpAndDataType: OpAndDataType(put.OpAndDataType),
+		ExpireAtNs:    put.ExpireAtNs,
+		Version:       NewVersionVector(put.Version),
 		Value:         put.Value,
 	}
 }
@@ -0,0 +1,138 @@
+package codec
+
+import (
+	"encoding/binary"
+	"sort"
+
+	"github.com/chrislusf/vasto/pb"
+)
+
+// hasVersion is set on the OpAndDataType byte if the version vector follows the ExpireAtNs and DeletedAtNs if any.
+const hasVersion = 0x20
+
+// VersionVector counts the writes of each writer that an entry has seen. A missing writer counts 0.
+type VersionVector map[string]uint64
+
+// Causality is how one version is ordered with another.
+type Causality int
+
+const (
+	// VersionEqual means both versions have seen the same writes.
+	VersionEqual Causality = iota
+	// VersionBefore means the other version has seen all writes of this version, and more.
+	VersionBefore
+	// VersionAfter means this version has seen all writes of the other version, and more.
+	VersionAfter
+	// VersionConcurrent means each version has seen some writes the other has not.
+	VersionConcurrent
+)
+
+// NewVersionVector converts the version in the request, or returns nil if it is not set.
+func NewVersionVector(version *pb.VersionVector) VersionVector {
+	if version == nil {
+		return nil
+	}
+	v := make(VersionVector, len(version.Clocks))
+	for _, clock := range version.Clocks {
+		if clock.Counter > v[clock.Writer] {
+			v[clock.Writer] = clock.Counter
+		}
+	}
+	return v
+}
+
+// ToPb converts the version for the responses, with the writers sorted.
+func (v VersionVector) ToPb() *pb.VersionVector {
+	if v == nil {
+		return nil
+	}
+	version := &pb.VersionVector{}
+	for _, writer := range v.writers() {
+		version.Clocks = append(version.Clocks, &pb.VersionClock{Writer: writer, Counter: v[writer]})
+	}
+	return version
+}
+
+// Compare returns how this version is ordered with the other version.
+func (v VersionVector) Compare(other VersionVector) Causality {
+	var before, after bool
+	for writer, counter := range v {
+		if counter > other[writer] {
+			after = true
+		} else if counter < other[writer] {
+			before = true
+		}
+	}
+	for writer, counter := range other {
+		if _, found := v[writer]; !found && counter > 0 {
+			before = true
+		}
+	}
+	switch {
+	case before && after:
+		return VersionConcurrent
+	case before:
+		return VersionBefore
+	case after:
+		return VersionAfter
+	}
+	return VersionEqual
+}
+
+// Merge returns the version that has seen the writes of both versions.
+func (v VersionVector) Merge(other VersionVector) VersionVector {
+	merged := make(VersionVector, len(v))
+	for writer, counter := range v {
+		merged[writer] = counter
+	}
+	for writer, counter := range other {
+		if counter > merged[writer] {
+			merged[writer] = counter
+		}
+	}
+	return merged
+}
+
+func (v VersionVector) writers() []string {
+	writers := make([]string, 0, len(v))
+	for writer := range v {
+		writers = append(writers, writer)
+	}
+	sort.Strings(writers)
+	return writers
+}
+
+// toBytes encodes the version as the writer count, and then the length, bytes, and counter of each writer.
+func (v VersionVector) toBytes() []byte {
+	b := make([]byte, binary.MaxVarintLen64)
+	b = b[:binary.PutUvarint(b, uint64(len(v)))]
+	for _, writer := range v.writers() {
+		t := make([]byte, binary.MaxVarintLen64+len(writer)+8)
+		n := binary.PutUvarint(t, uint64(len(writer)))
+		n += copy(t[n:], writer)
+		binary.LittleEndian.PutUint64(t[n:], v[writer])
+		b = append(b, t[:n+8]...)
+	}
+	return b
+}
+
+// versionFromBytes decodes the version at the start of b, and returns the number of bytes read, or 0 if b is malformed.
+func versionFromBytes(b []byte) (v VersionVector, n int) {
+	count, n := binary.Uvarint(b)
+	if n <= 0 || count > uint64(len(b)) {
+		return nil, 0
+	}
+	v = make(VersionVector, count)
+	for i := uint64(0); i < count; i++ {
+		size, m := binary.Uvarint(b[n:])
+		if m <= 0 || uint64(len(b)-n-m) < size+8 {
+			return nil, 0
+		}
+		n += m
+		writer := string(b[n : n+int(size)])
+		n += int(size)
+		v[writer] = binary.LittleEndian.Uint64(b[n:])
+		n += 8
+	}
+	return v, n
+}
@@ -0,0 +1,67 @@
+package codec
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/chrislusf/vasto/pb"
+)
+
+func TestVersionVectorCompare(t *testing.T) {
+
+	for _, c := range []struct {
+		a, b VersionVector
+		want Causality
+	}{
+		{VersionVector{}, nil, VersionEqual},
+		{VersionVector{"a": 1}, VersionVector{"a": 1, "b": 0}, VersionEqual},
+		{VersionVector{"a": 1}, VersionVector{"a": 2}, VersionBefore},
+		{VersionVector{"a": 1}, VersionVector{"a": 1, "b": 1}, VersionBefore},
+		{VersionVector{"a": 2, "b": 1}, VersionVector{"a": 1}, VersionAfter},
+		{VersionVector{"a": 1}, nil, VersionAfter},
+		{VersionVector{"a": 2}, VersionVector{"a": 1, "b": 1}, VersionConcurrent},
+	} {
+		if got := c.a.Compare(c.b); got != c.want {
+			t.Errorf("compare %v with %v: %v, expected %v", c.a, c.b, got, c.want)
+		}
+	}
+
+	merged := VersionVector{"a": 2}.Merge(VersionVector{"a": 1, "b": 1})
+	if !reflect.DeepEqual(merged, VersionVector{"a": 2, "b": 1}) {
+		t.Errorf("merged: %v", merged)
+	}
+
+}
+
+func TestVersionedEntry(t *testing.T) {
+
+	version := NewVersionVector(&pb.VersionVector{Clocks: []*pb.VersionClock{
+		{Writer: "us-east", Counter: 3},
+		{Writer: "eu-west", Counter: 1},
+	}})
+
+	for _, entry := range []*Entry{
+		{PartitionHash: 1234, UpdatedAtNs: 10, Version: version, Value: []byte("v1")},
+		{PartitionHash: 1234, UpdatedAtNs: 10, ExpireAtNs: 20, DeletedAtNs: 10, Version: version, Value: []byte("v1")},
+		{PartitionHash: 1234, UpdatedAtNs: 10, DeletedAtNs: 10, Version: version},
+		{PartitionHash: 1234, UpdatedAtNs: 10, OpAndDataType: OpAndDataType(pb.OpAndDataType_FLOAT64), Version: VersionVector{}, Value: []byte("v1")},
+	} {
+		decoded := FromBytes(entry.ToBytes())
+		if decoded == nil || !reflect.DeepEqual(decoded.Version, entry.Version) || decoded.OpAndDataType != entry.OpAndDataType ||
+			decoded.ExpireAtNs != entry.ExpireAtNs || decoded.DeletedAtNs != entry.DeletedAtNs || !bytes.Equal(decoded.Value, entry.Value) {
+			t.Errorf("codec versioned entry %+v: %+v", entry, decoded)
+		}
+	}
+
+	if !reflect.DeepEqual(version.ToPb().Clocks, []*pb.VersionClock{{Writer: "eu-west", Counter: 1}, {Writer: "us-east", Counter: 3}}) {
+		t.Errorf("version to pb: %v", version.ToPb())
+	}
+
+	// a truncated version fails to decode
+	b := (&Entry{UpdatedAtNs: 10, Version: version}).ToBytes()
+	if FromBytes(b[:len(b)-4]) != nil {
+		t.Errorf("decoded a truncated version")
+	}
+
+}
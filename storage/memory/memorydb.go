@@ -0,0 +1,296 @@
+package memory
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/chrislusf/vasto/pb"
+	"github.com/chrislusf/vasto/storage/codec"
+)
+
+// ErrorShutdownInProgress is returned after the db is closed, the same as the rocksdb's.
+var ErrorShutdownInProgress = errors.New("shutdown in progress")
+
+// MergeOperator merges the operand into the existing value, with the same methods as the rocksdb merge operator,
+// so that this package does not depend on the rocksdb.
+type MergeOperator interface {
+	Name() string
+	FullMerge(key, existingValue []byte, operands [][]byte) ([]byte, bool)
+}
+
+// Memory keeps the entries in a map, with the same methods as the local rocksdb,
+// e.g., for unit tests of the store logic without the on-disk db.
+// The data is lost when the process exits. There is no compaction, so the compaction settings are ignored,
+// and expired entries or entries of other shards are not purged.
+type Memory struct {
+	mergeOperator MergeOperator
+	data          map[string][]byte
+	isClosed      bool
+	lock          sync.RWMutex
+}
+
+// NewDb creates an empty in-memory db. The mergeOperator can be nil if Merge is not used.
+func NewDb(mergeOperator MergeOperator) *Memory {
+	return &Memory{
+		mergeOperator: mergeOperator,
+		data:          make(map[string][]byte),
+	}
+}
+
+// Put puts to the memory
+func (d *Memory) Put(key []byte, msg []byte) error {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	if d.isClosed {
+		return ErrorShutdownInProgress
+	}
+	d.data[string(key)] = copyBytes(msg)
+	return nil
+}
+
+// Merge merges the msg to the existing value with the merge operator
+func (d *Memory) Merge(key []byte, msg []byte) error {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	if d.isClosed {
+		return ErrorShutdownInProgress
+	}
+	if d.mergeOperator == nil {
+		return fmt.Errorf("merge %s: no merge operator", string(key))
+	}
+	merged, ok := d.mergeOperator.FullMerge(key, d.data[string(key)], [][]byte{msg})
+	if !ok {
+		return fmt.Errorf("merge %s: %s failed", string(key), d.mergeOperator.Name())
+	}
+	d.data[string(key)] = merged
+	return nil
+}
+
+// Get gets from the memory, or returns nil if the key is not found
+func (d *Memory) Get(key []byte) ([]byte, error) {
+	d.lock.RLock()
+	defer d.lock.RUnlock()
+	if d.isClosed {
+		return nil, ErrorShutdownInProgress
+	}
+	return copyBytes(d.data[string(key)]), nil
+}
+
+// MultiGet gets multiple keys from the memory.
+// The values are in the same order as the keys, and the value is empty if the key is not found.
+func (d *Memory) MultiGet(keys [][]byte) ([][]byte, error) {
+	d.lock.RLock()
+	defer d.lock.RUnlock()
+	if d.isClosed {
+		return nil, ErrorShutdownInProgress
+	}
+	values := make([][]byte, len(keys))
+	for i, key := range keys {
+		values[i] = copyBytes(d.data[string(key)])
+	}
+	return values, nil
+}
+
+// Delete deletes from the memory
+func (d *Memory) Delete(key []byte) error {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	if d.isClosed {
+		return ErrorShutdownInProgress
+	}
+	delete(d.data, string(key))
+	return nil
+}
+
+// FullScanContext scan through all entries in key order, and stops with the ctx error once ctx is done.
+func (d *Memory) FullScanContext(ctx context.Context, batchSize uint64, limit uint64, fn func([]*pb.RawKeyValue) error) error {
+
+	keys, err := d.sortedKeys(nil, nil)
+	if err != nil {
+		return err
+	}
+
+	var rowCount uint64
+	rows := make([]*pb.RawKeyValue, 0, batchSize)
+	for _, key := range keys {
+
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		value, found := d.getValue(key)
+		if !found {
+			continue
+		}
+
+		rowCount++
+		rows = append(rows, &pb.RawKeyValue{
+			Key:   []byte(key),
+			Value: value,
+		})
+
+		if rowCount%batchSize == 0 {
+			if err := fn(rows); err != nil {
+				return err
+			}
+			rows = rows[:0]
+		}
+
+		if limit > 0 && rowCount >= limit {
+			break
+		}
+	}
+
+	if len(rows) > 0 {
+		return fn(rows)
+	}
+	return nil
+}
+
+// PrefixScanContext paginate through all entries with the prefix after the lastKey,
+// and stops with the ctx error once ctx is done.
+func (d *Memory) PrefixScanContext(ctx context.Context, prefix, lastKey []byte, limit int, fn func(key, value []byte) bool) error {
+
+	keys, err := d.sortedKeys(prefix, lastKey)
+	if err != nil {
+		return err
+	}
+
+	for i, key := range keys {
+
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		if limit > 0 && i >= limit {
+			break
+		}
+
+		value, found := d.getValue(key)
+		if !found {
+			continue
+		}
+
+		if !fn([]byte(key), value) {
+			break
+		}
+	}
+
+	return nil
+}
+
+// sortedKeys returns the keys with the prefix and after the lastKey, in order.
+func (d *Memory) sortedKeys(prefix, lastKey []byte) ([]string, error) {
+	d.lock.RLock()
+	defer d.lock.RUnlock()
+	if d.isClosed {
+		return nil, ErrorShutdownInProgress
+	}
+	var keys []string
+	for key := range d.data {
+		if bytes.HasPrefix([]byte(key), prefix) && (len(lastKey) == 0 || key > string(lastKey)) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys, nil
+}
+
+func (d *Memory) getValue(key string) ([]byte, bool) {
+	d.lock.RLock()
+	defer d.lock.RUnlock()
+	value, found := d.data[key]
+	return copyBytes(value), found
+}
+
+// AddSorted adds the key values from the addFunc behind the existing data, i.e., the existing keys are kept.
+func (d *Memory) AddSorted(name string, addFunc func(add func(key, value []byte) error) (int64, error)) error {
+	_, err := addFunc(func(key, value []byte) error {
+		d.lock.Lock()
+		defer d.lock.Unlock()
+		if d.isClosed {
+			return ErrorShutdownInProgress
+		}
+		if _, found := d.data[string(key)]; !found {
+			d.data[string(key)] = copyBytes(value)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %v", name, err)
+	}
+	return nil
+}
+
+// SetCompactionForShard is ignored, since there is no compaction.
+func (d *Memory) SetCompactionForShard(shardId, shardCount int) {
+}
+
+// SetTtlCompaction is ignored, since there is no compaction.
+func (d *Memory) SetTtlCompaction(enabled bool, onExpired func(key []byte, entry *codec.Entry)) {
+}
+
+// SetSoftDeleteRetention is ignored, since there is no compaction. The soft deleted entries are kept.
+func (d *Memory) SetSoftDeleteRetention(retention time.Duration) {
+}
+
+// PrepareForClusterResize is ignored, since there is no compaction.
+func (d *Memory) PrepareForClusterResize() {
+}
+
+// CompleteClusterResize is ignored, since there is no compaction.
+func (d *Memory) CompleteClusterResize() {
+}
+
+// Compact is ignored, since there is no compaction.
+func (d *Memory) Compact() {
+}
+
+// Destroy removes all data
+func (d *Memory) Destroy() {
+	d.lock.Lock()
+	d.data = make(map[string][]byte)
+	d.lock.Unlock()
+}
+
+// EnsureDirectory does nothing, since there is no directory.
+func (d *Memory) EnsureDirectory() {
+}
+
+// Reopen accepts the reads and writes again after Close, with the data kept.
+func (d *Memory) Reopen() {
+	d.lock.Lock()
+	d.isClosed = false
+	d.lock.Unlock()
+}
+
+// Close rejects the following reads and writes until Reopen.
+func (d *Memory) Close() {
+	d.lock.Lock()
+	d.isClosed = true
+	d.lock.Unlock()
+}
+
+// LiveFilesSize always returns 0, since there are no files.
+func (d *Memory) LiveFilesSize() uint64 {
+	return 0
+}
+
+// HasBackfilled always returns false, since AddSorted can be called any number of times.
+func (d *Memory) HasBackfilled() bool {
+	return false
+}
+
+func copyBytes(b []byte) []byte {
+	if b == nil {
+		return nil
+	}
+	t := make([]byte, len(b))
+	copy(t, b)
+	return t
+}
@@ -0,0 +1,117 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/chrislusf/vasto/pb"
+)
+
+func TestPutGetDelete(t *testing.T) {
+
+	db := NewDb(nil)
+
+	db.Put([]byte("k1"), []byte("v1"))
+	if b, _ := db.Get([]byte("k1")); string(b) != "v1" {
+		t.Errorf("get k1: %s", string(b))
+	}
+
+	values, _ := db.MultiGet([][]byte{[]byte("k1"), []byte("k2")})
+	if len(values) != 2 || string(values[0]) != "v1" || len(values[1]) != 0 {
+		t.Errorf("multi get: %q", values)
+	}
+
+	db.Delete([]byte("k1"))
+	if b, _ := db.Get([]byte("k1")); len(b) != 0 {
+		t.Errorf("get deleted k1: %s", string(b))
+	}
+
+	if err := db.Merge([]byte("k1"), []byte("v1")); err == nil {
+		t.Errorf("merge without merge operator")
+	}
+
+	db.Close()
+	if _, err := db.Get([]byte("k1")); err == nil {
+		t.Errorf("get after close")
+	}
+	db.Reopen()
+	if _, err := db.Get([]byte("k1")); err != nil {
+		t.Errorf("get after reopen: %v", err)
+	}
+
+}
+
+func TestScan(t *testing.T) {
+
+	db := NewDb(nil)
+
+	for i := 0; i < 1000; i++ {
+		db.Put([]byte(fmt.Sprintf("k%3d", i)), []byte(fmt.Sprintf("v%3d", i)))
+	}
+
+	var keys []string
+	db.PrefixScanContext(context.Background(), []byte("k12"), []byte("k123"), 3, func(key, value []byte) bool {
+		keys = append(keys, string(key))
+		return true
+	})
+	if fmt.Sprint(keys) != "[k124 k125 k126]" {
+		t.Errorf("prefix scan after k123: %v", keys)
+	}
+
+	var counter, batches int
+	lastKey := ""
+	db.FullScanContext(context.Background(), 100, 0, func(rows []*pb.RawKeyValue) error {
+		batches++
+		for _, row := range rows {
+			if string(row.Key) <= lastKey {
+				t.Errorf("full scan out of order: %s after %s", string(row.Key), lastKey)
+			}
+			lastKey = string(row.Key)
+			counter++
+		}
+		return nil
+	})
+	if counter != 1000 || batches != 10 {
+		t.Errorf("full scan %d rows in %d batches", counter, batches)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	counter = 0
+	err := db.FullScanContext(ctx, 1, 0, func(rows []*pb.RawKeyValue) error {
+		counter++
+		if counter == 10 {
+			cancel()
+		}
+		return nil
+	})
+	if err != context.Canceled || counter != 10 {
+		t.Errorf("cancelled full scan %d rows: %v", counter, err)
+	}
+
+}
+
+func TestAddSorted(t *testing.T) {
+
+	db := NewDb(nil)
+	db.Put([]byte("k2"), []byte("existing"))
+
+	err := db.AddSorted("test", func(add func(key, value []byte) error) (int64, error) {
+		for i := 1; i <= 3; i++ {
+			if err := add([]byte(fmt.Sprintf("k%d", i)), []byte(fmt.Sprintf("v%d", i))); err != nil {
+				return int64(i - 1), err
+			}
+		}
+		return 3, nil
+	})
+	if err != nil {
+		t.Fatalf("add sorted: %v", err)
+	}
+
+	for key, expected := range map[string]string{"k1": "v1", "k2": "existing", "k3": "v3"} {
+		if b, _ := db.Get([]byte(key)); string(b) != expected {
+			t.Errorf("get %s: %s, expecting %s", key, string(b), expected)
+		}
+	}
+
+}
@@ -105,6 +105,27 @@ func (d *Rocks) Get(key []byte) (data []byte, err error) {
 	return
 }
 
+// MultiGet gets multiple keys from local rocksdb with one native multi get.
+// The values are in the same order as the keys, and the value is empty if the key is not found.
+func (d *Rocks) MultiGet(keys [][]byte) (values [][]byte, err error) {
+	if newClientCounter := atomic.AddInt32(&d.clientCounter, 1); newClientCounter > 0 {
+		var slices gorocksdb.Slices
+		if slices, err = d.db.MultiGet(d.ro, keys...); err == nil {
+			values = make([][]byte, len(keys))
+			for i, slice := range slices {
+				if slice.Exists() {
+					values[i] = append([]byte(nil), slice.Data()...)
+				}
+			}
+			slices.Destroy()
+		}
+	} else {
+		err = ErrorShutdownInProgress
+	}
+	atomic.AddInt32(&d.clientCounter, -1)
+	return
+}
+
 // Delete deletes from local rocksdb
 func (d *Rocks) Delete(k []byte) (err error) {
 	// println("del", string(k))
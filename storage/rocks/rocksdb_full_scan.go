@@ -1,6 +1,7 @@
 package rocks
 
 import (
+	"context"
 	"fmt"
 	"github.com/chrislusf/gorocksdb"
 	"github.com/chrislusf/vasto/pb"
@@ -9,6 +10,12 @@ import (
 
 // FullScan scan through all entries
 func (d *Rocks) FullScan(batchSize uint64, limit uint64, fn func([]*pb.RawKeyValue) error) error {
+	return d.FullScanContext(context.Background(), batchSize, limit, fn)
+}
+
+// FullScanContext scan through all entries, and stops with the ctx error once ctx is done.
+// The ctx is checked before each key, so the rows already collected for the current batch are not passed to fn.
+func (d *Rocks) FullScanContext(ctx context.Context, batchSize uint64, limit uint64, fn func([]*pb.RawKeyValue) error) error {
 	newClientCounter := atomic.AddInt32(&d.clientCounter, 1)
 	defer atomic.AddInt32(&d.clientCounter, -1)
 	if newClientCounter <= 0 {
@@ -25,6 +32,10 @@ func (d *Rocks) FullScan(batchSize uint64, limit uint64, fn func([]*pb.RawKeyVal
 	rows := make([]*pb.RawKeyValue, 0, batchSize)
 	for iter.SeekToFirst(); iter.Valid(); iter.Next() {
 
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
 		k := iter.Key()
 		v := iter.Value()
 
@@ -2,6 +2,7 @@ package rocks
 
 import (
 	"bytes"
+	"context"
 
 	"fmt"
 	"github.com/chrislusf/gorocksdb"
@@ -11,6 +12,11 @@ import (
 // PrefixScan paginate through all entries with the prefix
 // the first scan can have empty lastKey and limit = 0
 func (d *Rocks) PrefixScan(prefix, lastKey []byte, limit int, fn func(key, value []byte) bool) error {
+	return d.PrefixScanContext(context.Background(), prefix, lastKey, limit, fn)
+}
+
+// PrefixScanContext paginate through all entries with the prefix, and stops with the ctx error once ctx is done.
+func (d *Rocks) PrefixScanContext(ctx context.Context, prefix, lastKey []byte, limit int, fn func(key, value []byte) bool) error {
 	if newClientCounter := atomic.AddInt32(&d.clientCounter, 1); newClientCounter <= 0 {
 		atomic.AddInt32(&d.clientCounter, -1)
 		return ErrorShutdownInProgress
@@ -20,7 +26,7 @@ func (d *Rocks) PrefixScan(prefix, lastKey []byte, limit int, fn func(key, value
 	opts.SetFillCache(false)
 	iter := d.db.NewIterator(opts)
 
-	err := d.enumerate(iter, prefix, lastKey, limit, fn)
+	err := d.enumerate(ctx, iter, prefix, lastKey, limit, fn)
 
 	iter.Close()
 	opts.Destroy()
@@ -30,7 +36,7 @@ func (d *Rocks) PrefixScan(prefix, lastKey []byte, limit int, fn func(key, value
 	return err
 }
 
-func (d *Rocks) enumerate(iter *gorocksdb.Iterator, prefix, lastKey []byte, limit int, fn func(key, value []byte) bool) error {
+func (d *Rocks) enumerate(ctx context.Context, iter *gorocksdb.Iterator, prefix, lastKey []byte, limit int, fn func(key, value []byte) bool) error {
 
 	if len(lastKey) == 0 {
 		iter.Seek(prefix)
@@ -49,6 +55,10 @@ func (d *Rocks) enumerate(iter *gorocksdb.Iterator, prefix, lastKey []byte, limi
 	i := 0
 	for ; iter.Valid(); iter.Next() {
 
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
 		if limit > 0 {
 			i++
 			if i > limit {
@@ -9,9 +9,13 @@ import (
 )
 
 type shardingCompactionFilter struct {
-	shardId    int32
-	shardCount int
-	isResizing bool
+	shardId     int32
+	shardCount  int
+	isResizing  bool
+	keepExpired bool
+	onExpired   func(key []byte, entry *codec.Entry)
+	// soft deleted entries are purged after the retention
+	softDeleteRetention time.Duration
 }
 
 func (m *shardingCompactionFilter) configure(shardId int32, shardCount int) {
@@ -35,11 +39,17 @@ func (m *shardingCompactionFilter) Filter(level int, key, val []byte) (bool, []b
 			return true, nil
 		}
 	}
-	if entry.TtlSecond == 0 {
+	if entry.IsDeleted() && entry.DeletedAtNs+uint64(m.softDeleteRetention) < uint64(time.Now().UnixNano()) {
+		return true, nil
+	}
+	if entry.ExpiresAtNs() == 0 || m.keepExpired {
 		return false, nil
 	}
-	if entry.UpdatedAtNs/uint64(1000000)+uint64(entry.TtlSecond) < uint64(time.Now().Unix()) {
-		// glog.V(1).Infof("skipping updatedAt:%d, ttl:%d", entry.UpdatedAtNs/uint64(1000000), entry.TtlSecond, string(key), string(val))
+	if entry.IsExpiredAt(uint64(time.Now().UnixNano())) {
+		// glog.V(1).Infof("skipping updatedAt:%d, ttl:%d", entry.UpdatedAtNs/uint64(time.Second), entry.TtlSecond, string(key), string(val))
+		if m.onExpired != nil {
+			m.onExpired(key, entry)
+		}
 		return true, nil
 	}
 	return false, nil
@@ -51,6 +61,18 @@ func (d *Rocks) SetCompactionForShard(shardId, shardCount int) {
 	d.compactionFilter.configure(int32(shardId), shardCount)
 }
 
+// SetTtlCompaction sets whether expired entries are physically purged during compaction.
+// If onExpired is not nil, it is called with each purged expired entry.
+func (d *Rocks) SetTtlCompaction(enabled bool, onExpired func(key []byte, entry *codec.Entry)) {
+	d.compactionFilter.keepExpired = !enabled
+	d.compactionFilter.onExpired = onExpired
+}
+
+// SetSoftDeleteRetention sets how long soft deleted entries are kept before being physically purged during compaction.
+func (d *Rocks) SetSoftDeleteRetention(retention time.Duration) {
+	d.compactionFilter.softDeleteRetention = retention
+}
+
 func (d *Rocks) PrepareForClusterResize() {
 	d.compactionFilter.isResizing = true
 }
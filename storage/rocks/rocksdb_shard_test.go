@@ -9,6 +9,7 @@ import (
 	"github.com/chrislusf/vasto/util"
 	"github.com/magiconair/properties/assert"
 	"math"
+	"sync/atomic"
 	"time"
 )
 
@@ -76,3 +77,75 @@ func TestSetTtlCompactionForShard(t *testing.T) {
 	assert.Equal(t, counter4, 0, "compaction with ttl")
 
 }
+
+func TestTtlCompactionWithExpiredHandler(t *testing.T) {
+
+	db := setupTestDb()
+	defer cleanup(db)
+
+	total := 1000
+	now := uint64(time.Now().UnixNano())
+
+	for i := 0; i < total; i++ {
+		key := []byte(fmt.Sprintf("k%5d", i))
+		ttlSecond := uint32(0)
+		if i%2 == 0 {
+			ttlSecond = 1
+		}
+		entry := &codec.Entry{
+			PartitionHash: util.Hash(key),
+			UpdatedAtNs:   now - uint64(3*time.Second),
+			TtlSecond:     ttlSecond,
+			OpAndDataType: codec.OpAndDataType(pb.OpAndDataType_BYTES),
+			Value:         []byte(fmt.Sprintf("v%5d", i)),
+		}
+		db.Put(key, entry.ToBytes())
+	}
+
+	db.SetCompactionForShard(0, 1)
+
+	db.SetTtlCompaction(false, nil)
+	db.Compact()
+	assert.Equal(t, count(db), total, "keep expired entries")
+
+	var expiredCount int32
+	db.SetTtlCompaction(true, func(key []byte, entry *codec.Entry) {
+		atomic.AddInt32(&expiredCount, 1)
+	})
+	db.Compact()
+	assert.Equal(t, count(db), total/2, "purge expired entries")
+	assert.Equal(t, int(atomic.LoadInt32(&expiredCount)), total/2, "expired entries handled")
+
+}
+
+func TestSoftDeleteCompaction(t *testing.T) {
+
+	db := setupTestDb()
+	defer cleanup(db)
+
+	total := 1000
+	now := uint64(time.Now().UnixNano())
+
+	for i := 0; i < total; i++ {
+		key := []byte(fmt.Sprintf("k%5d", i))
+		entry := &codec.Entry{
+			PartitionHash: util.Hash(key),
+			UpdatedAtNs:   now,
+			OpAndDataType: codec.OpAndDataType(pb.OpAndDataType_BYTES),
+			Value:         []byte(fmt.Sprintf("v%5d", i)),
+		}
+		switch i % 3 {
+		case 1:
+			entry.DeletedAtNs = now - uint64(2*time.Hour)
+		case 2:
+			entry.DeletedAtNs = now
+		}
+		db.Put(key, entry.ToBytes())
+	}
+
+	db.SetCompactionForShard(0, 1)
+	db.SetSoftDeleteRetention(time.Hour)
+	db.Compact()
+	assert.Equal(t, count(db), total-total/3, "purge soft deleted entries after the retention")
+
+}
@@ -81,3 +81,23 @@ func (d *Rocks) AddSstByWriter(name string, writerFunc func(*gorocksdb.SSTFileWr
 
 	return nil
 }
+
+// AddSorted adds the sorted key values from the addFunc by ingesting behind, the same as AddSstByWriter,
+// without exposing the sst file writer.
+func (d *Rocks) AddSorted(name string, addFunc func(add func(key, value []byte) error) (int64, error)) error {
+	return d.AddSstByWriter(name, func(w *gorocksdb.SSTFileWriter) (int64, error) {
+		return addFunc(w.Add)
+	})
+}
+
+// HasBackfilled checks whether any file is in the bottom level, where the data is ingested behind.
+func (d *Rocks) HasBackfilled() (hasBackfilled bool) {
+	for fileId, meta := range d.GetLiveFilesMetaData() {
+		glog.V(1).Infof("%s %d name:%s, level:%d size:%d SmallestKey:%s LargestKey:%s",
+			d.path, fileId, meta.Name, meta.Level, meta.Size, string(meta.SmallestKey), string(meta.LargestKey))
+		if meta.Level >= 6 {
+			hasBackfilled = true
+		}
+	}
+	return
+}
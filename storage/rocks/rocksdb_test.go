@@ -2,6 +2,7 @@ package rocks
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"github.com/chrislusf/vasto/pb"
 	"math/rand"
@@ -32,6 +33,23 @@ func TestPutGet(t *testing.T) {
 	}
 }
 
+func TestMultiGet(t *testing.T) {
+	db := setupTestDb()
+	defer cleanup(db)
+
+	db.Put([]byte("k1"), []byte("v1"))
+	db.Put([]byte("k3"), []byte("v3"))
+
+	values, err := db.MultiGet([][]byte{[]byte("k1"), []byte("k2"), []byte("k3")})
+	if err != nil {
+		t.Errorf("multi get should not return any error. err: %v", err)
+	}
+
+	if len(values) != 3 || string(values[0]) != "v1" || len(values[1]) != 0 || string(values[2]) != "v3" {
+		t.Errorf("unexpected multi get values: %q", values)
+	}
+}
+
 func TestMerge(t *testing.T) {
 	db := setupTestDb()
 	defer cleanup(db)
@@ -188,6 +206,51 @@ func TestFullScan(t *testing.T) {
 
 }
 
+func TestScanContextCancel(t *testing.T) {
+
+	db := setupTestDb()
+	defer cleanup(db)
+
+	for i := 0; i < 100; i++ {
+		db.Put([]byte(fmt.Sprintf("k%3d", i)), []byte(fmt.Sprintf("v%3d", i)))
+	}
+
+	cancelAfter := 10
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var counter1 int
+	err := db.FullScanContext(ctx, 1, 0, func(rows []*pb.RawKeyValue) error {
+		counter1 += len(rows)
+		if counter1 == cancelAfter {
+			cancel()
+		}
+		return nil
+	})
+	if err != context.Canceled {
+		t.Errorf("full scan cancelled with %v", err)
+	}
+	if counter1 != cancelAfter {
+		t.Errorf("full scan expecting %d rows before cancel, but actual %d rows", cancelAfter, counter1)
+	}
+
+	ctx, cancel = context.WithCancel(context.Background())
+	var counter2 int
+	err = db.PrefixScanContext(ctx, []byte("k"), nil, 0, func(key, value []byte) bool {
+		counter2++
+		if counter2 == cancelAfter {
+			cancel()
+		}
+		return true
+	})
+	if err != context.Canceled {
+		t.Errorf("prefix scan cancelled with %v", err)
+	}
+	if counter2 != cancelAfter {
+		t.Errorf("prefix scan expecting %d rows before cancel, but actual %d rows", cancelAfter, counter2)
+	}
+
+}
+
 func setupTestDb() *Rocks {
 	db := NewDb("/tmp/rocks-test-go", &bytesMergeOperator{})
 	return db
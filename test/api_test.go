@@ -120,6 +120,22 @@ func startMasterAndStore() int {
 		DiskSizeGb:        getInt(10),
 		Tags:              getString(""),
 		DisableBinLog:     getBool(false),
+		TtlCompaction:     getString(""),
+		BatchWindowMs:     getInt(100),
+		BatchWindows:      getString(""),
+		BatchWindowKb:     getInt(64),
+		DataCenter:        getString(""),
+		DeadLetterAfter:   getInt(3),
+		ShardMaxInFlight:  getInt(1024),
+		TtlSweepSeconds:   getInt(0),
+		MetricsAddress:    getString(""),
+		InMemory:          getBool(false),
+		SoftDeleteHours:   getInt(24),
+		LogBackpressureMb: getInt(0),
+		MaxKeyLength:      getInt(65536),
+		SlowDeleteMs:      getInt(100),
+		RepairOnStartup:   getBool(false),
+		IntentTtlSeconds:  getInt(600),
 	}
 
 	go s.RunStore(storeOption)
@@ -2,22 +2,49 @@ package topology
 
 import (
 	"bytes"
+	"crypto/tls"
+	"errors"
 	"fmt"
 
 	"github.com/chrislusf/glog"
 	"github.com/chrislusf/vasto/pb"
+	"github.com/chrislusf/vasto/util"
 	"github.com/dgryski/go-jump"
 	"sort"
+	"sync"
 )
 
-// Cluster manages one cluster topology
+// Cluster manages one cluster topology.
+// It is safe to read and change the cluster from multiple goroutines.
+// The nodes returned are never changed in place, so they can be read without locking.
 type Cluster struct {
+	// lock guards all the fields below except keyspace.
+	lock              sync.RWMutex
 	keyspace          string
 	dataCenter        string
 	logicalShards     []LogicalShardGroup
 	expectedSize      int
 	replicationFactor int
 	nextCluster       *Cluster
+	idAllocator       IdAllocator
+	tlsConfig         *tls.Config
+	// weights has the shard weights set by SetWeight.
+	weights map[int]float64
+	// health has the node health set by SetHealth, by store address.
+	health map[string]NodeHealth
+	// the listeners registered by OnNodeAdded and OnNodeRemoved
+	nodeAddedListeners   []*nodeListener
+	nodeRemovedListeners []*nodeListener
+	// keyHasher is set by SetKeyHasher, and nil for the DefaultKeyHasher.
+	keyHasher KeyHasher
+	// statusHistory has the latest status transitions by shard identifier, up to statusHistoryDepth for each.
+	// The depth is 0 for the DefaultStatusHistoryDepth, and -1 to keep no history.
+	statusHistory      map[string][]ShardStatusTransition
+	statusHistoryDepth int
+	// messageSizeLimit is set by SetMessageSizeLimit, and zero to use the global limit.
+	messageSizeLimit MessageSizeLimit
+	// localDataCenter is set by SetLocalDataCenter, and empty to compress no connections.
+	localDataCenter string
 }
 
 // LogicalShardGroup is a list of shards with the same shard id
@@ -41,6 +68,30 @@ func (shards LogicalShardGroup) String() string {
 // SetShard sets the tuple of server and shardInfo to the cluster.
 // It returns the previous shardInfo if found.
 func (cluster *Cluster) SetShard(store *pb.StoreResource, shard *pb.ShardInfo) (oldShardInfo *pb.ShardInfo) {
+	return cluster.SetNode(store, shard).GetShardInfo()
+}
+
+// SetNode sets the tuple of server and shardInfo to the cluster, the same as SetShard.
+// It returns the node previously having the shard on the same address or on the same server, if found,
+// so that the caller can detect a server claimed by another address.
+func (cluster *Cluster) SetNode(store *pb.StoreResource, shard *pb.ShardInfo) (displaced *pb.ClusterNode) {
+	cluster.lock.Lock()
+	node, displaced := cluster.setShard(store, shard)
+	cluster.lock.Unlock()
+
+	if displaced == nil {
+		cluster.notifyNodeChanges([]*pb.ClusterNode{node}, nil)
+	} else if displaced.StoreResource.Address != store.Address {
+		cluster.notifyNodeChanges([]*pb.ClusterNode{node}, []*pb.ClusterNode{displaced})
+	}
+
+	return displaced
+}
+
+func (cluster *Cluster) setShard(store *pb.StoreResource, shard *pb.ShardInfo) (node, displaced *pb.ClusterNode) {
+	defer func() {
+		cluster.recordStatus(displaced, shard)
+	}()
 	shardId := int(shard.ShardId)
 	if len(cluster.logicalShards) < shardId+1 {
 		capacity := shardId + 1
@@ -51,28 +102,60 @@ func (cluster *Cluster) SetShard(store *pb.StoreResource, shard *pb.ShardInfo) (
 	shardGroup := cluster.logicalShards[shardId]
 	for i := 0; i < len(shardGroup); i++ {
 		if shardGroup[i].StoreResource.Address == store.Address && shardGroup[i].ShardInfo.ShardId == shard.ShardId {
-			oldShardInfo = shardGroup[i].ShardInfo
-			shardGroup[i].ShardInfo = shard
+			displaced = shardGroup[i]
+			node = &pb.ClusterNode{
+				StoreResource: shardGroup[i].StoreResource,
+				ShardInfo:     shard,
+			}
+			shardGroup[i] = node
+			return
+		}
+		// the same server is never listed twice in one shard group, even if its address has changed
+		if shardGroup[i].ShardInfo.ServerId == shard.ServerId && shardGroup[i].ShardInfo.ShardId == shard.ShardId {
+			displaced = shardGroup[i]
+			node = &pb.ClusterNode{
+				StoreResource: store,
+				ShardInfo:     shard,
+			}
+			shardGroup[i] = node
 			return
 		}
 	}
-	shardGroup = append(shardGroup, &pb.ClusterNode{
+	node = &pb.ClusterNode{
 		StoreResource: store,
 		ShardInfo:     shard,
-	})
+	}
+	shardGroup = append(shardGroup, node)
 	cluster.logicalShards[shardId] = sortedShards(shardGroup, len(cluster.logicalShards))
 	if cluster.expectedSize != int(shard.ClusterSize) {
-		cluster.SetExpectedSize(int(shard.ClusterSize))
+		cluster.setExpectedSize(int(shard.ClusterSize))
 	}
-	if cluster.replicationFactor != int(shard.ReplicationFactor) {
-		cluster.SetReplicationFactor(int(shard.ReplicationFactor))
+	if cluster.replicationFactor != int(shard.ReplicationFactor) && shard.ReplicationFactor > 0 {
+		cluster.replicationFactor = int(shard.ReplicationFactor)
 	}
 	return
 }
 
 // ReplaceShard ReplaceShard the shardInfo on the server in the cluster.
-// It returns true if the operation is successful.
+// It returns true if the operation is successful, and notifies the listeners of the replaced and the added nodes
+// if the shard is moved to another address.
 func (cluster *Cluster) ReplaceShard(newStore *pb.StoreResource, shard *pb.ShardInfo) (isReplaced bool) {
+	cluster.lock.Lock()
+	node, replaced := cluster.replaceShard(newStore, shard)
+	cluster.lock.Unlock()
+
+	if replaced == nil {
+		glog.Errorf("replace shard error: shard %s not found", shard.IdentifierOnThisServer())
+		return false
+	}
+
+	if replaced.StoreResource.Address != newStore.Address {
+		cluster.notifyNodeChanges([]*pb.ClusterNode{node}, []*pb.ClusterNode{replaced})
+	}
+	return true
+}
+
+func (cluster *Cluster) replaceShard(newStore *pb.StoreResource, shard *pb.ShardInfo) (node, replaced *pb.ClusterNode) {
 	shardId := int(shard.ShardId)
 	if len(cluster.logicalShards) < shardId+1 {
 		capacity := shardId + 1
@@ -83,17 +166,48 @@ func (cluster *Cluster) ReplaceShard(newStore *pb.StoreResource, shard *pb.Shard
 	shardGroup := cluster.logicalShards[shardId]
 	for i := 0; i < len(shardGroup); i++ {
 		if shardGroup[i].ShardInfo.IdentifierOnThisServer() == shard.IdentifierOnThisServer() {
-			shardGroup[i].ShardInfo = shard
-			shardGroup[i].StoreResource = newStore
-			return true
+			replaced = shardGroup[i]
+			node = &pb.ClusterNode{
+				StoreResource: newStore,
+				ShardInfo:     shard,
+			}
+			shardGroup[i] = node
+			return
 		}
 	}
-	glog.Errorf("replace shard error: shard %s not found", shard.IdentifierOnThisServer())
-	return false
+	return nil, nil
 }
 
 // RemoveShard returns true if no other shards is on this store
 func (cluster *Cluster) RemoveShard(store *pb.StoreResource, shard *pb.ShardInfo) (storeDeleted bool) {
+	cluster.lock.Lock()
+	removed := cluster.removeShard(store, shard)
+	storeDeleted = !cluster.isStoreInUse(store) && !cluster.nextCluster.hasStore(store)
+	cluster.lock.Unlock()
+
+	if removed != nil {
+		cluster.notifyNodeChanges(nil, []*pb.ClusterNode{removed})
+	}
+
+	return storeDeleted
+}
+
+// RemoveNode removes the shard on the store from the cluster, the same as RemoveShard.
+// It returns the removed node, or nil if the shard was not in the cluster,
+// so that the caller can detect repeated removals.
+func (cluster *Cluster) RemoveNode(store *pb.StoreResource, shard *pb.ShardInfo) (removed *pb.ClusterNode) {
+	cluster.lock.Lock()
+	removed = cluster.removeShard(store, shard)
+	cluster.lock.Unlock()
+
+	if removed != nil {
+		cluster.notifyNodeChanges(nil, []*pb.ClusterNode{removed})
+	}
+
+	return removed
+}
+
+func (cluster *Cluster) removeShard(store *pb.StoreResource, shard *pb.ShardInfo) (removed *pb.ClusterNode) {
 	shardId := int(shard.ShardId)
 	if len(cluster.logicalShards) <= shardId {
 		return
@@ -101,6 +215,8 @@ func (cluster *Cluster) RemoveShard(store *pb.StoreResource, shard *pb.ShardInfo
 	shardGroup := cluster.logicalShards[shardId]
 	for i := 0; i < len(shardGroup); i++ {
 		if shardGroup[i].StoreResource.Address == store.Address && shardGroup[i].ShardInfo.ShardId == shard.ShardId {
+			removed = shardGroup[i]
+			cluster.recordStatus(removed, nil)
 			copy(shardGroup[i:], shardGroup[i+1:])
 			shardGroup[len(shardGroup)-1] = nil // or the zero value of T
 			shardGroup = shardGroup[:len(shardGroup)-1]
@@ -110,31 +226,66 @@ func (cluster *Cluster) RemoveShard(store *pb.StoreResource, shard *pb.ShardInfo
 	}
 
 	// if no shards and no clients, set the cluster size to be 0
-	if cluster.CurrentSize() == 0 {
+	if cluster.currentSize() == 0 {
 		cluster.expectedSize = 0
 		cluster.logicalShards = nil
 	}
 
-	// check other shards that may be using the store
+	return
+}
+
+// RemoveStore removes the server from the cluster.
+// It returns the shards which were on the server.
+func (cluster *Cluster) RemoveStore(store *pb.StoreResource) (removedShards []*pb.ShardInfo) {
+	cluster.lock.Lock()
+	removedNodes := cluster.removeStore(store.Address)
+	cluster.lock.Unlock()
+
+	cluster.notifyNodeChanges(nil, removedNodes)
+
+	for _, node := range removedNodes {
+		removedShards = append(removedShards, node.ShardInfo)
+	}
+	return
+}
+
+// RemoveByAddress removes all the shards on the server with the store address,
+// e.g., when a failure detector only knows the address of a dead server.
+// It returns the removed nodes, and found is false if no server has the address.
+// If servers with different ids have the same address, which Validate also reports,
+// nothing is removed and an error is returned, since it is unknown which server is dead.
+func (cluster *Cluster) RemoveByAddress(address string) (removed []*pb.ClusterNode, found bool, err error) {
+	cluster.lock.Lock()
+
+	serverIds := make(map[uint32]bool)
 	for _, shardGroup := range cluster.logicalShards {
-		for i := 0; i < len(shardGroup); i++ {
-			if shardGroup[i] != nil && shardGroup[i].StoreResource.Address == store.Address {
-				return false
+		for _, node := range shardGroup {
+			if node.StoreResource.Address == address {
+				serverIds[node.ShardInfo.ServerId] = true
 			}
 		}
 	}
 
-	return !cluster.isStoreInUse(store) && !cluster.GetNextCluster().isStoreInUse(store)
+	if len(serverIds) > 1 {
+		cluster.lock.Unlock()
+		return nil, true, fmt.Errorf("address %s is used by %d servers", address, len(serverIds))
+	}
+
+	removed = cluster.removeStore(address)
+	cluster.lock.Unlock()
+
+	cluster.notifyNodeChanges(nil, removed)
+
+	return removed, len(removed) > 0, nil
 }
 
-// RemoveStore removes the server from the cluster.
-// It returns the shards which were on the server.
-func (cluster *Cluster) RemoveStore(store *pb.StoreResource) (removedShards []*pb.ShardInfo) {
+func (cluster *Cluster) removeStore(address string) (removedNodes []*pb.ClusterNode) {
 	for shardId, shardGroup := range cluster.logicalShards {
 		for i := 0; i < len(shardGroup); i++ {
-			if shardGroup[i].StoreResource.Address == store.Address {
+			if shardGroup[i].StoreResource.Address == address {
 
-				removedShards = append(removedShards, shardGroup[i].ShardInfo)
+				removedNodes = append(removedNodes, shardGroup[i])
+				cluster.recordStatus(shardGroup[i], nil)
 
 				copy(shardGroup[i:], shardGroup[i+1:])
 				shardGroup[len(shardGroup)-1] = nil // or the zero value of T
@@ -147,19 +298,69 @@ func (cluster *Cluster) RemoveStore(store *pb.StoreResource) (removedShards []*p
 	return
 }
 
-func (cluster *Cluster) isStoreInUse(store *pb.StoreResource) bool {
+// hasStore checks whether any shard is on the store. The cluster can be nil.
+func (cluster *Cluster) hasStore(store *pb.StoreResource) bool {
 	if cluster == nil {
 		return false
 	}
+	cluster.lock.RLock()
+	defer cluster.lock.RUnlock()
+	return cluster.isStoreInUse(store)
+}
+
+func (cluster *Cluster) isStoreInUse(store *pb.StoreResource) bool {
 	// check other shards that may be using the store
-	for _, shardGroup := range cluster.logicalShards {
-		for i := 0; i < len(shardGroup); i++ {
-			if shardGroup[i] != nil && shardGroup[i].StoreResource.Address == store.Address {
-				return true
+	return cluster.eachNode(func(shardId int, node *pb.ClusterNode) error {
+		if node.StoreResource.Address == store.Address {
+			return errStopIteration
+		}
+		return nil
+	}) == errStopIteration
+}
+
+var errStopIteration = errors.New("stop iteration")
+
+// isLive checks whether the node is set and has an address.
+func isLive(node *pb.ClusterNode) bool {
+	return node != nil && node.StoreResource != nil && node.StoreResource.Address != ""
+}
+
+// EachNode calls fn for each live node, i.e., set and having an address, by shard id and then by replica.
+// It stops and returns the error if fn returns an error.
+// fn is called on a snapshot of the nodes, so it can change the cluster.
+func (cluster *Cluster) EachNode(fn func(shardId int, node *pb.ClusterNode) error) error {
+	type shardNode struct {
+		shardId int
+		node    *pb.ClusterNode
+	}
+	var nodes []shardNode
+	cluster.lock.RLock()
+	cluster.eachNode(func(shardId int, node *pb.ClusterNode) error {
+		nodes = append(nodes, shardNode{shardId, node})
+		return nil
+	})
+	cluster.lock.RUnlock()
+
+	for _, n := range nodes {
+		if err := fn(n.shardId, n.node); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (cluster *Cluster) eachNode(fn func(shardId int, node *pb.ClusterNode) error) error {
+	for shardId, shardGroup := range cluster.logicalShards {
+		for _, node := range shardGroup {
+			if !isLive(node) {
+				continue
+			}
+			if err := fn(shardId, node); err != nil {
+				return err
 			}
 		}
 	}
-	return false
+	return nil
 }
 
 func sortedShards(shards LogicalShardGroup, clusterSize int) LogicalShardGroup {
@@ -177,23 +378,57 @@ func sortedShards(shards LogicalShardGroup, clusterSize int) LogicalShardGroup {
 	return shards
 }
 
-// FindShardId calculates a Jump hash for the keyHash provided
+// FindShardId calculates a Jump hash for the keyHash provided.
+// It returns -1 if the cluster size is 0, e.g., before the cluster is populated. See LookupShardId.
 func (cluster *Cluster) FindShardId(keyHash uint64) int {
+	cluster.lock.RLock()
+	defer cluster.lock.RUnlock()
+	return cluster.findShardId(keyHash)
+}
+
+// LookupShardId is the same as FindShardId, but reports whether the cluster has any shard for the keyHash.
+func (cluster *Cluster) LookupShardId(keyHash uint64) (shardId int, ok bool) {
+	shardId = cluster.FindShardId(keyHash)
+	return shardId, shardId >= 0
+}
+
+func (cluster *Cluster) findShardId(keyHash uint64) int {
+	if cluster.expectedSize <= 0 {
+		return -1
+	}
 	return int(jump.Hash(keyHash, cluster.expectedSize))
 }
 
 // ExpectedSize returns the expected size of the cluster
 func (cluster *Cluster) ExpectedSize() int {
+	cluster.lock.RLock()
+	defer cluster.lock.RUnlock()
 	return cluster.expectedSize
 }
 
 // ReplicationFactor returns the replication factor of the cluster
 func (cluster *Cluster) ReplicationFactor() int {
+	cluster.lock.RLock()
+	defer cluster.lock.RUnlock()
 	return cluster.replicationFactor
 }
 
+// IsReplicationSatisfiable checks whether the cluster has enough servers for the replication factor.
+// Otherwise, each shard has fewer replicas than ReplicationFactor(), which is allowed, e.g., while the cluster grows.
+func (cluster *Cluster) IsReplicationSatisfiable() bool {
+	cluster.lock.RLock()
+	defer cluster.lock.RUnlock()
+	return cluster.replicationFactor <= cluster.expectedSize
+}
+
 // SetExpectedSize sets the expected size of the cluster
 func (cluster *Cluster) SetExpectedSize(expectedSize int) {
+	cluster.lock.Lock()
+	defer cluster.lock.Unlock()
+	cluster.setExpectedSize(expectedSize)
+}
+
+func (cluster *Cluster) setExpectedSize(expectedSize int) {
 	if expectedSize > 0 {
 		cluster.expectedSize = expectedSize
 		if len(cluster.logicalShards) == 0 {
@@ -207,22 +442,40 @@ func (cluster *Cluster) SetExpectedSize(expectedSize int) {
 
 // SetNextCluster creates a new cluster and sets the size and replication factor
 func (cluster *Cluster) SetNextCluster(expectedSize int, replicationFactor int) *Cluster {
-	cluster.nextCluster = NewCluster(cluster.keyspace, expectedSize, replicationFactor)
+	cluster.lock.Lock()
+	defer cluster.lock.Unlock()
+	cluster.nextCluster = cluster.newResizedCluster(expectedSize, replicationFactor)
 	return cluster.nextCluster
 }
 
+// newResizedCluster creates an empty cluster with the new size, hashing the keys the same way,
+// and dialing from the same local data center.
+func (cluster *Cluster) newResizedCluster(expectedSize int, replicationFactor int) *Cluster {
+	resized := NewCluster(cluster.keyspace, expectedSize, replicationFactor)
+	resized.keyHasher = cluster.keyHasher
+	resized.localDataCenter = cluster.localDataCenter
+	return resized
+}
+
 // GetNextCluster returns the next cluster
 func (cluster *Cluster) GetNextCluster() *Cluster {
+	cluster.lock.RLock()
+	defer cluster.lock.RUnlock()
 	return cluster.nextCluster
 }
 
 // RemoveNextCluster clears the pointer to the next cluster
 func (cluster *Cluster) RemoveNextCluster() {
+	cluster.lock.Lock()
+	defer cluster.lock.Unlock()
 	cluster.nextCluster = nil
 }
 
-// SetReplicationFactor sets the replication factor of the cluster
+// SetReplicationFactor sets the replication factor of the cluster.
+// A replicationFactor not greater than 0 is ignored.
 func (cluster *Cluster) SetReplicationFactor(replicationFactor int) {
+	cluster.lock.Lock()
+	defer cluster.lock.Unlock()
 	if replicationFactor > 0 {
 		cluster.replicationFactor = replicationFactor
 	}
@@ -230,6 +483,12 @@ func (cluster *Cluster) SetReplicationFactor(replicationFactor int) {
 
 // CurrentSize returns the cluster current size
 func (cluster *Cluster) CurrentSize() int {
+	cluster.lock.RLock()
+	defer cluster.lock.RUnlock()
+	return cluster.currentSize()
+}
+
+func (cluster *Cluster) currentSize() int {
 	for i := len(cluster.logicalShards); i > 0; i-- {
 		if len(cluster.logicalShards[i-1]) == 0 {
 			continue
@@ -241,7 +500,15 @@ func (cluster *Cluster) CurrentSize() int {
 
 // GetNode returns the server having the shard.
 // replica denotes the shard replica.
+// Each server holds at most one replica of a shard, so when the cluster is smaller than
+// the replication factor, there are fewer replicas than ReplicationFactor().
 func (cluster *Cluster) GetNode(shardId int, replica int) (*pb.ClusterNode, bool) {
+	cluster.lock.RLock()
+	defer cluster.lock.RUnlock()
+	return cluster.getNode(shardId, replica)
+}
+
+func (cluster *Cluster) getNode(shardId int, replica int) (*pb.ClusterNode, bool) {
 	shards := cluster.getShards(shardId)
 	if replica >= len(shards) {
 		return nil, false
@@ -250,6 +517,130 @@ func (cluster *Cluster) GetNode(shardId int, replica int) (*pb.ClusterNode, bool
 	return shards[replica], true
 }
 
+// GetReplicaNodes returns the servers holding the key, the primary first and then the replicas.
+// The replicas in data centers not used yet are picked first, and then the rest in ring order.
+// Missing servers and servers without an address are skipped, so fewer than ReplicationFactor() servers
+// are returned only when fewer servers hold the shard.
+func (cluster *Cluster) GetReplicaNodes(keyHash uint64) (nodes []*pb.ClusterNode) {
+	cluster.lock.RLock()
+	defer cluster.lock.RUnlock()
+	return cluster.getReplicaNodes(keyHash)
+}
+
+func (cluster *Cluster) getReplicaNodes(keyHash uint64) (nodes []*pb.ClusterNode) {
+	var candidates []*pb.ClusterNode
+	seen := make(map[uint32]bool)
+	for _, node := range cluster.getShards(cluster.findShardId(keyHash)) {
+		if !isLive(node) {
+			continue
+		}
+		if seen[node.ShardInfo.ServerId] {
+			continue
+		}
+		seen[node.ShardInfo.ServerId] = true
+		candidates = append(candidates, node)
+	}
+	if len(candidates) == 0 {
+		return nil
+	}
+
+	picked := make([]bool, len(candidates))
+	pick := func(i int) {
+		picked[i] = true
+		nodes = append(nodes, candidates[i])
+	}
+
+	pick(0)
+	usedDataCenters := map[string]bool{candidates[0].GetDataCenter(): true}
+	for i, node := range candidates {
+		if len(nodes) >= cluster.replicationFactor {
+			return
+		}
+		dataCenter := node.GetDataCenter()
+		if picked[i] || dataCenter == "" || usedDataCenters[dataCenter] {
+			continue
+		}
+		usedDataCenters[dataCenter] = true
+		pick(i)
+	}
+	for i := range candidates {
+		if len(nodes) >= cluster.replicationFactor {
+			return
+		}
+		if !picked[i] {
+			pick(i)
+		}
+	}
+	return
+}
+
+// GetReplicaNode returns the replica-th server holding the key, in the same order as GetReplicaNodes,
+// so replica 0 is the primary. A replica beyond the servers holding the key wraps around,
+// so that clients can spread the reads by replica number.
+func (cluster *Cluster) GetReplicaNode(keyHash uint64, replica int) (*pb.ClusterNode, bool) {
+	nodes := cluster.GetReplicaNodes(keyHash)
+	if len(nodes) == 0 || replica < 0 {
+		return nil, false
+	}
+	return nodes[replica%len(nodes)], true
+}
+
+// MissingShardId groups the keys in GetShardIdsForKeys which have no live primary server.
+const MissingShardId = -1
+
+// GetShardIdsForKeys groups the key hashes by the shard id, so that the caller can send one request per shard.
+// The keys whose shard has no live primary server, or all keys if the cluster is empty, are grouped under MissingShardId.
+func (cluster *Cluster) GetShardIdsForKeys(keyHashes []uint64) map[int][]uint64 {
+	cluster.lock.RLock()
+	defer cluster.lock.RUnlock()
+
+	shardKeys := make(map[int][]uint64)
+	for _, keyHash := range keyHashes {
+		shardId := cluster.findShardId(keyHash)
+		if node, found := cluster.getNode(shardId, 0); !found || !isLive(node) {
+			shardId = MissingShardId
+		}
+		shardKeys[shardId] = append(shardKeys[shardId], keyHash)
+	}
+	return shardKeys
+}
+
+// DistributeKeys counts the key hashes of each shard id, regardless of whether the shard has servers.
+// The counts are sized to the expected size, and are empty if the cluster has no shards yet.
+func (cluster *Cluster) DistributeKeys(keyHashes []uint64) []int {
+	cluster.lock.RLock()
+	defer cluster.lock.RUnlock()
+
+	if cluster.expectedSize <= 0 {
+		return nil
+	}
+	counts := make([]int, cluster.expectedSize)
+	for _, keyHash := range keyHashes {
+		counts[cluster.findShardId(keyHash)]++
+	}
+	return counts
+}
+
+// AssignmentForNode lists the shard ids the server holds, as primary or as replica, in ascending order.
+func (cluster *Cluster) AssignmentForNode(serverId int) (primary []int, replica []int) {
+	cluster.lock.RLock()
+	defer cluster.lock.RUnlock()
+
+	for shardId, shardGroup := range cluster.logicalShards {
+		for i, shard := range shardGroup {
+			if int(shard.ShardInfo.ServerId) != serverId {
+				continue
+			}
+			if i == 0 {
+				primary = append(primary, shardId)
+			} else {
+				replica = append(replica, shardId)
+			}
+		}
+	}
+	return
+}
+
 func (cluster *Cluster) getShards(shardId int) LogicalShardGroup {
 	if shardId < 0 || shardId >= len(cluster.logicalShards) {
 		return nil
@@ -257,13 +648,29 @@ func (cluster *Cluster) getShards(shardId int) LogicalShardGroup {
 	return cluster.logicalShards[shardId]
 }
 
-// GetAllShards returns a list of all logic shard groups.
+// GetAllShards returns a copy of all logic shard groups.
 func (cluster *Cluster) GetAllShards() []LogicalShardGroup {
-	return cluster.logicalShards
+	cluster.lock.RLock()
+	defer cluster.lock.RUnlock()
+
+	shardGroups := make([]LogicalShardGroup, len(cluster.logicalShards))
+	for i, shardGroup := range cluster.logicalShards {
+		if shardGroup != nil {
+			shardGroups[i] = append(LogicalShardGroup(nil), shardGroup...)
+		}
+	}
+	return shardGroups
 }
 
+// DefaultReplicationFactor is used when the replication factor is not set, e.g., 0 from an older cluster config.
+const DefaultReplicationFactor = 1
+
 // NewCluster creates a new cluster.
+// A replicationFactor not greater than 0 falls back to DefaultReplicationFactor.
 func NewCluster(keyspace string, expectedSize int, replicationFactor int) *Cluster {
+	if replicationFactor <= 0 {
+		replicationFactor = DefaultReplicationFactor
+	}
 	return &Cluster{
 		keyspace:          keyspace,
 		logicalShards:     make([]LogicalShardGroup, expectedSize),
@@ -272,7 +679,59 @@ func NewCluster(keyspace string, expectedSize int, replicationFactor int) *Clust
 	}
 }
 
+// Clone returns a snapshot of the cluster, which changes independently of the cluster.
+// The nodes are shared since they are never changed in place.
+func (cluster *Cluster) Clone() *Cluster {
+	if cluster == nil {
+		return nil
+	}
+	cluster.lock.RLock()
+	defer cluster.lock.RUnlock()
+
+	clone := &Cluster{
+		keyspace:           cluster.keyspace,
+		dataCenter:         cluster.dataCenter,
+		logicalShards:      make([]LogicalShardGroup, len(cluster.logicalShards)),
+		expectedSize:       cluster.expectedSize,
+		replicationFactor:  cluster.replicationFactor,
+		nextCluster:        cluster.nextCluster.Clone(),
+		idAllocator:        cluster.idAllocator,
+		tlsConfig:          cluster.tlsConfig,
+		keyHasher:          cluster.keyHasher,
+		statusHistoryDepth: cluster.statusHistoryDepth,
+		messageSizeLimit:   cluster.messageSizeLimit,
+		localDataCenter:    cluster.localDataCenter,
+	}
+	if len(cluster.statusHistory) > 0 {
+		clone.statusHistory = make(map[string][]ShardStatusTransition, len(cluster.statusHistory))
+		for identifier, history := range cluster.statusHistory {
+			clone.statusHistory[identifier] = append([]ShardStatusTransition(nil), history...)
+		}
+	}
+	if len(cluster.health) > 0 {
+		clone.health = make(map[string]NodeHealth, len(cluster.health))
+		for address, health := range cluster.health {
+			clone.health[address] = health
+		}
+	}
+	if len(cluster.weights) > 0 {
+		clone.weights = make(map[int]float64, len(cluster.weights))
+		for shardId, weight := range cluster.weights {
+			clone.weights[shardId] = weight
+		}
+	}
+	for i, shardGroup := range cluster.logicalShards {
+		if shardGroup != nil {
+			clone.logicalShards[i] = append(LogicalShardGroup(nil), shardGroup...)
+		}
+	}
+	return clone
+}
+
 func (cluster *Cluster) String() string {
+	cluster.lock.RLock()
+	defer cluster.lock.RUnlock()
+
 	var output bytes.Buffer
 	output.Write([]byte{'['})
 	for i := 0; i < len(cluster.logicalShards); i++ {
@@ -282,16 +741,36 @@ func (cluster *Cluster) String() string {
 		shards := cluster.logicalShards[i]
 		if len(shards) == 0 {
 			output.Write([]byte{'_'})
-		} else {
+		} else if len(cluster.health) == 0 {
 			output.WriteString(shards.String())
+		} else {
+			output.WriteString(cluster.healthString(shards))
 		}
 	}
 	output.Write([]byte{']'})
-	output.WriteString(fmt.Sprintf(" size %d/%d ", cluster.CurrentSize(), cluster.ExpectedSize()))
+	output.WriteString(fmt.Sprintf(" size %d/%d ", cluster.currentSize(), cluster.expectedSize))
 
 	return output.String()
 }
 
+// Fingerprint hashes the cluster size and where each shard is and its status.
+// Two clusters with the same fingerprint have the same topology.
+func (cluster *Cluster) Fingerprint() uint64 {
+	cluster.lock.RLock()
+	defer cluster.lock.RUnlock()
+
+	var buf bytes.Buffer
+	buf.WriteString(fmt.Sprintf("%s %d %d", cluster.keyspace, cluster.expectedSize, cluster.replicationFactor))
+	for _, shardGroup := range cluster.logicalShards {
+		buf.WriteString(";")
+		for _, shard := range shardGroup {
+			buf.WriteString(fmt.Sprintf(" %s@%s:%s:%v", shard.ShardInfo.IdentifierOnThisServer(),
+				shard.StoreResource.GetAddress(), shard.ShardInfo.Status, shard.ShardInfo.IsCandidate))
+		}
+	}
+	return util.Hash(buf.Bytes())
+}
+
 // Debug prints out the detailed info of the cluster.
 func (cluster *Cluster) Debug(prefix string) {
 	for _, shardGroup := range cluster.GetAllShards() {
@@ -300,8 +779,8 @@ func (cluster *Cluster) Debug(prefix string) {
 		}
 	}
 
-	if cluster.nextCluster != nil {
-		cluster.nextCluster.Debug(prefix + "  >")
+	if nextCluster := cluster.GetNextCluster(); nextCluster != nil {
+		nextCluster.Debug(prefix + "  >")
 	}
 
 }
@@ -0,0 +1,143 @@
+package topology
+
+import (
+	"fmt"
+	"sort"
+)
+
+// DriftKind is how the actual placement of a shard differs from the expected placement.
+type DriftKind int
+
+const (
+	// DriftMissing means an expected server does not have the shard.
+	DriftMissing DriftKind = iota
+	// DriftExtra means a server has an unexpected copy, while all the expected copies exist.
+	DriftExtra
+	// DriftMisplaced means a server has an unexpected copy, while some expected copies are missing.
+	DriftMisplaced
+)
+
+func (kind DriftKind) String() string {
+	switch kind {
+	case DriftMissing:
+		return "missing"
+	case DriftExtra:
+		return "extra"
+	case DriftMisplaced:
+		return "misplaced"
+	}
+	return fmt.Sprintf("DriftKind(%d)", int(kind))
+}
+
+// DriftReport is one shard copy whose actual placement differs from the expected placement.
+type DriftReport struct {
+	ShardId  int
+	ServerId int
+	Kind     DriftKind
+}
+
+func (r DriftReport) String() string {
+	return fmt.Sprintf("%d.%d %v", r.ServerId, r.ShardId, r.Kind)
+}
+
+// DetectDrift compares the servers expected to have each shard, by the cluster size and replication factor,
+// with the servers reported to have the shard. The reports are sorted by shard id and server id.
+func (cluster *Cluster) DetectDrift() (reports []DriftReport) {
+	cluster.lock.RLock()
+	defer cluster.lock.RUnlock()
+
+	shardCount := cluster.expectedSize
+	if len(cluster.logicalShards) > shardCount {
+		shardCount = len(cluster.logicalShards)
+	}
+
+	for shardId := 0; shardId < shardCount; shardId++ {
+
+		expected := make(map[int]bool)
+		for _, shard := range PartitionShards(0, shardId, cluster.expectedSize, cluster.replicationFactor) {
+			expected[shard.ServerId] = true
+		}
+
+		actual := make(map[int]bool)
+		for _, node := range cluster.getShards(shardId) {
+			actual[int(node.ShardInfo.ServerId)] = true
+		}
+
+		var missing, unexpected []int
+		for serverId := range expected {
+			if !actual[serverId] {
+				missing = append(missing, serverId)
+			}
+		}
+		for serverId := range actual {
+			if !expected[serverId] {
+				unexpected = append(unexpected, serverId)
+			}
+		}
+
+		for _, serverId := range missing {
+			reports = append(reports, DriftReport{ShardId: shardId, ServerId: serverId, Kind: DriftMissing})
+		}
+		for _, serverId := range unexpected {
+			kind := DriftExtra
+			if len(missing) > 0 {
+				kind = DriftMisplaced
+			}
+			reports = append(reports, DriftReport{ShardId: shardId, ServerId: serverId, Kind: kind})
+		}
+	}
+
+	sort.Slice(reports, func(i, j int) bool {
+		if reports[i].ShardId != reports[j].ShardId {
+			return reports[i].ShardId < reports[j].ShardId
+		}
+		return reports[i].ServerId < reports[j].ServerId
+	})
+
+	return reports
+}
+
+// ClusterState is the structured form of the sizes and gaps shown by Cluster.String(),
+// e.g., to compute the progress of a growing cluster.
+type ClusterState struct {
+	// Current is the current size, i.e., 1 + the largest shard id having servers.
+	Current int
+	// Expected is the expected size.
+	Expected int
+	// Next is the expected size of the next cluster, or 0 if the cluster is not resizing.
+	Next int
+	// Missing are the shard ids less than the expected size, but without any server.
+	Missing []int
+	// Free are the shard ids not less than the expected size, but still having servers.
+	Free []int
+}
+
+// State returns the sizes of the cluster and the shard ids not matching the expected size.
+func (cluster *Cluster) State() ClusterState {
+	cluster.lock.RLock()
+	defer cluster.lock.RUnlock()
+
+	state := ClusterState{
+		Current:  cluster.currentSize(),
+		Expected: cluster.expectedSize,
+	}
+	if cluster.nextCluster != nil {
+		state.Next = cluster.nextCluster.ExpectedSize()
+	}
+
+	shardCount := cluster.expectedSize
+	if len(cluster.logicalShards) > shardCount {
+		shardCount = len(cluster.logicalShards)
+	}
+	for shardId := 0; shardId < shardCount; shardId++ {
+		hasServers := len(cluster.getShards(shardId)) > 0
+		if shardId < cluster.expectedSize && !hasServers {
+			state.Missing = append(state.Missing, shardId)
+		}
+		if shardId >= cluster.expectedSize && hasServers {
+			state.Free = append(state.Free, shardId)
+		}
+	}
+
+	return state
+}
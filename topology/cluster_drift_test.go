@@ -0,0 +1,49 @@
+package topology
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/chrislusf/vasto/pb"
+	"github.com/magiconair/properties/assert"
+)
+
+func TestDetectDrift(t *testing.T) {
+
+	ring3 := createRing(3)
+	assert.Equal(t, len(ring3.DetectDrift()), 0, "no drift")
+
+	// shard 1 moved from server 2 to server 0
+	ring3.RemoveShard(&pb.StoreResource{Address: "localhost:7002"}, &pb.ShardInfo{ServerId: 2, ShardId: 1})
+	ring3.SetShard(&pb.StoreResource{Address: "localhost:7000"}, &pb.ShardInfo{ServerId: 0, ShardId: 1, ClusterSize: 3, ReplicationFactor: 2})
+	// shard 2 has an extra copy on server 1
+	ring3.SetShard(&pb.StoreResource{Address: "localhost:7001"}, &pb.ShardInfo{ServerId: 1, ShardId: 2, ClusterSize: 3, ReplicationFactor: 2})
+	// shard 0 lost the copy on server 1
+	ring3.RemoveShard(&pb.StoreResource{Address: "localhost:7001"}, &pb.ShardInfo{ServerId: 1, ShardId: 0})
+
+	assert.Equal(t, fmt.Sprint(ring3.DetectDrift()), "[1.0 missing 0.1 misplaced 2.1 missing 1.2 extra]", "drift reports")
+
+}
+
+func TestClusterState(t *testing.T) {
+
+	ring3 := createRing(3)
+	assert.Equal(t, fmt.Sprintf("%+v", ring3.State()), "{Current:3 Expected:3 Next:0 Missing:[] Free:[]}", "full cluster")
+
+	for replica := 0; replica < 2; replica++ {
+		node, _ := ring3.GetNode(1, 0)
+		ring3.RemoveShard(node.StoreResource, node.ShardInfo)
+	}
+	ring3.SetNextCluster(5, 2)
+
+	state := ring3.State()
+	assert.Equal(t, ring3.String(), "[0@0,1 _ 2@2,0] size 3/3 ", "string")
+	assert.Equal(t, fmt.Sprintf("%+v", state), "{Current:3 Expected:3 Next:5 Missing:[1] Free:[]}", "missing shard")
+
+	// the growing cluster
+	next := ring3.GetNextCluster()
+	next.SetShard(&pb.StoreResource{Address: "localhost:7003"}, &pb.ShardInfo{ServerId: 3, ShardId: 3, ClusterSize: 5, ReplicationFactor: 2})
+	assert.Equal(t, next.String(), "[_ _ _ 3@3 _] size 4/5 ", "next string")
+	assert.Equal(t, fmt.Sprintf("%+v", next.State()), "{Current:4 Expected:5 Next:0 Missing:[0 1 2 4] Free:[]}", "growing")
+
+}
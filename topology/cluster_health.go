@@ -0,0 +1,109 @@
+package topology
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/chrislusf/vasto/pb"
+)
+
+// NodeHealth is the health of a store, as seen by this cluster view.
+type NodeHealth int
+
+const (
+	// NodeHealthy is the default health of a store.
+	NodeHealthy NodeHealth = iota
+	// NodeSuspect is a store which recently failed, but may still be up.
+	NodeSuspect
+	// NodeDown is a store known to be unavailable.
+	NodeDown
+)
+
+func (health NodeHealth) String() string {
+	switch health {
+	case NodeHealthy:
+		return "healthy"
+	case NodeSuspect:
+		return "suspect"
+	case NodeDown:
+		return "down"
+	}
+	return "unknown"
+}
+
+// SetHealth sets the health of the store at the address, for all the shards on the store.
+func (cluster *Cluster) SetHealth(address string, health NodeHealth) {
+	cluster.lock.Lock()
+	defer cluster.lock.Unlock()
+
+	if health == NodeHealthy {
+		delete(cluster.health, address)
+		return
+	}
+	if cluster.health == nil {
+		cluster.health = make(map[string]NodeHealth)
+	}
+	cluster.health[address] = health
+}
+
+// markHealth is the same as SetHealth, but only takes the write lock if the health changes.
+// It never changes a store set down, which is only changed by SetHealth, since a connection to it is not proof of its health.
+func (cluster *Cluster) markHealth(address string, health NodeHealth) {
+	cluster.lock.RLock()
+	current := cluster.health[address]
+	cluster.lock.RUnlock()
+
+	if current != health && current != NodeDown {
+		cluster.SetHealth(address, health)
+	}
+}
+
+// Health returns the health of the node's store.
+func (cluster *Cluster) Health(node *pb.ClusterNode) NodeHealth {
+	cluster.lock.RLock()
+	defer cluster.lock.RUnlock()
+	return cluster.nodeHealth(node)
+}
+
+func (cluster *Cluster) nodeHealth(node *pb.ClusterNode) NodeHealth {
+	return cluster.health[node.GetStoreResource().GetAddress()]
+}
+
+// GetHealthyReplicaNode is the same as GetReplicaNode, but skips the unhealthy nodes,
+// so reads fail over to the next healthy replica in the order of GetReplicaNodes.
+// It returns false if no node holding the key is healthy.
+func (cluster *Cluster) GetHealthyReplicaNode(keyHash uint64, replica int) (*pb.ClusterNode, bool) {
+	cluster.lock.RLock()
+	defer cluster.lock.RUnlock()
+
+	nodes := cluster.getReplicaNodes(keyHash)
+	if len(nodes) == 0 || replica < 0 {
+		return nil, false
+	}
+	for i := 0; i < len(nodes); i++ {
+		node := nodes[(replica+i)%len(nodes)]
+		if cluster.nodeHealth(node) == NodeHealthy {
+			return node, true
+		}
+	}
+	return nil, false
+}
+
+// healthString is the same as LogicalShardGroup.String(), but marks the suspect servers with "?" and the down servers with "!".
+func (cluster *Cluster) healthString(shards LogicalShardGroup) string {
+	var buf bytes.Buffer
+	buf.WriteString(fmt.Sprintf("%d@", shards[0].ShardInfo.ShardId))
+	for i, shard := range shards {
+		if i != 0 {
+			buf.WriteString(",")
+		}
+		buf.WriteString(fmt.Sprintf("%d", shard.ShardInfo.ServerId))
+		switch cluster.nodeHealth(shard) {
+		case NodeSuspect:
+			buf.WriteString("?")
+		case NodeDown:
+			buf.WriteString("!")
+		}
+	}
+	return buf.String()
+}
@@ -0,0 +1,87 @@
+package topology
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/chrislusf/vasto/pb"
+	"github.com/magiconair/properties/assert"
+	"google.golang.org/grpc"
+)
+
+func TestGetHealthyReplicaNode(t *testing.T) {
+
+	ring3 := createRing(3)
+	keyHash := uint64(123)
+	primary, _ := ring3.GetReplicaNode(keyHash, 0)
+	replica, _ := ring3.GetReplicaNode(keyHash, 1)
+
+	node, found := ring3.GetHealthyReplicaNode(keyHash, 0)
+	assert.Equal(t, found && node == primary, true, "healthy primary")
+
+	ring3.SetHealth(primary.StoreResource.Address, NodeDown)
+	assert.Equal(t, ring3.Health(primary), NodeDown, "primary down")
+	node, found = ring3.GetHealthyReplicaNode(keyHash, 0)
+	assert.Equal(t, found && node == replica, true, "fail over to the replica")
+
+	ring3.SetHealth(replica.StoreResource.Address, NodeSuspect)
+	_, found = ring3.GetHealthyReplicaNode(keyHash, 0)
+	assert.Equal(t, found, false, "all replicas unhealthy")
+
+	ring3.SetHealth(primary.StoreResource.Address, NodeHealthy)
+	node, found = ring3.GetHealthyReplicaNode(keyHash, 1)
+	assert.Equal(t, found && node == primary, true, "replica 1 wraps to the healthy primary")
+
+}
+
+func TestConnectionMarksHealth(t *testing.T) {
+
+	listener, err := net.Listen("tcp", "localhost:0")
+	assert.Equal(t, err, nil, "listen")
+	address := listener.Addr().String()
+	listener.Close()
+
+	cluster := NewCluster("ks1", 1, 1)
+	cluster.SetShard(&pb.StoreResource{Address: "localhost:7000", AdminAddress: address},
+		&pb.ShardInfo{ServerId: 0, ShardId: 0, ClusterSize: 1, ReplicationFactor: 1})
+	node, _ := cluster.GetNode(0, 0)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	err = cluster.WithConnectionContext(ctx, "unreachable", 0, func(ctx context.Context, node *pb.ClusterNode, conn *grpc.ClientConn) error {
+		return nil
+	})
+	assert.Equal(t, err != nil, true, "dial timeout")
+	assert.Equal(t, cluster.Health(node), NodeSuspect, "suspect after dial failure")
+	assert.Equal(t, cluster.String(), "[0@0?] size 1/1 ", "suspect in string")
+
+	cluster.SetHealth("localhost:7000", NodeDown)
+	assert.Equal(t, cluster.String(), "[0@0!] size 1/1 ", "down in string")
+
+	// dialing without a deadline does not wait for the connection, and does not change a down store
+	err = cluster.WithConnection("lazy", 0, func(node *pb.ClusterNode, conn *grpc.ClientConn) error {
+		return nil
+	})
+	assert.Equal(t, err, nil, "dial")
+	assert.Equal(t, cluster.Health(node), NodeDown, "still down after a lazy dial")
+
+	// a lazy connection failing the call is not proof of health
+	cluster.SetHealth("localhost:7000", NodeSuspect)
+	err = cluster.WithConnection("lazy", 0, func(node *pb.ClusterNode, conn *grpc.ClientConn) error {
+		return fmt.Errorf("call failed")
+	})
+	assert.Equal(t, err != nil, true, "call failed")
+	assert.Equal(t, cluster.Health(node), NodeSuspect, "still suspect after a failed call")
+
+	// a successful call is
+	err = cluster.WithConnection("lazy", 0, func(node *pb.ClusterNode, conn *grpc.ClientConn) error {
+		return nil
+	})
+	assert.Equal(t, err, nil, "call")
+	assert.Equal(t, cluster.Health(node), NodeHealthy, "healthy after a successful call")
+	assert.Equal(t, cluster.String(), "[0@0] size 1/1 ", "healthy in string")
+
+}
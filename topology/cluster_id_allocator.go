@@ -0,0 +1,100 @@
+package topology
+
+import (
+	"fmt"
+
+	"github.com/chrislusf/vasto/pb"
+)
+
+// IdAllocator picks the server id for a store joining the cluster.
+// usedIds has the server ids already in the cluster, and the returned id must be less than clusterSize.
+type IdAllocator interface {
+	AllocateId(usedIds map[int]bool, clusterSize int) (int, error)
+}
+
+// LowestFreeIdAllocator picks the lowest unused server id, reusing the ids of removed servers.
+type LowestFreeIdAllocator struct{}
+
+// AllocateId implements IdAllocator
+func (LowestFreeIdAllocator) AllocateId(usedIds map[int]bool, clusterSize int) (int, error) {
+	for id := 0; id < clusterSize; id++ {
+		if !usedIds[id] {
+			return id, nil
+		}
+	}
+	return 0, fmt.Errorf("all %d server ids are used", clusterSize)
+}
+
+// NextIdAllocator picks the server id after the highest used one.
+type NextIdAllocator struct{}
+
+// AllocateId implements IdAllocator
+func (NextIdAllocator) AllocateId(usedIds map[int]bool, clusterSize int) (int, error) {
+	next := 0
+	for id := range usedIds {
+		if id+1 > next {
+			next = id + 1
+		}
+	}
+	if next >= clusterSize {
+		return 0, fmt.Errorf("server id %d is out of cluster size %d", next, clusterSize)
+	}
+	return next, nil
+}
+
+// SetIdAllocator changes how Join picks the server ids. The default is LowestFreeIdAllocator.
+func (cluster *Cluster) SetIdAllocator(idAllocator IdAllocator) {
+	cluster.lock.Lock()
+	defer cluster.lock.Unlock()
+	cluster.idAllocator = idAllocator
+}
+
+// Join allocates a server id for the store, and adds all the shards the server should have.
+// It returns the node of the server's primary shard.
+// Concurrent Join calls never allocate the same server id.
+func (cluster *Cluster) Join(store *pb.StoreResource) (*pb.ClusterNode, error) {
+	cluster.lock.Lock()
+	node, added, err := cluster.join(store)
+	cluster.lock.Unlock()
+
+	cluster.notifyNodeChanges(added, nil)
+
+	return node, err
+}
+
+func (cluster *Cluster) join(store *pb.StoreResource) (node *pb.ClusterNode, added []*pb.ClusterNode, err error) {
+
+	if cluster.isStoreInUse(store) {
+		return nil, nil, fmt.Errorf("store %s already in cluster %s", store.Address, cluster.keyspace)
+	}
+
+	usedIds := make(map[int]bool)
+	for _, shardGroup := range cluster.logicalShards {
+		for _, node := range shardGroup {
+			usedIds[int(node.ShardInfo.ServerId)] = true
+		}
+	}
+
+	idAllocator := cluster.idAllocator
+	if idAllocator == nil {
+		idAllocator = LowestFreeIdAllocator{}
+	}
+	serverId, err := idAllocator.AllocateId(usedIds, cluster.expectedSize)
+	if err != nil {
+		return nil, nil, fmt.Errorf("join cluster %s: %v", cluster.keyspace, err)
+	}
+
+	for _, clusterShard := range LocalShards(serverId, cluster.expectedSize, cluster.replicationFactor) {
+		shardNode, _ := cluster.setShard(store, &pb.ShardInfo{
+			KeyspaceName:      cluster.keyspace,
+			ServerId:          uint32(serverId),
+			ShardId:           uint32(clusterShard.ShardId),
+			ClusterSize:       uint32(cluster.expectedSize),
+			ReplicationFactor: uint32(cluster.replicationFactor),
+		})
+		added = append(added, shardNode)
+	}
+
+	node, _ = cluster.getNode(serverId, 0)
+	return node, added, nil
+}
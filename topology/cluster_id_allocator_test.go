@@ -0,0 +1,62 @@
+package topology
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/chrislusf/vasto/pb"
+	"github.com/magiconair/properties/assert"
+)
+
+func TestJoin(t *testing.T) {
+
+	store := func(i int) *pb.StoreResource {
+		return &pb.StoreResource{Address: fmt.Sprint("localhost:", 7000+i)}
+	}
+
+	cluster := NewCluster("ks1", 4, 2)
+	for i := 0; i < 3; i++ {
+		node, err := cluster.Join(store(i))
+		assert.Equal(t, err, nil, "join")
+		assert.Equal(t, node.ShardInfo.ServerId, uint32(i), "allocated server id")
+	}
+
+	_, err := cluster.Join(store(1))
+	assert.Equal(t, err != nil, true, "join twice")
+
+	cluster.RemoveStore(store(1))
+	node, _ := cluster.Join(store(11))
+	assert.Equal(t, node.ShardInfo.ServerId, uint32(1), "reuse removed server id")
+
+	cluster.RemoveStore(store(11))
+	cluster.SetIdAllocator(NextIdAllocator{})
+	node, _ = cluster.Join(store(3))
+	assert.Equal(t, node.ShardInfo.ServerId, uint32(3), "next server id")
+	assert.Equal(t, len(cluster.GetAllShards()[3]), 2, "shard 3 replicas")
+
+	_, err = cluster.Join(store(4))
+	assert.Equal(t, err != nil, true, "out of cluster size")
+
+}
+
+func TestConcurrentJoin(t *testing.T) {
+
+	cluster := NewCluster("ks1", 8, 1)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			cluster.Join(&pb.StoreResource{Address: fmt.Sprint("localhost:", 7000+i)})
+		}(i)
+	}
+	wg.Wait()
+
+	for i := 0; i < 8; i++ {
+		node, found := cluster.GetNode(i, 0)
+		assert.Equal(t, found && node.ShardInfo.ServerId == uint32(i), true, "server id allocated once")
+	}
+
+}
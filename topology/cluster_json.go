@@ -0,0 +1,123 @@
+package topology
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/chrislusf/vasto/pb"
+)
+
+type clusterJSON struct {
+	Keyspace          string        `json:"keyspace"`
+	DataCenter        string        `json:"dataCenter"`
+	ExpectedSize      int           `json:"expectedSize"`
+	NextSize          int           `json:"nextSize"`
+	ReplicationFactor int           `json:"replicationFactor"`
+	Shards            [][]*nodeJSON `json:"shards"`
+}
+
+type nodeJSON struct {
+	Id           uint32 `json:"id"`
+	ShardId      uint32 `json:"shardId"`
+	Network      string `json:"network"`
+	Address      string `json:"address"`
+	AdminAddress string `json:"adminAddress"`
+	DataCenter   string `json:"dataCenter,omitempty"`
+	Status       string `json:"status"`
+	IsCandidate  bool   `json:"isCandidate,omitempty"`
+}
+
+// MarshalJSON describes the cluster and its live nodes, for admin tools.
+// The shards are listed by shard id, each with its nodes by replica, and a shard without nodes is null,
+// so that the position of the gaps is kept.
+// The nodes of the next cluster are not included, only its size.
+func (cluster *Cluster) MarshalJSON() ([]byte, error) {
+	cluster.lock.RLock()
+	defer cluster.lock.RUnlock()
+
+	c := clusterJSON{
+		Keyspace:          cluster.keyspace,
+		DataCenter:        cluster.dataCenter,
+		ExpectedSize:      cluster.expectedSize,
+		ReplicationFactor: cluster.replicationFactor,
+		Shards:            make([][]*nodeJSON, len(cluster.logicalShards)),
+	}
+	if cluster.nextCluster != nil {
+		c.NextSize = cluster.nextCluster.ExpectedSize()
+	}
+
+	for shardId, shardGroup := range cluster.logicalShards {
+		for _, node := range shardGroup {
+			if !isLive(node) {
+				continue
+			}
+			c.Shards[shardId] = append(c.Shards[shardId], &nodeJSON{
+				Id:           node.ShardInfo.ServerId,
+				ShardId:      node.ShardInfo.ShardId,
+				Network:      node.StoreResource.Network,
+				Address:      node.StoreResource.Address,
+				AdminAddress: node.StoreResource.AdminAddress,
+				DataCenter:   node.StoreResource.DataCenter,
+				Status:       node.ShardInfo.Status.String(),
+				IsCandidate:  node.ShardInfo.IsCandidate,
+			})
+		}
+	}
+
+	return json.Marshal(c)
+}
+
+// UnmarshalJSON restores the cluster from the output of MarshalJSON, e.g., for test fixtures.
+// The nodes get the cluster size and the replication factor of the cluster,
+// and the next cluster is created empty with the next size.
+func (cluster *Cluster) UnmarshalJSON(data []byte) error {
+	var c clusterJSON
+	if err := json.Unmarshal(data, &c); err != nil {
+		return err
+	}
+
+	logicalShards := make([]LogicalShardGroup, len(c.Shards))
+	for shardId, nodes := range c.Shards {
+		for _, n := range nodes {
+			if n == nil {
+				continue
+			}
+			status, found := pb.ShardInfo_Status_value[n.Status]
+			if !found {
+				return fmt.Errorf("shard %d server %d: unknown status %q", shardId, n.Id, n.Status)
+			}
+			logicalShards[shardId] = append(logicalShards[shardId], &pb.ClusterNode{
+				StoreResource: &pb.StoreResource{
+					Network:      n.Network,
+					Address:      n.Address,
+					AdminAddress: n.AdminAddress,
+					DataCenter:   n.DataCenter,
+				},
+				ShardInfo: &pb.ShardInfo{
+					KeyspaceName:      c.Keyspace,
+					ServerId:          n.Id,
+					ShardId:           n.ShardId,
+					ClusterSize:       uint32(c.ExpectedSize),
+					ReplicationFactor: uint32(c.ReplicationFactor),
+					Status:            pb.ShardInfo_Status(status),
+					IsCandidate:       n.IsCandidate,
+				},
+			})
+		}
+	}
+
+	cluster.lock.Lock()
+	defer cluster.lock.Unlock()
+
+	cluster.keyspace = c.Keyspace
+	cluster.dataCenter = c.DataCenter
+	cluster.expectedSize = c.ExpectedSize
+	cluster.replicationFactor = c.ReplicationFactor
+	cluster.logicalShards = logicalShards
+	cluster.nextCluster = nil
+	if c.NextSize > 0 {
+		cluster.nextCluster = cluster.newResizedCluster(c.NextSize, c.ReplicationFactor)
+	}
+
+	return nil
+}
@@ -0,0 +1,43 @@
+package topology
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/magiconair/properties/assert"
+)
+
+func TestClusterJSON(t *testing.T) {
+
+	cluster := createRing(3)
+	node, _ := cluster.GetNode(1, 0)
+	cluster.RemoveShard(node.StoreResource, node.ShardInfo)
+	node, _ = cluster.GetNode(1, 0)
+	cluster.RemoveShard(node.StoreResource, node.ShardInfo)
+	cluster.SetNextCluster(4, 2)
+
+	data, err := json.Marshal(cluster)
+	assert.Equal(t, err, nil, "marshal")
+	assert.Equal(t, strings.Contains(string(data), `"nextSize":4`), true, "next size")
+	assert.Equal(t, strings.Contains(string(data), `"address":"localhost:7000","adminAddress":"localhost:8000"`), true, "node address")
+
+	var decoded map[string]interface{}
+	json.Unmarshal(data, &decoded)
+	shards := decoded["shards"].([]interface{})
+	assert.Equal(t, len(shards), 3, "shard slots")
+	assert.Equal(t, shards[1] == nil, true, "empty shard kept as null")
+
+	restored := &Cluster{}
+	err = json.Unmarshal(data, restored)
+	assert.Equal(t, err, nil, "unmarshal")
+	assert.Equal(t, restored.String(), cluster.String(), "round trip")
+	assert.Equal(t, restored.GetNextCluster().ExpectedSize(), 4, "next cluster")
+
+	again, _ := json.Marshal(restored)
+	assert.Equal(t, string(again), string(data), "marshal again")
+
+	err = json.Unmarshal([]byte(`{"shards":[[{"id":1,"status":"UNKNOWN"}]]}`), &Cluster{})
+	assert.Equal(t, err != nil, true, "unknown status")
+
+}
@@ -0,0 +1,43 @@
+package topology
+
+import (
+	"github.com/chrislusf/vasto/util"
+)
+
+// KeyHasher hashes a raw key, or a partition key, into the key hash to find the shard.
+type KeyHasher func(key []byte) uint64
+
+// DefaultKeyHasher is the 64-bit xxHash, XXH64, of the key with the seed 0, the same as util.Hash used by the go client.
+// To route the same way, clients in other languages take XXH64(key, seed 0) as the key hash,
+// and then the jump consistent hash of the key hash over the expected cluster size.
+var DefaultKeyHasher KeyHasher = util.Hash
+
+// SetKeyHasher changes how HashKey and FindShardIdForKey hash the raw keys.
+// A nil keyHasher restores the DefaultKeyHasher.
+// All the clients of the keyspace must hash the same way, or the keys are routed to the wrong shards.
+func (cluster *Cluster) SetKeyHasher(keyHasher KeyHasher) {
+	cluster.lock.Lock()
+	defer cluster.lock.Unlock()
+	cluster.keyHasher = keyHasher
+}
+
+// HashKey returns the key hash of the raw key.
+func (cluster *Cluster) HashKey(key []byte) uint64 {
+	cluster.lock.RLock()
+	defer cluster.lock.RUnlock()
+	return cluster.hashKey(key)
+}
+
+func (cluster *Cluster) hashKey(key []byte) uint64 {
+	if cluster.keyHasher == nil {
+		return DefaultKeyHasher(key)
+	}
+	return cluster.keyHasher(key)
+}
+
+// FindShardIdForKey hashes the raw key and finds the shard id, the same as FindShardId(HashKey(key)).
+func (cluster *Cluster) FindShardIdForKey(key []byte) int {
+	cluster.lock.RLock()
+	defer cluster.lock.RUnlock()
+	return cluster.findShardId(cluster.hashKey(key))
+}
@@ -0,0 +1,33 @@
+package topology
+
+import (
+	"testing"
+
+	"github.com/dgryski/go-jump"
+	"github.com/magiconair/properties/assert"
+)
+
+func TestKeyHasher(t *testing.T) {
+
+	// XXH64 with the seed 0, for clients in other languages to verify against
+	assert.Equal(t, DefaultKeyHasher([]byte("")), uint64(0xef46db3751d8e999), "xxh64 of empty key")
+	assert.Equal(t, DefaultKeyHasher([]byte("a")), uint64(0xd24ec4f1a98c6e5b), "xxh64 of a")
+
+	ring := createRing(5)
+	key := []byte("some key")
+	assert.Equal(t, ring.HashKey(key), DefaultKeyHasher(key), "default key hash")
+	assert.Equal(t, ring.FindShardIdForKey(key), int(jump.Hash(DefaultKeyHasher(key), 5)), "default shard for key")
+	assert.Equal(t, ring.FindShardIdForKey(key), ring.FindShardId(ring.HashKey(key)), "shard for key hash")
+
+	ring.SetKeyHasher(func(key []byte) uint64 {
+		return uint64(len(key))
+	})
+	assert.Equal(t, ring.HashKey(key), uint64(8), "custom key hash")
+	assert.Equal(t, ring.FindShardIdForKey(key), int(jump.Hash(8, 5)), "custom shard for key")
+	assert.Equal(t, ring.Clone().HashKey(key), uint64(8), "cloned key hasher")
+	assert.Equal(t, ring.SetNextCluster(6, 2).HashKey(key), uint64(8), "resized key hasher")
+
+	ring.SetKeyHasher(nil)
+	assert.Equal(t, ring.HashKey(key), DefaultKeyHasher(key), "restored default key hash")
+
+}
@@ -0,0 +1,98 @@
+package topology
+
+const moveCostSampleCount = 10000
+
+// ServerLink is the direction of data transfer between two servers.
+type ServerLink struct {
+	FromServerId int
+	ToServerId   int
+}
+
+// MoveCostEstimate is the estimated data transfer of a cluster resize.
+type MoveCostEstimate struct {
+	TotalBytes uint64
+	// LinkBytes is the bytes sent from one server to another
+	LinkBytes map[ServerLink]uint64
+}
+
+// MoveCostEstimate estimates the bytes transferred to resize the cluster to expectedSize,
+// following the same bootstrap plans as an actual resize.
+// shardBytes is the data size of each shard id, e.g., collected from the store shard statuses.
+// The fraction of keys moving between shards is estimated by sampling the key hashes.
+func (cluster *Cluster) MoveCostEstimate(expectedSize int, shardBytes map[int]uint64) *MoveCostEstimate {
+
+	estimate := &MoveCostEstimate{
+		LinkBytes: make(map[ServerLink]uint64),
+	}
+
+	cluster.lock.RLock()
+	defer cluster.lock.RUnlock()
+
+	fromSize, replicationFactor := cluster.expectedSize, cluster.replicationFactor
+	if fromSize <= 0 || expectedSize <= 0 || fromSize == expectedSize {
+		return estimate
+	}
+
+	fractions := cluster.keyMoveFractions(expectedSize)
+
+	for serverId := 0; serverId < expectedSize; serverId++ {
+		for _, shard := range LocalShards(serverId, expectedSize, replicationFactor) {
+			plan := BootstrapPlanWithTopoChange(&BootstrapRequest{
+				ServerId:          serverId,
+				ShardId:           shard.ShardId,
+				FromClusterSize:   fromSize,
+				ToClusterSize:     expectedSize,
+				ReplicationFactor: replicationFactor,
+			})
+			sources := plan.BootstrapSource
+			if plan.PickBestBootstrapSource && len(sources) > 0 {
+				sources = []ClusterShard{pickClosestSource(serverId, sources)}
+			}
+			for _, source := range sources {
+				if source.ServerId == serverId {
+					continue
+				}
+				bytes := uint64(float64(shardBytes[source.ShardId]) * fractions[source.ShardId][shard.ShardId])
+				estimate.TotalBytes += bytes
+				estimate.LinkBytes[ServerLink{FromServerId: source.ServerId, ToServerId: serverId}] += bytes
+			}
+		}
+	}
+
+	return estimate
+}
+
+// keyMoveFractions returns the fraction of keys in each current shard that belong to each shard after the resize.
+func (cluster *Cluster) keyMoveFractions(expectedSize int) (fractions map[int]map[int]float64) {
+
+	resized := cluster.newResizedCluster(expectedSize, cluster.replicationFactor)
+
+	counts := make(map[int]int)
+	fractions = make(map[int]map[int]float64)
+	for i := 0; i < moveCostSampleCount; i++ {
+		keyHash := uint64(i) * 0x9E3779B97F4A7C15
+		before, after := cluster.findShardId(keyHash), resized.findShardId(keyHash)
+		counts[before]++
+		if fractions[before] == nil {
+			fractions[before] = make(map[int]float64)
+		}
+		fractions[before][after]++
+	}
+
+	for before, afters := range fractions {
+		for after := range afters {
+			afters[after] /= float64(counts[before])
+		}
+	}
+	return fractions
+}
+
+// pickClosestSource prefers the source already on the server, which needs no transfer.
+func pickClosestSource(serverId int, sources []ClusterShard) ClusterShard {
+	for _, source := range sources {
+		if source.ServerId == serverId {
+			return source
+		}
+	}
+	return sources[0]
+}
@@ -0,0 +1,33 @@
+package topology
+
+import (
+	"testing"
+
+	"github.com/magiconair/properties/assert"
+)
+
+func TestMoveCostEstimate(t *testing.T) {
+
+	cluster := NewCluster("ks1", 3, 1)
+	shardBytes := map[int]uint64{0: 300, 1: 300, 2: 300}
+
+	estimate := cluster.MoveCostEstimate(3, shardBytes)
+	assert.Equal(t, estimate.TotalBytes, uint64(0), "same size")
+
+	// the new shard 3 receives about a quarter of each existing shard
+	estimate = cluster.MoveCostEstimate(4, shardBytes)
+	assert.Equal(t, estimate.TotalBytes > 200 && estimate.TotalBytes < 250, true, "grow total bytes")
+	assert.Equal(t, len(estimate.LinkBytes), 3, "grow links")
+	for link, bytes := range estimate.LinkBytes {
+		assert.Equal(t, link.ToServerId, 3, "grow to the new server")
+		assert.Equal(t, bytes > 60 && bytes < 90, true, "grow link bytes")
+	}
+
+	// the retiring shard 2 is spread to shard 0 and 1
+	estimate = cluster.MoveCostEstimate(2, shardBytes)
+	assert.Equal(t, estimate.TotalBytes > 270 && estimate.TotalBytes < 330, true, "shrink total bytes")
+	for link := range estimate.LinkBytes {
+		assert.Equal(t, link.FromServerId, 2, "shrink from the retiring server")
+	}
+
+}
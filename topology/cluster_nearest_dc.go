@@ -0,0 +1,32 @@
+package topology
+
+import (
+	"github.com/chrislusf/vasto/pb"
+)
+
+// GetNodesPreferringDC is the same as GetReplicaNodes, but moves the servers in the data center to the front,
+// keeping the order otherwise. Reads going to the first node stay in the data center,
+// and cross data centers only if no server in the data center holds the key.
+// An empty data center keeps the order of GetReplicaNodes.
+func (cluster *Cluster) GetNodesPreferringDC(keyHash uint64, dataCenter string) []*pb.ClusterNode {
+	cluster.lock.RLock()
+	defer cluster.lock.RUnlock()
+
+	nodes := cluster.getReplicaNodes(keyHash)
+	if dataCenter == "" {
+		return nodes
+	}
+
+	sorted := make([]*pb.ClusterNode, 0, len(nodes))
+	for _, node := range nodes {
+		if node.GetDataCenter() == dataCenter {
+			sorted = append(sorted, node)
+		}
+	}
+	for _, node := range nodes {
+		if node.GetDataCenter() != dataCenter {
+			sorted = append(sorted, node)
+		}
+	}
+	return sorted
+}
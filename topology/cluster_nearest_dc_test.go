@@ -0,0 +1,46 @@
+package topology
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/chrislusf/vasto/pb"
+	"github.com/magiconair/properties/assert"
+)
+
+func TestGetNodesPreferringDC(t *testing.T) {
+
+	// servers 0 and 2 in dc1, servers 1 and 3 in dc2
+	cluster := NewCluster("ks1", 4, 2)
+	for serverId, dataCenter := range []string{"dc1", "dc2", "dc1", "dc2"} {
+		for _, shard := range LocalShards(serverId, 4, 2) {
+			cluster.SetShard(&pb.StoreResource{
+				Address:    fmt.Sprint("localhost:", 7000+serverId),
+				DataCenter: dataCenter,
+			}, &pb.ShardInfo{
+				ServerId:          uint32(serverId),
+				ShardId:           uint32(shard.ShardId),
+				ClusterSize:       4,
+				ReplicationFactor: 2,
+			})
+		}
+	}
+
+	for keyHash := uint64(0); keyHash < 100; keyHash++ {
+		replicas := cluster.GetReplicaNodes(keyHash)
+		assert.Equal(t, len(replicas), 2, "replication factor")
+
+		for _, dataCenter := range []string{"dc1", "dc2"} {
+			nodes := cluster.GetNodesPreferringDC(keyHash, dataCenter)
+			assert.Equal(t, len(nodes), 2, "same nodes as the replicas")
+			assert.Equal(t, nodes[0].GetDataCenter(), dataCenter, "local replica first")
+			assert.Equal(t, nodes[1].GetDataCenter() != dataCenter, true, "remote replica last")
+		}
+
+		// no replica in the caller's data center
+		nodes := cluster.GetNodesPreferringDC(keyHash, "dc3")
+		assert.Equal(t, nodes, replicas, "replica order kept for other data centers")
+		assert.Equal(t, cluster.GetNodesPreferringDC(keyHash, ""), replicas, "replica order kept for unknown data center")
+	}
+
+}
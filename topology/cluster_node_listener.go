@@ -0,0 +1,79 @@
+package topology
+
+import (
+	"github.com/chrislusf/vasto/pb"
+)
+
+// NodeListener is called with a node added to or removed from the cluster.
+type NodeListener func(node *pb.ClusterNode)
+
+// nodeListener is a registered NodeListener, compared by pointer when unregistered.
+type nodeListener struct {
+	fn NodeListener
+}
+
+// OnNodeAdded registers fn to be called when SetShard, SetNode, or Join adds a node,
+// including a node taking over a shard from another address, e.g., by ReplaceShard.
+// The listeners are called synchronously in the order of registration, after the cluster is changed and unlocked,
+// so they can read or change the cluster.
+// It returns a function to unregister fn, e.g., when the listening shard is shut down.
+func (cluster *Cluster) OnNodeAdded(fn NodeListener) (unregister func()) {
+	cluster.lock.Lock()
+	defer cluster.lock.Unlock()
+	listener := &nodeListener{fn: fn}
+	cluster.nodeAddedListeners = append(cluster.nodeAddedListeners, listener)
+	return func() {
+		cluster.lock.Lock()
+		defer cluster.lock.Unlock()
+		cluster.nodeAddedListeners = withoutListener(cluster.nodeAddedListeners, listener)
+	}
+}
+
+// OnNodeRemoved registers fn to be called when RemoveShard, RemoveNode, or RemoveStore removes a node,
+// or when a node is replaced by one on another address.
+// The listeners are called, and unregistered, the same way as the ones registered by OnNodeAdded.
+func (cluster *Cluster) OnNodeRemoved(fn NodeListener) (unregister func()) {
+	cluster.lock.Lock()
+	defer cluster.lock.Unlock()
+	listener := &nodeListener{fn: fn}
+	cluster.nodeRemovedListeners = append(cluster.nodeRemovedListeners, listener)
+	return func() {
+		cluster.lock.Lock()
+		defer cluster.lock.Unlock()
+		cluster.nodeRemovedListeners = withoutListener(cluster.nodeRemovedListeners, listener)
+	}
+}
+
+// withoutListener returns a new slice without the listener,
+// so that the listeners being notified are not changed.
+func withoutListener(listeners []*nodeListener, listener *nodeListener) (remaining []*nodeListener) {
+	for _, l := range listeners {
+		if l != listener {
+			remaining = append(remaining, l)
+		}
+	}
+	return remaining
+}
+
+// notifyNodeChanges calls the listeners for the removed nodes, and then for the added nodes.
+// It must be called without holding the lock.
+func (cluster *Cluster) notifyNodeChanges(added, removed []*pb.ClusterNode) {
+	if len(added) == 0 && len(removed) == 0 {
+		return
+	}
+
+	cluster.lock.RLock()
+	addedListeners, removedListeners := cluster.nodeAddedListeners, cluster.nodeRemovedListeners
+	cluster.lock.RUnlock()
+
+	for _, node := range removed {
+		for _, listener := range removedListeners {
+			listener.fn(node)
+		}
+	}
+	for _, node := range added {
+		for _, listener := range addedListeners {
+			listener.fn(node)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package topology
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/chrislusf/vasto/pb"
+	"github.com/magiconair/properties/assert"
+)
+
+func TestNodeListeners(t *testing.T) {
+
+	cluster := NewCluster("ks1", 2, 1)
+
+	var events []string
+	for _, name := range []string{"a", "b"} {
+		name := name
+		cluster.OnNodeAdded(func(node *pb.ClusterNode) {
+			events = append(events, fmt.Sprintf("%s+%s", name, node.StoreResource.Address))
+		})
+		cluster.OnNodeRemoved(func(node *pb.ClusterNode) {
+			events = append(events, fmt.Sprintf("%s-%s", name, node.StoreResource.Address))
+		})
+	}
+
+	shard := &pb.ShardInfo{ServerId: 0, ShardId: 0, ClusterSize: 2, ReplicationFactor: 1}
+	cluster.SetShard(&pb.StoreResource{Address: "localhost:7000"}, shard)
+	assert.Equal(t, fmt.Sprint(events), "[a+localhost:7000 b+localhost:7000]", "added in registration order")
+
+	// status changes are not membership changes
+	events = nil
+	cluster.SetShard(&pb.StoreResource{Address: "localhost:7000"}, &pb.ShardInfo{ServerId: 0, ShardId: 0, ClusterSize: 2, ReplicationFactor: 1, Status: pb.ShardInfo_READY})
+	assert.Equal(t, len(events), 0, "status change")
+
+	// the server moves to another address
+	cluster.SetShard(&pb.StoreResource{Address: "localhost:7100"}, shard)
+	assert.Equal(t, fmt.Sprint(events), "[a-localhost:7000 b-localhost:7000 a+localhost:7100 b+localhost:7100]", "moved")
+
+	// the shard is replaced by another server
+	events = nil
+	cluster.ReplaceShard(&pb.StoreResource{Address: "localhost:7200"}, shard)
+	assert.Equal(t, fmt.Sprint(events), "[a-localhost:7100 b-localhost:7100 a+localhost:7200 b+localhost:7200]", "replaced")
+	cluster.ReplaceShard(&pb.StoreResource{Address: "localhost:7100"}, shard)
+
+	events = nil
+	cluster.RemoveShard(&pb.StoreResource{Address: "localhost:7100"}, shard)
+	cluster.RemoveShard(&pb.StoreResource{Address: "localhost:7100"}, shard)
+	assert.Equal(t, fmt.Sprint(events), "[a-localhost:7100 b-localhost:7100]", "removed once")
+
+	// listeners can change the cluster
+	events = nil
+	cluster.SetExpectedSize(2)
+	cluster.OnNodeAdded(func(node *pb.ClusterNode) {
+		cluster.RemoveNode(node.StoreResource, node.ShardInfo)
+	})
+	_, err := cluster.Join(&pb.StoreResource{Address: "localhost:7001"})
+	assert.Equal(t, err, nil, "join")
+	assert.Equal(t, fmt.Sprint(events), "[a+localhost:7001 b+localhost:7001 a-localhost:7001 b-localhost:7001]", "joined and removed")
+
+	// unregistered listeners are not called
+	events = nil
+	unregister := cluster.OnNodeRemoved(func(node *pb.ClusterNode) {
+		events = append(events, "c-"+node.StoreResource.Address)
+	})
+	unregister()
+	cluster.SetShard(&pb.StoreResource{Address: "localhost:7002"}, shard)
+	cluster.RemoveShard(&pb.StoreResource{Address: "localhost:7002"}, shard)
+	assert.Equal(t, fmt.Sprint(events), "[a+localhost:7002 b+localhost:7002 a-localhost:7002 b-localhost:7002]", "unregistered")
+
+}
@@ -9,12 +9,29 @@ func (cluster *Cluster) ToCluster() *pb.Cluster {
 	if cluster == nil {
 		return &pb.Cluster{}
 	}
+	cluster.lock.RLock()
+	defer cluster.lock.RUnlock()
+
 	return &pb.Cluster{
 		Keyspace:            cluster.keyspace,
 		Nodes:               cluster.toNodes(),
-		ExpectedClusterSize: uint32(cluster.ExpectedSize()),
-		CurrentClusterSize:  uint32(cluster.CurrentSize()),
+		ExpectedClusterSize: uint32(cluster.expectedSize),
+		CurrentClusterSize:  uint32(cluster.currentSize()),
+		ReplicationFactor:   uint32(cluster.replicationFactor),
+	}
+}
+
+// FromCluster creates a cluster from the pb.Cluster object.
+// A missing replication factor falls back to DefaultReplicationFactor.
+func FromCluster(c *pb.Cluster) *Cluster {
+	cluster := NewCluster(c.Keyspace, int(c.ExpectedClusterSize), int(c.ReplicationFactor))
+	for _, node := range c.Nodes {
+		if node.StoreResource == nil || node.ShardInfo == nil {
+			continue
+		}
+		cluster.SetShard(node.StoreResource, node.ShardInfo)
 	}
+	return cluster
 }
 
 func (cluster *Cluster) toNodes() (nodes []*pb.ClusterNode) {
@@ -0,0 +1,38 @@
+package topology
+
+import (
+	"github.com/chrislusf/vasto/pb"
+)
+
+// PromoteNextSize completes a resize. If the cluster has all the shards of the next cluster size,
+// and all of them are READY, the expected size and the replication factor are set to the next cluster's,
+// and the next cluster is removed.
+// It returns false and changes nothing if there is no next cluster, or the resize is not finished yet.
+func (cluster *Cluster) PromoteNextSize() bool {
+	cluster.lock.Lock()
+	defer cluster.lock.Unlock()
+
+	if cluster.nextCluster == nil {
+		return false
+	}
+	nextSize, nextReplicationFactor := cluster.nextCluster.ExpectedSize(), cluster.nextCluster.ReplicationFactor()
+	if nextSize <= 0 || cluster.currentSize() != nextSize {
+		return false
+	}
+	for shardId := 0; shardId < nextSize; shardId++ {
+		shardGroup := cluster.logicalShards[shardId]
+		if len(shardGroup) == 0 {
+			return false
+		}
+		for _, node := range shardGroup {
+			if node.ShardInfo.Status != pb.ShardInfo_READY {
+				return false
+			}
+		}
+	}
+
+	cluster.setExpectedSize(nextSize)
+	cluster.replicationFactor = nextReplicationFactor
+	cluster.nextCluster = nil
+	return true
+}
@@ -0,0 +1,47 @@
+package topology
+
+import (
+	"testing"
+
+	"github.com/chrislusf/vasto/pb"
+	"github.com/magiconair/properties/assert"
+)
+
+func TestPromoteNextSize(t *testing.T) {
+
+	ring := createRing(3)
+	assert.Equal(t, ring.PromoteNextSize(), false, "no next cluster")
+
+	ring.SetNextCluster(4, 3)
+	assert.Equal(t, ring.PromoteNextSize(), false, "new shard not added")
+
+	newStore := &pb.StoreResource{Network: "tcp", Address: "localhost:7003", AdminAddress: "localhost:8003"}
+	ring.SetShard(newStore, &pb.ShardInfo{
+		KeyspaceName:      "ks1",
+		ServerId:          3,
+		ShardId:           3,
+		ClusterSize:       3,
+		ReplicationFactor: 2,
+	})
+	assert.Equal(t, ring.PromoteNextSize(), false, "shards not ready")
+
+	for _, shardGroup := range ring.GetAllShards() {
+		for _, node := range shardGroup {
+			ring.SetShard(node.StoreResource, &pb.ShardInfo{
+				KeyspaceName:      node.ShardInfo.KeyspaceName,
+				ServerId:          node.ShardInfo.ServerId,
+				ShardId:           node.ShardInfo.ShardId,
+				ClusterSize:       node.ShardInfo.ClusterSize,
+				ReplicationFactor: node.ShardInfo.ReplicationFactor,
+				Status:            pb.ShardInfo_READY,
+			})
+		}
+	}
+	assert.Equal(t, ring.ExpectedSize(), 3, "size before promotion")
+	assert.Equal(t, ring.PromoteNextSize(), true, "promoted")
+	assert.Equal(t, ring.ExpectedSize(), 4, "size after promotion")
+	assert.Equal(t, ring.ReplicationFactor(), 3, "replication factor after promotion")
+	assert.Equal(t, ring.GetNextCluster() == nil, true, "next cluster removed")
+	assert.Equal(t, ring.PromoteNextSize(), false, "already promoted")
+
+}
@@ -0,0 +1,79 @@
+package topology
+
+import (
+	"fmt"
+	"sort"
+)
+
+// ReplicaCopy copies the shard from the source server to the server, which becomes a new replica of the shard.
+type ReplicaCopy struct {
+	ShardId        int
+	ServerId       int
+	SourceServerId int
+}
+
+func (c ReplicaCopy) String() string {
+	return fmt.Sprintf("%d@%d<-%d", c.ShardId, c.ServerId, c.SourceServerId)
+}
+
+// ReReplicationPlan lists the replicas each shard gains when changing the replication factor from oldRF to newRF.
+// The first replica of a shard, which is kept under any replication factor, is the source of the copies.
+// Lowering the replication factor needs no copies, since the remaining replicas already have the keys.
+// The copies are sorted by shard id and then server id.
+func (cluster *Cluster) ReReplicationPlan(oldRF, newRF int) (copies []ReplicaCopy) {
+
+	if oldRF <= 0 || newRF <= oldRF {
+		return nil
+	}
+
+	cluster.lock.RLock()
+	clusterSize := cluster.expectedSize
+	cluster.lock.RUnlock()
+
+	return reReplicationPlan(clusterSize, oldRF, newRF)
+}
+
+// ChangeReplicationFactor sets the replication factor of the cluster,
+// and returns the copies needed to bring the existing keys to the new replication factor.
+// A replicationFactor not greater than 0 is ignored.
+func (cluster *Cluster) ChangeReplicationFactor(replicationFactor int) (copies []ReplicaCopy) {
+	cluster.lock.Lock()
+	defer cluster.lock.Unlock()
+
+	if replicationFactor <= 0 || replicationFactor == cluster.replicationFactor {
+		return nil
+	}
+	oldRF := cluster.replicationFactor
+	cluster.replicationFactor = replicationFactor
+
+	if oldRF <= 0 || replicationFactor <= oldRF {
+		return nil
+	}
+	return reReplicationPlan(cluster.expectedSize, oldRF, replicationFactor)
+}
+
+func reReplicationPlan(clusterSize, oldRF, newRF int) (copies []ReplicaCopy) {
+	for shardId := 0; shardId < clusterSize; shardId++ {
+		existing := PartitionShards(shardId, shardId, clusterSize, oldRF)
+		if len(existing) == 0 {
+			continue
+		}
+		for _, shard := range PartitionShards(shardId, shardId, clusterSize, newRF) {
+			if ShardListContains(existing, shard) {
+				continue
+			}
+			copies = append(copies, ReplicaCopy{
+				ShardId:        shard.ShardId,
+				ServerId:       shard.ServerId,
+				SourceServerId: existing[0].ServerId,
+			})
+		}
+	}
+	sort.Slice(copies, func(i, j int) bool {
+		if copies[i].ShardId != copies[j].ShardId {
+			return copies[i].ShardId < copies[j].ShardId
+		}
+		return copies[i].ServerId < copies[j].ServerId
+	})
+	return copies
+}
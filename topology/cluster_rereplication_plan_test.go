@@ -0,0 +1,42 @@
+package topology
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/magiconair/properties/assert"
+)
+
+func TestReReplicationPlan(t *testing.T) {
+
+	cluster := NewCluster("ks1", 3, 1)
+
+	copies := cluster.ReReplicationPlan(1, 2)
+	assert.Equal(t, fmt.Sprint(copies), "[0@1<-0 1@2<-1 2@0<-2]", "raise rf from 1 to 2")
+
+	// every shard gets a copy on a server not holding it yet
+	copied := make(map[int]bool)
+	for _, c := range copies {
+		assert.Equal(t, c.SourceServerId, c.ShardId, "copy from the first replica")
+		if c.ServerId == c.SourceServerId {
+			t.Errorf("copy %v to the source server", c)
+		}
+		copied[c.ShardId] = true
+	}
+	for shardId := 0; shardId < 3; shardId++ {
+		if !copied[shardId] {
+			t.Errorf("shard %d has no copy target", shardId)
+		}
+	}
+
+	assert.Equal(t, len(cluster.ReReplicationPlan(2, 2)), 0, "same rf")
+	assert.Equal(t, len(cluster.ReReplicationPlan(2, 1)), 0, "lower rf")
+	assert.Equal(t, len(cluster.ReReplicationPlan(1, 5)), 6, "rf capped by the cluster size")
+
+	assert.Equal(t, fmt.Sprint(cluster.ChangeReplicationFactor(2)), "[0@1<-0 1@2<-1 2@0<-2]", "change rf from 1 to 2")
+	assert.Equal(t, cluster.ReplicationFactor(), 2, "changed rf")
+	assert.Equal(t, len(cluster.ChangeReplicationFactor(2)), 0, "unchanged rf")
+	assert.Equal(t, len(cluster.ChangeReplicationFactor(0)), 0, "ignored rf")
+	assert.Equal(t, cluster.ReplicationFactor(), 2, "ignored rf")
+
+}
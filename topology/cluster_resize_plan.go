@@ -0,0 +1,42 @@
+package topology
+
+import (
+	"fmt"
+)
+
+// ShardMove moves the keys from the source shard to the shard.
+type ShardMove struct {
+	ShardId       int
+	SourceShardId int
+}
+
+func (m ShardMove) String() string {
+	return fmt.Sprintf("%d<-%d", m.ShardId, m.SourceShardId)
+}
+
+// ComputeResizePlan lists the shards each shard needs to copy keys from, when resizing the cluster from fromSize to toSize.
+// With jump hash, keys only move from the existing shards to the added shards when growing,
+// and from the removed shards to the remaining shards when shrinking.
+// The moves are sorted by shard id and then source shard id.
+func (cluster *Cluster) ComputeResizePlan(fromSize, toSize int) (moves []ShardMove) {
+
+	if fromSize <= 0 || toSize <= 0 || fromSize == toSize {
+		return nil
+	}
+
+	if fromSize < toSize {
+		for shardId := fromSize; shardId < toSize; shardId++ {
+			for sourceShardId := 0; sourceShardId < fromSize; sourceShardId++ {
+				moves = append(moves, ShardMove{ShardId: shardId, SourceShardId: sourceShardId})
+			}
+		}
+		return moves
+	}
+
+	for shardId := 0; shardId < toSize; shardId++ {
+		for sourceShardId := toSize; sourceShardId < fromSize; sourceShardId++ {
+			moves = append(moves, ShardMove{ShardId: shardId, SourceShardId: sourceShardId})
+		}
+	}
+	return moves
+}
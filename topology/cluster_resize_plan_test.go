@@ -0,0 +1,36 @@
+package topology
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/dgryski/go-jump"
+	"github.com/magiconair/properties/assert"
+)
+
+func TestComputeResizePlan(t *testing.T) {
+
+	cluster := createRing(3)
+
+	assert.Equal(t, len(cluster.ComputeResizePlan(3, 3)), 0, "same size")
+	assert.Equal(t, fmt.Sprint(cluster.ComputeResizePlan(3, 4)), "[3<-0 3<-1 3<-2]", "grow by one")
+	assert.Equal(t, fmt.Sprint(cluster.ComputeResizePlan(4, 2)), "[0<-2 0<-3 1<-2 1<-3]", "shrink by two")
+
+	// every moved key is covered by the plan
+	for _, sizes := range [][2]int{{3, 5}, {5, 3}, {1, 4}} {
+		planned := make(map[ShardMove]bool)
+		for _, move := range cluster.ComputeResizePlan(sizes[0], sizes[1]) {
+			planned[move] = true
+		}
+		for keyHash := uint64(0); keyHash < 10000; keyHash++ {
+			move := ShardMove{
+				ShardId:       int(jump.Hash(keyHash*0x9E3779B97F4A7C15, sizes[1])),
+				SourceShardId: int(jump.Hash(keyHash*0x9E3779B97F4A7C15, sizes[0])),
+			}
+			if move.ShardId != move.SourceShardId && !planned[move] {
+				t.Errorf("resize %d to %d misses move %v", sizes[0], sizes[1], move)
+			}
+		}
+	}
+
+}
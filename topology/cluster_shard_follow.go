@@ -12,7 +12,8 @@ func (shard ClusterShard) String() string {
 	return fmt.Sprintf("%d.%d", shard.ServerId, shard.ShardId)
 }
 
-// PeerShards list peer shards that are on other cluster nodes
+// PeerShards list peer shards that are on other cluster nodes.
+// When the cluster is smaller than the replication factor, each server is listed at most once.
 func PeerShards(selfServerId int, selfShardId int, clusterSize int, replicationFactor int) (peers []ClusterShard) {
 
 	if selfShardId >= clusterSize {
@@ -36,7 +37,8 @@ func PeerShards(selfServerId int, selfShardId int, clusterSize int, replicationF
 	return
 }
 
-// PartitionShards list shards that belongs to the same partition
+// PartitionShards list shards that belongs to the same partition.
+// The list may be shorter than the replication factor, since each server is listed at most once.
 func PartitionShards(selfServerId int, selfShardId int, clusterSize int, replicationFactor int) (shards []ClusterShard) {
 
 	if selfShardId >= clusterSize {
@@ -57,7 +59,8 @@ func PartitionShards(selfServerId int, selfShardId int, clusterSize int, replica
 	return
 }
 
-// LocalShards list shards that local node should have
+// LocalShards list shards that local node should have.
+// The list may be shorter than the replication factor, since each shard is listed at most once.
 func LocalShards(selfServerId int, clusterSize int, replicationFactor int) (shards []ClusterShard) {
 
 	if selfServerId >= clusterSize {
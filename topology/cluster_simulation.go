@@ -0,0 +1,63 @@
+package topology
+
+// SimulationReport shows how the sample keys are placed before and after a proposed cluster change.
+type SimulationReport struct {
+	SampleCount int
+	// KeyCountsBefore and KeyCountsAfter are the number of keys on each server, including replicas.
+	KeyCountsBefore []int
+	KeyCountsAfter  []int
+	// MovedKeyCount is the number of keys assigned to a different shard.
+	MovedKeyCount int
+	// CopiedKeyCount is the number of key replicas that a server needs to receive.
+	CopiedKeyCount int
+}
+
+// MovedFraction is the fraction of the sample keys assigned to a different shard.
+func (r *SimulationReport) MovedFraction() float64 {
+	if r.SampleCount == 0 {
+		return 0
+	}
+	return float64(r.MovedKeyCount) / float64(r.SampleCount)
+}
+
+// Simulate places the sample key hashes with the current cluster size and replication factor,
+// and with the proposed ones. It does not change the cluster.
+func (cluster *Cluster) Simulate(keyHashes []uint64, expectedSize int, replicationFactor int) *SimulationReport {
+	cluster.lock.RLock()
+	defer cluster.lock.RUnlock()
+
+	report := &SimulationReport{
+		KeyCountsBefore: make([]int, cluster.expectedSize),
+		KeyCountsAfter:  make([]int, expectedSize),
+	}
+	if cluster.expectedSize <= 0 || expectedSize <= 0 {
+		return report
+	}
+	report.SampleCount = len(keyHashes)
+
+	resized := cluster.newResizedCluster(expectedSize, replicationFactor)
+
+	for _, keyHash := range keyHashes {
+		before := cluster.findShardId(keyHash)
+		after := resized.findShardId(keyHash)
+		if before != after {
+			report.MovedKeyCount++
+		}
+
+		serversBefore := make(map[int]bool)
+		for _, shard := range PartitionShards(0, before, cluster.expectedSize, cluster.replicationFactor) {
+			report.KeyCountsBefore[shard.ServerId]++
+			if before == after {
+				serversBefore[shard.ServerId] = true
+			}
+		}
+		for _, shard := range PartitionShards(0, after, expectedSize, replicationFactor) {
+			report.KeyCountsAfter[shard.ServerId]++
+			if !serversBefore[shard.ServerId] {
+				report.CopiedKeyCount++
+			}
+		}
+	}
+
+	return report
+}
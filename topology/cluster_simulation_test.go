@@ -0,0 +1,39 @@
+package topology
+
+import (
+	"testing"
+
+	"github.com/magiconair/properties/assert"
+)
+
+func TestSimulateResize(t *testing.T) {
+
+	ring := createRing(4)
+
+	var keyHashes []uint64
+	for i := 0; i < 10000; i++ {
+		keyHashes = append(keyHashes, uint64(i)*2654435761)
+	}
+
+	report := ring.Simulate(keyHashes, 4, 2)
+	assert.Equal(t, report.MovedKeyCount, 0, "no change")
+	assert.Equal(t, report.CopiedKeyCount, 0, "no copies")
+	assert.Equal(t, report.KeyCountsBefore, report.KeyCountsAfter, "same key counts")
+
+	report = ring.Simulate(keyHashes, 5, 2)
+	assert.Equal(t, len(report.KeyCountsAfter), 5, "servers after resize")
+	if report.MovedFraction() < 0.15 || report.MovedFraction() > 0.25 {
+		t.Errorf("moved fraction %.3f, expecting about 0.2", report.MovedFraction())
+	}
+
+	report = ring.Simulate(keyHashes, 4, 3)
+	assert.Equal(t, report.MovedKeyCount, 0, "replication change does not move keys")
+	assert.Equal(t, report.CopiedKeyCount, len(keyHashes), "one more copy for each key")
+
+	total := 0
+	for _, count := range report.KeyCountsAfter {
+		total += count
+	}
+	assert.Equal(t, total, 3*len(keyHashes), "total copies")
+
+}
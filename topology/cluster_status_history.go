@@ -0,0 +1,101 @@
+package topology
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/chrislusf/vasto/pb"
+)
+
+// DefaultStatusHistoryDepth is the number of the latest status transitions kept for each shard on each server.
+const DefaultStatusHistoryDepth = 8
+
+// ShardStatusTransition is one status change of a shard on a server, e.g., during a resize or a node replacement.
+type ShardStatusTransition struct {
+	From pb.ShardInfo_Status
+	To   pb.ShardInfo_Status
+	// Added is set if the shard was not in the cluster, and Removed is set if the shard is removed from the cluster.
+	Added   bool
+	Removed bool
+	At      time.Time
+}
+
+func (t ShardStatusTransition) String() string {
+	switch {
+	case t.Added:
+		return fmt.Sprintf("added %v", t.To)
+	case t.Removed:
+		return fmt.Sprintf("removed %v", t.From)
+	}
+	return fmt.Sprintf("%v -> %v", t.From, t.To)
+}
+
+// SetStatusHistoryDepth changes the number of the latest status transitions kept for each shard,
+// DefaultStatusHistoryDepth by default. A depth of 0 or less stops keeping the history and clears it.
+func (cluster *Cluster) SetStatusHistoryDepth(depth int) {
+	cluster.lock.Lock()
+	defer cluster.lock.Unlock()
+
+	if depth <= 0 {
+		depth = -1
+		cluster.statusHistory = nil
+	}
+	cluster.statusHistoryDepth = depth
+	for identifier, history := range cluster.statusHistory {
+		if len(history) > depth {
+			cluster.statusHistory[identifier] = append([]ShardStatusTransition(nil), history[len(history)-depth:]...)
+		}
+	}
+}
+
+// GetShardStatusHistory returns the latest status transitions of the shard on the server, the oldest first.
+// The identifier is the ShardInfo.IdentifierOnThisServer(), i.e., keyspace_name.server_id.shard_id.
+// The history is kept after the shard is removed from the cluster.
+func (cluster *Cluster) GetShardStatusHistory(identifier string) []ShardStatusTransition {
+	cluster.lock.RLock()
+	defer cluster.lock.RUnlock()
+	return append([]ShardStatusTransition(nil), cluster.statusHistory[identifier]...)
+}
+
+// recordStatus keeps the status transition from the previous node to the shard, if the status changed.
+// A nil previous node means the shard is added, and a nil shard means the previous node is removed.
+func (cluster *Cluster) recordStatus(previous *pb.ClusterNode, shard *pb.ShardInfo) {
+
+	depth := cluster.statusHistoryDepth
+	if depth < 0 {
+		return
+	}
+	if depth == 0 {
+		depth = DefaultStatusHistoryDepth
+	}
+
+	var transition ShardStatusTransition
+	var identifier string
+	switch {
+	case previous == nil && shard == nil:
+		return
+	case previous == nil:
+		identifier = shard.IdentifierOnThisServer()
+		transition = ShardStatusTransition{To: shard.Status, Added: true}
+	case shard == nil:
+		identifier = previous.ShardInfo.IdentifierOnThisServer()
+		transition = ShardStatusTransition{From: previous.ShardInfo.Status, Removed: true}
+	default:
+		if previous.ShardInfo.Status == shard.Status {
+			return
+		}
+		identifier = shard.IdentifierOnThisServer()
+		transition = ShardStatusTransition{From: previous.ShardInfo.Status, To: shard.Status}
+	}
+	transition.At = time.Now()
+
+	if cluster.statusHistory == nil {
+		cluster.statusHistory = make(map[string][]ShardStatusTransition)
+	}
+	history := append(cluster.statusHistory[identifier], transition)
+	if len(history) > depth {
+		// copy to release the dropped transitions
+		history = append([]ShardStatusTransition(nil), history[len(history)-depth:]...)
+	}
+	cluster.statusHistory[identifier] = history
+}
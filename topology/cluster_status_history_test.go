@@ -0,0 +1,57 @@
+package topology
+
+import (
+	"testing"
+
+	"github.com/chrislusf/vasto/pb"
+	"github.com/magiconair/properties/assert"
+)
+
+func TestShardStatusHistory(t *testing.T) {
+
+	ring := createRing(3)
+	store := &pb.StoreResource{Network: "tcp", Address: "localhost:7001", AdminAddress: "localhost:8001"}
+	setStatus := func(status pb.ShardInfo_Status) {
+		ring.SetShard(store, &pb.ShardInfo{
+			KeyspaceName:      "ks1",
+			ServerId:          1,
+			ShardId:           1,
+			ClusterSize:       3,
+			ReplicationFactor: 2,
+			Status:            status,
+		})
+	}
+
+	history := ring.GetShardStatusHistory("ks1.1.1")
+	assert.Equal(t, len(history), 1, "added shard")
+	assert.Equal(t, history[0].String(), "added EMPTY", "added shard")
+
+	setStatus(pb.ShardInfo_BOOTSTRAP)
+	setStatus(pb.ShardInfo_BOOTSTRAP)
+	setStatus(pb.ShardInfo_READY)
+	history = ring.GetShardStatusHistory("ks1.1.1")
+	assert.Equal(t, len(history), 3, "unchanged status is not recorded")
+	assert.Equal(t, history[1].String(), "EMPTY -> BOOTSTRAP", "first transition")
+	assert.Equal(t, history[2].String(), "BOOTSTRAP -> READY", "second transition")
+
+	clone := ring.Clone()
+	ring.RemoveShard(store, &pb.ShardInfo{KeyspaceName: "ks1", ServerId: 1, ShardId: 1})
+	history = ring.GetShardStatusHistory("ks1.1.1")
+	assert.Equal(t, history[len(history)-1].String(), "removed READY", "removed shard")
+	assert.Equal(t, len(clone.GetShardStatusHistory("ks1.1.1")), 3, "cloned history")
+
+	ring.SetStatusHistoryDepth(2)
+	history = ring.GetShardStatusHistory("ks1.1.1")
+	assert.Equal(t, len(history), 2, "trimmed history")
+	assert.Equal(t, history[0].String(), "BOOTSTRAP -> READY", "oldest kept transition")
+	setStatus(pb.ShardInfo_BOOTSTRAP)
+	history = ring.GetShardStatusHistory("ks1.1.1")
+	assert.Equal(t, len(history), 2, "bounded history")
+	assert.Equal(t, history[1].String(), "added BOOTSTRAP", "latest transition")
+
+	ring.SetStatusHistoryDepth(0)
+	setStatus(pb.ShardInfo_READY)
+	assert.Equal(t, len(ring.GetShardStatusHistory("ks1.1.1")), 0, "disabled history")
+	assert.Equal(t, len(ring.GetShardStatusHistory("ks1.0.0")), 0, "cleared history")
+
+}
@@ -0,0 +1,78 @@
+package topology
+
+import (
+	"fmt"
+
+	"github.com/chrislusf/vasto/pb"
+)
+
+// SwapNode replaces the dead server in place with the store of the spare server.
+// The spare store takes over all the shards of the dead server id, in BOOTSTRAP status since it has to restore
+// their data from the other replicas, and all the shards of the spare server id are removed.
+// Since the dead server id and the cluster size are kept, the keys are mapped to the same shards as before.
+// It returns an error and changes nothing if the dead server id is out of the cluster size, or still has live shards,
+// or if the spare server id is within the cluster size, since it then holds shards of its own, or has no shard.
+func (cluster *Cluster) SwapNode(deadId, spareId int) error {
+	cluster.lock.Lock()
+	added, removed, err := cluster.swapNode(deadId, spareId)
+	cluster.lock.Unlock()
+
+	cluster.notifyNodeChanges(added, removed)
+
+	return err
+}
+
+func (cluster *Cluster) swapNode(deadId, spareId int) (added, removed []*pb.ClusterNode, err error) {
+
+	if deadId == spareId {
+		return nil, nil, fmt.Errorf("swap server %d with itself", deadId)
+	}
+	if deadId < 0 || deadId >= cluster.expectedSize {
+		return nil, nil, fmt.Errorf("server %d out of cluster size %d", deadId, cluster.expectedSize)
+	}
+	if spareId < cluster.expectedSize {
+		return nil, nil, fmt.Errorf("spare server %d within cluster size %d", spareId, cluster.expectedSize)
+	}
+
+	var spareNodes, deadNodes []*pb.ClusterNode
+	cluster.eachNode(func(shardId int, node *pb.ClusterNode) error {
+		switch int(node.ShardInfo.ServerId) {
+		case spareId:
+			spareNodes = append(spareNodes, node)
+		case deadId:
+			deadNodes = append(deadNodes, node)
+		}
+		return nil
+	})
+	if len(deadNodes) > 0 {
+		return nil, nil, fmt.Errorf("server %d still has %d live shards in cluster %s", deadId, len(deadNodes), cluster.keyspace)
+	}
+	if len(spareNodes) == 0 {
+		return nil, nil, fmt.Errorf("spare server %d not found in cluster %s", spareId, cluster.keyspace)
+	}
+	spareStore := spareNodes[0].StoreResource
+
+	expectedSize := cluster.expectedSize
+	for _, node := range spareNodes {
+		if removedNode := cluster.removeShard(node.StoreResource, node.ShardInfo); removedNode != nil {
+			removed = append(removed, removedNode)
+		}
+	}
+
+	for _, clusterShard := range LocalShards(deadId, expectedSize, cluster.replicationFactor) {
+		node, displaced := cluster.setShard(spareStore, &pb.ShardInfo{
+			KeyspaceName:      cluster.keyspace,
+			ServerId:          uint32(deadId),
+			ShardId:           uint32(clusterShard.ShardId),
+			ClusterSize:       uint32(expectedSize),
+			ReplicationFactor: uint32(cluster.replicationFactor),
+			Status:            pb.ShardInfo_BOOTSTRAP,
+		})
+		added = append(added, node)
+		if displaced != nil {
+			removed = append(removed, displaced)
+		}
+	}
+
+	return added, removed, nil
+}
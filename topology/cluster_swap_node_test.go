@@ -0,0 +1,71 @@
+package topology
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/chrislusf/vasto/pb"
+	"github.com/magiconair/properties/assert"
+)
+
+func TestSwapNode(t *testing.T) {
+
+	cluster := createRing(4)
+
+	// the spare server 4 is outside of the cluster size, on a free shard
+	cluster.SetShard(&pb.StoreResource{Network: "tcp", Address: "localhost:7004", AdminAddress: "localhost:8004"},
+		&pb.ShardInfo{KeyspaceName: "ks1", ServerId: 4, ShardId: 4, ClusterSize: 4, ReplicationFactor: 2})
+
+	// the server ids and stores of the replicas of each shard, in the replica order
+	placement := func() (replicas []string) {
+		for _, shards := range cluster.GetAllShards()[:4] {
+			var servers []string
+			for _, node := range shards {
+				servers = append(servers, fmt.Sprintf("%d@%s", node.ShardInfo.ServerId, node.StoreResource.Address))
+			}
+			replicas = append(replicas, fmt.Sprint(servers))
+		}
+		return
+	}
+	expected := placement()
+	for i := range expected {
+		expected[i] = strings.Replace(expected[i], "1@localhost:7001", "1@localhost:7004", 1)
+	}
+
+	assert.Equal(t, cluster.SwapNode(1, 4) != nil, true, "dead server still has live shards")
+
+	// server 1 fails
+	cluster.RemoveStore(&pb.StoreResource{Address: "localhost:7001"})
+
+	assert.Equal(t, cluster.SwapNode(1, 1) != nil, true, "swap with itself")
+	assert.Equal(t, cluster.SwapNode(4, 1) != nil, true, "dead server out of cluster size")
+	assert.Equal(t, cluster.SwapNode(1, 3) != nil, true, "spare server within cluster size")
+	assert.Equal(t, cluster.SwapNode(1, 5) != nil, true, "spare server not found")
+
+	assert.Equal(t, cluster.SwapNode(1, 4), nil, "swap")
+	assert.Equal(t, cluster.ExpectedSize(), 4, "same cluster size")
+
+	// each shard is on the same server ids, in the same replica order, with the spare store in the dead slot
+	assert.Equal(t, placement(), expected, "replica placement")
+
+	for _, shardId := range []int{0, 1} {
+		found := false
+		for _, node := range cluster.GetAllShards()[shardId] {
+			if node.ShardInfo.ServerId != 1 {
+				continue
+			}
+			found = true
+			assert.Equal(t, node.ShardInfo.Status, pb.ShardInfo_BOOTSTRAP, "restoring from replicas")
+		}
+		assert.Equal(t, found, true, "shard on the dead server id")
+	}
+
+	cluster.EachNode(func(shardId int, node *pb.ClusterNode) error {
+		if node.ShardInfo.ServerId == 4 {
+			t.Errorf("spare server id still has shard %d", shardId)
+		}
+		return nil
+	})
+
+}
@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"github.com/chrislusf/vasto/pb"
 	"github.com/magiconair/properties/assert"
+	"math/rand"
+	"sync"
 	"testing"
 )
 
@@ -34,6 +36,30 @@ func TestClusterProto(t *testing.T) {
 	assert.Equal(t, cluster.Keyspace, "ks1", "keyspace")
 	assert.Equal(t, cluster.ExpectedClusterSize, uint32(3), "expected cluster size")
 	assert.Equal(t, cluster.CurrentClusterSize, uint32(3), "current cluster size")
+	assert.Equal(t, cluster.ReplicationFactor, uint32(2), "replication factor")
+
+	restored := FromCluster(cluster)
+	assert.Equal(t, restored.String(), ring3.String(), "restored cluster")
+	assert.Equal(t, restored.ReplicationFactor(), 2, "restored replication factor")
+
+}
+
+func TestClusterProtoWithZeroReplicationFactor(t *testing.T) {
+
+	cluster := createRing(3).ToCluster()
+	cluster.ReplicationFactor = 0
+	for _, node := range cluster.Nodes {
+		node.ShardInfo.ReplicationFactor = 0
+	}
+
+	restored := FromCluster(cluster)
+	assert.Equal(t, restored.ReplicationFactor(), DefaultReplicationFactor, "default replication factor")
+
+	restored.SetReplicationFactor(0)
+	assert.Equal(t, restored.ReplicationFactor(), DefaultReplicationFactor, "zero replication factor is ignored")
+
+	assert.Equal(t, NewCluster("ks1", 3, 0).ReplicationFactor(), DefaultReplicationFactor, "new cluster with zero replication factor")
+	assert.Equal(t, NewCluster("ks1", 3, -1).ReplicationFactor(), DefaultReplicationFactor, "new cluster with negative replication factor")
 
 }
 
@@ -151,6 +177,36 @@ func TestRemoveStore(t *testing.T) {
 
 }
 
+func TestRemoveByAddress(t *testing.T) {
+
+	ring3 := createRing(3)
+
+	var notified []string
+	ring3.OnNodeRemoved(func(node *pb.ClusterNode) {
+		notified = append(notified, node.ShardInfo.IdentifierOnThisServer())
+	})
+
+	removed, found, err := ring3.RemoveByAddress("localhost:7009")
+	assert.Equal(t, len(removed), 0, "remove missing address")
+	assert.Equal(t, found, false, "missing address")
+	assert.Equal(t, err, nil, "remove missing address error")
+
+	removed, found, err = ring3.RemoveByAddress("localhost:7001")
+	assert.Equal(t, found, true, "existing address")
+	assert.Equal(t, err, nil, "remove existing address error")
+	assert.Equal(t, len(removed), 2, "removed nodes")
+	assert.Equal(t, notified, []string{"ks1.1.0", "ks1.1.1"}, "notified removed nodes")
+	assert.Equal(t, ring3.String(), "[0@0 1@2 2@2,0] size 3/3 ", "ring after removal")
+
+	// an address shared by two servers is ambiguous
+	ring3.SetShard(&pb.StoreResource{Address: "localhost:7002"}, &pb.ShardInfo{KeyspaceName: "ks1", ServerId: 1, ShardId: 1})
+	removed, found, err = ring3.RemoveByAddress("localhost:7002")
+	assert.Equal(t, len(removed), 0, "remove shared address")
+	assert.Equal(t, found, true, "shared address")
+	assert.Equal(t, err != nil, true, "remove shared address error")
+
+}
+
 func TestNextCluster(t *testing.T) {
 
 	ring := createRing(0)
@@ -180,3 +236,383 @@ func TestNextCluster(t *testing.T) {
 	ring.RemoveNextCluster()
 
 }
+
+func TestClusterFingerprint(t *testing.T) {
+
+	ring3 := createRing(3)
+	assert.Equal(t, ring3.Fingerprint(), createRing(3).Fingerprint(), "same topology")
+	assert.Equal(t, ring3.Fingerprint() != createRing(4).Fingerprint(), true, "different size")
+
+	node, _ := ring3.GetNode(1, 0)
+	fingerprint := ring3.Fingerprint()
+	shardInfo := node.ShardInfo.Clone()
+	shardInfo.Status = pb.ShardInfo_BOOTSTRAP
+	ring3.SetShard(node.StoreResource, shardInfo)
+	assert.Equal(t, ring3.Fingerprint() != fingerprint, true, "different shard status")
+
+}
+
+func TestAssignmentForNode(t *testing.T) {
+
+	ring3 := createRing(3)
+
+	primary, replica := ring3.AssignmentForNode(1)
+	assert.Equal(t, primary, []int{1}, "primary shards on server 1")
+	assert.Equal(t, replica, []int{0}, "replica shards on server 1")
+
+	primary, replica = ring3.AssignmentForNode(5)
+	assert.Equal(t, len(primary)+len(replica), 0, "no shards on missing server 5")
+
+}
+
+func TestReplicasOnSmallCluster(t *testing.T) {
+
+	cluster := NewCluster("ks1", 2, 3)
+	for serverId := 0; serverId < 2; serverId++ {
+		for _, shard := range LocalShards(serverId, 2, 3) {
+			cluster.SetShard(&pb.StoreResource{
+				Address: fmt.Sprint("localhost:", 7000+serverId),
+			}, &pb.ShardInfo{
+				ServerId:          uint32(serverId),
+				ShardId:           uint32(shard.ShardId),
+				ClusterSize:       2,
+				ReplicationFactor: 3,
+			})
+		}
+	}
+
+	assert.Equal(t, cluster.CurrentSize() < cluster.ReplicationFactor(), true, "cluster smaller than replication factor")
+	assert.Equal(t, len(PartitionShards(0, 0, 2, 3)), 2, "partition shards")
+
+	for shardId := 0; shardId < 2; shardId++ {
+		first, _ := cluster.GetNode(shardId, 0)
+		second, _ := cluster.GetNode(shardId, 1)
+		assert.Equal(t, first.ShardInfo.ServerId != second.ShardInfo.ServerId, true, "different servers")
+		_, found := cluster.GetNode(shardId, 2)
+		assert.Equal(t, found, false, "no third replica")
+	}
+
+	// the same server moved to a new address
+	cluster.SetShard(&pb.StoreResource{
+		Address: "localhost:7100",
+	}, &pb.ShardInfo{
+		ServerId:          1,
+		ShardId:           0,
+		ClusterSize:       2,
+		ReplicationFactor: 3,
+	})
+	assert.Equal(t, len(cluster.GetAllShards()[0]), 2, "server listed once")
+	second, _ := cluster.GetNode(0, 1)
+	assert.Equal(t, second.StoreResource.Address, "localhost:7100", "new address")
+
+}
+
+func TestGetReplicaNodes(t *testing.T) {
+
+	assert.Equal(t, len(createRing(0).GetReplicaNodes(123)), 0, "empty cluster")
+
+	ring3 := createRing(3)
+	for keyHash := uint64(0); keyHash < 100; keyHash++ {
+		shardId := ring3.FindShardId(keyHash)
+		nodes := ring3.GetReplicaNodes(keyHash)
+		assert.Equal(t, len(nodes), 2, "replication factor")
+		assert.Equal(t, int(nodes[0].ShardInfo.ServerId), shardId, "primary first")
+		assert.Equal(t, int(nodes[1].ShardInfo.ServerId), (shardId+1)%3, "replica wraps around")
+	}
+
+	// the replica without an address is skipped
+	node, _ := ring3.GetNode(2, 1)
+	node.StoreResource = &pb.StoreResource{}
+	for keyHash := uint64(0); keyHash < 100; keyHash++ {
+		if ring3.FindShardId(keyHash) != 2 {
+			continue
+		}
+		nodes := ring3.GetReplicaNodes(keyHash)
+		assert.Equal(t, len(nodes), 1, "skip server without address")
+		assert.Equal(t, nodes[0].ShardInfo.ServerId, uint32(2), "primary")
+	}
+
+}
+
+func TestGetReplicaNode(t *testing.T) {
+
+	_, found := createRing(0).GetReplicaNode(123, 0)
+	assert.Equal(t, found, false, "empty cluster")
+
+	ring3 := createRing(3)
+	keyHash := uint64(123)
+	shardId := ring3.FindShardId(keyHash)
+
+	// read from the primary, and then from each replica
+	primary, _ := ring3.GetReplicaNode(keyHash, 0)
+	assert.Equal(t, int(primary.ShardInfo.ServerId), shardId, "primary")
+	replica, _ := ring3.GetReplicaNode(keyHash, 1)
+	assert.Equal(t, int(replica.ShardInfo.ServerId), (shardId+1)%3, "replica 1")
+
+	// wraps around
+	node, _ := ring3.GetReplicaNode(keyHash, 2)
+	assert.Equal(t, node == primary, true, "replica 2 wraps to the primary")
+
+	// the replica without an address is skipped, the same as GetReplicaNodes
+	replica.StoreResource = &pb.StoreResource{}
+	node, _ = ring3.GetReplicaNode(keyHash, 1)
+	assert.Equal(t, node == primary, true, "skip server without address")
+
+}
+
+func TestConcurrentClusterChanges(t *testing.T) {
+
+	cluster := createRing(4)
+
+	var wg sync.WaitGroup
+	for serverId := 0; serverId < 4; serverId++ {
+		wg.Add(1)
+		go func(serverId int) {
+			defer wg.Done()
+			store := &pb.StoreResource{Address: fmt.Sprint("localhost:", 7000+serverId)}
+			shard := &pb.ShardInfo{
+				ServerId:          uint32(serverId),
+				ShardId:           uint32(serverId),
+				ClusterSize:       4,
+				ReplicationFactor: 2,
+			}
+			for i := 0; i < 100; i++ {
+				cluster.RemoveShard(store, shard)
+				cluster.SetShard(store, shard)
+				cluster.SetExpectedSize(4)
+			}
+		}(serverId)
+	}
+	for reader := 0; reader < 4; reader++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for keyHash := uint64(0); keyHash < 100; keyHash++ {
+				if node, found := cluster.GetNode(cluster.FindShardId(keyHash), 0); found {
+					_ = node.ShardInfo.ServerId
+				}
+				cluster.GetReplicaNodes(keyHash)
+				_ = cluster.CurrentSize()
+				_ = cluster.String()
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, cluster.CurrentSize(), 4, "all shards set back")
+
+}
+
+func TestClusterClone(t *testing.T) {
+
+	cluster := createRing(3)
+	cluster.SetNextCluster(4, 2)
+
+	clone := cluster.Clone()
+	assert.Equal(t, clone.String(), cluster.String(), "same topology")
+	assert.Equal(t, clone.Fingerprint(), cluster.Fingerprint(), "same fingerprint")
+	assert.Equal(t, clone.GetNextCluster().ExpectedSize(), 4, "cloned next cluster")
+
+	clone.RemoveStore(&pb.StoreResource{Address: "localhost:7001"})
+	clone.SetExpectedSize(2)
+	clone.SetReplicationFactor(1)
+	clone.GetNextCluster().SetExpectedSize(5)
+
+	assert.Equal(t, cluster.String(), "[0@0,1 1@1,2 2@2,0] size 3/3 ", "original topology unchanged")
+	assert.Equal(t, cluster.ReplicationFactor(), 2, "original replication factor unchanged")
+	assert.Equal(t, cluster.GetNextCluster().ExpectedSize(), 4, "original next cluster unchanged")
+
+	var nilCluster *Cluster
+	assert.Equal(t, nilCluster.Clone() == nil, true, "clone nil cluster")
+
+}
+
+func TestGetReplicaNodesAcrossDataCenters(t *testing.T) {
+
+	// servers 0 and 1 in dc1, server 2 in dc2
+	cluster := NewCluster("ks1", 3, 2)
+	for serverId, dataCenter := range []string{"dc1", "dc1", "dc2"} {
+		for _, shard := range LocalShards(serverId, 3, 3) {
+			cluster.SetShard(&pb.StoreResource{
+				Address:    fmt.Sprint("localhost:", 7000+serverId),
+				DataCenter: dataCenter,
+			}, &pb.ShardInfo{
+				ServerId:          uint32(serverId),
+				ShardId:           uint32(shard.ShardId),
+				ClusterSize:       3,
+				ReplicationFactor: 2,
+			})
+		}
+	}
+
+	for keyHash := uint64(0); keyHash < 100; keyHash++ {
+		nodes := cluster.GetReplicaNodes(keyHash)
+		assert.Equal(t, len(nodes), 2, "replication factor")
+		assert.Equal(t, int(nodes[0].ShardInfo.ServerId), cluster.FindShardId(keyHash), "primary first")
+		assert.Equal(t, nodes[0].GetDataCenter() != nodes[1].GetDataCenter(), true, "replica in the other data center")
+	}
+
+}
+
+func TestEachNode(t *testing.T) {
+
+	cluster := createRing(3)
+	node, _ := cluster.GetNode(1, 1)
+	cluster.ReplaceShard(&pb.StoreResource{}, node.ShardInfo)
+
+	var visited []string
+	err := cluster.EachNode(func(shardId int, node *pb.ClusterNode) error {
+		visited = append(visited, fmt.Sprintf("%d.%d", shardId, node.ShardInfo.ServerId))
+		return nil
+	})
+	assert.Equal(t, err, nil, "iterate all")
+	assert.Equal(t, visited, []string{"0.0", "0.1", "1.1", "2.2", "2.0"}, "skip the node without address")
+
+	visited = nil
+	stop := fmt.Errorf("stop")
+	err = cluster.EachNode(func(shardId int, node *pb.ClusterNode) error {
+		visited = append(visited, fmt.Sprintf("%d.%d", shardId, node.ShardInfo.ServerId))
+		if shardId == 1 {
+			return stop
+		}
+		return nil
+	})
+	assert.Equal(t, err, stop, "stop early")
+	assert.Equal(t, len(visited), 3, "visited until stopped")
+
+}
+
+func TestSetNodeReturnsDisplacedNode(t *testing.T) {
+
+	cluster := createRing(3)
+	before, _ := cluster.GetNode(1, 0)
+
+	shard := before.ShardInfo.Clone()
+	displaced := cluster.SetNode(&pb.StoreResource{Address: "localhost:7101"}, shard)
+	assert.Equal(t, displaced == before, true, "displaced node")
+
+	after, _ := cluster.GetNode(1, 0)
+	assert.Equal(t, after.StoreResource.Address, "localhost:7101", "new node installed")
+
+	displaced = cluster.SetNode(&pb.StoreResource{Address: "localhost:7105"}, &pb.ShardInfo{
+		ServerId:          5,
+		ShardId:           1,
+		ClusterSize:       3,
+		ReplicationFactor: 2,
+	})
+	assert.Equal(t, displaced == nil, true, "no displaced node")
+
+}
+
+func TestRemoveNodeReportsRemoved(t *testing.T) {
+
+	cluster := createRing(3)
+	before, _ := cluster.GetNode(1, 0)
+
+	removed := cluster.RemoveNode(before.StoreResource, before.ShardInfo)
+	assert.Equal(t, removed == before, true, "removed node")
+
+	removed = cluster.RemoveNode(before.StoreResource, before.ShardInfo)
+	assert.Equal(t, removed == nil, true, "already removed")
+
+	removed = cluster.RemoveNode(&pb.StoreResource{Address: "localhost:7009"}, &pb.ShardInfo{ShardId: 9})
+	assert.Equal(t, removed == nil, true, "shard not in the cluster")
+
+}
+
+func TestIsReplicationSatisfiable(t *testing.T) {
+
+	cluster := NewCluster("ks1", 3, 3)
+	assert.Equal(t, cluster.IsReplicationSatisfiable(), true, "equal")
+
+	cluster.SetReplicationFactor(4)
+	assert.Equal(t, cluster.IsReplicationSatisfiable(), false, "over")
+
+	cluster.SetExpectedSize(5)
+	assert.Equal(t, cluster.IsReplicationSatisfiable(), true, "under after growing")
+
+	cluster.SetReplicationFactor(1)
+	assert.Equal(t, cluster.IsReplicationSatisfiable(), true, "under")
+
+}
+
+func TestLookupShardIdOnEmptyCluster(t *testing.T) {
+
+	cluster := NewCluster("ks1", 0, 1)
+	shardId, ok := cluster.LookupShardId(12345)
+	assert.Equal(t, ok, false, "empty cluster")
+	assert.Equal(t, shardId, -1, "no shard id")
+	assert.Equal(t, cluster.FindShardId(12345), -1, "find on empty cluster")
+
+	cluster.SetExpectedSize(3)
+	shardId, ok = cluster.LookupShardId(12345)
+	assert.Equal(t, ok, true, "populated cluster")
+	assert.Equal(t, shardId >= 0 && shardId < 3, true, "shard id in range")
+
+}
+
+func TestGetShardIdsForKeys(t *testing.T) {
+
+	ring3 := createRing(3)
+	assert.Equal(t, len(ring3.GetShardIdsForKeys(nil)), 0, "no keys")
+
+	var keyHashes []uint64
+	for keyHash := uint64(0); keyHash < 100; keyHash++ {
+		keyHashes = append(keyHashes, keyHash)
+	}
+
+	shardKeys := ring3.GetShardIdsForKeys(keyHashes)
+	count := 0
+	for shardId, keys := range shardKeys {
+		for _, keyHash := range keys {
+			assert.Equal(t, ring3.FindShardId(keyHash), shardId, "grouped by shard id")
+		}
+		count += len(keys)
+	}
+	assert.Equal(t, count, 100, "all keys grouped")
+	assert.Equal(t, len(shardKeys[MissingShardId]), 0, "no missing shard")
+
+	// keys of a shard without servers
+	for replica := 0; replica < 2; replica++ {
+		node, _ := ring3.GetNode(1, 0)
+		ring3.RemoveShard(node.StoreResource, node.ShardInfo)
+	}
+	shardKeys = ring3.GetShardIdsForKeys(keyHashes)
+	assert.Equal(t, len(shardKeys[1]), 0, "empty shard")
+	for _, keyHash := range shardKeys[MissingShardId] {
+		assert.Equal(t, ring3.FindShardId(keyHash), 1, "missing shard keys")
+	}
+	assert.Equal(t, len(shardKeys[MissingShardId]) > 0, true, "keys of the empty shard")
+
+	shardKeys = NewCluster("ks1", 0, 1).GetShardIdsForKeys(keyHashes)
+	assert.Equal(t, len(shardKeys[MissingShardId]), 100, "empty cluster")
+
+}
+
+func TestDistributeKeys(t *testing.T) {
+
+	assert.Equal(t, len(createRing(0).DistributeKeys([]uint64{1, 2})), 0, "no shards")
+
+	ring7 := createRing(7)
+	random := rand.New(rand.NewSource(1))
+	keyCount := 100000
+	keyHashes := make([]uint64, keyCount)
+	for i := range keyHashes {
+		keyHashes[i] = random.Uint64()
+	}
+
+	counts := ring7.DistributeKeys(keyHashes)
+	assert.Equal(t, len(counts), 7, "one count per shard")
+
+	expected := float64(keyCount) / 7
+	var total int
+	var chiSquare float64
+	for _, count := range counts {
+		total += count
+		chiSquare += (float64(count) - expected) * (float64(count) - expected) / expected
+	}
+	assert.Equal(t, total, keyCount, "all keys counted")
+	// the critical value of 6 degrees of freedom at p=0.001
+	assert.Equal(t, chiSquare < 22.46, true, fmt.Sprintf("chi-square %f of %v", chiSquare, counts))
+
+}
@@ -0,0 +1,81 @@
+package topology
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/chrislusf/vasto/pb"
+)
+
+// Validate checks the shards and servers in the cluster, and returns one error for each problem found:
+// a node missing the shard info or the store, a server id out of the expected size holding shards within it,
+// a store missing the address or the admin address,
+// or different servers with the same address or admin address, which would write the same keys twice.
+// The shards out of the expected size are the free shards of the spare servers, e.g., for SwapNode, and are valid.
+// An empty network is the same as "tcp".
+// The shard errors come first in the order of the shard ids, and then the server errors in the order of the server ids.
+func (cluster *Cluster) Validate() (errs []error) {
+	cluster.lock.RLock()
+	defer cluster.lock.RUnlock()
+
+	stores := make(map[int]*pb.StoreResource)
+	// the servers holding shards within the cluster size
+	inCluster := make(map[int]bool)
+	for shardId, shardGroup := range cluster.logicalShards {
+		for _, node := range shardGroup {
+			if node == nil || node.ShardInfo == nil || node.StoreResource == nil {
+				errs = append(errs, fmt.Errorf("shard %d: incomplete node %v", shardId, node))
+				continue
+			}
+			serverId := int(node.ShardInfo.ServerId)
+			if cluster.expectedSize <= 0 || shardId < cluster.expectedSize {
+				inCluster[serverId] = true
+			}
+			if _, found := stores[serverId]; !found {
+				stores[serverId] = node.StoreResource
+			}
+		}
+	}
+
+	var serverIds []int
+	for serverId := range stores {
+		serverIds = append(serverIds, serverId)
+	}
+	sort.Ints(serverIds)
+
+	addresses := make(map[string]int)
+	for _, serverId := range serverIds {
+		store := stores[serverId]
+		if cluster.expectedSize > 0 && serverId >= cluster.expectedSize && inCluster[serverId] {
+			errs = append(errs, fmt.Errorf("server %d: server id out of cluster size %d", serverId, cluster.expectedSize))
+		}
+		if store.Address == "" {
+			errs = append(errs, fmt.Errorf("server %d: store has no address", serverId))
+		}
+		if store.AdminAddress == "" {
+			errs = append(errs, fmt.Errorf("server %d: store has no admin address", serverId))
+		}
+
+		network := store.Network
+		if network == "" {
+			network = "tcp"
+		}
+		if store.Address != "" {
+			errs = checkDuplicateAddress(errs, addresses, serverId, network+" address "+store.Address)
+		}
+		if store.AdminAddress != "" {
+			errs = checkDuplicateAddress(errs, addresses, serverId, "admin address "+store.AdminAddress)
+		}
+	}
+
+	return errs
+}
+
+// checkDuplicateAddress remembers the server of the address, and adds an error if another server already has the address.
+func checkDuplicateAddress(errs []error, addresses map[string]int, serverId int, address string) []error {
+	if otherServerId, found := addresses[address]; found {
+		return append(errs, fmt.Errorf("server %d: %s is also used by server %d", serverId, address, otherServerId))
+	}
+	addresses[address] = serverId
+	return errs
+}
@@ -0,0 +1,42 @@
+package topology
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/chrislusf/vasto/pb"
+	"github.com/magiconair/properties/assert"
+)
+
+func TestValidate(t *testing.T) {
+
+	ring := createRing(3)
+	assert.Equal(t, len(ring.Validate()), 0, "valid ring")
+
+	// server 3 has the same address as server 1, and is out of the cluster size
+	ring.SetShard(&pb.StoreResource{
+		Address:      "localhost:7001",
+		AdminAddress: "localhost:8003",
+	}, &pb.ShardInfo{
+		KeyspaceName: "ks1",
+		ServerId:     3,
+		ShardId:      2,
+	})
+	// server 4 is a spare server on its free shard, but has no admin address
+	ring.SetShard(&pb.StoreResource{
+		Network: "tcp",
+		Address: "localhost:7004",
+	}, &pb.ShardInfo{
+		KeyspaceName: "ks1",
+		ServerId:     4,
+		ShardId:      4,
+	})
+
+	errs := fmt.Sprint(ring.Validate())
+	assert.Equal(t, errs, "["+
+		"server 3: server id out of cluster size 3 "+
+		"server 3: tcp address localhost:7001 is also used by server 1 "+
+		"server 4: store has no admin address"+
+		"]", "invalid ring")
+
+}
@@ -0,0 +1,30 @@
+package topology
+
+import (
+	"github.com/chrislusf/vasto/pb"
+)
+
+// ClusterView is the read only part of the Cluster, for code that only routes the keys,
+// so that it can not change the topology by mistake.
+type ClusterView interface {
+	// FindShardId returns the shard id of the key hash
+	FindShardId(keyHash uint64) int
+	// GetNode returns the server having the shard replica
+	GetNode(shardId int, replica int) (*pb.ClusterNode, bool)
+	// CurrentSize returns the number of shards in the cluster now
+	CurrentSize() int
+	// ExpectedSize returns the expected number of shards
+	ExpectedSize() int
+	// ReplicationFactor returns the replication factor of the cluster
+	ReplicationFactor() int
+	// GetReplicaNodes returns the servers having the shard of the key hash
+	GetReplicaNodes(keyHash uint64) []*pb.ClusterNode
+}
+
+var _ ClusterView = (*Cluster)(nil)
+
+// View returns the cluster as a ClusterView, which only has the read methods.
+// The view is not a snapshot, and sees the later changes of the cluster. Use Clone().View() for a snapshot.
+func (cluster *Cluster) View() ClusterView {
+	return cluster
+}
@@ -0,0 +1,31 @@
+package topology
+
+import (
+	"testing"
+
+	"github.com/magiconair/properties/assert"
+)
+
+func TestClusterView(t *testing.T) {
+
+	cluster := createRing(3)
+	view := cluster.View()
+
+	assert.Equal(t, view.CurrentSize(), 3, "current size")
+	assert.Equal(t, view.ExpectedSize(), 3, "expected size")
+	assert.Equal(t, view.ReplicationFactor(), 2, "replication factor")
+
+	for keyHash := uint64(0); keyHash < 100; keyHash++ {
+		assert.Equal(t, view.FindShardId(keyHash), cluster.FindShardId(keyHash), "same shard")
+		assert.Equal(t, view.GetReplicaNodes(keyHash), cluster.GetReplicaNodes(keyHash), "same replicas")
+	}
+
+	node, found := view.GetNode(1, 1)
+	assert.Equal(t, found, true, "shard 1 replica 1")
+	assert.Equal(t, node.StoreResource.Address, "localhost:7002", "shard 1 replica 1")
+
+	// the view follows the changes of the cluster
+	cluster.SetExpectedSize(4)
+	assert.Equal(t, view.ExpectedSize(), 4, "changed expected size")
+
+}
@@ -0,0 +1,65 @@
+package topology
+
+import (
+	"math"
+)
+
+// SetWeight sets the share of keys for the shard in FindShardIdWeighted, e.g., by the disk size of its server.
+// A shard without a weight, or with a weight not greater than 0, has the weight of 1.
+func (cluster *Cluster) SetWeight(shardId int, weight float64) {
+	cluster.lock.Lock()
+	defer cluster.lock.Unlock()
+
+	if weight <= 0 {
+		delete(cluster.weights, shardId)
+		return
+	}
+	if cluster.weights == nil {
+		cluster.weights = make(map[int]float64)
+	}
+	cluster.weights[shardId] = weight
+}
+
+// Weight returns the weight of the shard used by FindShardIdWeighted.
+func (cluster *Cluster) Weight(shardId int) float64 {
+	cluster.lock.RLock()
+	defer cluster.lock.RUnlock()
+	return cluster.weight(shardId)
+}
+
+func (cluster *Cluster) weight(shardId int) float64 {
+	if weight, found := cluster.weights[shardId]; found {
+		return weight
+	}
+	return 1
+}
+
+// FindShardIdWeighted picks a shard for the keyHash, with the chance proportional to the shard weight.
+// It uses weighted rendezvous hashing, so changing one shard's weight or the cluster size
+// only moves keys to or from the changed shards.
+// FindShardId is not affected by the weights.
+func (cluster *Cluster) FindShardIdWeighted(keyHash uint64) int {
+	cluster.lock.RLock()
+	defer cluster.lock.RUnlock()
+
+	bestShardId, bestScore := 0, math.Inf(-1)
+	for shardId := 0; shardId < cluster.expectedSize; shardId++ {
+		// uniform in (0, 1)
+		u := (float64(mix64(keyHash^mix64(uint64(shardId)))>>11) + 0.5) / (1 << 53)
+		score := -cluster.weight(shardId) / math.Log(u)
+		if score > bestScore {
+			bestShardId, bestScore = shardId, score
+		}
+	}
+	return bestShardId
+}
+
+// mix64 is the splitmix64 finalizer, to spread the bits of x.
+func mix64(x uint64) uint64 {
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+	return x
+}
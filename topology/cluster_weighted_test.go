@@ -0,0 +1,43 @@
+package topology
+
+import (
+	"math"
+	"testing"
+
+	"github.com/magiconair/properties/assert"
+)
+
+func TestFindShardIdWeighted(t *testing.T) {
+
+	cluster := createRing(3)
+	cluster.SetWeight(0, 2)
+	assert.Equal(t, cluster.Weight(0), float64(2), "weight set")
+	assert.Equal(t, cluster.Weight(1), float64(1), "default weight")
+
+	counts := make([]int, 3)
+	const sampleCount = 40000
+	for i := uint64(0); i < sampleCount; i++ {
+		counts[cluster.FindShardIdWeighted(i*0x9E3779B97F4A7C15)]++
+	}
+
+	// weights 2:1:1
+	for shardId, want := range []float64{0.5, 0.25, 0.25} {
+		got := float64(counts[shardId]) / sampleCount
+		assert.Equal(t, math.Abs(got-want) < 0.02, true, "share proportional to weight")
+	}
+
+	// increasing a weight only moves keys to that shard
+	moved := 0
+	for i := uint64(0); i < 1000; i++ {
+		before := cluster.FindShardIdWeighted(i)
+		cluster.SetWeight(2, 3)
+		after := cluster.FindShardIdWeighted(i)
+		cluster.SetWeight(2, 0)
+		if before != after {
+			moved++
+			assert.Equal(t, after, 2, "keys only move to the heavier shard")
+		}
+	}
+	assert.Equal(t, moved > 0, true, "some keys moved")
+
+}
@@ -0,0 +1,35 @@
+package topology
+
+import (
+	"github.com/chrislusf/vasto/pb"
+	"google.golang.org/grpc/encoding/gzip"
+)
+
+// SetLocalDataCenter sets the data center of this process, to dial the servers of this cluster.
+// Connections to servers in other data centers are compressed with gzip,
+// while connections within the same data center stay uncompressed.
+// An empty data center, the default, disables compression.
+func (cluster *Cluster) SetLocalDataCenter(dataCenter string) {
+	cluster.lock.Lock()
+	defer cluster.lock.Unlock()
+	cluster.localDataCenter = dataCenter
+}
+
+func (cluster *Cluster) loadLocalDataCenter() string {
+	cluster.lock.RLock()
+	defer cluster.lock.RUnlock()
+	return cluster.localDataCenter
+}
+
+// ConnectionCompressor returns the compressor name used for connections from the local data center to the node,
+// or an empty string if the connection is not compressed.
+func ConnectionCompressor(localDataCenter string, node *pb.ClusterNode) string {
+	if localDataCenter == "" || node == nil || node.StoreResource == nil {
+		return ""
+	}
+	remote := node.StoreResource.DataCenter
+	if remote == "" || remote == localDataCenter {
+		return ""
+	}
+	return gzip.Name
+}
@@ -3,23 +3,32 @@ package topology
 import (
 	"context"
 	"fmt"
+	"time"
 
-	"github.com/chrislusf/glog"
 	"github.com/chrislusf/vasto/pb"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
 )
 
 // WithConnection dials a connection to a server in the cluster by serverId
 func (cluster *Cluster) WithConnection(name string, serverId int, fn func(*pb.ClusterNode, *grpc.ClientConn) error) error {
+	return cluster.WithConnectionContext(context.Background(), name, serverId, withoutContext(fn))
+}
+
+// WithConnectionContext dials a connection to a server in the cluster by serverId.
+// If ctx has a deadline, the dial waits for the connection to be ready and fails when the deadline is exceeded.
+// The ctx is also passed to fn for the calls on the connection.
+func (cluster *Cluster) WithConnectionContext(ctx context.Context, name string, serverId int, fn func(context.Context, *pb.ClusterNode, *grpc.ClientConn) error) error {
 
 	node, ok := cluster.GetNode(serverId, 0)
 
+	log := newConnectionLogger(name, cluster.keyspace, serverId, node)
 	if !ok {
-		glog.Errorf("cluster misses server %d: %+v", serverId, cluster.String())
-		return fmt.Errorf("server %d not found", serverId)
+		log.logErrorf("cluster misses server %d: %+v", serverId, cluster.String())
+		return log.serverNotFoundf("server %d not found", serverId)
 	}
 
-	return doWithConnect(name, node, serverId, fn)
+	return doWithConnect(ctx, log, node, cluster.dialSettings(), cluster.markHealth, fn)
 }
 
 // VastoNodes are the servers in a cluster
@@ -27,32 +36,114 @@ type VastoNodes []*pb.ClusterNode
 
 // WithConnection dials a connection to a server of the cluster nodes by serverId
 func (nodes VastoNodes) WithConnection(name string, serverId int, fn func(*pb.ClusterNode, *grpc.ClientConn) error) error {
+	return nodes.WithConnectionContext(context.Background(), name, serverId, withoutContext(fn))
+}
+
+// WithConnectionContext dials a connection to a server of the cluster nodes by serverId, honoring the ctx cancellation and deadline.
+func (nodes VastoNodes) WithConnectionContext(ctx context.Context, name string, serverId int, fn func(context.Context, *pb.ClusterNode, *grpc.ClientConn) error) error {
 
 	if serverId < 0 || serverId >= len(nodes) {
-		return fmt.Errorf("server %d not found in %d servers: %+v", serverId, len(nodes), nodes)
+		return newConnectionLogger(name, nodes.keyspace(), serverId, nil).serverNotFoundf("server %d not found in %d servers: %+v", serverId, len(nodes), nodes)
 	}
 
 	node := nodes[serverId]
 
-	return doWithConnect(name, node, serverId, fn)
+	return doWithConnect(ctx, newConnectionLogger(name, nodes.keyspace(), serverId, node), node, loadDialSettings(), nil, fn)
+
+}
 
+// keyspace returns the keyspace of the first node having one.
+func (nodes VastoNodes) keyspace() string {
+	for _, node := range nodes {
+		if keyspaceName := node.GetShardInfo().GetKeyspaceName(); keyspaceName != "" {
+			return keyspaceName
+		}
+	}
+	return ""
 }
 
-func doWithConnect(name string, node *pb.ClusterNode, serverId int, fn func(*pb.ClusterNode, *grpc.ClientConn) error) error {
+func withoutContext(fn func(*pb.ClusterNode, *grpc.ClientConn) error) func(context.Context, *pb.ClusterNode, *grpc.ClientConn) error {
+	if fn == nil {
+		return nil
+	}
+	return func(ctx context.Context, node *pb.ClusterNode, conn *grpc.ClientConn) error {
+		return fn(node, conn)
+	}
+}
+
+// doWithConnect calls fn with a connection to the node, retrying by the retry policy.
+// If markHealth is not nil, the node is marked suspect when dialing fails, and healthy when fn succeeds
+// or the connection is ready. A connection dialed without waiting is not proof of health, so fn failing on it
+// leaves the health as is.
+// The errors from the connection layer carry the fields of the logger, while the errors from fn are returned as is.
+func doWithConnect(ctx context.Context, log connectionLogger, node *pb.ClusterNode, settings dialSettings,
+	markHealth func(address string, health NodeHealth), fn func(context.Context, *pb.ClusterNode, *grpc.ClientConn) error) error {
 
 	if node == nil {
-		return fmt.Errorf("%s: server %d is missing", name, serverId)
+		return log.serverNotFoundf("server %d is missing", log.serverId)
+	}
+
+	policy := loadRetryPolicy()
+	for attempt := 1; ; attempt++ {
+		dialFailed, ready, err := connectOnce(ctx, log, node, settings, fn)
+		if markHealth != nil {
+			switch {
+			case dialFailed:
+				markHealth(node.StoreResource.Address, NodeSuspect)
+			case err == nil || ready:
+				markHealth(node.StoreResource.Address, NodeHealthy)
+			}
+		}
+		if err == nil || !(dialFailed || isUnavailable(err)) {
+			return err
+		}
+		if attempt >= policy.MaxAttempts || ctx.Err() != nil {
+			if attempt > 1 {
+				return fmt.Errorf("%v, after %d attempts", err, attempt)
+			}
+			return err
+		}
+		delay := policy.backoff(attempt)
+		log.logInfof(1, "attempt %d failed, retry in %v: %v", attempt, delay, err)
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%v, after %d attempts: %v", err, attempt, ctx.Err())
+		case <-time.After(delay):
+		}
 	}
+}
+
+// connectOnce calls fn with a connection to the node, and reports whether dialing the connection failed,
+// and whether the connection is ready after fn.
+func connectOnce(ctx context.Context, log connectionLogger, node *pb.ClusterNode, settings dialSettings, fn func(context.Context, *pb.ClusterNode, *grpc.ClientConn) error) (dialFailed, ready bool, err error) {
 
 	// glog.V(2).Infof("connecting to server %d at %s", serverId, node.GetAdminAddress())
 
-	grpcConnection, err := grpc.DialContext(context.Background(), node.StoreResource.AdminAddress, grpc.WithInsecure())
+	callOptions := settings.messageSizeLimit.callOptions()
+	if compressor := ConnectionCompressor(settings.localDataCenter, node); compressor != "" {
+		log.logInfof(2, "compress connection with %s", compressor)
+		callOptions = append(callOptions, grpc.UseCompressor(compressor))
+	}
+	dialOptions := []grpc.DialOption{grpcDialOption(settings.tlsConfig), grpc.WithDefaultCallOptions(callOptions...)}
+	if _, hasDeadline := ctx.Deadline(); hasDeadline {
+		dialOptions = append(dialOptions, grpc.WithBlock())
+	}
+
+	address := node.StoreResource.AdminAddress
+	key := newPoolKey(address, settings)
+	grpcConnection, err := connectionPool.checkout(key, func() (*grpc.ClientConn, error) {
+		return grpc.DialContext(ctx, address, dialOptions...)
+	})
 	if err != nil {
-		return fmt.Errorf("%s: fail to dial %s: %v", name, node.StoreResource.AdminAddress, err)
+		return true, false, log.errorf("fail to dial server %d at %s: %v", log.serverId, address, err)
 	}
-	defer grpcConnection.Close()
+	defer connectionPool.checkin(key, grpcConnection)
 
 	// glog.V(2).Infof("%s: connect to shard %s on %s", name, node.ShardInfo.IdentifierOnThisServer(), node.StoreResource.AdminAddress)
 
-	return fn(node, grpcConnection)
+	startTime := time.Now()
+	err = fn(ctx, node, grpcConnection)
+	observeCall(log.qualifiedName(), address, startTime, err)
+
+	return false, grpcConnection.GetState() == connectivity.Ready, err
 }
@@ -1,10 +1,16 @@
 package topology
 
 import (
+	"context"
+	"crypto/tls"
+	"errors"
 	"github.com/chrislusf/vasto/pb"
 	"github.com/magiconair/properties/assert"
 	"google.golang.org/grpc"
+	"net"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestClusterWithConnection(t *testing.T) {
@@ -62,3 +68,180 @@ func TestPrimaryShardsWithConnection(t *testing.T) {
 	assert.Equal(t, err != nil, true, "shards with nil node")
 
 }
+
+func TestReloadTLS(t *testing.T) {
+
+	assert.Equal(t, loadTLS() == nil, true, "insecure by default")
+
+	config := &tls.Config{ServerName: "store1"}
+	ReloadTLS(config)
+	config.ServerName = "changed after reload"
+	assert.Equal(t, loadTLS().ServerName, "store1", "reloaded tls config")
+
+	ReloadTLS(nil)
+	assert.Equal(t, loadTLS() == nil, true, "back to insecure")
+
+}
+
+func TestTLSOption(t *testing.T) {
+
+	config, err := TLSOption{}.TLSConfig()
+	assert.Equal(t, config == nil && err == nil, true, "insecure without tls option")
+
+	config, err = TLSOption{ServerName: "store1"}.TLSConfig()
+	assert.Equal(t, err, nil, "server name only")
+	assert.Equal(t, config.ServerName, "store1", "server name override")
+
+	_, err = TLSOption{CaFile: "/not/existing/ca.pem"}.TLSConfig()
+	assert.Equal(t, err != nil, true, "missing ca file")
+
+	_, err = TLSOption{CertFile: "/not/existing/cert.pem"}.TLSConfig()
+	assert.Equal(t, err != nil, true, "missing client key")
+
+	ring := createRing(1)
+	assert.Equal(t, ring.loadTLS() == nil, true, "cluster is insecure by default")
+
+	ReloadTLS(&tls.Config{ServerName: "global"})
+	defer ReloadTLS(nil)
+	assert.Equal(t, ring.loadTLS().ServerName, "global", "cluster falls back to the reloaded tls config")
+
+	ring.SetTLS(&tls.Config{ServerName: "cluster"})
+	assert.Equal(t, ring.loadTLS().ServerName, "cluster", "cluster tls config")
+
+}
+
+func TestConnectionCompressor(t *testing.T) {
+
+	node := func(dataCenter string) *pb.ClusterNode {
+		return &pb.ClusterNode{StoreResource: &pb.StoreResource{DataCenter: dataCenter}}
+	}
+
+	assert.Equal(t, ConnectionCompressor("", node("dc2")), "", "no local data center")
+	assert.Equal(t, ConnectionCompressor("dc1", node("dc1")), "", "same data center")
+	assert.Equal(t, ConnectionCompressor("dc1", node("")), "", "unknown data center")
+	assert.Equal(t, ConnectionCompressor("dc1", node("dc2")), "gzip", "remote data center")
+
+	cluster := NewCluster("ks1", 1, 1)
+	cluster.SetLocalDataCenter("dc1")
+	assert.Equal(t, cluster.dialSettings().localDataCenter, "dc1", "local data center of the cluster")
+	assert.Equal(t, cluster.SetNextCluster(2, 1).dialSettings().localDataCenter, "dc1", "local data center of the next cluster")
+	assert.Equal(t, loadDialSettings().localDataCenter, "", "no local data center without a cluster")
+
+	cluster.SetNode(&pb.StoreResource{DataCenter: "dc2"}, &pb.ShardInfo{ShardId: 0})
+	err := cluster.WithConnection("compressed conn", 0, func(node *pb.ClusterNode, conn *grpc.ClientConn) error {
+		return nil
+	})
+	assert.Equal(t, err, nil, "dial compressed connection")
+
+}
+
+func TestConnectionPool(t *testing.T) {
+
+	listener, err := net.Listen("tcp", "localhost:0")
+	assert.Equal(t, err, nil, "listen")
+	server := grpc.NewServer()
+	go server.Serve(listener)
+	defer server.Stop()
+	defer CloseAllConnections()
+
+	address := listener.Addr().String()
+	nodes := VastoNodes([]*pb.ClusterNode{{
+		StoreResource: &pb.StoreResource{
+			AdminAddress: address,
+		},
+	}})
+
+	var firstConn *grpc.ClientConn
+	nodes.WithConnection("pooled conn", 0, func(node *pb.ClusterNode, conn *grpc.ClientConn) error {
+		firstConn = conn
+		return nil
+	})
+	key := newPoolKey(address, loadDialSettings())
+	assert.Equal(t, connectionPool.idleCount(key), 1, "connection returned to the pool")
+
+	nodes.WithConnection("pooled conn", 0, func(node *pb.ClusterNode, conn *grpc.ClientConn) error {
+		assert.Equal(t, conn == firstConn, true, "reuse pooled connection")
+		return nil
+	})
+
+	// not reused with a different TLS config
+	tlsKey := newPoolKey(address, dialSettings{tlsConfig: &tls.Config{}})
+	conn, err := connectionPool.checkout(tlsKey, func() (*grpc.ClientConn, error) {
+		return nil, errors.New("dial with tls")
+	})
+	assert.Equal(t, conn == nil && err != nil, true, "dial for a different tls config")
+	assert.Equal(t, connectionPool.idleCount(key), 1, "pooled connection kept for its settings")
+
+	// the idle connections dialed with the replaced config are closed
+	ReloadTLS(nil)
+	assert.Equal(t, connectionPool.idleCount(key), 0, "idle connections with the replaced tls config closed")
+
+	nodes.WithConnection("pooled conn", 0, func(node *pb.ClusterNode, conn *grpc.ClientConn) error {
+		return nil
+	})
+	CloseAllConnections()
+	assert.Equal(t, connectionPool.idleCount(key), 0, "pool closed")
+
+}
+
+func TestWithConnectionContextTimeout(t *testing.T) {
+
+	listener, err := net.Listen("tcp", "localhost:0")
+	assert.Equal(t, err, nil, "listen")
+	address := listener.Addr().String()
+	listener.Close()
+
+	nodes := VastoNodes([]*pb.ClusterNode{{
+		StoreResource: &pb.StoreResource{
+			AdminAddress: address,
+		},
+	}})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	called := false
+	err = nodes.WithConnectionContext(ctx, "unreachable", 0, func(ctx context.Context, node *pb.ClusterNode, conn *grpc.ClientConn) error {
+		called = true
+		return nil
+	})
+	assert.Equal(t, called, false, "fn not called")
+	assert.Equal(t, err != nil, true, "dial timeout")
+	assert.Equal(t, strings.Contains(err.Error(), "server 0 at "+address), true, "error names server and address")
+
+}
+
+func TestCallObserver(t *testing.T) {
+
+	type call struct {
+		name, adminAddress string
+		err                error
+	}
+	var calls []call
+	SetCallObserver(func(name string, adminAddress string, duration time.Duration, err error) {
+		calls = append(calls, call{name, adminAddress, err})
+	})
+	defer SetCallObserver(nil)
+
+	ring3 := createRing(3)
+	callErr := errors.New("call failed")
+	ring3.WithConnection("observed ok", 2, func(node *pb.ClusterNode, conn *grpc.ClientConn) error {
+		return nil
+	})
+	err := ring3.WithConnection("observed error", 1, func(node *pb.ClusterNode, conn *grpc.ClientConn) error {
+		return callErr
+	})
+	assert.Equal(t, err, callErr, "call error")
+
+	assert.Equal(t, calls, []call{
+		{"ks1/observed ok", "localhost:8002", nil},
+		{"ks1/observed error", "localhost:8001", callErr},
+	}, "observed calls")
+
+	SetCallObserver(nil)
+	ring3.WithConnection("not observed", 0, func(node *pb.ClusterNode, conn *grpc.ClientConn) error {
+		return nil
+	})
+	assert.Equal(t, len(calls), 2, "stopped observing")
+
+}
@@ -0,0 +1,49 @@
+package topology
+
+import (
+	"sync"
+
+	"github.com/chrislusf/vasto/pb"
+	"google.golang.org/grpc"
+)
+
+// fanOutConcurrency bounds the connections dialed at the same time by WithConnectionToAll.
+const fanOutConcurrency = 16
+
+// WithConnectionToAll dials all the servers of the cluster nodes concurrently, at most fanOutConcurrency at a time,
+// and calls fn on each connection.
+// The errors are indexed by the server id, with nil for the servers succeeded, e.g., errs[2] is the error of server 2.
+// A missing server, i.e., a nil node, has a *ServerNotFoundError.
+func (nodes VastoNodes) WithConnectionToAll(name string, fn func(serverId int, node *pb.ClusterNode, conn *grpc.ClientConn) error) (errs []error) {
+
+	errs = make([]error, len(nodes))
+
+	serverIds := make(chan int)
+	var wg sync.WaitGroup
+	for i := 0; i < fanOutConcurrency && i < len(nodes); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for serverId := range serverIds {
+				errs[serverId] = nodes.withConnectionToServer(name, serverId, fn)
+			}
+		}()
+	}
+
+	for serverId := range nodes {
+		serverIds <- serverId
+	}
+	close(serverIds)
+	wg.Wait()
+
+	return errs
+}
+
+func (nodes VastoNodes) withConnectionToServer(name string, serverId int, fn func(serverId int, node *pb.ClusterNode, conn *grpc.ClientConn) error) error {
+	if nodes[serverId] == nil {
+		return newConnectionLogger(name, nodes.keyspace(), serverId, nil).serverNotFoundf("server %d not found", serverId)
+	}
+	return nodes.WithConnection(name, serverId, func(node *pb.ClusterNode, conn *grpc.ClientConn) error {
+		return fn(serverId, node, conn)
+	})
+}
@@ -0,0 +1,63 @@
+package topology
+
+import (
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/chrislusf/vasto/pb"
+	"github.com/magiconair/properties/assert"
+	"google.golang.org/grpc"
+)
+
+func TestWithConnectionToAll(t *testing.T) {
+
+	nodes := make([]*pb.ClusterNode, 40)
+	for i := range nodes {
+		if i == 5 {
+			continue
+		}
+		nodes[i] = &pb.ClusterNode{
+			StoreResource: &pb.StoreResource{
+				AdminAddress: fmt.Sprint("localhost:", 8000+i),
+			},
+			ShardInfo: &pb.ShardInfo{ServerId: uint32(i)},
+		}
+	}
+
+	var running, maxRunning int32
+	errs := VastoNodes(nodes).WithConnectionToAll("fan out test", func(serverId int, node *pb.ClusterNode, conn *grpc.ClientConn) error {
+		n := atomic.AddInt32(&running, 1)
+		defer atomic.AddInt32(&running, -1)
+		for {
+			m := atomic.LoadInt32(&maxRunning)
+			if n <= m || atomic.CompareAndSwapInt32(&maxRunning, m, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		if int(node.ShardInfo.ServerId) != serverId {
+			return fmt.Errorf("node of server %d", node.ShardInfo.ServerId)
+		}
+		if serverId == 3 {
+			return fmt.Errorf("failed on purpose")
+		}
+		return nil
+	})
+
+	assert.Equal(t, len(errs), 40, "one error for each server")
+	for serverId, err := range errs {
+		switch serverId {
+		case 3:
+			assert.Equal(t, err.Error(), "failed on purpose", "error of server 3")
+		case 5:
+			assert.Equal(t, err != nil, true, "error of missing server 5")
+		default:
+			assert.Equal(t, err, nil, fmt.Sprintf("error of server %d", serverId))
+		}
+	}
+	assert.Equal(t, maxRunning > 1, true, "concurrent")
+	assert.Equal(t, maxRunning <= fanOutConcurrency, true, "bounded concurrency")
+
+}
@@ -0,0 +1,95 @@
+package topology
+
+import (
+	"fmt"
+
+	"github.com/chrislusf/glog"
+	"github.com/chrislusf/vasto/pb"
+)
+
+// connectionLogger logs and reports the errors of a connection to a server with the same fields,
+// so that the logs and the returned errors can be correlated to the server.
+type connectionLogger struct {
+	name         string
+	keyspace     string
+	dataCenter   string
+	serverId     int
+	adminAddress string
+}
+
+// newConnectionLogger takes the fields from the node if it is not nil, and falls back to the keyspace.
+func newConnectionLogger(name string, keyspace string, serverId int, node *pb.ClusterNode) connectionLogger {
+	l := connectionLogger{
+		name:     name,
+		keyspace: keyspace,
+		serverId: serverId,
+	}
+	if node == nil {
+		return l
+	}
+	if keyspaceName := node.GetShardInfo().GetKeyspaceName(); keyspaceName != "" {
+		l.keyspace = keyspaceName
+	}
+	l.dataCenter = node.GetDataCenter()
+	l.adminAddress = node.GetStoreResource().GetAdminAddress()
+	return l
+}
+
+// qualifiedName prefixes the name with the keyspace, as "keyspace/name",
+// so that the connections of different keyspaces in the same process can be told apart.
+func (l connectionLogger) qualifiedName() string {
+	if l.keyspace == "" {
+		return l.name
+	}
+	return l.keyspace + "/" + l.name
+}
+
+// String formats the fields as "keyspace/name dataCenter=... serverId=... adminAddress=...", skipping the empty ones.
+func (l connectionLogger) String() string {
+	s := l.qualifiedName()
+	if l.dataCenter != "" {
+		s += " dataCenter=" + l.dataCenter
+	}
+	s += fmt.Sprintf(" serverId=%d", l.serverId)
+	if l.adminAddress != "" {
+		s += " adminAddress=" + l.adminAddress
+	}
+	return s
+}
+
+// errorf returns an error prefixed with the fields.
+func (l connectionLogger) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("%v: %s", l, fmt.Sprintf(format, args...))
+}
+
+// ServerNotFoundError is returned by WithConnection when the server is not in the cluster or the cluster nodes,
+// e.g., for the caller to refresh the topology instead of retrying the same server.
+// It has the same message as the other connection errors, prefixed with the connection fields.
+type ServerNotFoundError struct {
+	ServerId int
+	message  string
+}
+
+func (e *ServerNotFoundError) Error() string {
+	return e.message
+}
+
+// serverNotFoundf returns a ServerNotFoundError of the server of the logger, with the message prefixed with the fields.
+func (l connectionLogger) serverNotFoundf(format string, args ...interface{}) error {
+	return &ServerNotFoundError{
+		ServerId: l.serverId,
+		message:  l.errorf(format, args...).Error(),
+	}
+}
+
+// logErrorf logs an error prefixed with the fields.
+func (l connectionLogger) logErrorf(format string, args ...interface{}) {
+	glog.Errorf("%v: %s", l, fmt.Sprintf(format, args...))
+}
+
+// logInfof logs at the verbosity level, prefixed with the fields.
+func (l connectionLogger) logInfof(level glog.Level, format string, args ...interface{}) {
+	if glog.V(level) {
+		glog.Infof("%v: %s", l, fmt.Sprintf(format, args...))
+	}
+}
@@ -0,0 +1,49 @@
+package topology
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/chrislusf/vasto/pb"
+	"github.com/magiconair/properties/assert"
+	"google.golang.org/grpc"
+)
+
+func TestConnectionLogger(t *testing.T) {
+
+	node := &pb.ClusterNode{
+		StoreResource: &pb.StoreResource{AdminAddress: "localhost:8002", DataCenter: "dc1"},
+		ShardInfo:     &pb.ShardInfo{KeyspaceName: "ks2", ServerId: 2},
+	}
+	assert.Equal(t, newConnectionLogger("copy", "ks1", 2, node).String(),
+		"ks2/copy dataCenter=dc1 serverId=2 adminAddress=localhost:8002", "fields from node")
+	assert.Equal(t, newConnectionLogger("copy", "ks1", 3, nil).String(),
+		"ks1/copy serverId=3", "fields without node")
+	assert.Equal(t, newConnectionLogger("copy", "", 3, nil).String(),
+		"copy serverId=3", "fields without keyspace")
+
+	ring0 := createRing(0)
+	err := ring0.WithConnection("missing", 2, nil)
+	assert.Equal(t, err.Error(), "ks1/missing serverId=2: server 2 not found", "cluster error")
+	var notFound *ServerNotFoundError
+	assert.Equal(t, errors.As(err, &notFound), true, "cluster error type")
+	assert.Equal(t, notFound.ServerId, 2, "cluster error server id")
+
+	nodes := VastoNodes([]*pb.ClusterNode{node, nil})
+	err = nodes.WithConnection("nil node", 1, func(node *pb.ClusterNode, conn *grpc.ClientConn) error {
+		return nil
+	})
+	assert.Equal(t, err.Error(), "ks2/nil node serverId=1: server 1 is missing", "nodes error")
+	assert.Equal(t, errors.As(err, &notFound), true, "nodes error type")
+	assert.Equal(t, notFound.ServerId, 1, "nodes error server id")
+
+	err = nodes.WithConnection("out of range", 5, nil)
+	assert.Equal(t, errors.As(err, &notFound), true, "out of range error type")
+	assert.Equal(t, notFound.ServerId, 5, "out of range error server id")
+
+	err = createRing(3).WithConnection("dial", 1, func(node *pb.ClusterNode, conn *grpc.ClientConn) error {
+		return errors.New("failed")
+	})
+	assert.Equal(t, errors.As(err, &notFound), false, "other errors")
+
+}
@@ -0,0 +1,103 @@
+package topology
+
+import (
+	"crypto/tls"
+	"math"
+	"sync/atomic"
+
+	"google.golang.org/grpc"
+)
+
+const (
+	// DefaultMaxRecvMsgSize is the gRPC default max size of a received message.
+	DefaultMaxRecvMsgSize = 4 * 1024 * 1024
+	// DefaultMaxSendMsgSize is the gRPC default max size of a sent message.
+	DefaultMaxSendMsgSize = math.MaxInt32
+)
+
+// MessageSizeLimit limits the sizes of the gRPC messages on the connections to the servers,
+// e.g., raised for large values or bulk copying of shard data.
+// A field not greater than 0 uses the gRPC default, DefaultMaxRecvMsgSize or DefaultMaxSendMsgSize.
+type MessageSizeLimit struct {
+	MaxRecvMsgSize int
+	MaxSendMsgSize int
+}
+
+var currentMessageSizeLimit atomic.Value
+
+// SetMessageSizeLimit sets the message size limit for all the connections to the servers.
+// Only new connections use the new limit, and the idle pooled connections dialed with the old limit are closed.
+func SetMessageSizeLimit(limit MessageSizeLimit) {
+	old := loadMessageSizeLimit()
+	currentMessageSizeLimit.Store(limit)
+	if old != limit {
+		closeIdleWithMessageSizeLimit(old)
+	}
+}
+
+// closeIdleWithMessageSizeLimit closes the idle pooled connections dialed with the replaced limit.
+func closeIdleWithMessageSizeLimit(old MessageSizeLimit) {
+	connectionPool.closeIdleIf(func(key poolKey) bool {
+		return key.messageSizeLimit == old
+	})
+}
+
+func loadMessageSizeLimit() MessageSizeLimit {
+	limit, _ := currentMessageSizeLimit.Load().(MessageSizeLimit)
+	return limit
+}
+
+// SetMessageSizeLimit sets the message size limit to dial the servers of this cluster, instead of the one set by SetMessageSizeLimit.
+// A zero limit falls back to the one set by SetMessageSizeLimit.
+// The idle pooled connections dialed with the replaced limit of this cluster are closed.
+func (cluster *Cluster) SetMessageSizeLimit(limit MessageSizeLimit) {
+	cluster.lock.Lock()
+	old := cluster.messageSizeLimit
+	cluster.messageSizeLimit = limit
+	cluster.lock.Unlock()
+	if old != (MessageSizeLimit{}) && old != limit {
+		closeIdleWithMessageSizeLimit(old)
+	}
+}
+
+func (cluster *Cluster) loadMessageSizeLimit() MessageSizeLimit {
+	cluster.lock.RLock()
+	defer cluster.lock.RUnlock()
+	if cluster.messageSizeLimit != (MessageSizeLimit{}) {
+		return cluster.messageSizeLimit
+	}
+	return loadMessageSizeLimit()
+}
+
+func (limit MessageSizeLimit) callOptions() []grpc.CallOption {
+	recvSize, sendSize := limit.MaxRecvMsgSize, limit.MaxSendMsgSize
+	if recvSize <= 0 {
+		recvSize = DefaultMaxRecvMsgSize
+	}
+	if sendSize <= 0 {
+		sendSize = DefaultMaxSendMsgSize
+	}
+	return []grpc.CallOption{grpc.MaxCallRecvMsgSize(recvSize), grpc.MaxCallSendMsgSize(sendSize)}
+}
+
+// dialSettings are the settings to dial a server.
+type dialSettings struct {
+	tlsConfig        *tls.Config
+	messageSizeLimit MessageSizeLimit
+	localDataCenter  string
+}
+
+func (cluster *Cluster) dialSettings() dialSettings {
+	return dialSettings{
+		tlsConfig:        cluster.loadTLS(),
+		messageSizeLimit: cluster.loadMessageSizeLimit(),
+		localDataCenter:  cluster.loadLocalDataCenter(),
+	}
+}
+
+func loadDialSettings() dialSettings {
+	return dialSettings{
+		tlsConfig:        loadTLS(),
+		messageSizeLimit: loadMessageSizeLimit(),
+	}
+}
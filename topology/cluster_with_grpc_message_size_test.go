@@ -0,0 +1,46 @@
+package topology
+
+import (
+	"testing"
+
+	"github.com/chrislusf/vasto/pb"
+	"github.com/magiconair/properties/assert"
+	"google.golang.org/grpc"
+)
+
+func TestMessageSizeLimit(t *testing.T) {
+
+	defer SetMessageSizeLimit(MessageSizeLimit{})
+
+	assert.Equal(t, MessageSizeLimit{}.callOptions(), []grpc.CallOption{
+		grpc.MaxCallRecvMsgSize(DefaultMaxRecvMsgSize),
+		grpc.MaxCallSendMsgSize(DefaultMaxSendMsgSize),
+	}, "grpc defaults")
+
+	cluster := createRing(3)
+	SetMessageSizeLimit(MessageSizeLimit{MaxRecvMsgSize: 64 << 20})
+	assert.Equal(t, cluster.dialSettings().messageSizeLimit, MessageSizeLimit{MaxRecvMsgSize: 64 << 20}, "global limit")
+	assert.Equal(t, cluster.dialSettings().messageSizeLimit.callOptions(), []grpc.CallOption{
+		grpc.MaxCallRecvMsgSize(64 << 20),
+		grpc.MaxCallSendMsgSize(DefaultMaxSendMsgSize),
+	}, "default send limit")
+
+	cluster.SetMessageSizeLimit(MessageSizeLimit{MaxRecvMsgSize: 128 << 20, MaxSendMsgSize: 128 << 20})
+	assert.Equal(t, cluster.dialSettings().messageSizeLimit.MaxRecvMsgSize, 128<<20, "cluster limit")
+	assert.Equal(t, cluster.Clone().dialSettings().messageSizeLimit.MaxSendMsgSize, 128<<20, "cloned cluster limit")
+
+	err := cluster.WithConnection("large messages", 1, func(node *pb.ClusterNode, conn *grpc.ClientConn) error {
+		return nil
+	})
+	assert.Equal(t, err, nil, "dial with message size limit")
+	node, _ := cluster.GetNode(1, 0)
+	key := newPoolKey(node.StoreResource.AdminAddress, cluster.dialSettings())
+	assert.Equal(t, connectionPool.idleCount(key), 1, "pooled with the cluster limit")
+	globalKey := newPoolKey(node.StoreResource.AdminAddress, loadDialSettings())
+	assert.Equal(t, connectionPool.idleCount(globalKey), 0, "not pooled with the global limit")
+
+	cluster.SetMessageSizeLimit(MessageSizeLimit{})
+	assert.Equal(t, cluster.dialSettings().messageSizeLimit.MaxRecvMsgSize, 64<<20, "fall back to global limit")
+	assert.Equal(t, connectionPool.idleCount(key), 0, "idle connections with the replaced limit closed")
+
+}
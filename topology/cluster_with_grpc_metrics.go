@@ -0,0 +1,36 @@
+package topology
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// CallObserver receives the duration and the error of each call made with a connection to a server,
+// e.g., to spot one slow peer. The name is the one passed to WithConnection, prefixed with the keyspace as "keyspace/name",
+// and the adminAddress is the address of the server.
+// Each attempt is observed separately, and a failed dial is not observed since fn is not called.
+type CallObserver func(name string, adminAddress string, duration time.Duration, err error)
+
+type callObserverHolder struct {
+	observer CallObserver
+}
+
+var currentCallObserver atomic.Value
+
+// SetCallObserver sets the observer of the calls on all the connections to the servers.
+// A nil observer stops observing.
+func SetCallObserver(observer CallObserver) {
+	currentCallObserver.Store(callObserverHolder{observer: observer})
+}
+
+func loadCallObserver() CallObserver {
+	holder, _ := currentCallObserver.Load().(callObserverHolder)
+	return holder.observer
+}
+
+// observeCall reports the call since the start time to the observer, if any.
+func observeCall(name string, adminAddress string, startTime time.Time, err error) {
+	if observer := loadCallObserver(); observer != nil {
+		observer(name, adminAddress, time.Since(startTime), err)
+	}
+}
@@ -0,0 +1,119 @@
+package topology
+
+import (
+	"crypto/tls"
+	"sync"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+)
+
+const defaultMaxIdleConnectionsPerAddress = 4
+
+// grpcConnectionPool keeps idle grpc connections by the admin address and the dial settings, to avoid dialing for every call.
+// A connection is only reused with the same settings it was dialed with, e.g., not after the TLS config,
+// the message size limit, or the local data center changes.
+type grpcConnectionPool struct {
+	sync.Mutex
+	idle              map[poolKey][]*grpc.ClientConn
+	maxIdlePerAddress int
+}
+
+// poolKey is the admin address and the dial settings of a pooled connection.
+type poolKey struct {
+	address          string
+	tlsConfig        *tls.Config
+	messageSizeLimit MessageSizeLimit
+	localDataCenter  string
+}
+
+func newPoolKey(address string, settings dialSettings) poolKey {
+	return poolKey{
+		address:          address,
+		tlsConfig:        settings.tlsConfig,
+		messageSizeLimit: settings.messageSizeLimit,
+		localDataCenter:  settings.localDataCenter,
+	}
+}
+
+var connectionPool = &grpcConnectionPool{
+	idle:              make(map[poolKey][]*grpc.ClientConn),
+	maxIdlePerAddress: defaultMaxIdleConnectionsPerAddress,
+}
+
+// SetMaxIdleConnectionsPerAddress caps the idle connections kept for each store admin address.
+// 0 disables pooling, so every call dials a new connection.
+func SetMaxIdleConnectionsPerAddress(maxIdle int) {
+	connectionPool.Lock()
+	connectionPool.maxIdlePerAddress = maxIdle
+	connectionPool.Unlock()
+}
+
+// CloseAllConnections closes all the idle pooled connections, e.g., when shutting down.
+func CloseAllConnections() {
+	connectionPool.closeAll()
+}
+
+func isBroken(conn *grpc.ClientConn) bool {
+	state := conn.GetState()
+	return state == connectivity.TransientFailure || state == connectivity.Shutdown
+}
+
+// checkout returns an idle healthy connection to the address with the same dial settings, or dials a new one.
+func (p *grpcConnectionPool) checkout(key poolKey, dial func() (*grpc.ClientConn, error)) (*grpc.ClientConn, error) {
+	p.Lock()
+	for len(p.idle[key]) > 0 {
+		conns := p.idle[key]
+		conn := conns[len(conns)-1]
+		p.idle[key] = conns[:len(conns)-1]
+		if isBroken(conn) {
+			conn.Close()
+			continue
+		}
+		p.Unlock()
+		return conn, nil
+	}
+	p.Unlock()
+
+	return dial()
+}
+
+// checkin keeps the connection for later calls, unless it is broken or there are enough idle connections.
+func (p *grpcConnectionPool) checkin(key poolKey, conn *grpc.ClientConn) {
+	p.Lock()
+	defer p.Unlock()
+
+	if isBroken(conn) || len(p.idle[key]) >= p.maxIdlePerAddress {
+		conn.Close()
+		return
+	}
+	p.idle[key] = append(p.idle[key], conn)
+}
+
+func (p *grpcConnectionPool) idleCount(key poolKey) int {
+	p.Lock()
+	defer p.Unlock()
+	return len(p.idle[key])
+}
+
+func (p *grpcConnectionPool) closeAll() {
+	p.closeIdleIf(func(key poolKey) bool {
+		return true
+	})
+}
+
+// closeIdleIf closes the idle connections with the matching keys, e.g., dialed with a replaced TLS config.
+func (p *grpcConnectionPool) closeIdleIf(match func(key poolKey) bool) {
+	p.Lock()
+	defer p.Unlock()
+
+	for key, conns := range p.idle {
+		if !match(key) {
+			continue
+		}
+		for _, conn := range conns {
+			conn.Close()
+		}
+		delete(p.idle, key)
+	}
+}
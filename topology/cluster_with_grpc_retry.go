@@ -0,0 +1,61 @@
+package topology
+
+import (
+	"math/rand"
+	"sync/atomic"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// RetryPolicy retries the calls to a server when the connection fails,
+// e.g., when the server is briefly restarting.
+// Only dial failures and UNAVAILABLE errors are retried, not the other errors returned by the calls.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts. 0 or 1 means no retries.
+	MaxAttempts int
+	// BaseDelay is the delay before the first retry, doubled for each following retry.
+	BaseDelay time.Duration
+	// MaxDelay caps the delay between retries, or defaultMaxRetryDelay if not positive.
+	MaxDelay time.Duration
+}
+
+const defaultMaxRetryDelay = time.Minute
+
+var currentRetryPolicy atomic.Value
+
+// SetRetryPolicy sets the retry policy for all the connections to the servers.
+// The default policy does not retry.
+func SetRetryPolicy(policy RetryPolicy) {
+	currentRetryPolicy.Store(policy)
+}
+
+func loadRetryPolicy() RetryPolicy {
+	policy, _ := currentRetryPolicy.Load().(RetryPolicy)
+	return policy
+}
+
+// backoff returns the exponential delay after the attempt, with jitter to spread the retries.
+func (policy RetryPolicy) backoff(attempt int) time.Duration {
+	maxDelay := policy.MaxDelay
+	if maxDelay <= 0 {
+		maxDelay = defaultMaxRetryDelay
+	}
+	delay := policy.BaseDelay
+	for i := 1; i < attempt && delay < maxDelay; i++ {
+		delay *= 2
+	}
+	if delay > maxDelay {
+		delay = maxDelay
+	}
+	if delay <= 0 {
+		return 0
+	}
+	// keep at least half of the delay
+	return delay/2 + time.Duration(rand.Int63n(int64(delay/2)+1))
+}
+
+func isUnavailable(err error) bool {
+	return status.Code(err) == codes.Unavailable
+}
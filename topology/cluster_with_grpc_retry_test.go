@@ -0,0 +1,64 @@
+package topology
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/chrislusf/vasto/pb"
+	"github.com/magiconair/properties/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestRetryPolicyBackoff(t *testing.T) {
+
+	policy := RetryPolicy{MaxAttempts: 5, BaseDelay: 100 * time.Millisecond, MaxDelay: 300 * time.Millisecond}
+
+	for attempt, want := range []time.Duration{100, 200, 300, 300} {
+		want *= time.Millisecond
+		delay := policy.backoff(attempt + 1)
+		assert.Equal(t, delay >= want/2 && delay <= want, true, "backoff within jitter range")
+	}
+
+	assert.Equal(t, RetryPolicy{}.backoff(3), time.Duration(0), "no delay by default")
+
+}
+
+func TestWithConnectionRetry(t *testing.T) {
+
+	nodes := VastoNodes([]*pb.ClusterNode{{
+		StoreResource: &pb.StoreResource{
+			AdminAddress: "localhost:7007",
+		},
+	}})
+
+	attempts := 0
+	unavailable := func(ctx context.Context, node *pb.ClusterNode, conn *grpc.ClientConn) error {
+		attempts++
+		return status.Error(codes.Unavailable, "restarting")
+	}
+
+	err := nodes.WithConnectionContext(context.Background(), "no retry", 0, unavailable)
+	assert.Equal(t, err != nil && attempts == 1, true, "no retry by default")
+
+	SetRetryPolicy(RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond})
+	defer SetRetryPolicy(RetryPolicy{})
+
+	attempts = 0
+	err = nodes.WithConnectionContext(context.Background(), "retry", 0, unavailable)
+	assert.Equal(t, attempts, 3, "retry unavailable")
+	assert.Equal(t, strings.Contains(err.Error(), "after 3 attempts"), true, "report attempts")
+
+	attempts = 0
+	err = nodes.WithConnectionContext(context.Background(), "application error", 0, func(ctx context.Context, node *pb.ClusterNode, conn *grpc.ClientConn) error {
+		attempts++
+		return errors.New("not found")
+	})
+	assert.Equal(t, err.Error(), "not found", "application error")
+	assert.Equal(t, attempts, 1, "do not retry application errors")
+
+}
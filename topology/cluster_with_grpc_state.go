@@ -0,0 +1,32 @@
+package topology
+
+import (
+	"context"
+
+	"github.com/chrislusf/vasto/pb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+)
+
+// ConnState reports the gRPC connectivity state of the connection to the server by serverId,
+// reusing a pooled connection or dialing a new one, without calling any RPC.
+// A newly dialed connection may still be CONNECTING, so a health probe should check again later
+// before treating the server as unreachable. The node health is not changed.
+// It returns a *ServerNotFoundError if the server is not in the cluster.
+func (cluster *Cluster) ConnState(serverId int) (connectivity.State, error) {
+
+	node, ok := cluster.GetNode(serverId, 0)
+
+	log := newConnectionLogger("connection state", cluster.keyspace, serverId, node)
+	if !ok {
+		return connectivity.Shutdown, log.serverNotFoundf("server %d not found", serverId)
+	}
+
+	state := connectivity.Shutdown
+	err := doWithConnect(context.Background(), log, node, cluster.dialSettings(), nil,
+		func(ctx context.Context, node *pb.ClusterNode, conn *grpc.ClientConn) error {
+			state = conn.GetState()
+			return nil
+		})
+	return state, err
+}
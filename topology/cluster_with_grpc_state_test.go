@@ -0,0 +1,47 @@
+package topology
+
+import (
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/chrislusf/vasto/pb"
+	"github.com/magiconair/properties/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+)
+
+func TestConnState(t *testing.T) {
+
+	listener, err := net.Listen("tcp", "localhost:0")
+	assert.Equal(t, err, nil, "listen")
+	server := grpc.NewServer()
+	go server.Serve(listener)
+	defer server.Stop()
+	defer CloseAllConnections()
+
+	cluster := NewCluster("ks1", 1, 1)
+	cluster.SetShard(&pb.StoreResource{
+		Address:      "localhost:7000",
+		AdminAddress: listener.Addr().String(),
+	}, &pb.ShardInfo{
+		KeyspaceName:      "ks1",
+		ClusterSize:       1,
+		ReplicationFactor: 1,
+	})
+
+	state, err := cluster.ConnState(0)
+	for deadline := time.Now().Add(5 * time.Second); err == nil && state != connectivity.Ready && time.Now().Before(deadline); {
+		time.Sleep(10 * time.Millisecond)
+		state, err = cluster.ConnState(0)
+	}
+	assert.Equal(t, err, nil, "connection state")
+	assert.Equal(t, state, connectivity.Ready, "pooled connection ready")
+
+	_, err = cluster.ConnState(3)
+	var notFound *ServerNotFoundError
+	assert.Equal(t, errors.As(err, &notFound), true, "server not in the cluster")
+	assert.Equal(t, notFound.ServerId, 3, "missing server id")
+
+}
@@ -0,0 +1,123 @@
+package topology
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+	"sync/atomic"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+type tlsConfigHolder struct {
+	config *tls.Config
+}
+
+var currentTLSConfig atomic.Value
+
+// ReloadTLS sets the TLS config used to dial the store admin addresses.
+// It can be called at any time, e.g., when the certificates are rotated.
+// Only new connections use the new config. Connections in use continue until closed,
+// and the idle pooled connections dialed with the old config are closed.
+// A nil config switches new connections back to insecure.
+func ReloadTLS(config *tls.Config) {
+	if config != nil {
+		config = config.Clone()
+	}
+	old := loadTLS()
+	currentTLSConfig.Store(tlsConfigHolder{config: config})
+	closeIdleWithTLS(old)
+}
+
+// closeIdleWithTLS closes the idle pooled connections dialed with the replaced TLS config.
+func closeIdleWithTLS(old *tls.Config) {
+	connectionPool.closeIdleIf(func(key poolKey) bool {
+		return key.tlsConfig == old
+	})
+}
+
+func loadTLS() *tls.Config {
+	holder, ok := currentTLSConfig.Load().(tlsConfigHolder)
+	if !ok {
+		return nil
+	}
+	return holder.config
+}
+
+// TLSOption locates the certificates to dial the store admin addresses.
+// CaFile verifies the stores, CertFile and KeyFile are the client certificate for mutual TLS,
+// and ServerName overrides the server name to verify, e.g., when dialing by ip.
+type TLSOption struct {
+	CaFile     string
+	CertFile   string
+	KeyFile    string
+	ServerName string
+}
+
+// TLSConfig loads the certificates into a TLS config.
+// It returns nil if no option is set, so that the connections stay insecure.
+func (option TLSOption) TLSConfig() (*tls.Config, error) {
+
+	if option == (TLSOption{}) {
+		return nil, nil
+	}
+
+	config := &tls.Config{
+		ServerName: option.ServerName,
+	}
+
+	if option.CaFile != "" {
+		caCert, err := ioutil.ReadFile(option.CaFile)
+		if err != nil {
+			return nil, fmt.Errorf("read ca file %s: %v", option.CaFile, err)
+		}
+		config.RootCAs = x509.NewCertPool()
+		if !config.RootCAs.AppendCertsFromPEM(caCert) {
+			return nil, fmt.Errorf("no certificates found in ca file %s", option.CaFile)
+		}
+	}
+
+	if option.CertFile != "" || option.KeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(option.CertFile, option.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("load client cert %s and key %s: %v", option.CertFile, option.KeyFile, err)
+		}
+		config.Certificates = []tls.Certificate{cert}
+	}
+
+	return config, nil
+}
+
+// SetTLS sets the TLS config to dial the servers of this cluster, instead of the one set by ReloadTLS.
+// A nil config falls back to the one set by ReloadTLS.
+// The idle pooled connections dialed with the replaced config of this cluster are closed.
+func (cluster *Cluster) SetTLS(config *tls.Config) {
+	if config != nil {
+		config = config.Clone()
+	}
+	cluster.lock.Lock()
+	old := cluster.tlsConfig
+	cluster.tlsConfig = config
+	cluster.lock.Unlock()
+	if old != nil {
+		closeIdleWithTLS(old)
+	}
+}
+
+func (cluster *Cluster) loadTLS() *tls.Config {
+	cluster.lock.RLock()
+	defer cluster.lock.RUnlock()
+	if cluster.tlsConfig != nil {
+		return cluster.tlsConfig
+	}
+	return loadTLS()
+}
+
+func grpcDialOption(config *tls.Config) grpc.DialOption {
+	if config == nil {
+		return grpc.WithInsecure()
+	}
+	return grpc.WithTransportCredentials(credentials.NewTLS(config))
+}
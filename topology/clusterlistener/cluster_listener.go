@@ -29,6 +29,8 @@ type ClusterListener struct {
 	connPools                 map[string]pool.Pool
 	connPoolLock              sync.Mutex
 	disableUnixSocket         bool
+	shardIdCache              *shardIdCache
+	localDataCenter           string
 }
 
 // NewClusterListener creates a cluster listener in a data center.
@@ -46,8 +48,11 @@ func NewClusterListener(clientName string) *ClusterListener {
 // This is used by store server. The keyspace should already exists in local store.
 func (clusterListener *ClusterListener) AddExistingKeyspace(keyspace string, clusterSize int, replicationFactor int) {
 	clusterListener.Lock()
-	clusterListener.clusters[keyspaceName(keyspace)] = topology.NewCluster(keyspace, clusterSize, replicationFactor)
+	cluster := topology.NewCluster(keyspace, clusterSize, replicationFactor)
+	cluster.SetLocalDataCenter(clusterListener.localDataCenter)
+	clusterListener.clusters[keyspaceName(keyspace)] = cluster
 	clusterListener.Unlock()
+	clusterListener.invalidateShardIdCache()
 }
 
 // AddNewKeyspace registers one keyspace to listen for changes. Mostly used by client APIs.
@@ -66,6 +71,7 @@ func (clusterListener *ClusterListener) RemoveKeyspace(keyspace string) {
 		clusterListener.keyspaceFollowMessageChan <- keyspaceFollowMessage{keyspace: keyspaceName(keyspace), isUnfollow: true}
 	}
 	clusterListener.Unlock()
+	clusterListener.invalidateShardIdCache()
 }
 
 // GetCluster gets the cluster of the keyspace in local data center
@@ -82,6 +88,7 @@ func (clusterListener *ClusterListener) GetOrSetCluster(keyspace string, cluster
 	t, ok := clusterListener.clusters[keyspaceName(keyspace)]
 	if !ok {
 		t = topology.NewCluster(keyspace, clusterSize, replicationFactor)
+		t.SetLocalDataCenter(clusterListener.localDataCenter)
 		clusterListener.clusters[keyspaceName(keyspace)] = t
 	}
 	if clusterSize > 0 {
@@ -91,6 +98,7 @@ func (clusterListener *ClusterListener) GetOrSetCluster(keyspace string, cluster
 		t.SetReplicationFactor(replicationFactor)
 	}
 	clusterListener.Unlock()
+	clusterListener.invalidateShardIdCache()
 	return t
 }
 
@@ -124,6 +132,17 @@ func (clusterListener *ClusterListener) SetUnixSocket(useUnixSocket bool) {
 	clusterListener.disableUnixSocket = !useUnixSocket
 }
 
+// SetLocalDataCenter sets the data center of this process on the clusters of all keyspaces, including the ones added later.
+// Connections to the stores in other data centers are compressed, see topology.Cluster.SetLocalDataCenter.
+func (clusterListener *ClusterListener) SetLocalDataCenter(dataCenter string) {
+	clusterListener.Lock()
+	defer clusterListener.Unlock()
+	clusterListener.localDataCenter = dataCenter
+	for _, cluster := range clusterListener.clusters {
+		cluster.SetLocalDataCenter(dataCenter)
+	}
+}
+
 // HasConnectedKeyspace checks whether the listener has the information of the keyspace or not.
 func (clusterListener *ClusterListener) HasConnectedKeyspace(keyspace string) bool {
 	cluster, found := clusterListener.GetCluster(keyspace)
@@ -143,6 +162,7 @@ func (clusterListener *ClusterListener) HasConnectedKeyspace(keyspace string) bo
 }
 
 func (clusterListener *ClusterListener) processClientMessage(msg *pb.ClientMessage) {
+	defer clusterListener.invalidateShardIdCache()
 	if msg.GetCluster() != nil {
 		glog.V(4).Infof("%s listener get cluster: %v", clusterListener.clientName, msg.GetCluster())
 		cluster := clusterListener.GetOrSetCluster(msg.Cluster.Keyspace, int(msg.Cluster.ExpectedClusterSize), int(msg.Cluster.ReplicationFactor))
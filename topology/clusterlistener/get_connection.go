@@ -16,6 +16,9 @@ func (clusterListener *ClusterListener) GetConnectionByShardId(keyspace string,
 	if !found {
 		return nil, fmt.Errorf("no keyspace %s", keyspace)
 	}
+	if shardId < 0 {
+		return nil, fmt.Errorf("keyspace %s has no shards yet", keyspace)
+	}
 
 	// find one shard
 	n, ok := r.GetNode(shardId, replica)
@@ -64,10 +67,6 @@ func (clusterListener *ClusterListener) GetConnectionByShardId(keyspace string,
 // GetShardId returns the shard id and partition hash based on the partition key
 func (clusterListener *ClusterListener) GetShardId(keyspace string, partitionKey []byte) (shardId int, partitionHash uint64) {
 	partitionHash = util.Hash(partitionKey)
-	r, found := clusterListener.GetCluster(keyspace)
-	if !found {
-		return -1, partitionHash
-	}
-	shardId = r.FindShardId(partitionHash)
+	shardId = clusterListener.FindShardId(keyspace, partitionHash)
 	return
 }
@@ -0,0 +1,115 @@
+package clusterlistener
+
+import (
+	"container/list"
+	"sync"
+)
+
+type shardIdCacheKey struct {
+	keyspace      keyspaceName
+	partitionHash uint64
+}
+
+type shardIdCacheEntry struct {
+	key     shardIdCacheKey
+	shardId int
+}
+
+// shardIdCache is a LRU cache from partition hash to shard id.
+// It is cleared on any topology change.
+type shardIdCache struct {
+	sync.Mutex
+	capacity   int
+	generation uint64
+	entries    map[shardIdCacheKey]*list.Element
+	lru        *list.List
+}
+
+func newShardIdCache(capacity int) *shardIdCache {
+	return &shardIdCache{
+		capacity: capacity,
+		entries:  make(map[shardIdCacheKey]*list.Element),
+		lru:      list.New(),
+	}
+}
+
+// get returns the cached shard id, and the generation to use when calling put.
+func (c *shardIdCache) get(key shardIdCacheKey) (shardId int, found bool, generation uint64) {
+	c.Lock()
+	defer c.Unlock()
+	if element, ok := c.entries[key]; ok {
+		c.lru.MoveToFront(element)
+		return element.Value.(*shardIdCacheEntry).shardId, true, c.generation
+	}
+	return -1, false, c.generation
+}
+
+// put adds the shard id, unless the cache is invalidated after the generation was read.
+func (c *shardIdCache) put(key shardIdCacheKey, shardId int, generation uint64) {
+	c.Lock()
+	defer c.Unlock()
+	if generation != c.generation {
+		return
+	}
+	if element, ok := c.entries[key]; ok {
+		element.Value.(*shardIdCacheEntry).shardId = shardId
+		c.lru.MoveToFront(element)
+		return
+	}
+	c.entries[key] = c.lru.PushFront(&shardIdCacheEntry{key: key, shardId: shardId})
+	if c.lru.Len() > c.capacity {
+		oldest := c.lru.Back()
+		c.lru.Remove(oldest)
+		delete(c.entries, oldest.Value.(*shardIdCacheEntry).key)
+	}
+}
+
+func (c *shardIdCache) invalidate() {
+	c.Lock()
+	c.generation++
+	c.entries = make(map[shardIdCacheKey]*list.Element)
+	c.lru.Init()
+	c.Unlock()
+}
+
+// EnableShardIdCache caches the shard ids of the most recently used partition hashes,
+// to skip the hashing for repeated operations on the same partitions.
+// The cache is cleared on any topology change. This should be called before the listener is used.
+func (clusterListener *ClusterListener) EnableShardIdCache(capacity int) {
+	if capacity > 0 {
+		clusterListener.shardIdCache = newShardIdCache(capacity)
+	}
+}
+
+func (clusterListener *ClusterListener) invalidateShardIdCache() {
+	if clusterListener.shardIdCache != nil {
+		clusterListener.shardIdCache.invalidate()
+	}
+}
+
+// FindShardId returns the shard id for the partition hash, or -1 if the keyspace is not found or has no shards yet.
+func (clusterListener *ClusterListener) FindShardId(keyspace string, partitionHash uint64) int {
+	cache := clusterListener.shardIdCache
+	if cache == nil {
+		r, found := clusterListener.GetCluster(keyspace)
+		if !found {
+			return -1
+		}
+		return r.FindShardId(partitionHash)
+	}
+
+	key := shardIdCacheKey{keyspaceName(keyspace), partitionHash}
+	shardId, found, generation := cache.get(key)
+	if found {
+		return shardId
+	}
+	r, found := clusterListener.GetCluster(keyspace)
+	if !found {
+		return -1
+	}
+	shardId, found = r.LookupShardId(partitionHash)
+	if found {
+		cache.put(key, shardId, generation)
+	}
+	return shardId
+}
@@ -0,0 +1,38 @@
+package clusterlistener
+
+import (
+	"testing"
+
+	"github.com/magiconair/properties/assert"
+)
+
+func TestShardIdCache(t *testing.T) {
+
+	c := newShardIdCache(2)
+
+	key1 := shardIdCacheKey{"ks1", 1}
+	key2 := shardIdCacheKey{"ks1", 2}
+	key3 := shardIdCacheKey{"ks1", 3}
+
+	_, found, generation := c.get(key1)
+	assert.Equal(t, found, false, "empty cache")
+
+	c.put(key1, 5, generation)
+	c.put(key2, 6, generation)
+	shardId, found, _ := c.get(key1)
+	assert.Equal(t, found, true, "cached key1")
+	assert.Equal(t, shardId, 5, "cached key1 shard id")
+
+	c.put(key3, 7, generation)
+	_, found, _ = c.get(key2)
+	assert.Equal(t, found, false, "evicted least recently used key2")
+
+	c.invalidate()
+	_, found, _ = c.get(key1)
+	assert.Equal(t, found, false, "invalidated key1")
+
+	c.put(key1, 8, generation)
+	_, found, _ = c.get(key1)
+	assert.Equal(t, found, false, "skip stale put after invalidation")
+
+}
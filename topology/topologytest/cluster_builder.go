@@ -0,0 +1,102 @@
+// Package topologytest builds clusters with stub servers for tests.
+package topologytest
+
+import (
+	"fmt"
+
+	"github.com/chrislusf/vasto/pb"
+	"github.com/chrislusf/vasto/topology"
+)
+
+// ClusterBuilder populates a cluster with stub servers.
+// Server i listens on localhost:7000+i, with the admin address localhost:8000+i,
+// and holds shard i and its replicas, i.e., shards i, i-1, ..., i-replicationFactor+1, wrapped around the expected size.
+type ClusterBuilder struct {
+	keyspace          string
+	serverIds         []int
+	expectedSize      int
+	replicationFactor int
+	dataCenters       map[int]string
+}
+
+// NewClusterBuilder starts a cluster of the keyspace with the replication factor of 1.
+func NewClusterBuilder(keyspace string) *ClusterBuilder {
+	return &ClusterBuilder{
+		keyspace:          keyspace,
+		replicationFactor: 1,
+		dataCenters:       make(map[int]string),
+	}
+}
+
+// WithServers sets the servers to add. By default, all the servers of the expected size are added.
+func (b *ClusterBuilder) WithServers(serverIds ...int) *ClusterBuilder {
+	b.serverIds = append(b.serverIds, serverIds...)
+	return b
+}
+
+// ExpectedSize sets the expected size. By default, it is 1 + the largest server id.
+func (b *ClusterBuilder) ExpectedSize(expectedSize int) *ClusterBuilder {
+	b.expectedSize = expectedSize
+	return b
+}
+
+// ReplicationFactor sets the replication factor.
+func (b *ClusterBuilder) ReplicationFactor(replicationFactor int) *ClusterBuilder {
+	b.replicationFactor = replicationFactor
+	return b
+}
+
+// InDataCenter puts the servers in the data center.
+func (b *ClusterBuilder) InDataCenter(dataCenter string, serverIds ...int) *ClusterBuilder {
+	for _, serverId := range serverIds {
+		b.dataCenters[serverId] = dataCenter
+	}
+	return b
+}
+
+// Build creates the cluster with the servers.
+func (b *ClusterBuilder) Build() *topology.Cluster {
+
+	expectedSize := b.expectedSize
+	if expectedSize == 0 {
+		for _, serverId := range b.serverIds {
+			if serverId+1 > expectedSize {
+				expectedSize = serverId + 1
+			}
+		}
+	}
+
+	serverIds := b.serverIds
+	if len(serverIds) == 0 {
+		for serverId := 0; serverId < expectedSize; serverId++ {
+			serverIds = append(serverIds, serverId)
+		}
+	}
+
+	cluster := topology.NewCluster(b.keyspace, expectedSize, b.replicationFactor)
+	for _, serverId := range serverIds {
+		store := StoreResource(serverId)
+		store.DataCenter = b.dataCenters[serverId]
+		for r := 0; r < b.replicationFactor && r < expectedSize; r++ {
+			shardId := (serverId - r + expectedSize) % expectedSize
+			cluster.SetShard(store, &pb.ShardInfo{
+				KeyspaceName:      b.keyspace,
+				ServerId:          uint32(serverId),
+				ShardId:           uint32(shardId),
+				ClusterSize:       uint32(expectedSize),
+				ReplicationFactor: uint32(b.replicationFactor),
+			})
+		}
+	}
+
+	return cluster
+}
+
+// StoreResource returns the stub store of the server id.
+func StoreResource(serverId int) *pb.StoreResource {
+	return &pb.StoreResource{
+		Network:      "tcp",
+		Address:      fmt.Sprint("localhost:", 7000+serverId),
+		AdminAddress: fmt.Sprint("localhost:", 8000+serverId),
+	}
+}
@@ -0,0 +1,24 @@
+package topologytest
+
+import (
+	"testing"
+
+	"github.com/magiconair/properties/assert"
+)
+
+func TestClusterBuilder(t *testing.T) {
+
+	cluster := NewClusterBuilder("ks1").ExpectedSize(3).ReplicationFactor(2).Build()
+	assert.Equal(t, cluster.String(), "[0@0,1 1@1,2 2@2,0] size 3/3 ", "all servers")
+
+	cluster = NewClusterBuilder("ks1").WithServers(0, 2).ExpectedSize(4).Build()
+	assert.Equal(t, cluster.String(), "[0@0 _ 2@2 _] size 3/4 ", "some servers")
+
+	cluster = NewClusterBuilder("ks1").WithServers(0, 1).InDataCenter("dc1", 1).Build()
+	node, _ := cluster.GetNode(1, 0)
+	assert.Equal(t, node.StoreResource.Address, "localhost:7001", "address")
+	assert.Equal(t, node.StoreResource.AdminAddress, "localhost:8001", "admin address")
+	assert.Equal(t, node.GetDataCenter(), "dc1", "data center")
+	assert.Equal(t, cluster.ExpectedSize(), 2, "expected size from servers")
+
+}
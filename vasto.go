@@ -54,6 +54,22 @@ var (
 		DiskSizeGb:        store.Flag("diskSizeGb", "disk size in GB").Default("10").Int(),
 		Tags:              store.Flag("tags", "comma separated tags").Default("").String(),
 		DisableBinLog:     store.Flag("disableBinLog", "disable binary log").Default("false").Bool(),
+		TtlCompaction:     store.Flag("ttlCompaction", "comma separated keyspaces to purge expired entries during compaction").Default("").String(),
+		BatchWindowMs:     store.Flag("batchWindowMs", "max wait in milliseconds to batch up binlog entries sent to the following shards, 0 to send right away").Default("100").Int(),
+		BatchWindows:      store.Flag("batchWindows", "comma separated keyspace:milliseconds to override batchWindowMs for the keyspace shards, e.g., a shorter one for latency-sensitive keyspaces").Default("").String(),
+		BatchWindowKb:     store.Flag("batchWindowKb", "send the binlog entries right away once this many KB are available, without waiting for the batch window").Default("64").Int(),
+		DataCenter:        store.Flag("dataCenter", "data center name, connections to stores in other data centers are compressed").Default("").String(),
+		DeadLetterAfter:   store.Flag("deadLetterAfter", "failed attempts before a followed binlog entry is moved to the dead letter log, 0 to retry forever").Default("0").Int(),
+		ShardMaxInFlight:  store.Flag("shardMaxInFlight", "max concurrent requests on one shard before replying shard busy, 0 for no limit").Default("1024").Int(),
+		TtlSweepSeconds:   store.Flag("ttlSweepSeconds", "interval in seconds to scan and delete expired entries, 0 to disable").Default("0").Int(),
+		MetricsAddress:    store.Flag("metricsAddress", "host:port to expose prometheus metrics at /metrics, empty to disable").Default("").String(),
+		InMemory:          store.Flag("inMemory", "keep the data in memory instead of rocksdb, lost on restart and can not bootstrap from peers, e.g., for tests").Default("false").Bool(),
+		SoftDeleteHours:   store.Flag("softDeleteHours", "hours to keep the soft deleted values before purging them during compaction").Default("24").Int(),
+		LogBackpressureMb: store.Flag("logBackpressureMb", "reject writes when a follower lags behind the binlog over this size in MB, 0 to disable").Default("0").Int(),
+		MaxKeyLength:      store.Flag("maxKeyLength", "reject writes with keys longer than this many bytes, 0 for no limit").Default("65536").Int(),
+		SlowDeleteMs:      store.Flag("slowDeleteMs", "log and count deletes whose binlog append and db delete take longer than this many milliseconds, 0 to disable").Default("100").Int(),
+		RepairOnStartup:   store.Flag("repairOnStartup", "apply the latest binlog entries lost by the db when opening the shards, skipped for inMemory").Default("false").Bool(),
+		IntentTtlSeconds:  store.Flag("intentTtlSeconds", "abort the prepared delete transactions not committed or aborted within this many seconds, 0 to keep them until then").Default("600").Int(),
 	}
 	storeProfile = store.Flag("cpuprofile", "cpu profile output file").Default("").String()
 
@@ -72,6 +88,22 @@ var (
 		LogFileCount:      server.Flag("store.logFileCount", "log file count limit").Default("3").Int(),
 		DiskSizeGb:        server.Flag("store.diskSizeGb", "disk size in GB").Default("10").Int(),
 		Tags:              server.Flag("store.tags", "comma separated tags").Default("").String(),
+		TtlCompaction:     server.Flag("store.ttlCompaction", "comma separated keyspaces to purge expired entries during compaction").Default("").String(),
+		BatchWindowMs:     server.Flag("store.batchWindowMs", "max wait in milliseconds to batch up binlog entries sent to the following shards, 0 to send right away").Default("100").Int(),
+		BatchWindows:      server.Flag("store.batchWindows", "comma separated keyspace:milliseconds to override store.batchWindowMs for the keyspace shards, e.g., a shorter one for latency-sensitive keyspaces").Default("").String(),
+		BatchWindowKb:     server.Flag("store.batchWindowKb", "send the binlog entries right away once this many KB are available, without waiting for the batch window").Default("64").Int(),
+		DataCenter:        server.Flag("store.dataCenter", "data center name, connections to stores in other data centers are compressed").Default("").String(),
+		DeadLetterAfter:   server.Flag("store.deadLetterAfter", "failed attempts before a followed binlog entry is moved to the dead letter log, 0 to retry forever").Default("0").Int(),
+		ShardMaxInFlight:  server.Flag("store.shardMaxInFlight", "max concurrent requests on one shard before replying shard busy, 0 for no limit").Default("1024").Int(),
+		TtlSweepSeconds:   server.Flag("store.ttlSweepSeconds", "interval in seconds to scan and delete expired entries, 0 to disable").Default("0").Int(),
+		MetricsAddress:    server.Flag("store.metricsAddress", "host:port to expose prometheus metrics at /metrics, empty to disable").Default("").String(),
+		InMemory:          server.Flag("store.inMemory", "keep the data in memory instead of rocksdb, lost on restart and can not bootstrap from peers, e.g., for tests").Default("false").Bool(),
+		SoftDeleteHours:   server.Flag("store.softDeleteHours", "hours to keep the soft deleted values before purging them during compaction").Default("24").Int(),
+		LogBackpressureMb: server.Flag("store.logBackpressureMb", "reject writes when a follower lags behind the binlog over this size in MB, 0 to disable").Default("0").Int(),
+		MaxKeyLength:      server.Flag("store.maxKeyLength", "reject writes with keys longer than this many bytes, 0 for no limit").Default("65536").Int(),
+		SlowDeleteMs:      server.Flag("store.slowDeleteMs", "log and count deletes whose binlog append and db delete take longer than this many milliseconds, 0 to disable").Default("100").Int(),
+		RepairOnStartup:   server.Flag("store.repairOnStartup", "apply the latest binlog entries lost by the db when opening the shards, skipped for inMemory").Default("false").Bool(),
+		IntentTtlSeconds:  server.Flag("store.intentTtlSeconds", "abort the prepared delete transactions not committed or aborted within this many seconds, 0 to keep them until then").Default("600").Int(),
 	}
 	serverProfile = server.Flag("cpuprofile", "cpu profile output file").Default("").String()
 